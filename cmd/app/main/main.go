@@ -3,16 +3,28 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"reviewer-assignment-service/internal/app/config"
+	"reviewer-assignment-service/internal/app/grpcapi"
 	"reviewer-assignment-service/internal/app/routes"
+	"reviewer-assignment-service/internal/app/scheduler"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/notifications"
 	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/internal/infrastructure/cache"
 	"reviewer-assignment-service/internal/infrastructure/database"
+	infranotifiers "reviewer-assignment-service/internal/infrastructure/notifiers"
 	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
 	"syscall"
 	"time"
+	_ "time/tzdata"
+
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -24,15 +36,71 @@ func main() {
 	}
 	defer db.Close()
 
-	userRepo := postgres.NewUserDataBase(db)
-	teamRepo := postgres.NewTeamDataBase(db)
-	pullRequestRepo := postgres.NewPullRequestDataBase(db)
+	systemClock := clock.System{}
+	teamCache := cache.NewInProcess(cfg.Cache.Size, cfg.Cache.TTL, systemClock)
+
+	userRepo := cache.NewUserRepository(postgres.NewUserDataBase(db), teamCache)
+	userIdentityRepo := postgres.NewUserIdentityDataBase(db)
+	teamRepo := cache.NewTeamRepository(postgres.NewTeamDataBase(db), teamCache)
+	pullRequestRepo := cache.NewPullRequestRepository(postgres.NewPullRequestDataBase(db), teamCache)
+	externalPullRequestRepo := postgres.NewExternalPullRequestDataBase(db)
+	reviewerRuleRepo := postgres.NewReviewerRuleDataBase(db)
+	userTagRepo := postgres.NewUserTagDataBase(db)
+	reviewPatternRepo := postgres.NewReviewPatternDataBase(db)
+	reviewLoadRepo := postgres.NewReviewLoadDataBase(db)
+	reviewSLARepo := postgres.NewReviewSLADataBase(db)
+	pullRequestEventRepo := postgres.NewPullRequestEventDataBase(db)
+	reviewRepo := postgres.NewReviewDataBase(db)
+	idempotencyKeyRepo := postgres.NewIdempotencyKeyDataBase(db)
+	notificationPreferenceRepo := postgres.NewNotificationPreferenceDataBase(db)
+	notificationRepo := postgres.NewNotificationDataBase(db)
+
+	broker := events.NewBroker()
+
+	notifiers := map[models.NotificationChannel]notifications.Notifier{
+		models.ChannelSlack:   infranotifiers.NewSlackNotifier(),
+		models.ChannelWebhook: infranotifiers.NewWebhookNotifier(),
+	}
+	if smtp := cfg.Notifications.SMTP; smtp.Addr != "" {
+		notifiers[models.ChannelEmail] = infranotifiers.NewEmailNotifier(smtp.Addr, smtp.From, smtp.Username, smtp.Password)
+	}
 
-	userService := impl.NewUserService(userRepo)
+	userService := impl.NewUserService(userRepo, userIdentityRepo)
+	notificationService := impl.NewNotificationService(userRepo, notificationPreferenceRepo, notificationRepo, notifiers, systemClock)
 	teamService := impl.NewTeamService(teamRepo)
-	pullRequestService := impl.NewPullRequestService(pullRequestRepo)
+	pullRequestService := impl.NewPullRequestService(pullRequestRepo, reviewerRuleRepo, userTagRepo, reviewPatternRepo, reviewLoadRepo, reviewRepo, pullRequestEventRepo, broker, notificationService, systemClock)
+	transactionManager := cache.NewTransactionManager(postgres.NewTransactionManager(db), teamCache)
+	integrationService := impl.NewIntegrationService(pullRequestService, userService, externalPullRequestRepo, transactionManager, systemClock)
+	importService := impl.NewImportService(transactionManager)
+	syncService := impl.NewOrgSyncService(transactionManager, pullRequestService)
+	membershipService := impl.NewMembershipService(transactionManager, pullRequestService)
+	reviewerRuleService := impl.NewReviewerRuleService(reviewerRuleRepo, teamRepo, userRepo, userTagRepo, reviewPatternRepo, reviewLoadRepo, systemClock)
+	reviewSLAService := impl.NewReviewSLAService(reviewSLARepo, pullRequestEventRepo, pullRequestService, systemClock)
+	reviewService := impl.NewReviewService(reviewRepo, pullRequestEventRepo, pullRequestService, systemClock)
+	idempotencyService := impl.NewIdempotencyService(idempotencyKeyRepo, cfg.Idempotency.KeyTTL, systemClock)
+	userEventService := impl.NewUserEventService(userRepo, pullRequestEventRepo, broker)
+	digestService := impl.NewDigestService(userRepo, pullRequestRepo, reviewRepo, notificationService, systemClock)
 
-	router := routes.SetupRouter(userService, pullRequestService, teamService)
+	router := routes.SetupRouter(
+		userService,
+		pullRequestService,
+		teamService,
+		integrationService,
+		importService,
+		syncService,
+		membershipService,
+		reviewerRuleService,
+		reviewSLAService,
+		reviewService,
+		idempotencyService,
+		userEventService,
+		notificationService,
+		digestService,
+		teamCache,
+		cfg.Integrations,
+		cfg.RateLimits,
+		systemClock,
+	)
 
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -41,6 +109,14 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	server.RegisterOnShutdown(broker.Close)
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go scheduler.NewSLAEscalator(reviewSLAService, cfg.Scheduler.SLACheckInterval, systemClock).Run(schedulerCtx)
+	go scheduler.NewNotificationDispatcher(notificationService, cfg.Notifications.DeliveryInterval, systemClock).Run(schedulerCtx)
+	if cfg.Notifications.DigestHour >= 0 {
+		go scheduler.NewDigestScheduler(digestService, cfg.Notifications.DigestHour, scheduler.DigestCheckInterval, systemClock).Run(schedulerCtx)
+	}
 
 	go func() {
 		log.Printf("Server starting on port %s", cfg.Server.Port)
@@ -49,14 +125,35 @@ func main() {
 		}
 	}()
 
+	var grpcServer *grpc.Server
+	if cfg.GRPC.AuthToken != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		grpcServer = grpcapi.NewServer(userService, teamService, pullRequestService, cfg.GRPC.AuthToken, systemClock)
+		go func() {
+			log.Printf("gRPC server starting on port %s", cfg.GRPC.Port)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	} else {
+		log.Println("GRPC_AUTH_TOKEN is not set, the gRPC server is disabled")
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopScheduler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
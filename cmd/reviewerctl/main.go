@@ -0,0 +1,10 @@
+package main
+
+import (
+	"os"
+	"reviewer-assignment-service/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
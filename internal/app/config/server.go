@@ -2,17 +2,34 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server        ServerConfig
+	GRPC          GRPCConfig
+	Database      DatabaseConfig
+	Integrations  IntegrationsConfig
+	Scheduler     SchedulerConfig
+	Idempotency   IdempotencyConfig
+	RateLimits    RateLimitConfig
+	Notifications NotificationsConfig
+	Cache         CacheConfig
 }
 
 type ServerConfig struct {
 	Port string
 }
 
+// GRPCConfig.AuthToken is the bearer token gRPC clients send; the gRPC server is not started
+// without one.
+type GRPCConfig struct {
+	Port      string
+	AuthToken string
+}
+
 type DatabaseConfig struct {
 	Host     string
 	Port     string
@@ -22,11 +39,64 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+type IntegrationsConfig struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+type SchedulerConfig struct {
+	SLACheckInterval time.Duration
+}
+
+type IdempotencyConfig struct {
+	KeyTTL time.Duration
+}
+
+type RateLimitConfig struct {
+	Users        RateLimit
+	Teams        RateLimit
+	PullRequests RateLimit
+}
+
+// RateLimit allows Requests per client every Per; zero Requests turns the limit off.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// NotificationsConfig.DigestHour is the UTC hour after which the daily digests go out; -1 turns
+// them off.
+type NotificationsConfig struct {
+	SMTP             SMTPConfig
+	DeliveryInterval time.Duration
+	DigestHour       int
+}
+
+// SMTPConfig is left empty to disable email notifications; Username is only needed when the
+// server asks for authentication.
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// CacheConfig.Size is how many teams, and as many candidate lists, are kept for TTL; zero turns
+// the cache off.
+type CacheConfig struct {
+	Size int
+	TTL  time.Duration
+}
+
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
 		},
+		GRPC: GRPCConfig{
+			Port:      getEnv("GRPC_PORT", "9090"),
+			AuthToken: getEnv("GRPC_AUTH_TOKEN", ""),
+		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5434"),
@@ -35,6 +105,35 @@ func Load() *Config {
 			DBName:   getEnv("DB_NAME", "postgres"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
+		Integrations: IntegrationsConfig{
+			GitHubWebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
+			GitLabWebhookToken:  getEnv("GITLAB_WEBHOOK_TOKEN", ""),
+		},
+		Scheduler: SchedulerConfig{
+			SLACheckInterval: getDuration("SLA_CHECK_INTERVAL", 5*time.Minute),
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL: getDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		},
+		RateLimits: RateLimitConfig{
+			Users:        getRateLimit("RATE_LIMIT_USERS", RateLimit{Requests: 600, Per: time.Minute}),
+			Teams:        getRateLimit("RATE_LIMIT_TEAMS", RateLimit{Requests: 600, Per: time.Minute}),
+			PullRequests: getRateLimit("RATE_LIMIT_PULL_REQUESTS", RateLimit{Requests: 600, Per: time.Minute}),
+		},
+		Notifications: NotificationsConfig{
+			SMTP: SMTPConfig{
+				Addr:     getEnv("SMTP_ADDR", ""),
+				From:     getEnv("SMTP_FROM", "reviewers@localhost"),
+				Username: getEnv("SMTP_USERNAME", ""),
+				Password: getEnv("SMTP_PASSWORD", ""),
+			},
+			DeliveryInterval: getDuration("NOTIFICATION_DELIVERY_INTERVAL", 30*time.Second),
+			DigestHour:       getHour("DIGEST_HOUR", 9),
+		},
+		Cache: CacheConfig{
+			Size: getSize("CACHE_SIZE", 1000),
+			TTL:  getDuration("CACHE_TTL", time.Minute),
+		},
 	}
 }
 
@@ -44,3 +143,55 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getHour reads an hour of the day; "off" returns -1.
+func getHour(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "off" {
+		return -1
+	}
+	if hour, err := strconv.Atoi(value); err == nil && hour >= 0 && hour < 24 {
+		return hour
+	}
+	return defaultValue
+}
+
+// getSize reads a positive count; "off" returns 0.
+func getSize(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "off" {
+		return 0
+	}
+	if size, err := strconv.Atoi(value); err == nil && size > 0 {
+		return size
+	}
+	return defaultValue
+}
+
+// getRateLimit reads limits written as "100/1m"; "off" disables the limit.
+func getRateLimit(key string, defaultValue RateLimit) RateLimit {
+	value := os.Getenv(key)
+	if value == "off" {
+		return RateLimit{}
+	}
+	requests, per, ok := strings.Cut(value, "/")
+	if !ok {
+		return defaultValue
+	}
+	limit := RateLimit{}
+	var err error
+	if limit.Requests, err = strconv.Atoi(requests); err != nil || limit.Requests <= 0 {
+		return defaultValue
+	}
+	if limit.Per, err = time.ParseDuration(per); err != nil || limit.Per <= 0 {
+		return defaultValue
+	}
+	return limit
+}
@@ -0,0 +1,40 @@
+package docs
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+var loadSpec = sync.OnceValues(func() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(specYAML)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+})
+
+var specJSON = sync.OnceValues(func() ([]byte, error) {
+	doc, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+})
+
+func Spec() (*openapi3.T, error) {
+	return loadSpec()
+}
+
+func JSON() ([]byte, error) {
+	return specJSON()
+}
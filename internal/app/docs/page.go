@@ -0,0 +1,225 @@
+package docs
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed page.html
+var pageTemplate string
+
+type pageView struct {
+	Title       string
+	Version     string
+	Description string
+	Operations  []operationView
+	Schemas     []schemaView
+}
+
+type operationView struct {
+	Method      string
+	Path        string
+	Summary     string
+	Tag         string
+	Parameters  []parameterView
+	RequestBody string
+	Responses   []responseView
+}
+
+type parameterView struct {
+	Name     string
+	In       string
+	Type     string
+	Required bool
+}
+
+type responseView struct {
+	Status      string
+	Description string
+	Body        string
+}
+
+type schemaView struct {
+	Name       string
+	Properties []propertyView
+}
+
+type propertyView struct {
+	Name     string
+	Type     string
+	Required bool
+}
+
+var methodOrder = map[string]int{
+	"GET":    0,
+	"POST":   1,
+	"PUT":    2,
+	"PATCH":  3,
+	"DELETE": 4,
+}
+
+var renderedPage = sync.OnceValues(func() ([]byte, error) {
+	doc, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("docs").Parse(pageTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, buildPageView(doc)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+})
+
+func Page() ([]byte, error) {
+	return renderedPage()
+}
+
+func buildPageView(doc *openapi3.T) pageView {
+	view := pageView{
+		Title:       doc.Info.Title,
+		Version:     doc.Info.Version,
+		Description: doc.Info.Description,
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			view.Operations = append(view.Operations, buildOperationView(method, path, op))
+		}
+	}
+	sort.Slice(view.Operations, func(i, j int) bool {
+		a, b := view.Operations[i], view.Operations[j]
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return methodOrder[a.Method] < methodOrder[b.Method]
+	})
+
+	for name, schemaRef := range doc.Components.Schemas {
+		view.Schemas = append(view.Schemas, buildSchemaView(name, schemaRef.Value))
+	}
+	sort.Slice(view.Schemas, func(i, j int) bool {
+		return view.Schemas[i].Name < view.Schemas[j].Name
+	})
+
+	return view
+}
+
+func buildOperationView(method, path string, op *openapi3.Operation) operationView {
+	view := operationView{
+		Method:  method,
+		Path:    path,
+		Summary: op.Summary,
+	}
+	if len(op.Tags) > 0 {
+		view.Tag = op.Tags[0]
+	}
+
+	for _, paramRef := range op.Parameters {
+		param := paramRef.Value
+		view.Parameters = append(view.Parameters, parameterView{
+			Name:     param.Name,
+			In:       param.In,
+			Type:     schemaType(param.Schema),
+			Required: param.Required,
+		})
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		view.RequestBody = contentType(op.RequestBody.Value.Content)
+	}
+
+	for status, responseRef := range op.Responses.Map() {
+		response := responseRef.Value
+		description := ""
+		if response.Description != nil {
+			description = *response.Description
+		}
+		view.Responses = append(view.Responses, responseView{
+			Status:      status,
+			Description: description,
+			Body:        contentType(response.Content),
+		})
+	}
+	sort.Slice(view.Responses, func(i, j int) bool {
+		return view.Responses[i].Status < view.Responses[j].Status
+	})
+
+	return view
+}
+
+func buildSchemaView(name string, schema *openapi3.Schema) schemaView {
+	view := schemaView{Name: name}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, field := range schema.Required {
+		required[field] = true
+	}
+
+	for field, propRef := range schema.Properties {
+		view.Properties = append(view.Properties, propertyView{
+			Name:     field,
+			Type:     schemaType(propRef),
+			Required: required[field],
+		})
+	}
+	sort.Slice(view.Properties, func(i, j int) bool {
+		return view.Properties[i].Name < view.Properties[j].Name
+	})
+
+	return view
+}
+
+func contentType(content openapi3.Content) string {
+	for _, mediaType := range content {
+		return schemaType(mediaType.Schema)
+	}
+	return ""
+}
+
+func schemaType(ref *openapi3.SchemaRef) string {
+	if ref == nil {
+		return ""
+	}
+	if ref.Ref != "" {
+		return ref.Ref[strings.LastIndex(ref.Ref, "/")+1:]
+	}
+	if ref.Value == nil || ref.Value.Type == nil {
+		return ""
+	}
+
+	types := ref.Value.Type.Slice()
+	if len(types) == 0 {
+		return ""
+	}
+	if types[0] == openapi3.TypeArray {
+		return "[]" + schemaType(ref.Value.Items)
+	}
+	if len(ref.Value.Enum) > 0 {
+		values := make([]string, 0, len(ref.Value.Enum))
+		for _, v := range ref.Value.Enum {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return types[0] + " (" + strings.Join(values, " | ") + ")"
+	}
+	if ref.Value.Format != "" {
+		return types[0] + " (" + ref.Value.Format + ")"
+	}
+	return types[0]
+}
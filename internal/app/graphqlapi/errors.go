@@ -0,0 +1,36 @@
+package graphqlapi
+
+import (
+	"reviewer-assignment-service/internal/app/response_errors"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+)
+
+// serviceError reports a service error with the code the REST API uses for it in the extensions
+// of the GraphQL error.
+type serviceError struct {
+	code    string
+	message string
+}
+
+func toError(err error) error {
+	code, message, _ := response_errors.ClassifyError(err)
+	return &serviceError{code: code, message: message}
+}
+
+func (e *serviceError) Error() string {
+	return e.message
+}
+
+func (e *serviceError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func formatLimitError(err *limitError) []gqlerrors.FormattedError {
+	return []gqlerrors.FormattedError{{
+		Message:    err.message,
+		Locations:  []location.SourceLocation{},
+		Extensions: map[string]interface{}{"code": err.code},
+	}}
+}
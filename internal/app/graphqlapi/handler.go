@@ -0,0 +1,91 @@
+// Package graphqlapi serves read queries over users, teams and pull requests at /graphql, so a
+// dashboard can fetch a team with its members and their reviews in one request.
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/domain/services"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+const maxRequestBytes = 1 << 20
+
+type Handler struct {
+	schema      graphql.Schema
+	limits      Limits
+	userService services.UserService
+	prService   services.PullRequestService
+}
+
+func NewHandler(
+	userService services.UserService,
+	teamService services.TeamService,
+	prService services.PullRequestService,
+	limits Limits,
+) *Handler {
+	return &Handler{
+		schema:      newSchema(userService, teamService, prService),
+		limits:      limits,
+		userService: userService,
+		prService:   prService,
+	}
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP answers every well-formed request with 200, reporting query errors in the errors
+// array of the result as GraphQL clients expect.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		response_errors.SendValidationError(w, "query is required")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.execute(r.Context(), req))
+}
+
+func (h *Handler) execute(ctx context.Context, req request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&h.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	if err := h.limits.check(h.schema, doc); err != nil {
+		return &graphql.Result{Errors: formatLimitError(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(h.userService, h.prService)),
+	})
+}
@@ -0,0 +1,145 @@
+package graphqlapi
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bound the queries the endpoint runs. Depth is the longest chain of nested fields.
+// Complexity counts every selected field, and what is selected under a list counts listFactor
+// times. Introspection fields count for nothing.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+var DefaultLimits = Limits{MaxDepth: 8, MaxComplexity: 5000}
+
+const listFactor = 5
+
+type limitError struct {
+	code    string
+	message string
+}
+
+func (e *limitError) Error() string {
+	return e.message
+}
+
+// check measures every operation in the document, which has to be valid already.
+func (l Limits) check(schema graphql.Schema, doc *ast.Document) *limitError {
+	m := &measure{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		measured:  make(map[string]cost),
+		schema:    schema,
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			m.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		c := m.selectionSet(operation.SelectionSet, schema.QueryType())
+		if c.depth > l.MaxDepth {
+			return &limitError{"QUERY_TOO_DEEP", fmt.Sprintf("Query depth %d exceeds the limit of %d", c.depth, l.MaxDepth)}
+		}
+		if c.complexity > l.MaxComplexity {
+			return &limitError{"QUERY_TOO_COMPLEX", fmt.Sprintf("Query complexity %d exceeds the limit of %d", c.complexity, l.MaxComplexity)}
+		}
+	}
+	return nil
+}
+
+type cost struct {
+	depth      int
+	complexity int
+}
+
+type measure struct {
+	fragments map[string]*ast.FragmentDefinition
+	// measured remembers each named fragment, so spreading one many times costs one walk.
+	measured map[string]cost
+	schema   graphql.Schema
+}
+
+func (m *measure) selectionSet(set *ast.SelectionSet, parent *graphql.Object) cost {
+	var total cost
+	if set == nil || parent == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch node := selection.(type) {
+		case *ast.Field:
+			c = m.field(node, parent)
+		case *ast.InlineFragment:
+			c = m.selectionSet(node.SelectionSet, m.typeCondition(node.TypeCondition, parent))
+		case *ast.FragmentSpread:
+			c = m.fragment(node.Name.Value, parent)
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+func (m *measure) field(node *ast.Field, parent *graphql.Object) cost {
+	definition, ok := parent.Fields()[node.Name.Value]
+	if !ok {
+		return cost{}
+	}
+
+	object, list := unwrap(definition.Type)
+	c := m.selectionSet(node.SelectionSet, object)
+	if list {
+		c.complexity *= listFactor
+	}
+	return cost{depth: c.depth + 1, complexity: c.complexity + 1}
+}
+
+func (m *measure) fragment(name string, parent *graphql.Object) cost {
+	if c, ok := m.measured[name]; ok {
+		return c
+	}
+	fragment, ok := m.fragments[name]
+	if !ok {
+		return cost{}
+	}
+	c := m.selectionSet(fragment.SelectionSet, m.typeCondition(fragment.TypeCondition, parent))
+	m.measured[name] = c
+	return c
+}
+
+func (m *measure) typeCondition(condition *ast.Named, parent *graphql.Object) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	object, _ := m.schema.Type(condition.Name.Value).(*graphql.Object)
+	return object
+}
+
+// unwrap gives the object a field returns, or nil for scalars and enums, and whether it is a list.
+func unwrap(t graphql.Type) (*graphql.Object, bool) {
+	list := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			list = true
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped, list
+		default:
+			return nil, list
+		}
+	}
+}
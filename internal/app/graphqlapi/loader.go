@@ -0,0 +1,94 @@
+package graphqlapi
+
+import (
+	"context"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+	"sync"
+)
+
+// batch collects the keys resolvers ask for while the executor walks one level of the query and
+// fetches all of them with a single call when the first of the returned thunks is called. The
+// executor calls thunks breadth first, so each level costs one call however many parents it has.
+type batch[K comparable, V any] struct {
+	mu      sync.Mutex
+	fetch   func(keys []K) (map[K]V, error)
+	seen    map[K]struct{}
+	pending []K
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newBatch[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *batch[K, V] {
+	return &batch[K, V]{
+		fetch:  fetch,
+		seen:   make(map[K]struct{}),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+func (b *batch[K, V]) load(key K) func() (V, error) {
+	b.mu.Lock()
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.pending = append(b.pending, key)
+	}
+	b.mu.Unlock()
+
+	return func() (V, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if len(b.pending) > 0 {
+			b.flush()
+		}
+		return b.values[key], b.errs[key]
+	}
+}
+
+func (b *batch[K, V]) flush() {
+	keys := b.pending
+	b.pending = nil
+
+	values, err := b.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			b.errs[key] = err
+			continue
+		}
+		b.values[key] = values[key]
+	}
+}
+
+// loaders live for one request, so nothing is cached between requests.
+type loaders struct {
+	users     *batch[int, *models.User]
+	reviewing *batch[int, []*models.PullRequest]
+}
+
+func newLoaders(userService services.UserService, prService services.PullRequestService) *loaders {
+	return &loaders{
+		users: newBatch(func(ids []int) (map[int]*models.User, error) {
+			users, err := userService.GetByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[int]*models.User, len(users))
+			for _, user := range users {
+				byID[user.ID] = user
+			}
+			return byID, nil
+		}),
+		reviewing: newBatch(prService.GetByReviewerIDs),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,265 @@
+package graphqlapi
+
+import (
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+var pullRequestStatus = graphql.NewEnum(graphql.EnumConfig{
+	Name: "PullRequestStatus",
+	Values: graphql.EnumValueConfigMap{
+		"OPEN":   &graphql.EnumValueConfig{Value: models.StatusOpen},
+		"MERGED": &graphql.EnumValueConfig{Value: models.StatusMerged},
+		"CLOSED": &graphql.EnumValueConfig{Value: models.StatusClosed},
+	},
+})
+
+// newSchema panics when the schema is inconsistent, which only a change to this file can cause.
+func newSchema(userService services.UserService, teamService services.TeamService, prService services.PullRequestService) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":       userField(graphql.NewNonNull(graphql.Int), func(u *models.User) interface{} { return u.ID }),
+			"name":     userField(graphql.NewNonNull(graphql.String), func(u *models.User) interface{} { return u.Name }),
+			"email":    userField(graphql.NewNonNull(graphql.String), func(u *models.User) interface{} { return u.Email }),
+			"isActive": userField(graphql.NewNonNull(graphql.Boolean), func(u *models.User) interface{} { return u.IsActive }),
+			"teamName": userField(graphql.NewNonNull(graphql.String), func(u *models.User) interface{} { return u.TeamName }),
+		},
+	})
+
+	pullRequestType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PullRequest",
+		Fields: graphql.Fields{
+			"id":        prField(graphql.NewNonNull(graphql.Int), func(pr *models.PullRequest) interface{} { return pr.ID }),
+			"name":      prField(graphql.NewNonNull(graphql.String), func(pr *models.PullRequest) interface{} { return pr.Name }),
+			"status":    prField(graphql.NewNonNull(pullRequestStatus), func(pr *models.PullRequest) interface{} { return pr.Status }),
+			"author":    prField(graphql.NewNonNull(userType), func(pr *models.PullRequest) interface{} { return pr.Author }),
+			"reviewers": prField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))), func(pr *models.PullRequest) interface{} { return pr.Reviewers }),
+			"paths":     prField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(pr *models.PullRequest) interface{} { return nonNil(pr.Paths) }),
+			"labels":    prField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(pr *models.PullRequest) interface{} { return nonNil(pr.Labels) }),
+			"size": prField(graphql.Int, func(pr *models.PullRequest) interface{} {
+				if pr.Size == nil {
+					return nil
+				}
+				return *pr.Size
+			}),
+			"createdAt": prField(graphql.NewNonNull(graphql.DateTime), func(pr *models.PullRequest) interface{} { return pr.CreatedAt }),
+			"mergedAt": prField(graphql.DateTime, func(pr *models.PullRequest) interface{} {
+				if pr.MergedAt.IsZero() {
+					return nil
+				}
+				return pr.MergedAt
+			}),
+			"version": prField(graphql.NewNonNull(graphql.Int), func(pr *models.PullRequest) interface{} { return pr.Version }),
+		},
+	})
+
+	userType.AddFieldConfig("reviewing", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pullRequestType))),
+		Description: "Pull requests the user is a reviewer of, newest first.",
+		Args: graphql.FieldConfigArgument{
+			"status": &graphql.ArgumentConfig{Type: pullRequestStatus},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			status, filtered := p.Args["status"].(models.PRStatus)
+			thunk := loadersFrom(p.Context).reviewing.load(p.Source.(*models.User).ID)
+			return func() (interface{}, error) {
+				prs, err := thunk()
+				if err != nil {
+					return nil, toError(err)
+				}
+				reviewing := make([]*models.PullRequest, 0, len(prs))
+				for _, pr := range prs {
+					if !filtered || pr.Status == status {
+						reviewing = append(reviewing, pr)
+					}
+				}
+				return reviewing, nil
+			}, nil
+		},
+	})
+
+	teamType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Team",
+		Fields: graphql.Fields{
+			"id":      teamField(graphql.NewNonNull(graphql.Int), func(t *models.Team) interface{} { return t.ID }),
+			"name":    teamField(graphql.NewNonNull(graphql.String), func(t *models.Team) interface{} { return t.Name }),
+			"version": teamField(graphql.NewNonNull(graphql.Int), func(t *models.Team) interface{} { return t.Version }),
+			"members": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Description: "Members of the team ordered by id.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					team := p.Source.(*models.Team)
+					memberIDs := make([]int, 0, len(team.Members))
+					for id := range team.Members {
+						memberIDs = append(memberIDs, id)
+					}
+					sort.Ints(memberIDs)
+
+					users := loadersFrom(p.Context).users
+					thunks := make([]func() (*models.User, error), len(memberIDs))
+					for i, id := range memberIDs {
+						thunks[i] = users.load(id)
+					}
+					return func() (interface{}, error) {
+						members := make([]*models.User, 0, len(thunks))
+						for _, thunk := range thunks {
+							member, err := thunk()
+							if err != nil {
+								return nil, toError(err)
+							}
+							if member != nil {
+								members = append(members, member)
+							}
+						}
+						return members, nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := positiveID(p.Args["id"], "id")
+					if err != nil {
+						return nil, toError(err)
+					}
+					return result(userService.GetByID(id))
+				},
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list(userService.GetAll())
+				},
+			},
+			"team": &graphql.Field{
+				Type:        teamType,
+				Description: "Looks a team up by exactly one of id and name.",
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"name": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rawID, byID := p.Args["id"]
+					name, byName := p.Args["name"].(string)
+					if byID == byName {
+						return nil, toError(validators.NewValidationError("exactly one of id and name is required"))
+					}
+					if byName {
+						if err := validators.ValidateTeamName(name); err != nil {
+							return nil, toError(err)
+						}
+						return result(teamService.GetByName(name))
+					}
+					id, err := positiveID(rawID, "id")
+					if err != nil {
+						return nil, toError(err)
+					}
+					return result(teamService.GetByID(id))
+				},
+			},
+			"teams": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(teamType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list(teamService.GetAll())
+				},
+			},
+			"pullRequest": &graphql.Field{
+				Type: pullRequestType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := positiveID(p.Args["id"], "id")
+					if err != nil {
+						return nil, toError(err)
+					}
+					return result(prService.GetByID(id))
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+func userField(t graphql.Output, value func(u *models.User) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(*models.User)), nil
+		},
+	}
+}
+
+func teamField(t graphql.Output, value func(t *models.Team) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(*models.Team)), nil
+		},
+	}
+}
+
+func prField(t graphql.Output, value func(pr *models.PullRequest) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(*models.PullRequest)), nil
+		},
+	}
+}
+
+// result turns a service call into a resolver result; a typed nil has to become an untyped one
+// for the executor to see it as null.
+func result[T any](value *T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, toError(err)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	return value, nil
+}
+
+func list[T any](values []T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, toError(err)
+	}
+	if values == nil {
+		return []T{}, nil
+	}
+	return values, nil
+}
+
+func positiveID(arg interface{}, field string) (int, error) {
+	id, _ := arg.(int)
+	if id <= 0 {
+		return 0, validators.NewValidationError(field + " must be positive")
+	}
+	return id, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
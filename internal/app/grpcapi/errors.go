@@ -0,0 +1,42 @@
+package grpcapi
+
+import (
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the ErrorInfo domain of the errors the API returns.
+const ErrorDomain = "reviewer-assignment-service"
+
+// ToStatus classifies err like the REST API does and returns it as a gRPC status whose
+// ErrorInfo reason is the REST error code.
+func ToStatus(err error) error {
+	code, message, httpStatus := response_errors.ClassifyError(err)
+	st := status.New(grpcCode(code, httpStatus), message)
+	if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: ErrorDomain}); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func grpcCode(code string, httpStatus int) codes.Code {
+	switch {
+	case strings.HasSuffix(code, "_ALREADY_EXISTS"):
+		return codes.AlreadyExists
+	case httpStatus == http.StatusBadRequest:
+		return codes.InvalidArgument
+	case httpStatus == http.StatusNotFound:
+		return codes.NotFound
+	case httpStatus == http.StatusPreconditionFailed:
+		return codes.Aborted
+	case httpStatus == http.StatusConflict, httpStatus == http.StatusUnprocessableEntity:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,60 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptor turns a panic in a handler into an Internal error, like the REST
+// router's Recoverer.
+func RecoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("grpc %s panic: %v\n%s", info.FullMethod, recovered, debug.Stack())
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func LoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	started := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("grpc %s %s in %s", info.FullMethod, status.Code(err), time.Since(started))
+	return resp, err
+}
+
+// AuthInterceptor lets through calls whose authorization metadata is "Bearer <token>"; with an
+// empty token nothing gets through.
+func AuthInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !authorized(ctx, token) {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authorized(ctx context.Context, token string) bool {
+	if token == "" {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, credentials, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") &&
+			subtle.ConstantTimeCompare([]byte(credentials), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package grpcapi
+
+import (
+	"reviewer-assignment-service/internal/app/grpcapi/reviewerv1"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"sort"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// positiveID checks an id the way the REST validators do and converts it to a domain id.
+func positiveID(id int64, field string) (int, error) {
+	if id <= 0 {
+		return 0, validators.NewValidationError(field + " must be positive")
+	}
+	return int(id), nil
+}
+
+// checkVersion is the gRPC If-Match: a zero version skips the check.
+func checkVersion(expected int64, current int) error {
+	if expected != 0 && expected != int64(current) {
+		return models.ErrConflictingUpdate
+	}
+	return nil
+}
+
+func toUser(user *models.User) *reviewerv1.User {
+	if user == nil {
+		return nil
+	}
+	response := &reviewerv1.User{
+		Id:       int64(user.ID),
+		Name:     user.Name,
+		Email:    user.Email,
+		IsActive: user.IsActive,
+		TeamName: user.TeamName,
+	}
+	if user.WorkingHours.EndHour != 0 {
+		response.WorkingHours = toWorkingHours(user.WorkingHours)
+	}
+	return response
+}
+
+func toUsers(users []*models.User) []*reviewerv1.User {
+	responses := make([]*reviewerv1.User, len(users))
+	for i, user := range users {
+		responses[i] = toUser(user)
+	}
+	return responses
+}
+
+func toWorkingHours(hours models.WorkingHours) *reviewerv1.WorkingHours {
+	return &reviewerv1.WorkingHours{
+		Timezone:  hours.Timezone,
+		StartHour: int32(hours.StartHour),
+		EndHour:   int32(hours.EndHour),
+	}
+}
+
+func workingHoursDTO(hours *reviewerv1.WorkingHours) *dtos.WorkingHours {
+	if hours == nil {
+		return nil
+	}
+	return &dtos.WorkingHours{
+		Timezone:  hours.GetTimezone(),
+		StartHour: int(hours.GetStartHour()),
+		EndHour:   int(hours.GetEndHour()),
+	}
+}
+
+func toIdentity(identity *models.UserIdentity) *reviewerv1.UserIdentity {
+	return &reviewerv1.UserIdentity{
+		Id:       int64(identity.ID),
+		UserId:   int64(identity.UserID),
+		Provider: string(identity.Provider),
+		Login:    identity.Login,
+	}
+}
+
+func toTeam(team *models.Team) *reviewerv1.Team {
+	members := make([]*reviewerv1.TeamMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, &reviewerv1.TeamMember{
+			UserId:   int64(member.UserID),
+			Username: member.Username,
+			IsActive: member.IsActive,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserId < members[j].UserId })
+
+	return &reviewerv1.Team{
+		Id:      int64(team.ID),
+		Name:    team.Name,
+		Members: members,
+		Version: int64(team.Version),
+	}
+}
+
+func teamMembersDTO(members []*reviewerv1.TeamMember) []dtos.CreateTeamMemberRequest {
+	requests := make([]dtos.CreateTeamMemberRequest, len(members))
+	for i, member := range members {
+		requests[i] = dtos.CreateTeamMemberRequest{
+			UserID:   dtos.NewID(int(member.GetUserId())),
+			Username: member.GetUsername(),
+			IsActive: member.GetIsActive(),
+		}
+	}
+	return requests
+}
+
+var (
+	statusToProto = map[models.PRStatus]reviewerv1.PullRequestStatus{
+		models.StatusOpen:   reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+		models.StatusMerged: reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+		models.StatusClosed: reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_CLOSED,
+	}
+	statusFromProto = map[reviewerv1.PullRequestStatus]models.PRStatus{
+		reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:   models.StatusOpen,
+		reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED: models.StatusMerged,
+		reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_CLOSED: models.StatusClosed,
+	}
+)
+
+func toPullRequest(pr *models.PullRequest) *reviewerv1.PullRequest {
+	response := &reviewerv1.PullRequest{
+		Id:        int64(pr.ID),
+		Name:      pr.Name,
+		Status:    statusToProto[pr.Status],
+		Author:    toUser(pr.Author),
+		Reviewers: toUsers(pr.Reviewers),
+		Paths:     pr.Paths,
+		Labels:    pr.Labels,
+		CreatedAt: timestamppb.New(pr.CreatedAt),
+		Version:   int64(pr.Version),
+	}
+	if pr.Size != nil {
+		size := int32(*pr.Size)
+		response.Size = &size
+	}
+	if !pr.MergedAt.IsZero() {
+		response.MergedAt = timestamppb.New(pr.MergedAt)
+	}
+	return response
+}
+
+func toPullRequests(prs []*models.PullRequest) *reviewerv1.ListPullRequestsResponse {
+	responses := make([]*reviewerv1.PullRequest, len(prs))
+	for i, pr := range prs {
+		responses[i] = toPullRequest(pr)
+	}
+	return &reviewerv1.ListPullRequestsResponse{PullRequests: responses}
+}
+
+func ids(values []int64) []dtos.ID {
+	converted := make([]dtos.ID, len(values))
+	for i, value := range values {
+		converted[i] = dtos.NewID(int(value))
+	}
+	return converted
+}
@@ -0,0 +1,196 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"reviewer-assignment-service/internal/app/grpcapi/reviewerv1"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+type PullRequestServer struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+	prService   services.PullRequestService
+	userService services.UserService
+	clock       clock.Clock
+}
+
+func NewPullRequestServer(prService services.PullRequestService, userService services.UserService, clock clock.Clock) *PullRequestServer {
+	return &PullRequestServer{
+		prService:   prService,
+		userService: userService,
+		clock:       clock,
+	}
+}
+
+func (s *PullRequestServer) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.PullRequest, error) {
+	createReq := dtos.CreatePullRequestRequest{
+		Name:      req.GetName(),
+		AuthorID:  dtos.NewID(int(req.GetAuthorId())),
+		Reviewers: ids(req.GetReviewerIds()),
+		Paths:     req.GetPaths(),
+		Labels:    req.GetLabels(),
+	}
+	if req.Size != nil {
+		size := int(req.GetSize())
+		createReq.Size = &size
+	}
+	if err := validators.ValidateCreatePullRequestRequest(&createReq); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	author, err := s.userService.GetByID(createReq.AuthorID.Int())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	pr := mappers.ToPullRequestModel(&createReq, author, s.clock.Now())
+	reviewers, err := s.reviewers(req.GetReviewerIds())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if _, _, err := pr.SetReviewers(reviewers); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	if err := s.prService.Create(pr); err != nil {
+		return nil, ToStatus(err)
+	}
+	if len(req.GetReviewerIds()) == 0 {
+		// With nobody to assign the pull request is still created, just without reviewers.
+		if err := s.prService.AssignReviewers(pr); err != nil && !errors.Is(err, models.ErrReviewerNotFound) {
+			return nil, ToStatus(err)
+		}
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *PullRequestServer) GetPullRequest(ctx context.Context, req *reviewerv1.GetPullRequestRequest) (*reviewerv1.PullRequest, error) {
+	pr, err := s.getPullRequest(req.GetId())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *PullRequestServer) ListPullRequestsByAuthor(ctx context.Context, req *reviewerv1.ListPullRequestsByAuthorRequest) (*reviewerv1.ListPullRequestsResponse, error) {
+	authorID, err := positiveID(req.GetAuthorId(), "author_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	prs, err := s.prService.GetByAuthorID(authorID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toPullRequests(prs), nil
+}
+
+func (s *PullRequestServer) ListPullRequestsByReviewer(ctx context.Context, req *reviewerv1.ListPullRequestsByReviewerRequest) (*reviewerv1.ListPullRequestsResponse, error) {
+	reviewerID, err := positiveID(req.GetReviewerId(), "reviewer_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	prs, err := s.prService.GetByReviewerID(reviewerID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toPullRequests(prs), nil
+}
+
+func (s *PullRequestServer) UpdatePullRequest(ctx context.Context, req *reviewerv1.UpdatePullRequestRequest) (*reviewerv1.PullRequest, error) {
+	updateReq := dtos.UpdatePullRequestRequest{
+		Name:      req.GetName(),
+		Status:    string(statusFromProto[req.GetStatus()]),
+		Reviewers: ids(req.GetReviewerIds()),
+	}
+	if err := validators.ValidateUpdatePullRequestRequest(&updateReq); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	pr, err := s.getPullRequest(req.GetId())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := checkVersion(req.GetVersion(), pr.Version); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	reviewers, err := s.reviewers(req.GetReviewerIds())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := s.prService.Edit(pr, updateReq.Name, models.PRStatus(updateReq.Status), reviewers); err != nil {
+		return nil, ToStatus(err)
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *PullRequestServer) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.PullRequest, error) {
+	oldReviewerID, err := positiveID(req.GetOldReviewerId(), "old_reviewer_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	pr, err := s.getPullRequest(req.GetId())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := checkVersion(req.GetVersion(), pr.Version); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	oldReviewer, err := s.userService.GetByID(oldReviewerID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := s.prService.ReassignReviewers(pr, oldReviewer); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	updated, err := s.prService.GetByID(pr.ID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toPullRequest(updated), nil
+}
+
+func (s *PullRequestServer) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.PullRequest, error) {
+	pr, err := s.getPullRequest(req.GetId())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := checkVersion(req.GetVersion(), pr.Version); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	if err := s.prService.MergeRequest(pr); err != nil {
+		return nil, ToStatus(err)
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *PullRequestServer) getPullRequest(id int64) (*models.PullRequest, error) {
+	prID, err := positiveID(id, "pull request id")
+	if err != nil {
+		return nil, err
+	}
+	return s.prService.GetByID(prID)
+}
+
+func (s *PullRequestServer) reviewers(reviewerIDs []int64) ([]*models.User, error) {
+	reviewers := make([]*models.User, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		reviewer, err := s.userService.GetByID(int(reviewerID))
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, reviewer)
+	}
+	return reviewers, nil
+}
@@ -0,0 +1,33 @@
+// Package grpcapi serves the user, team and pull request operations over gRPC next to the REST
+// API, on top of the same domain services.
+package grpcapi
+
+//go:generate protoc -I ../../../api --go_out=../../.. --go_opt=module=reviewer-assignment-service --go-grpc_out=../../.. --go-grpc_opt=module=reviewer-assignment-service reviewer/v1/reviewer.proto
+
+import (
+	"reviewer-assignment-service/internal/app/grpcapi/reviewerv1"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"google.golang.org/grpc"
+)
+
+// NewServer only accepts calls that carry authToken; see AuthInterceptor.
+func NewServer(
+	userService services.UserService,
+	teamService services.TeamService,
+	prService services.PullRequestService,
+	authToken string,
+	clock clock.Clock,
+) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		RecoveryInterceptor,
+		LoggingInterceptor,
+		AuthInterceptor(authToken),
+	))
+
+	reviewerv1.RegisterUserServiceServer(server, NewUserServer(userService))
+	reviewerv1.RegisterTeamServiceServer(server, NewTeamServer(teamService))
+	reviewerv1.RegisterPullRequestServiceServer(server, NewPullRequestServer(prService, userService, clock))
+	return server
+}
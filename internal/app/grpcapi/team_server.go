@@ -0,0 +1,95 @@
+package grpcapi
+
+import (
+	"context"
+	"reviewer-assignment-service/internal/app/grpcapi/reviewerv1"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+type TeamServer struct {
+	reviewerv1.UnimplementedTeamServiceServer
+	teamService services.TeamService
+}
+
+func NewTeamServer(teamService services.TeamService) *TeamServer {
+	return &TeamServer{teamService: teamService}
+}
+
+func (s *TeamServer) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.Team, error) {
+	createReq := dtos.CreateTeamRequest{Name: req.GetName(), Members: teamMembersDTO(req.GetMembers())}
+	if err := validators.ValidateCreateTeamRequest(&createReq); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	team := mappers.ToTeamModel(createReq)
+	if err := s.teamService.Create(team); err != nil {
+		return nil, ToStatus(err)
+	}
+	return toTeam(team), nil
+}
+
+func (s *TeamServer) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.Team, error) {
+	teamID, err := positiveID(req.GetId(), "team id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	team, err := s.teamService.GetByID(teamID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toTeam(team), nil
+}
+
+func (s *TeamServer) GetTeamByName(ctx context.Context, req *reviewerv1.GetTeamByNameRequest) (*reviewerv1.Team, error) {
+	if err := validators.ValidateTeamName(req.GetName()); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	team, err := s.teamService.GetByName(req.GetName())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toTeam(team), nil
+}
+
+func (s *TeamServer) ListTeams(ctx context.Context, req *reviewerv1.ListTeamsRequest) (*reviewerv1.ListTeamsResponse, error) {
+	teams, err := s.teamService.GetAll()
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	response := &reviewerv1.ListTeamsResponse{Teams: make([]*reviewerv1.Team, len(teams))}
+	for i, team := range teams {
+		response.Teams[i] = toTeam(team)
+	}
+	return response, nil
+}
+
+func (s *TeamServer) UpdateTeam(ctx context.Context, req *reviewerv1.UpdateTeamRequest) (*reviewerv1.Team, error) {
+	teamID, err := positiveID(req.GetId(), "team id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	updateReq := dtos.UpdateTeamRequest{Name: req.GetName(), Members: teamMembersDTO(req.GetMembers())}
+	if err := validators.ValidateUpdateTeamRequest(&updateReq); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	team, err := s.teamService.GetByID(teamID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := checkVersion(req.GetVersion(), team.Version); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	mappers.UpdateTeamFromRequest(team, updateReq)
+	if err := s.teamService.Update(team); err != nil {
+		return nil, ToStatus(err)
+	}
+	return toTeam(team), nil
+}
@@ -0,0 +1,204 @@
+package grpcapi
+
+import (
+	"context"
+	"reviewer-assignment-service/internal/app/grpcapi/reviewerv1"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type UserServer struct {
+	reviewerv1.UnimplementedUserServiceServer
+	userService services.UserService
+}
+
+func NewUserServer(userService services.UserService) *UserServer {
+	return &UserServer{userService: userService}
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *reviewerv1.CreateUserRequest) (*reviewerv1.User, error) {
+	createReq := dtos.CreateUserRequest{
+		Username:     req.GetName(),
+		Email:        req.GetEmail(),
+		TeamName:     req.GetTeamName(),
+		IsActive:     req.GetIsActive(),
+		WorkingHours: workingHoursDTO(req.GetWorkingHours()),
+	}
+	if err := validators.ValidateCreateUserRequest(&createReq); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	user := mappers.CreateUserRequestToDomain(createReq)
+	if err := s.userService.Create(user); err != nil {
+		return nil, ToStatus(err)
+	}
+	return s.getUser(user.ID)
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *reviewerv1.GetUserRequest) (*reviewerv1.User, error) {
+	userID, err := positiveID(req.GetId(), "id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return s.getUser(userID)
+}
+
+func (s *UserServer) GetUserByEmail(ctx context.Context, req *reviewerv1.GetUserByEmailRequest) (*reviewerv1.User, error) {
+	if err := validators.ValidateEmail(req.GetEmail()); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	user, err := s.userService.GetByEmail(req.GetEmail())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toUser(user), nil
+}
+
+func (s *UserServer) GetUserByExternalLogin(ctx context.Context, req *reviewerv1.GetUserByExternalLoginRequest) (*reviewerv1.User, error) {
+	if err := validators.ValidateProvider(req.GetProvider()); err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := validators.ValidateExternalLogin(req.GetLogin()); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	user, err := s.userService.GetByExternalLogin(models.VCSProvider(req.GetProvider()), req.GetLogin())
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toUser(user), nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *reviewerv1.ListUsersRequest) (*reviewerv1.ListUsersResponse, error) {
+	users, err := s.userService.GetAll()
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &reviewerv1.ListUsersResponse{Users: toUsers(users)}, nil
+}
+
+func (s *UserServer) SetUserActive(ctx context.Context, req *reviewerv1.SetUserActiveRequest) (*reviewerv1.User, error) {
+	userID, err := positiveID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if _, err := s.userService.GetByID(userID); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	if err := s.userService.SetActive(userID, req.GetIsActive()); err != nil {
+		return nil, ToStatus(err)
+	}
+	return s.getUser(userID)
+}
+
+func (s *UserServer) SetWorkingHours(ctx context.Context, req *reviewerv1.SetWorkingHoursRequest) (*reviewerv1.User, error) {
+	userID, err := positiveID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	hours := workingHoursDTO(req.GetWorkingHours())
+	if hours == nil {
+		hours = &dtos.WorkingHours{}
+	}
+	if err := validators.ValidateWorkingHours(hours); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	if err := s.userService.SetWorkingHours(userID, mappers.WorkingHoursToDomain(*hours)); err != nil {
+		return nil, ToStatus(err)
+	}
+	return s.getUser(userID)
+}
+
+func (s *UserServer) DeactivateUser(ctx context.Context, req *reviewerv1.DeactivateUserRequest) (*reviewerv1.User, error) {
+	userID, err := positiveID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	if err := s.userService.Deactivate(userID); err != nil {
+		return nil, ToStatus(err)
+	}
+	return s.getUser(userID)
+}
+
+func (s *UserServer) ListIdentities(ctx context.Context, req *reviewerv1.ListIdentitiesRequest) (*reviewerv1.ListIdentitiesResponse, error) {
+	userID, err := positiveID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	identities, err := s.userService.GetIdentities(userID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	response := &reviewerv1.ListIdentitiesResponse{Identities: make([]*reviewerv1.UserIdentity, len(identities))}
+	for i, identity := range identities {
+		response.Identities[i] = toIdentity(identity)
+	}
+	return response, nil
+}
+
+func (s *UserServer) AddIdentity(ctx context.Context, req *reviewerv1.AddIdentityRequest) (*reviewerv1.UserIdentity, error) {
+	userID, err := positiveID(req.GetUserId(), "user_id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	createReq := dtos.CreateUserIdentityRequest{Provider: req.GetProvider(), Login: req.GetLogin()}
+	if err := validators.ValidateCreateUserIdentityRequest(&createReq); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	identity := mappers.CreateUserIdentityRequestToDomain(userID, createReq)
+	if err := s.userService.AddIdentity(identity); err != nil {
+		return nil, ToStatus(err)
+	}
+	return toIdentity(identity), nil
+}
+
+func (s *UserServer) UpdateIdentity(ctx context.Context, req *reviewerv1.UpdateIdentityRequest) (*reviewerv1.UserIdentity, error) {
+	identityID, err := positiveID(req.GetId(), "identity id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	if err := validators.ValidateExternalLogin(req.GetLogin()); err != nil {
+		return nil, ToStatus(err)
+	}
+
+	identity, err := s.userService.GetIdentityByID(identityID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	identity.UpdateLogin(req.GetLogin())
+	if err := s.userService.UpdateIdentity(identity); err != nil {
+		return nil, ToStatus(err)
+	}
+	return toIdentity(identity), nil
+}
+
+func (s *UserServer) DeleteIdentity(ctx context.Context, req *reviewerv1.DeleteIdentityRequest) (*emptypb.Empty, error) {
+	identityID, err := positiveID(req.GetId(), "identity id")
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+
+	if err := s.userService.DeleteIdentity(identityID); err != nil {
+		return nil, ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) getUser(userID int) (*reviewerv1.User, error) {
+	user, err := s.userService.GetByID(userID)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return toUser(user), nil
+}
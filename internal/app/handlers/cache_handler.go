@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+type CacheHandler struct {
+	cacheService services.CacheService
+}
+
+func NewCacheHandler(cacheService services.CacheService) *CacheHandler {
+	return &CacheHandler{cacheService: cacheService}
+}
+
+func (h *CacheHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	sendJSONResponse(w, http.StatusOK, mappers.ToCacheStatsListResponse(h.cacheService.Stats()))
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"reviewer-assignment-service/internal/app/docs"
+	"reviewer-assignment-service/internal/app/response_errors"
+)
+
+type DocsHandler struct{}
+
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{}
+}
+
+func (h *DocsHandler) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	spec, err := docs.JSON()
+	if err != nil {
+		response_errors.SendInternalError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(spec)
+}
+
+func (h *DocsHandler) GetDocsPage(w http.ResponseWriter, r *http.Request) {
+	page, err := docs.Page()
+	if err != nil {
+		response_errors.SendInternalError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(page)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"reviewer-assignment-service/internal/domain/models"
+	"strconv"
+	"strings"
+)
+
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", etag(version))
+}
+
+// checkIfMatch requires If-Match on changes to pull requests and teams: one of the listed tags has
+// to match the version the handler has just read, and * explicitly changes whichever version is
+// current.
+func checkIfMatch(r *http.Request, version int) error {
+	values := r.Header.Values("If-Match")
+	if len(values) == 0 {
+		return models.ErrVersionRequired
+	}
+	current := etag(version)
+	for _, tag := range strings.Split(strings.Join(values, ","), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current {
+			return nil
+		}
+	}
+	return models.ErrConflictingUpdate
+}
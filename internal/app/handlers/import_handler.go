@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/services"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxImportBodyBytes = 10 << 20
+	csvContentType     = "text/csv"
+)
+
+type ImportHandler struct {
+	importService services.ImportService
+}
+
+func NewImportHandler(importService services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response_errors.SendValidationError(w, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	var doc dtos.OrgDocument
+	body := http.MaxBytesReader(w, r.Body, maxImportBodyBytes)
+	if isCSV(r.Header.Get("Content-Type")) {
+		parsed, err := mappers.ReadOrgCSV(body)
+		if errors.Is(err, mappers.ErrInvalidCSV) {
+			response_errors.SendError(w, "INVALID_CSV", err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			response_errors.SendBadRequest(w, "Unable to read request body")
+			return
+		}
+		doc = parsed
+	} else if err := json.NewDecoder(body).Decode(&doc); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateOrgDocument(&doc); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	report, err := h.importService.Import(mappers.ToOrgDocumentModel(doc), dryRun)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ToImportReportResponse(report))
+}
+
+func (h *ImportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" && strings.Contains(r.Header.Get("Accept"), csvContentType) {
+		format = "csv"
+	}
+	if format != "" && format != "csv" && format != "json" {
+		response_errors.SendValidationError(w, "format must be 'json' or 'csv'")
+		return
+	}
+
+	doc, err := h.importService.Export()
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := mappers.ToOrgDocumentResponse(doc)
+	if format != "csv" {
+		sendJSONResponse(w, http.StatusOK, response)
+		return
+	}
+
+	w.Header().Set("Content-Type", csvContentType)
+	w.WriteHeader(http.StatusOK)
+	mappers.WriteOrgCSV(w, response)
+}
+
+func isCSV(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == csvContentType
+}
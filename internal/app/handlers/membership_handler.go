@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MembershipHandler struct {
+	membershipService services.MembershipService
+}
+
+func NewMembershipHandler(membershipService services.MembershipService) *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: membershipService,
+	}
+}
+
+func (h *MembershipHandler) MoveUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.MoveUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateMoveUserRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	move, err := h.membershipService.MoveUser(userID, req.TeamName, req.ReassignReviews)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ToMoveUserResponse(move))
+}
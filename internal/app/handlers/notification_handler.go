@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/notifications"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+	digestService       services.DigestService
+}
+
+func NewNotificationHandler(notificationService services.NotificationService, digestService services.DigestService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		digestService:       digestService,
+	}
+}
+
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(userID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.NotificationPreferencesToResponse(userID, preferences))
+}
+
+func (h *NotificationHandler) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.NotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateNotificationPreferencesRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	preferences, err := h.notificationService.SetPreferences(userID, mappers.NotificationPreferencesRequestToDomain(userID, req))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.NotificationPreferencesToResponse(userID, preferences))
+}
+
+// PreviewDigest shows the digest the user would get now, whether or not they get digests.
+func (h *NotificationHandler) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	digest, err := h.digestService.Build(userID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	subject, text, html, err := notifications.RenderDigest(digest)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.DigestToResponse(digest, subject, text, html))
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+	"strconv"
+)
+
+type OrgSyncHandler struct {
+	syncService services.OrgSyncService
+}
+
+func NewOrgSyncHandler(syncService services.OrgSyncService) *OrgSyncHandler {
+	return &OrgSyncHandler{
+		syncService: syncService,
+	}
+}
+
+// Sync reconciles the database with a desired org chart. Without apply=true only the plan is returned.
+func (h *OrgSyncHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	apply := false
+	if value := r.URL.Query().Get("apply"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response_errors.SendValidationError(w, "apply must be true or false")
+			return
+		}
+		apply = parsed
+	}
+
+	var doc dtos.OrgDocument
+	body := http.MaxBytesReader(w, r.Body, maxImportBodyBytes)
+	if isJSON(r.Header.Get("Content-Type")) {
+		if err := json.NewDecoder(body).Decode(&doc); err != nil {
+			response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+			return
+		}
+	} else {
+		parsed, err := mappers.ReadOrgYAML(body)
+		if errors.Is(err, mappers.ErrInvalidYAML) {
+			response_errors.SendError(w, "INVALID_YAML", err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			response_errors.SendBadRequest(w, "Unable to read request body")
+			return
+		}
+		doc = parsed
+	}
+
+	if err := validators.ValidateOrgDocument(&doc); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+	desired := mappers.ToOrgDocumentModel(doc)
+
+	if !apply {
+		plan, err := h.syncService.Plan(desired)
+		if err != nil {
+			response_errors.HandleServiceError(w, err)
+			return
+		}
+		sendJSONResponse(w, http.StatusOK, mappers.ToSyncResponse(plan, false, []*models.Reassignment{}))
+		return
+	}
+
+	result, err := h.syncService.Apply(desired)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+	sendJSONResponse(w, http.StatusOK, mappers.ToSyncResponse(result.Plan, result.Applied, result.Reassignments))
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
@@ -7,9 +7,9 @@ import (
 	"reviewer-assignment-service/internal/app/transport/dtos"
 	"reviewer-assignment-service/internal/app/transport/mappers"
 	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/clock"
 	"reviewer-assignment-service/internal/domain/models"
 	"reviewer-assignment-service/internal/domain/services"
-	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -17,12 +17,14 @@ import (
 type PullRequestHandler struct {
 	prService   services.PullRequestService
 	userService services.UserService
+	clock       clock.Clock
 }
 
-func NewPullRequestHandler(prService services.PullRequestService, userService services.UserService) *PullRequestHandler {
+func NewPullRequestHandler(prService services.PullRequestService, userService services.UserService, clock clock.Clock) *PullRequestHandler {
 	return &PullRequestHandler{
 		prService:   prService,
 		userService: userService,
+		clock:       clock,
 	}
 }
 
@@ -39,22 +41,16 @@ func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	author, err := h.userService.GetByID(req.AuthorID)
+	author, err := h.userService.GetByID(req.AuthorID.Int())
 	if err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
-	pr := &models.PullRequest{
-		Name:      req.Name,
-		Status:    models.StatusOpen,
-		Author:    author,
-		Reviewers: make([]*models.User, 0),
-		CreatedAt: time.Now(),
-	}
+	pr := mappers.ToPullRequestModel(&req, author, h.clock.Now())
 
 	for _, reviewerID := range req.Reviewers {
-		reviewer, err := h.userService.GetByID(reviewerID)
+		reviewer, err := h.userService.GetByID(reviewerID.Int())
 		if err != nil {
 			response_errors.HandleServiceError(w, err)
 			return
@@ -70,8 +66,18 @@ func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	response := mappers.ToPullRequestResponse(pr)
-	sendJSONResponse(w, http.StatusCreated, response)
+	// The pull request is stored by now, so a failed assignment is reported along with it: an
+	// error would make the client retry and create it a second time.
+	var warning error
+	if len(req.Reviewers) == 0 {
+		if warning = h.prService.AssignReviewers(pr); warning != nil {
+			if stored, err := h.prService.GetByID(pr.ID); err == nil {
+				pr = stored
+			}
+		}
+	}
+
+	h.sendAssignment(w, http.StatusCreated, pr, warning)
 }
 
 func (h *PullRequestHandler) GetPullRequestByID(w http.ResponseWriter, r *http.Request) {
@@ -88,6 +94,7 @@ func (h *PullRequestHandler) GetPullRequestByID(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	setETag(w, pr.Version)
 	response := mappers.ToPullRequestResponse(pr)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -152,28 +159,27 @@ func (h *PullRequestHandler) UpdatePullRequest(w http.ResponseWriter, r *http.Re
 		response_errors.HandleServiceError(w, err)
 		return
 	}
+	if err := checkIfMatch(r, pr.Version); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
 
-	pr.Name = req.Name
-	pr.Status = models.PRStatus(req.Status)
-
-	pr.Reviewers = make([]*models.User, 0)
+	reviewers := make([]*models.User, 0, len(req.Reviewers))
 	for _, reviewerID := range req.Reviewers {
-		reviewer, err := h.userService.GetByID(reviewerID)
+		reviewer, err := h.userService.GetByID(reviewerID.Int())
 		if err != nil {
 			response_errors.HandleServiceError(w, err)
 			return
 		}
-		if err := pr.AddReviewer(reviewer); err != nil {
-			response_errors.HandleServiceError(w, err)
-			return
-		}
+		reviewers = append(reviewers, reviewer)
 	}
 
-	if err := h.prService.Update(pr); err != nil {
+	if err := h.prService.Edit(pr, req.Name, models.PRStatus(req.Status), reviewers); err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
+	setETag(w, pr.Version)
 	response := mappers.ToPullRequestResponse(pr)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -191,12 +197,17 @@ func (h *PullRequestHandler) MergePullRequest(w http.ResponseWriter, r *http.Req
 		response_errors.HandleServiceError(w, err)
 		return
 	}
+	if err := checkIfMatch(r, pr.Version); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
 
 	if err := h.prService.MergeRequest(pr); err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
+	setETag(w, pr.Version)
 	response := mappers.ToPullRequestResponse(pr)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -225,8 +236,12 @@ func (h *PullRequestHandler) ReassignReviewers(w http.ResponseWriter, r *http.Re
 		response_errors.HandleServiceError(w, err)
 		return
 	}
+	if err := checkIfMatch(r, pr.Version); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
 
-	oldReviewer, err := h.userService.GetByID(req.OldReviewerID)
+	oldReviewer, err := h.userService.GetByID(req.OldReviewerID.Int())
 	if err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
@@ -243,8 +258,25 @@ func (h *PullRequestHandler) ReassignReviewers(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	response := mappers.ToPullRequestResponse(updatedPR)
-	sendJSONResponse(w, http.StatusOK, response)
+	h.sendAssignment(w, http.StatusOK, updatedPR, nil)
+}
+
+// sendAssignment answers with the reviewers' external logins so a bot can request reviews on the code host,
+// and with the warning if reviewers could not be assigned.
+func (h *PullRequestHandler) sendAssignment(w http.ResponseWriter, status int, pr *models.PullRequest, warning error) {
+	identities, err := h.userService.GetIdentitiesByUserIDs(mappers.ReviewerIDs(pr))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	setETag(w, pr.Version)
+	response := mappers.ToPullRequestResponseWithLogins(pr, identities)
+	if warning != nil {
+		code, message, _ := response_errors.ClassifyError(warning)
+		response.Warning = &dtos.Warning{Code: code, Message: message}
+	}
+	sendJSONResponse(w, status, response)
 }
 
 func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
@@ -271,23 +303,23 @@ func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request)
 		response_errors.HandleServiceError(w, err)
 		return
 	}
-
-	reviewer, err := h.userService.GetByID(req.ReviewerID)
-	if err != nil {
+	if err := checkIfMatch(r, pr.Version); err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
-	if err := pr.AddReviewer(reviewer); err != nil {
+	reviewer, err := h.userService.GetByID(req.ReviewerID.Int())
+	if err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
-	if err := h.prService.Update(pr); err != nil {
+	if err := h.prService.AddReviewers(pr, reviewer); err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
+	setETag(w, pr.Version)
 	response := mappers.ToPullRequestResponse(pr)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -312,17 +344,17 @@ func (h *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Reque
 		response_errors.HandleServiceError(w, err)
 		return
 	}
-
-	if err := pr.RemoveReviewer(reviewerID); err != nil {
+	if err := checkIfMatch(r, pr.Version); err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
-	if err := h.prService.Update(pr); err != nil {
+	if err := h.prService.RemoveReviewer(pr, reviewerID); err != nil {
 		response_errors.HandleServiceError(w, err)
 		return
 	}
 
+	setETag(w, pr.Version)
 	response := mappers.ToPullRequestResponse(pr)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReviewHandler struct {
+	reviewService services.ReviewService
+}
+
+func NewReviewHandler(reviewService services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *ReviewHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	prID, err := validators.ValidatePullRequestID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	reviews, err := h.reviewService.GetReviews(prID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.PullRequestReviewsToResponse(reviews))
+}
+
+func (h *ReviewHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	prID, err := validators.ValidatePullRequestID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateSubmitReviewRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	reviews, err := h.reviewService.SubmitReview(prID, req.ReviewerID.Int(), models.ReviewState(req.State))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.PullRequestReviewsToResponse(reviews))
+}
+
+func (h *ReviewHandler) GetTeamApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	policy, err := h.reviewService.GetPolicy(teamID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ApprovalPolicyToResponse(policy))
+}
+
+func (h *ReviewHandler) SetTeamApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.ApprovalPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateApprovalPolicyRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	policy := mappers.ApprovalPolicyRequestToDomain(teamID, req)
+	if err := h.reviewService.SetPolicy(policy); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ApprovalPolicyToResponse(policy))
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReviewSLAHandler struct {
+	slaService services.ReviewSLAService
+	clock      clock.Clock
+}
+
+func NewReviewSLAHandler(slaService services.ReviewSLAService, clock clock.Clock) *ReviewSLAHandler {
+	return &ReviewSLAHandler{
+		slaService: slaService,
+		clock:      clock,
+	}
+}
+
+func (h *ReviewSLAHandler) GetTeamSLA(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sla, err := h.slaService.GetSLA(teamID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ReviewSLAToResponse(sla))
+}
+
+func (h *ReviewSLAHandler) SetTeamSLA(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.ReviewSLARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateReviewSLARequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sla := mappers.ReviewSLARequestToDomain(teamID, req)
+	if err := h.slaService.SetSLA(sla); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ReviewSLAToResponse(sla))
+}
+
+func (h *ReviewSLAHandler) AcknowledgeReview(w http.ResponseWriter, r *http.Request) {
+	prID, err := validators.ValidatePullRequestID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.AcknowledgeReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateAcknowledgeReviewRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	assignments, err := h.slaService.Acknowledge(prID, req.ReviewerID.Int())
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"assignments": mappers.ReviewAssignmentsToResponse(assignments),
+	}
+	sendJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *ReviewSLAHandler) GetPullRequestEvents(w http.ResponseWriter, r *http.Request) {
+	prID, err := validators.ValidatePullRequestID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	events, err := h.slaService.GetEvents(prID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"events": mappers.PullRequestEventsToResponse(events),
+	}
+	sendJSONResponse(w, http.StatusOK, response)
+}
+
+// Escalate runs the same pass as the scheduler right away.
+func (h *ReviewSLAHandler) Escalate(w http.ResponseWriter, r *http.Request) {
+	events, err := h.slaService.Escalate(h.clock.Now())
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"events": mappers.PullRequestEventsToResponse(events),
+	}
+	sendJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReviewerRuleHandler struct {
+	ruleService services.ReviewerRuleService
+}
+
+func NewReviewerRuleHandler(ruleService services.ReviewerRuleService) *ReviewerRuleHandler {
+	return &ReviewerRuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+func (h *ReviewerRuleHandler) GetTeamRules(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	rules, err := h.ruleService.GetByTeamID(teamID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"rules": mappers.ReviewerRulesToResponse(rules),
+	}
+
+	sendJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *ReviewerRuleHandler) CreateTeamRule(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.CreateReviewerRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateCreateReviewerRuleRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	rule := mappers.CreateReviewerRuleRequestToDomain(teamID, req)
+	if err := h.ruleService.Create(rule); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"rule": mappers.ReviewerRuleToResponse(rule),
+	}
+
+	sendJSONResponse(w, http.StatusCreated, response)
+}
+
+func (h *ReviewerRuleHandler) DeleteTeamRule(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	ruleID, err := validators.ValidateRuleID(chi.URLParam(r, "ruleID"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	if err := h.ruleService.Delete(teamID, ruleID); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ReviewerRuleHandler) CheckCandidate(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	authorID, err := validators.ValidateAuthorID(r.URL.Query().Get("author_id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	candidateID, err := validators.ValidateCandidateID(r.URL.Query().Get("candidate_id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	check, err := h.ruleService.Check(teamID, authorID, candidateID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ToCandidateCheckResponse(check))
+}
+
+func (h *ReviewerRuleHandler) GetUserTags(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	tags, err := h.ruleService.GetUserTags(userID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ToUserTagsResponse(userID, tags))
+}
+
+func (h *ReviewerRuleHandler) SetUserTags(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.UserTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateUserTagsRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	tags, err := h.ruleService.SetUserTags(userID, req.Tags)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ToUserTagsResponse(userID, tags))
+}
+
+func (h *ReviewerRuleHandler) GetTeamPatterns(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	patterns, err := h.ruleService.GetPatternsByTeamID(teamID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"patterns": mappers.ReviewPatternsToResponse(patterns),
+	}
+
+	sendJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *ReviewerRuleHandler) CreateTeamPattern(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.CreateReviewPatternRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateCreateReviewPatternRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	pattern := mappers.CreateReviewPatternRequestToDomain(teamID, req)
+	if err := h.ruleService.CreatePattern(pattern); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pattern": mappers.ReviewPatternToResponse(pattern),
+	}
+
+	sendJSONResponse(w, http.StatusCreated, response)
+}
+
+func (h *ReviewerRuleHandler) DeleteTeamPattern(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	patternID, err := validators.ValidatePatternID(chi.URLParam(r, "patternID"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	if err := h.ruleService.DeletePattern(teamID, patternID); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ReviewerRuleHandler) GetTeamReviewLoad(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	load, err := h.ruleService.GetReviewLoad(teamID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ReviewLoadToResponse(load))
+}
+
+func (h *ReviewerRuleHandler) SetTeamReviewHalfLife(w http.ResponseWriter, r *http.Request) {
+	teamID, err := validators.ValidateTeamID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.ReviewHalfLifeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateReviewHalfLifeRequest(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	load, err := h.ruleService.SetReviewHalfLife(teamID, time.Duration(req.HalfLifeHours)*time.Hour)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, mappers.ReviewLoadToResponse(load))
+}
@@ -41,6 +41,7 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, team.Version)
 	response := mappers.ToTeamResponse(team)
 	sendJSONResponse(w, http.StatusCreated, response)
 }
@@ -59,6 +60,7 @@ func (h *TeamHandler) GetTeamByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, team.Version)
 	response := mappers.ToTeamResponse(team)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -76,6 +78,7 @@ func (h *TeamHandler) GetTeamByName(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, team.Version)
 	response := mappers.ToTeamResponse(team)
 	sendJSONResponse(w, http.StatusOK, response)
 }
@@ -116,6 +119,11 @@ func (h *TeamHandler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := checkIfMatch(r, team.Version); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
 	mappers.UpdateTeamFromRequest(team, req)
 
 	if err := h.teamService.Update(team); err != nil {
@@ -123,6 +131,7 @@ func (h *TeamHandler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, team.Version)
 	response := mappers.ToTeamResponse(team)
 	sendJSONResponse(w, http.StatusOK, response)
 }
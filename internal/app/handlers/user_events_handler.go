@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StreamHeartbeat is how often an idle event stream sends a comment, so that proxies and
+// clients do not time it out.
+const StreamHeartbeat = 10 * time.Second
+
+type UserEventsHandler struct {
+	eventService services.UserEventService
+	heartbeat    time.Duration
+}
+
+func NewUserEventsHandler(eventService services.UserEventService, heartbeat time.Duration) *UserEventsHandler {
+	return &UserEventsHandler{
+		eventService: eventService,
+		heartbeat:    heartbeat,
+	}
+}
+
+// StreamEvents sends the user's events as Server-Sent Events until the client leaves or the
+// server shuts down. The server's WriteTimeout would cut the stream off, so every write gets its
+// own deadline of two heartbeats instead, which also drops clients that stopped reading.
+func (h *UserEventsHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+	lastEventID, err := validators.ValidateLastEventID(r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sub, missed, err := h.eventService.Subscribe(userID, lastEventID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	stream := &eventStream{w: w, controller: http.NewResponseController(w), timeout: 2 * h.heartbeat}
+	if err := stream.open(); err != nil {
+		return
+	}
+
+	// Events from the log may also arrive through the subscription.
+	replayedThrough := lastEventID
+	for _, event := range missed {
+		if err := stream.event(event); err != nil {
+			return
+		}
+		replayedThrough = event.ID
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if event.ID <= replayedThrough {
+				continue
+			}
+			if err := stream.event(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := stream.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+type eventStream struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	timeout    time.Duration
+}
+
+func (s *eventStream) open() error {
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	s.w.WriteHeader(http.StatusOK)
+	return s.controller.Flush()
+}
+
+func (s *eventStream) event(event *models.PullRequestEvent) error {
+	data, err := json.Marshal(mappers.PullRequestEventToResponse(event))
+	if err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, data))
+}
+
+func (s *eventStream) write(message string) error {
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(s.w, message); err != nil {
+		return err
+	}
+	return s.controller.Flush()
+}
+
+func (s *eventStream) extendDeadline() error {
+	err := s.controller.SetWriteDeadline(time.Now().Add(s.timeout))
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
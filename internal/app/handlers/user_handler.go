@@ -7,9 +7,12 @@ import (
 	"reviewer-assignment-service/internal/app/transport/dtos"
 	"reviewer-assignment-service/internal/app/transport/mappers"
 	"reviewer-assignment-service/internal/app/validators"
-	"strconv"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
 
 	"reviewer-assignment-service/internal/domain/services"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -38,7 +41,8 @@ func (h *UserHandler) SetUserActive(w http.ResponseWriter, r *http.Request) {
 		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
 		return
 	}
-	userID, _ := strconv.Atoi(req.UserID)
+	markDeprecatedIDs(w, req.UserID)
+	userID := req.UserID.Int()
 
 	_, err := h.userService.GetByID(userID)
 	if err != nil {
@@ -67,22 +71,19 @@ func (h *UserHandler) SetUserActive(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) GetUserReviewPRs(w http.ResponseWriter, r *http.Request) {
-	userID := r.URL.Query().Get("user_id")
-
-	if err := validators.ValidateUserID(userID); err != nil {
+	userID, err := validators.ValidateUserID(r.URL.Query().Get("user_id"))
+	if err != nil {
 		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	userIDInt, _ := strconv.Atoi(userID)
-
-	_, err := h.userService.GetByID(userIDInt)
+	_, err = h.userService.GetByID(userID)
 	if err != nil {
 		response_errors.SendError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
 		return
 	}
 
-	prs, err := h.prService.GetByReviewerID(userIDInt)
+	prs, err := h.prService.GetByReviewerID(userID)
 	if err != nil {
 		response_errors.SendError(w, "INTERNAL_ERROR", "Failed to get user PRs", http.StatusInternalServerError)
 		return
@@ -143,7 +144,8 @@ func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, _ := strconv.Atoi(req.UserID)
+	markDeprecatedIDs(w, req.UserID)
+	userID := req.UserID.Int()
 
 	_, err := h.userService.GetByID(userID)
 	if err != nil {
@@ -178,7 +180,7 @@ func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var userResponses []dtos.UserResponse
+	userResponses := make([]dtos.UserResponse, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, mappers.UserToDetailedResponse(user))
 	}
@@ -193,14 +195,12 @@ func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
-	userIDStr := chi.URLParam(r, "id")
-
-	if err := validators.ValidateUserID(userIDStr); err != nil {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
 		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	userID, _ := strconv.Atoi(userIDStr)
 	user, err := h.userService.GetByID(userID)
 	if err != nil {
 		response_errors.SendError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
@@ -208,7 +208,7 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]interface{}{
-		"user": mappers.UserToResponse(user),
+		"user": mappers.UserToDetailedResponse(user),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -216,6 +216,40 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+func (h *UserHandler) SetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	var req dtos.WorkingHours
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_JSON", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateWorkingHours(&req); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	if err := h.userService.SetWorkingHours(userID, mappers.WorkingHoursToDomain(req)); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	user, err := h.userService.GetByID(userID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"user": mappers.UserToDetailedResponse(user),
+	})
+}
+
 func (h *UserHandler) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
 	email := r.URL.Query().Get("email")
 
@@ -238,3 +272,189 @@ func (h *UserHandler) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+func (h *UserHandler) GetUserByExternalLogin(w http.ResponseWriter, r *http.Request) {
+	provider := r.URL.Query().Get("provider")
+	login := r.URL.Query().Get("login")
+
+	if err := validators.ValidateProvider(provider); err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.ValidateExternalLogin(login); err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.GetByExternalLogin(models.VCSProvider(provider), login)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"user": mappers.UserToDetailedResponse(user),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *UserHandler) GetUserIdentities(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	identities, err := h.userService.GetIdentities(userID)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"identities": mappers.UserIdentitiesToResponse(identities),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *UserHandler) CreateUserIdentity(w http.ResponseWriter, r *http.Request) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dtos.CreateUserIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateCreateUserIdentityRequest(&req); err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	identity := mappers.CreateUserIdentityRequestToDomain(userID, req)
+	if err := h.userService.AddIdentity(identity); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"identity": mappers.UserIdentityToResponse(identity),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *UserHandler) GetUserIdentity(w http.ResponseWriter, r *http.Request) {
+	identity, ok := h.userIdentityFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	response := map[string]interface{}{
+		"identity": mappers.UserIdentityToResponse(identity),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *UserHandler) UpdateUserIdentity(w http.ResponseWriter, r *http.Request) {
+	identity, ok := h.userIdentityFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtos.UpdateUserIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validators.ValidateUpdateUserIdentityRequest(&req); err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	identity.UpdateLogin(req.Login)
+	if err := h.userService.UpdateIdentity(identity); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"identity": mappers.UserIdentityToResponse(identity),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *UserHandler) DeleteUserIdentity(w http.ResponseWriter, r *http.Request) {
+	identity, ok := h.userIdentityFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DeleteIdentity(identity.ID); err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// userIdentityFromPath loads the identity from the URL and makes sure it belongs to the user in the same path.
+func (h *UserHandler) userIdentityFromPath(w http.ResponseWriter, r *http.Request) (*models.UserIdentity, bool) {
+	userID, err := validators.ValidateUserID(chi.URLParam(r, "id"))
+	if err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	identityID, err := validators.ValidateIdentityID(chi.URLParam(r, "identityID"))
+	if err != nil {
+		response_errors.SendError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	identity, err := h.userService.GetIdentityByID(identityID)
+	if err == nil && identity.UserID != userID {
+		err = repositories.ErrUserIdentityNotFound
+	}
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return nil, false
+	}
+
+	return identity, true
+}
+
+func markDeprecatedIDs(w http.ResponseWriter, ids ...dtos.LegacyID) {
+	for _, id := range ids {
+		if id.IsLegacy() {
+			w.Header().Set("Deprecation", legacyIDDeprecation)
+			w.Header().Set("Link", `</docs>; rel="deprecation"`)
+			return
+		}
+	}
+}
+
+// legacyIDsDeprecatedAt is when string IDs were deprecated; the Deprecation header carries it
+// as a Unix timestamp.
+var legacyIDsDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+var legacyIDDeprecation = "@" + strconv.FormatInt(legacyIDsDeprecatedAt.Unix(), 10)
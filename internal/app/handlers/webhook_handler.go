@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/webhooks"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+const maxWebhookBodyBytes = 1 << 20
+
+type webhookParser func(header http.Header, body []byte, secret string) (*models.VCSEvent, error)
+
+type WebhookHandler struct {
+	integrationService services.IntegrationService
+	userService        services.UserService
+	githubSecret       string
+	gitlabToken        string
+}
+
+func NewWebhookHandler(
+	integrationService services.IntegrationService,
+	userService services.UserService,
+	githubSecret, gitlabToken string,
+) *WebhookHandler {
+	return &WebhookHandler{
+		integrationService: integrationService,
+		userService:        userService,
+		githubSecret:       githubSecret,
+		gitlabToken:        gitlabToken,
+	}
+}
+
+func (h *WebhookHandler) HandleGitHub(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, webhooks.ParseGitHub, h.githubSecret)
+}
+
+func (h *WebhookHandler) HandleGitLab(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, webhooks.ParseGitLab, h.gitlabToken)
+}
+
+func (h *WebhookHandler) handle(w http.ResponseWriter, r *http.Request, parse webhookParser, secret string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		response_errors.SendBadRequest(w, "Unable to read request body")
+		return
+	}
+
+	event, err := parse(r.Header, body, secret)
+	switch {
+	case errors.Is(err, webhooks.ErrInvalidSignature):
+		response_errors.SendError(w, "INVALID_SIGNATURE", "Webhook signature verification failed", http.StatusUnauthorized)
+		return
+	case errors.Is(err, webhooks.ErrIgnoredEvent):
+		sendJSONResponse(w, http.StatusOK, mappers.ToIgnoredWebhookResponse())
+		return
+	case errors.Is(err, webhooks.ErrInvalidPayload):
+		response_errors.SendError(w, "INVALID_JSON", "Invalid webhook payload", http.StatusBadRequest)
+		return
+	case err != nil:
+		response_errors.SendInternalError(w)
+		return
+	}
+
+	result, err := h.integrationService.HandleEvent(event)
+	if err != nil {
+		response_errors.HandleServiceError(w, err)
+		return
+	}
+
+	identities := make([]*models.UserIdentity, 0)
+	if result.PullRequest != nil {
+		identities, err = h.userService.GetIdentitiesByUserIDs(mappers.ReviewerIDs(result.PullRequest))
+		if err != nil {
+			response_errors.HandleServiceError(w, err)
+			return
+		}
+	}
+
+	response := mappers.ToWebhookResponse(event, result, identities)
+	if result.Warning != nil && response.PullRequest != nil {
+		code, message, _ := response_errors.ClassifyError(result.Warning)
+		response.PullRequest.Warning = &dtos.Warning{Code: code, Message: message}
+	}
+	sendJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,145 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+const (
+	KeyHeader      = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// retryable are the client errors a retry can get past without changing the request body: a
+// conflicting state, a missing or stale If-Match and a rate limit.
+var retryable = map[int]bool{
+	http.StatusConflict:             true,
+	http.StatusPreconditionFailed:   true,
+	http.StatusPreconditionRequired: true,
+	http.StatusTooManyRequests:      true,
+}
+
+// Middleware runs a request that carries an Idempotency-Key once and answers retries with the
+// stored response. Responses with a 5xx status or a retryable 4xx one are not stored, so such
+// requests can be retried with the same key. Requests without the header are passed through untouched.
+func Middleware(service services.IdempotencyService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(KeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > models.MaxIdempotencyKeyLength {
+				response_errors.HandleServiceError(w, validators.NewValidationError("Idempotency-Key must be at most 255 characters"))
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response_errors.SendBadRequest(w, "Could not read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			stored, err := service.Begin(key, RequestHash(r, body))
+			if err != nil {
+				response_errors.HandleServiceError(w, err)
+				return
+			}
+			if stored != nil {
+				replay(w, stored)
+				return
+			}
+
+			completed := false
+			defer func() {
+				if !completed {
+					if err := service.Release(key); err != nil {
+						log.Printf("Idempotency key %q was not released: %v", key, err)
+					}
+				}
+			}()
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			if rec.status >= http.StatusInternalServerError || retryable[rec.status] {
+				return
+			}
+
+			response := &models.IdempotencyKey{
+				Key:        key,
+				StatusCode: rec.status,
+				Headers:    w.Header().Clone(),
+				Body:       rec.body.Bytes(),
+			}
+			if err := service.Complete(response); err != nil {
+				log.Printf("Idempotency key %q: response was not stored: %v", key, err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// RequestHash fingerprints the method, URI and body. A JSON body is hashed in canonical form so
+// that a retry that only reorders fields or changes whitespace is still the same request.
+func RequestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(canonicalJSON(body))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func canonicalJSON(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return canonical
+}
+
+func replay(w http.ResponseWriter, stored *models.IdempotencyKey) {
+	for name, values := range stored.Headers {
+		w.Header()[name] = values
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+}
+
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"math"
+	"reviewer-assignment-service/internal/domain/clock"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket per client: a bucket holds up to Requests tokens and refills
+// evenly over Per, so a client can burst up to the limit and then keep the average rate.
+type Limiter struct {
+	requests int
+	per      time.Duration
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed; zero when it is allowed now.
+	RetryAfter time.Duration
+}
+
+// New returns nil when requests is not positive, which Middleware treats as no limit.
+func New(requests int, per time.Duration, clock clock.Clock) *Limiter {
+	if requests <= 0 || per <= 0 {
+		return nil
+	}
+	return &Limiter{
+		requests:  requests,
+		per:       per,
+		clock:     clock,
+		buckets:   make(map[string]*bucket),
+		lastSweep: clock.Now(),
+	}
+}
+
+func (l *Limiter) Allow(key string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.requests), updated: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.rate(), float64(l.requests))
+
+	decision := Decision{Limit: l.requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = l.durationFor(1 - b.tokens)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.Reset = l.durationFor(float64(l.requests) - b.tokens)
+	return decision
+}
+
+// rate is the number of tokens added per nanosecond.
+func (l *Limiter) rate() float64 {
+	return float64(l.requests) / float64(l.per)
+}
+
+func (l *Limiter) durationFor(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / l.rate()))
+}
+
+// sweep drops buckets that have been idle long enough to be full again; a missing bucket
+// behaves exactly like a full one, so this only bounds memory.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.per {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.per {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func (b *bucket) refill(now time.Time, rate, capacity float64) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)*rate)
+		b.updated = now
+	}
+}
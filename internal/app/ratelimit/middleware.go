@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"strconv"
+	"time"
+)
+
+const (
+	LimitHeader      = "RateLimit-Limit"
+	RemainingHeader  = "RateLimit-Remaining"
+	ResetHeader      = "RateLimit-Reset"
+	RetryAfterHeader = "Retry-After"
+)
+
+// Middleware answers 429 RATE_LIMITED once a client has used up its bucket. Every response
+// carries the RateLimit-* headers; a nil limiter passes all requests through.
+func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decision := limiter.Allow(ClientKey(r))
+
+			w.Header().Set(LimitHeader, strconv.Itoa(decision.Limit))
+			w.Header().Set(RemainingHeader, strconv.Itoa(decision.Remaining))
+			w.Header().Set(ResetHeader, seconds(decision.Reset))
+			if !decision.Allowed {
+				w.Header().Set(RetryAfterHeader, seconds(decision.RetryAfter))
+				response_errors.SendError(w, "RATE_LIMITED", "Too many requests, retry later", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientKey identifies the caller by the IP of the connection. The service has no client
+// authentication, and forwarded-for headers are not trusted because any client can set them.
+func ClientKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
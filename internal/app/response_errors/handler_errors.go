@@ -28,44 +28,89 @@ func SendError(w http.ResponseWriter, code, message string, status int) {
 }
 
 func HandleServiceError(w http.ResponseWriter, err error) {
+	code, message, status := ClassifyError(err)
+	SendError(w, code, message, status)
+}
+
+// ClassifyError gives the error code, message and HTTP status a service error is reported with;
+// the gRPC API reports errors with the same codes.
+func ClassifyError(err error) (code, message string, status int) {
 	switch {
 	case errors.Is(err, repositories.ErrUserAlreadyExists):
-		SendError(w, "USER_ALREADY_EXISTS", "User already exists", http.StatusConflict)
+		return "USER_ALREADY_EXISTS", "User already exists", http.StatusConflict
 	case errors.Is(err, repositories.ErrUserNotFoundInPersistence):
-		SendError(w, "USER_NOT_FOUND", "User not found", http.StatusNotFound)
+		return "USER_NOT_FOUND", "User not found", http.StatusNotFound
 	case errors.Is(err, repositories.ErrUserWithThatEmailNotFound):
-		SendError(w, "USER_NOT_FOUND", "User with this email not found", http.StatusNotFound)
+		return "USER_NOT_FOUND", "User with this email not found", http.StatusNotFound
+
+	case errors.Is(err, repositories.ErrUserIdentityNotFound):
+		return "IDENTITY_NOT_FOUND", "User identity not found", http.StatusNotFound
+	case errors.Is(err, repositories.ErrUserIdentityAlreadyExists):
+		return "IDENTITY_ALREADY_EXISTS", "Login is already mapped for this provider", http.StatusConflict
 
 	case errors.Is(err, repositories.ErrTeamNotFoundInPersistence):
-		SendError(w, "TEAM_NOT_FOUND", "Team not found", http.StatusNotFound)
+		return "TEAM_NOT_FOUND", "Team not found", http.StatusNotFound
 	case errors.Is(err, repositories.ErrTeamAlreadyExists):
-		SendError(w, "TEAM_ALREADY_EXISTS", "Team already exists", http.StatusConflict)
+		return "TEAM_ALREADY_EXISTS", "Team already exists", http.StatusConflict
 
 	case errors.Is(err, models.ErrMemberAlreadyInTeam):
-		SendError(w, "MEMBER_ALREADY_IN_TEAM", "User is already a member of this team", http.StatusConflict)
+		return "MEMBER_ALREADY_IN_TEAM", "User is already a member of this team", http.StatusConflict
 	case errors.Is(err, models.ErrMemberNotInTeam):
-		SendError(w, "MEMBER_NOT_IN_TEAM", "User is not a member of this team", http.StatusNotFound)
+		return "MEMBER_NOT_IN_TEAM", "User is not a member of this team", http.StatusNotFound
 
 	case errors.Is(err, models.ErrAuthorNotInTeam):
-		SendError(w, "AUTHOR_NOT_IN_TEAM", "Author not in team", http.StatusBadRequest)
+		return "AUTHOR_NOT_IN_TEAM", "Author not in team", http.StatusBadRequest
 	case errors.Is(err, models.ErrPRAlreadyMerged):
-		SendError(w, "PR_ALREADY_MERGED", "Cannot reassign on merged PR", http.StatusConflict)
+		return "PR_ALREADY_MERGED", "Cannot reassign on merged PR", http.StatusConflict
+	case errors.Is(err, models.ErrPRClosed):
+		return "PR_CLOSED", "Cannot modify reviewers on closed PR", http.StatusConflict
+	case errors.Is(err, models.ErrInvalidStatusChange):
+		return "INVALID_STATUS_CHANGE", "Pull requests are merged through POST /pull-requests/{id}/merge", http.StatusBadRequest
 	case errors.Is(err, models.ErrReviewerNotFound):
-		SendError(w, "REVIEWER_NOT_FOUND", "No active replacement candidate in team", http.StatusNotFound)
+		return "REVIEWER_NOT_FOUND", "No active replacement candidate in team", http.StatusNotFound
 	case errors.Is(err, models.ErrReviewerAlreadyAssigned):
-		SendError(w, "REVIEWER_ALREADY_ASSIGNED", "Reviewer already assigned to this PR", http.StatusConflict)
+		return "REVIEWER_ALREADY_ASSIGNED", "Reviewer already assigned to this PR", http.StatusConflict
 	case errors.Is(err, models.ErrTooManyReviewers):
-		SendError(w, "TOO_MANY_REVIEWERS", "Too many reviewers assigned", http.StatusBadRequest)
+		return "TOO_MANY_REVIEWERS", "Too many reviewers assigned", http.StatusBadRequest
 	case errors.Is(err, repositories.ErrPullRequestNotFoundInPersistence):
-		SendError(w, "PR_NOT_FOUND", "Pull request not found", http.StatusNotFound)
+		return "PR_NOT_FOUND", "Pull request not found", http.StatusNotFound
 	case errors.Is(err, repositories.ErrPullRequestAlreadyExists):
-		SendError(w, "PR_ALREADY_EXISTS", "Pull request already exists", http.StatusConflict)
+		return "PR_ALREADY_EXISTS", "Pull request already exists", http.StatusConflict
+	case errors.Is(err, models.ErrIdempotencyKeyReused):
+		return "IDEMPOTENCY_KEY_REUSED", "Idempotency key was already used with a different request", http.StatusUnprocessableEntity
+	case errors.Is(err, models.ErrIdempotencyKeyInProgress):
+		return "IDEMPOTENCY_KEY_IN_PROGRESS", "A request with this idempotency key is still in progress", http.StatusConflict
+	case errors.Is(err, models.ErrConflictingUpdate):
+		return "CONFLICTING_UPDATE", "Resource was modified by another request; fetch it again and retry", http.StatusPreconditionFailed
+	case errors.Is(err, models.ErrVersionRequired):
+		return "PRECONDITION_REQUIRED", "If-Match with the ETag of the version the change is based on is required", http.StatusPreconditionRequired
+
+	case errors.Is(err, models.ErrUnknownExternalUser):
+		return "UNKNOWN_EXTERNAL_USER", "External user is not mapped to a user", http.StatusUnprocessableEntity
+	case errors.Is(err, repositories.ErrExternalPullRequestAlreadyExists):
+		return "EXTERNAL_PR_ALREADY_EXISTS", "External pull request already linked", http.StatusConflict
+
+	case errors.Is(err, repositories.ErrReviewerRuleNotFound):
+		return "RULE_NOT_FOUND", "Reviewer rule not found", http.StatusNotFound
+	case errors.Is(err, repositories.ErrReviewPatternNotFound):
+		return "PATTERN_NOT_FOUND", "Review pattern not found", http.StatusNotFound
+	case errors.Is(err, models.ErrReviewerNotAssigned):
+		return "REVIEWER_NOT_ASSIGNED", "User is not a reviewer of the pull request", http.StatusNotFound
+	case errors.Is(err, models.ErrRuleUserNotInTeam):
+		return "RULE_USER_NOT_IN_TEAM", "Rule refers to a user outside the team", http.StatusBadRequest
+	case errors.Is(err, models.ErrRequiredReviewerUnavailable):
+		return "REQUIRED_REVIEWER_UNAVAILABLE", err.Error(), http.StatusUnprocessableEntity
+	case errors.Is(err, models.ErrNotEnoughApprovals):
+		return "NOT_ENOUGH_APPROVALS", err.Error(), http.StatusConflict
+
+	case errors.Is(err, models.ErrDuplicateTeamInDocument), errors.Is(err, models.ErrDuplicateEmailInDocument):
+		return "INVALID_DOCUMENT", err.Error(), http.StatusBadRequest
 
 	case isValidationError(err):
-		SendError(w, "VALIDATION_ERROR", err.Error(), http.StatusBadRequest)
+		return "VALIDATION_ERROR", err.Error(), http.StatusBadRequest
 
 	default:
-		SendError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		return "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError
 	}
 }
 
@@ -2,8 +2,13 @@ package routes
 
 import (
 	"net/http"
+	"reviewer-assignment-service/internal/app/config"
+	"reviewer-assignment-service/internal/app/graphqlapi"
 	"reviewer-assignment-service/internal/app/handlers"
+	"reviewer-assignment-service/internal/app/idempotency"
+	"reviewer-assignment-service/internal/app/ratelimit"
 
+	"reviewer-assignment-service/internal/domain/clock"
 	"reviewer-assignment-service/internal/domain/services"
 
 	"github.com/go-chi/chi/v5"
@@ -14,6 +19,21 @@ func SetupRouter(
 	userService services.UserService,
 	prService services.PullRequestService,
 	teamService services.TeamService,
+	integrationService services.IntegrationService,
+	importService services.ImportService,
+	syncService services.OrgSyncService,
+	membershipService services.MembershipService,
+	ruleService services.ReviewerRuleService,
+	slaService services.ReviewSLAService,
+	reviewService services.ReviewService,
+	idempotencyService services.IdempotencyService,
+	userEventService services.UserEventService,
+	notificationService services.NotificationService,
+	digestService services.DigestService,
+	cacheService services.CacheService,
+	integrations config.IntegrationsConfig,
+	rateLimits config.RateLimitConfig,
+	clock clock.Clock,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -30,21 +50,64 @@ func SetupRouter(
 
 	userHandler := handlers.NewUserHandler(userService, prService)
 	teamHandler := handlers.NewTeamHandler(teamService)
-	prHandler := handlers.NewPullRequestHandler(prService, userService)
+	prHandler := handlers.NewPullRequestHandler(prService, userService, clock)
+	importHandler := handlers.NewImportHandler(importService)
+	syncHandler := handlers.NewOrgSyncHandler(syncService)
+	membershipHandler := handlers.NewMembershipHandler(membershipService)
+	ruleHandler := handlers.NewReviewerRuleHandler(ruleService)
+	slaHandler := handlers.NewReviewSLAHandler(slaService, clock)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	userEventsHandler := handlers.NewUserEventsHandler(userEventService, handlers.StreamHeartbeat)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, digestService)
+	cacheHandler := handlers.NewCacheHandler(cacheService)
+	docsHandler := handlers.NewDocsHandler()
+	graphqlHandler := graphqlapi.NewHandler(userService, teamService, prService, graphqlapi.DefaultLimits)
+	idempotent := idempotency.Middleware(idempotencyService)
+	limitUsers := ratelimit.Middleware(ratelimit.New(rateLimits.Users.Requests, rateLimits.Users.Per, clock))
+	limitTeams := ratelimit.Middleware(ratelimit.New(rateLimits.Teams.Requests, rateLimits.Teams.Per, clock))
+	limitPullRequests := ratelimit.Middleware(ratelimit.New(rateLimits.PullRequests.Requests, rateLimits.PullRequests.Per, clock))
+	webhookHandler := handlers.NewWebhookHandler(
+		integrationService,
+		userService,
+		integrations.GitHubWebhookSecret,
+		integrations.GitLabWebhookToken,
+	)
 
 	r.Route("/", func(r chi.Router) {
 
 		r.Route("/users", func(r chi.Router) {
+			r.Use(limitUsers)
 			r.Post("/", userHandler.CreateUser)
-			r.Post("/setIsActive", userHandler.SetUserActive)
-			r.Post("/deactivate", userHandler.DeactivateUser)
+			r.With(idempotent).Post("/setIsActive", userHandler.SetUserActive)
+			r.With(idempotent).Post("/deactivate", userHandler.DeactivateUser)
 			r.Get("/getReview", userHandler.GetUserReviewPRs)
 			r.Get("/by-email", userHandler.GetUserByEmail)
+			r.Get("/by-login", userHandler.GetUserByExternalLogin)
 			r.Get("/", userHandler.GetAllUsers)
-			r.Get("/{id}", userHandler.GetUserByID)
+
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", userHandler.GetUserByID)
+				r.Post("/move", membershipHandler.MoveUser)
+				r.Put("/working-hours", userHandler.SetWorkingHours)
+				r.Get("/events", userEventsHandler.StreamEvents)
+				r.Get("/notifications", notificationHandler.GetPreferences)
+				r.Put("/notifications", notificationHandler.SetPreferences)
+				r.Get("/digest", notificationHandler.PreviewDigest)
+				r.Get("/tags", ruleHandler.GetUserTags)
+				r.Put("/tags", ruleHandler.SetUserTags)
+
+				r.Route("/identities", func(r chi.Router) {
+					r.Get("/", userHandler.GetUserIdentities)
+					r.Post("/", userHandler.CreateUserIdentity)
+					r.Get("/{identityID}", userHandler.GetUserIdentity)
+					r.Put("/{identityID}", userHandler.UpdateUserIdentity)
+					r.Delete("/{identityID}", userHandler.DeleteUserIdentity)
+				})
+			})
 		})
 
 		r.Route("/teams", func(r chi.Router) {
+			r.Use(limitTeams)
 			r.Get("/", teamHandler.GetAllTeams)
 			r.Post("/", teamHandler.CreateTeam)
 			r.Get("/by-name/{name}", teamHandler.GetTeamByName)
@@ -52,13 +115,36 @@ func SetupRouter(
 			r.Route("/{id}", func(r chi.Router) {
 				r.Get("/", teamHandler.GetTeamByID)
 				r.Put("/", teamHandler.UpdateTeam)
+
+				r.Route("/rules", func(r chi.Router) {
+					r.Get("/", ruleHandler.GetTeamRules)
+					r.Post("/", ruleHandler.CreateTeamRule)
+					r.Get("/check", ruleHandler.CheckCandidate)
+					r.Delete("/{ruleID}", ruleHandler.DeleteTeamRule)
+				})
+
+				r.Route("/patterns", func(r chi.Router) {
+					r.Get("/", ruleHandler.GetTeamPatterns)
+					r.Post("/", ruleHandler.CreateTeamPattern)
+					r.Delete("/{patternID}", ruleHandler.DeleteTeamPattern)
+				})
+
+				r.Get("/fairness", ruleHandler.GetTeamReviewLoad)
+				r.Put("/fairness", ruleHandler.SetTeamReviewHalfLife)
+
+				r.Get("/sla", slaHandler.GetTeamSLA)
+				r.Put("/sla", slaHandler.SetTeamSLA)
+
+				r.Get("/approvals", reviewHandler.GetTeamApprovalPolicy)
+				r.Put("/approvals", reviewHandler.SetTeamApprovalPolicy)
 			})
 		})
 
 	})
 
 	r.Route("/pull-requests", func(r chi.Router) {
-		r.Post("/", prHandler.CreatePullRequest)
+		r.Use(limitPullRequests)
+		r.With(idempotent).Post("/", prHandler.CreatePullRequest)
 
 		r.Get("/author/{authorID}", prHandler.GetPullRequestsByAuthor)
 		r.Get("/reviewer/{reviewerID}", prHandler.GetPullRequestsByReviewer)
@@ -67,15 +153,34 @@ func SetupRouter(
 			r.Get("/", prHandler.GetPullRequestByID)
 			r.Put("/", prHandler.UpdatePullRequest)
 
-			r.Post("/merge", prHandler.MergePullRequest)
-			r.Post("/reassign", prHandler.ReassignReviewers)
+			r.With(idempotent).Post("/merge", prHandler.MergePullRequest)
+			r.With(idempotent).Post("/reassign", prHandler.ReassignReviewers)
+			r.Post("/ack", slaHandler.AcknowledgeReview)
+			r.Get("/events", slaHandler.GetPullRequestEvents)
+			r.Get("/reviews", reviewHandler.GetReviews)
+			r.Post("/reviews", reviewHandler.SubmitReview)
 		})
 	})
 
+	r.Route("/integrations", func(r chi.Router) {
+		r.Post("/github", webhookHandler.HandleGitHub)
+		r.Post("/gitlab", webhookHandler.HandleGitLab)
+	})
+
+	r.Post("/import", importHandler.Import)
+	r.Get("/export", importHandler.Export)
+	r.Post("/sync", syncHandler.Sync)
+	r.Post("/sla/escalate", slaHandler.Escalate)
+	r.Post("/graphql", graphqlHandler.ServeHTTP)
+	r.Get("/cache/stats", cacheHandler.GetStats)
+
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status": "ok"}`))
 	})
 
+	r.Get("/openapi.json", docsHandler.GetOpenAPISpec)
+	r.Get("/docs", docsHandler.GetDocsPage)
+
 	return r
 }
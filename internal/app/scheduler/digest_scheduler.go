@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+)
+
+// DigestCheckInterval is how often the scheduler checks whether the day's digests are due.
+const DigestCheckInterval = 5 * time.Minute
+
+// DigestScheduler sends the daily digests once a day, on the first check after hour (UTC).
+type DigestScheduler struct {
+	digestService services.DigestService
+	hour          int
+	interval      time.Duration
+	clock         clock.Clock
+	sentOn        time.Time
+}
+
+func NewDigestScheduler(digestService services.DigestService, hour int, interval time.Duration, clock clock.Clock) *DigestScheduler {
+	return &DigestScheduler{
+		digestService: digestService,
+		hour:          hour,
+		interval:      interval,
+		clock:         clock,
+	}
+}
+
+// Run checks every interval until ctx is cancelled. A failed pass is logged and retried on the
+// next check; after a restart the day's digests are built again, but users who already got
+// theirs are skipped by the notification queue.
+func (s *DigestScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := s.clock.Now().UTC()
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			if now.Hour() < s.hour || s.sentOn.Equal(today) {
+				continue
+			}
+			queued, err := s.digestService.Send(now)
+			if err != nil {
+				log.Printf("Digest generation failed: %v", err)
+				continue
+			}
+			s.sentOn = today
+			log.Printf("Digest generation: %d digests queued", queued)
+		}
+	}
+}
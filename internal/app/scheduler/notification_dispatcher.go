@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+)
+
+// NotificationDispatcher periodically sends the queued notifications that are due.
+type NotificationDispatcher struct {
+	notificationService services.NotificationService
+	interval            time.Duration
+	clock               clock.Clock
+}
+
+func NewNotificationDispatcher(notificationService services.NotificationService, interval time.Duration, clock clock.Clock) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		notificationService: notificationService,
+		interval:            interval,
+		clock:               clock,
+	}
+}
+
+// Run delivers every interval until ctx is cancelled. A failed pass is logged and retried on the
+// next tick.
+func (d *NotificationDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := d.notificationService.Deliver(d.clock.Now())
+			if err != nil {
+				log.Printf("Notification delivery failed: %v", err)
+			}
+			if sent > 0 {
+				log.Printf("Notification delivery: %d sent", sent)
+			}
+		}
+	}
+}
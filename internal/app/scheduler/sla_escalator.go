@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+)
+
+// SLAEscalator periodically escalates the PRs whose reviewers have missed their team's SLA.
+type SLAEscalator struct {
+	slaService services.ReviewSLAService
+	interval   time.Duration
+	clock      clock.Clock
+}
+
+func NewSLAEscalator(slaService services.ReviewSLAService, interval time.Duration, clock clock.Clock) *SLAEscalator {
+	return &SLAEscalator{
+		slaService: slaService,
+		interval:   interval,
+		clock:      clock,
+	}
+}
+
+// Run checks every interval until ctx is cancelled. A failed pass is logged and retried on the
+// next tick.
+func (e *SLAEscalator) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			events, err := e.slaService.Escalate(e.clock.Now())
+			if err != nil {
+				log.Printf("SLA escalation failed: %v", err)
+			}
+			if len(events) > 0 {
+				log.Printf("SLA escalation: %d reviewer changes", len(events))
+			}
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package dtos
+
+type CacheStatsResponse struct {
+	Name    string `json:"name"`
+	Hits    int64  `json:"hits"`
+	Misses  int64  `json:"misses"`
+	Entries int    `json:"entries"`
+}
+
+type CacheStatsListResponse struct {
+	Caches []CacheStatsResponse `json:"caches"`
+}
@@ -0,0 +1,98 @@
+package dtos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ID is a numeric identifier; it is always encoded as a JSON number and only decoded from one.
+type ID struct {
+	value int
+}
+
+func NewID(value int) ID {
+	return ID{value: value}
+}
+
+func NewIDs(values []int) []ID {
+	ids := make([]ID, len(values))
+	for i, value := range values {
+		ids[i] = NewID(value)
+	}
+	return ids
+}
+
+func ParseID(s string) (ID, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return ID{}, ErrInvalidID
+	}
+	return NewID(value), nil
+}
+
+func (id ID) Int() int {
+	return id.value
+}
+
+func (id ID) String() string {
+	return strconv.Itoa(id.value)
+}
+
+func (id ID) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(id.value), 10), nil
+}
+
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ErrInvalidID
+	}
+	*id = NewID(value)
+	return nil
+}
+
+// LegacyID is an ID that the user endpoints also accept as a numeric string, the form they
+// took before IDs became numbers. It is only decoded in requests to those endpoints, which
+// answer the string form with a Deprecation header.
+type LegacyID struct {
+	ID
+	legacy bool
+}
+
+func (id LegacyID) IsLegacy() bool {
+	return id.legacy
+}
+
+func (id *LegacyID) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '"' {
+		*id = LegacyID{}
+		return id.ID.UnmarshalJSON(data)
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return ErrInvalidID
+	}
+	parsed, err := ParseID(s)
+	if err != nil {
+		return err
+	}
+	*id = LegacyID{ID: parsed, legacy: true}
+	return nil
+}
+
+func IDsToInts(ids []ID) []int {
+	values := make([]int, len(ids))
+	for i, id := range ids {
+		values[i] = id.Int()
+	}
+	return values
+}
+
+var ErrInvalidID = errors.New("id must be an integer")
@@ -0,0 +1,38 @@
+package dtos
+
+import "time"
+
+type NotificationPreference struct {
+	Channel string   `json:"channel"`
+	Target  string   `json:"target,omitempty"`
+	Kinds   []string `json:"kinds"`
+	Digest  bool     `json:"digest"`
+}
+
+type NotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+type NotificationPreferencesResponse struct {
+	UserID      ID                       `json:"user_id"`
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+type DigestItemResponse struct {
+	PullRequestID  ID        `json:"pull_request_id"`
+	Name           string    `json:"name"`
+	AuthorID       ID        `json:"author_id"`
+	State          string    `json:"state,omitempty"`
+	Since          time.Time `json:"since"`
+	WaitingSeconds int64     `json:"waiting_seconds"`
+}
+
+type DigestResponse struct {
+	UserID      ID                   `json:"user_id"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Reviewing   []DigestItemResponse `json:"reviewing"`
+	Authored    []DigestItemResponse `json:"authored"`
+	Subject     string               `json:"subject"`
+	Text        string               `json:"text"`
+	HTML        string               `json:"html"`
+}
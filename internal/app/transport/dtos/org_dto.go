@@ -0,0 +1,48 @@
+package dtos
+
+type OrgDocument struct {
+	Teams []OrgTeam `json:"teams"`
+}
+
+type OrgTeam struct {
+	Name    string      `json:"name"`
+	Members []OrgMember `json:"members"`
+}
+
+type OrgMember struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	IsActive bool   `json:"is_active"`
+}
+
+type ImportReportResponse struct {
+	DryRun           bool     `json:"dry_run"`
+	TeamsCreated     []string `json:"teams_created"`
+	TeamsUpdated     []string `json:"teams_updated"`
+	UsersCreated     []string `json:"users_created"`
+	UsersUpdated     []string `json:"users_updated"`
+	UsersDeactivated []string `json:"users_deactivated"`
+}
+
+type SyncChangeResponse struct {
+	Action   string `json:"action"`
+	UserID   *ID    `json:"user_id,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+	IsActive bool   `json:"is_active"`
+	FromTeam string `json:"from_team,omitempty"`
+	ToTeam   string `json:"to_team,omitempty"`
+}
+
+type ReassignmentResponse struct {
+	PullRequestID ID     `json:"pull_request_id"`
+	FromUserID    ID     `json:"from_user_id"`
+	ToUserID      *ID    `json:"to_user_id,omitempty"`
+	Outcome       string `json:"outcome"`
+}
+
+type SyncResponse struct {
+	Applied       bool                   `json:"applied"`
+	Changes       []SyncChangeResponse   `json:"changes"`
+	Reassignments []ReassignmentResponse `json:"reassignments"`
+}
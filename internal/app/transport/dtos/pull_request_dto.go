@@ -3,33 +3,47 @@ package dtos
 import "time"
 
 type CreatePullRequestRequest struct {
-	Name      string `json:"name"`
-	AuthorID  int    `json:"author_id"`
-	Reviewers []int  `json:"reviewers,omitempty"`
+	Name      string   `json:"name"`
+	AuthorID  ID       `json:"author_id"`
+	Reviewers []ID     `json:"reviewers,omitempty"`
+	Paths     []string `json:"paths,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Size      *int     `json:"size,omitempty"`
 }
 
 type UpdatePullRequestRequest struct {
 	Name      string `json:"name"`
 	Status    string `json:"status"`
-	Reviewers []int  `json:"reviewers,omitempty"`
+	Reviewers []ID   `json:"reviewers,omitempty"`
 }
 
 type ReassignReviewersRequest struct {
-	OldReviewerID int `json:"old_reviewer_id"`
+	OldReviewerID ID `json:"old_reviewer_id"`
 }
 
 type AddReviewerRequest struct {
-	ReviewerID int `json:"reviewer_id"`
+	ReviewerID ID `json:"reviewer_id"`
 }
 
 type PullRequestResponse struct {
-	ID        int             `json:"id"`
+	ID        ID              `json:"id"`
 	Name      string          `json:"name"`
 	Status    string          `json:"status"`
 	Author    *UserResponse   `json:"author"`
 	Reviewers []*UserResponse `json:"reviewers"`
+	Paths     []string        `json:"paths"`
+	Labels    []string        `json:"labels"`
+	Size      *int            `json:"size,omitempty"`
 	CreatedAt time.Time       `json:"created_at"`
 	MergedAt  *time.Time      `json:"merged_at,omitempty"`
+	Warning   *Warning        `json:"warning,omitempty"`
+}
+
+// Warning reports, with the code and message it would have as an error, a step that failed
+// after the pull request was stored.
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type PullRequestListResponse struct {
@@ -0,0 +1,26 @@
+package dtos
+
+type SubmitReviewRequest struct {
+	ReviewerID ID     `json:"reviewer_id"`
+	State      string `json:"state"`
+}
+
+type PullRequestReviewsResponse struct {
+	PullRequestID ID                         `json:"pull_request_id"`
+	Reviews       []ReviewAssignmentResponse `json:"reviews"`
+	Approvals     ApprovalStatusResponse     `json:"approvals"`
+}
+
+type ApprovalStatusResponse struct {
+	Required int `json:"required"`
+	Approved int `json:"approved"`
+}
+
+type ApprovalPolicyRequest struct {
+	RequiredApprovals int `json:"required_approvals"`
+}
+
+type ApprovalPolicyResponse struct {
+	TeamID            ID  `json:"team_id"`
+	RequiredApprovals int `json:"required_approvals"`
+}
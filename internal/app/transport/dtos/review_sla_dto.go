@@ -0,0 +1,35 @@
+package dtos
+
+import "time"
+
+type ReviewSLARequest struct {
+	Hours  int    `json:"hours"`
+	Action string `json:"action"`
+}
+
+type ReviewSLAResponse struct {
+	TeamID ID     `json:"team_id"`
+	Hours  int    `json:"hours"`
+	Action string `json:"action"`
+}
+
+type AcknowledgeReviewRequest struct {
+	ReviewerID ID `json:"reviewer_id"`
+}
+
+type ReviewAssignmentResponse struct {
+	ReviewerID     ID         `json:"reviewer_id"`
+	State          string     `json:"state"`
+	AssignedAt     time.Time  `json:"assigned_at"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	StateChangedAt *time.Time `json:"state_changed_at,omitempty"`
+}
+
+type PullRequestEventResponse struct {
+	ID             ID        `json:"id"`
+	PullRequestID  ID        `json:"pull_request_id"`
+	Kind           string    `json:"kind"`
+	UserID         *ID       `json:"user_id,omitempty"`
+	PreviousUserID *ID       `json:"previous_user_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,67 @@
+package dtos
+
+type CreateReviewerRuleRequest struct {
+	Kind       string `json:"kind"`
+	ReviewerID ID     `json:"reviewer_id"`
+	AuthorID   ID     `json:"author_id"`
+	Tag        string `json:"tag"`
+	AuthorTag  string `json:"author_tag"`
+}
+
+type ReviewerRuleResponse struct {
+	ID         ID     `json:"id"`
+	TeamID     ID     `json:"team_id"`
+	Kind       string `json:"kind"`
+	ReviewerID *ID    `json:"reviewer_id,omitempty"`
+	AuthorID   *ID    `json:"author_id,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	AuthorTag  string `json:"author_tag,omitempty"`
+}
+
+type CandidateCheckResponse struct {
+	AuthorID     ID       `json:"author_id"`
+	CandidateID  ID       `json:"candidate_id"`
+	Eligible     bool     `json:"eligible"`
+	Preferred    bool     `json:"preferred"`
+	Reasons      []string `json:"reasons"`
+	RequiredTags []string `json:"required_tags"`
+	ProvidedTags []string `json:"provided_tags"`
+}
+
+type UserTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type UserTagsResponse struct {
+	UserID ID       `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+type CreateReviewPatternRequest struct {
+	Pattern string `json:"pattern"`
+	Label   string `json:"label"`
+	Tag     string `json:"tag"`
+}
+
+type ReviewPatternResponse struct {
+	ID      ID     `json:"id"`
+	TeamID  ID     `json:"team_id"`
+	Pattern string `json:"pattern,omitempty"`
+	Label   string `json:"label,omitempty"`
+	Tag     string `json:"tag"`
+}
+
+type ReviewHalfLifeRequest struct {
+	HalfLifeHours int `json:"half_life_hours"`
+}
+
+type ReviewLoadResponse struct {
+	TeamID        ID                   `json:"team_id"`
+	HalfLifeHours int                  `json:"half_life_hours"`
+	Loads         []MemberLoadResponse `json:"loads"`
+}
+
+type MemberLoadResponse struct {
+	UserID ID      `json:"user_id"`
+	Load   float64 `json:"load"`
+}
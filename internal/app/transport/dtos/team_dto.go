@@ -6,7 +6,7 @@ type CreateTeamRequest struct {
 }
 
 type CreateTeamMemberRequest struct {
-	UserID   int    `json:"user_id" binding:"required"`
+	UserID   ID     `json:"user_id" binding:"required"`
 	Username string `json:"username" binding:"required"`
 	IsActive bool   `json:"is_active"`
 }
@@ -17,17 +17,17 @@ type UpdateTeamRequest struct {
 }
 
 type AddMemberRequest struct {
-	UserID int `json:"user_id" binding:"required"`
+	UserID ID `json:"user_id" binding:"required"`
 }
 
 type TeamResponse struct {
-	ID      int                  `json:"id"`
+	ID      ID                   `json:"id"`
 	Name    string               `json:"name"`
 	Members []TeamMemberResponse `json:"members"`
 }
 
 type TeamMemberResponse struct {
-	UserID   int    `json:"user_id"`
+	UserID   ID     `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
 }
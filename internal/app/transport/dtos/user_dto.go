@@ -1,34 +1,43 @@
 package dtos
 
 type SetUserActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	UserID   LegacyID `json:"user_id"`
+	IsActive bool     `json:"is_active"`
 }
 
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         ID                `json:"user_id"`
+	Username       string            `json:"username"`
+	TeamName       string            `json:"team_name"`
+	IsActive       bool              `json:"is_active"`
+	ExternalLogins map[string]string `json:"external_logins,omitempty"`
+	WorkingHours   *WorkingHours     `json:"working_hours,omitempty"`
 }
 
 type UserPRsResponse struct {
-	UserID       string            `json:"user_id"`
+	UserID       ID                `json:"user_id"`
 	PullRequests []PRShortResponse `json:"pull_requests"`
 }
 
 type PRShortResponse struct {
-	PullRequestID   string `json:"pull_request_id"`
+	PullRequestID   ID     `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	AuthorID        ID     `json:"author_id"`
 	Status          string `json:"status"`
 }
 
 type CreateUserRequest struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	Username     string        `json:"username"`
+	Email        string        `json:"email"`
+	TeamName     string        `json:"team_name"`
+	IsActive     bool          `json:"is_active"`
+	WorkingHours *WorkingHours `json:"working_hours,omitempty"`
+}
+
+type WorkingHours struct {
+	Timezone  string `json:"timezone"`
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
 }
 
 type GetUserByEmailRequest struct {
@@ -36,5 +45,33 @@ type GetUserByEmailRequest struct {
 }
 
 type DeactivateUserRequest struct {
-	UserID string `json:"user_id"`
+	UserID LegacyID `json:"user_id"`
+}
+
+type CreateUserIdentityRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+}
+
+type UpdateUserIdentityRequest struct {
+	Login string `json:"login"`
+}
+
+type UserIdentityResponse struct {
+	ID       ID     `json:"id"`
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   ID     `json:"user_id"`
+}
+
+type MoveUserRequest struct {
+	TeamName        string `json:"team_name"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type MoveUserResponse struct {
+	User     UserResponse           `json:"user"`
+	FromTeam string                 `json:"from_team"`
+	ToTeam   string                 `json:"to_team"`
+	Reviews  []ReassignmentResponse `json:"reviews"`
 }
@@ -0,0 +1,7 @@
+package dtos
+
+type WebhookResponse struct {
+	Outcome     string               `json:"outcome"`
+	Action      string               `json:"action,omitempty"`
+	PullRequest *PullRequestResponse `json:"pull_request,omitempty"`
+}
@@ -0,0 +1,19 @@
+package mappers
+
+import (
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func ToCacheStatsListResponse(stats []models.CacheStats) dtos.CacheStatsListResponse {
+	response := dtos.CacheStatsListResponse{Caches: make([]dtos.CacheStatsResponse, 0, len(stats))}
+	for _, s := range stats {
+		response.Caches = append(response.Caches, dtos.CacheStatsResponse{
+			Name:    s.Name,
+			Hits:    s.Hits,
+			Misses:  s.Misses,
+			Entries: s.Entries,
+		})
+	}
+	return response
+}
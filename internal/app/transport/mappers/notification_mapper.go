@@ -0,0 +1,74 @@
+package mappers
+
+import (
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func NotificationPreferencesRequestToDomain(userID int, req dtos.NotificationPreferencesRequest) []*models.NotificationPreference {
+	preferences := make([]*models.NotificationPreference, 0, len(req.Preferences))
+	for _, preference := range req.Preferences {
+		kinds := make([]models.PullRequestEventKind, 0, len(preference.Kinds))
+		for _, kind := range preference.Kinds {
+			kinds = append(kinds, models.PullRequestEventKind(kind))
+		}
+		preferences = append(preferences, &models.NotificationPreference{
+			UserID:  userID,
+			Channel: models.NotificationChannel(preference.Channel),
+			Target:  preference.Target,
+			Kinds:   kinds,
+			Digest:  preference.Digest,
+		})
+	}
+	return preferences
+}
+
+func NotificationPreferencesToResponse(userID int, preferences []*models.NotificationPreference) dtos.NotificationPreferencesResponse {
+	response := dtos.NotificationPreferencesResponse{
+		UserID:      dtos.NewID(userID),
+		Preferences: make([]dtos.NotificationPreference, 0, len(preferences)),
+	}
+	for _, preference := range preferences {
+		kinds := make([]string, 0, len(preference.Kinds))
+		for _, kind := range preference.Kinds {
+			kinds = append(kinds, string(kind))
+		}
+		response.Preferences = append(response.Preferences, dtos.NotificationPreference{
+			Channel: string(preference.Channel),
+			Target:  preference.Target,
+			Kinds:   kinds,
+			Digest:  preference.Digest,
+		})
+	}
+	return response
+}
+
+func DigestToResponse(digest *models.Digest, subject, text, html string) dtos.DigestResponse {
+	return dtos.DigestResponse{
+		UserID:      dtos.NewID(digest.User.ID),
+		GeneratedAt: digest.GeneratedAt,
+		Reviewing:   digestItemsToResponse(digest.Reviewing),
+		Authored:    digestItemsToResponse(digest.Authored),
+		Subject:     subject,
+		Text:        text,
+		HTML:        html,
+	}
+}
+
+func digestItemsToResponse(items []*models.DigestItem) []dtos.DigestItemResponse {
+	response := make([]dtos.DigestItemResponse, 0, len(items))
+	for _, item := range items {
+		entry := dtos.DigestItemResponse{
+			PullRequestID:  dtos.NewID(item.PullRequest.ID),
+			Name:           item.PullRequest.Name,
+			State:          string(item.State),
+			Since:          item.Since,
+			WaitingSeconds: int64(item.Waiting.Seconds()),
+		}
+		if item.PullRequest.Author != nil {
+			entry.AuthorID = dtos.NewID(item.PullRequest.Author.ID)
+		}
+		response = append(response, entry)
+	}
+	return response
+}
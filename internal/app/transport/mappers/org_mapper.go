@@ -0,0 +1,227 @@
+package mappers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var orgCSVHeader = []string{"team", "username", "email", "is_active"}
+
+var (
+	ErrInvalidCSV  = errors.New("invalid csv document")
+	ErrInvalidYAML = errors.New("invalid yaml document")
+)
+
+type yamlOrgDocument struct {
+	Teams []struct {
+		Name    string `yaml:"name"`
+		Members []struct {
+			Username string `yaml:"username"`
+			Email    string `yaml:"email"`
+			IsActive *bool  `yaml:"is_active"`
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+func ToOrgDocumentModel(doc dtos.OrgDocument) *models.OrgDocument {
+	teams := make([]*models.OrgTeam, len(doc.Teams))
+	for i, team := range doc.Teams {
+		members := make([]*models.OrgMember, len(team.Members))
+		for j, member := range team.Members {
+			members[j] = &models.OrgMember{
+				Username: member.Username,
+				Email:    member.Email,
+				IsActive: member.IsActive,
+			}
+		}
+		teams[i] = &models.OrgTeam{Name: team.Name, Members: members}
+	}
+
+	return &models.OrgDocument{Teams: teams}
+}
+
+func ToOrgDocumentResponse(doc *models.OrgDocument) dtos.OrgDocument {
+	teams := make([]dtos.OrgTeam, len(doc.Teams))
+	for i, team := range doc.Teams {
+		members := make([]dtos.OrgMember, len(team.Members))
+		for j, member := range team.Members {
+			members[j] = dtos.OrgMember{
+				Username: member.Username,
+				Email:    member.Email,
+				IsActive: member.IsActive,
+			}
+		}
+		teams[i] = dtos.OrgTeam{Name: team.Name, Members: members}
+	}
+
+	return dtos.OrgDocument{Teams: teams}
+}
+
+func ToImportReportResponse(report *models.ImportReport) dtos.ImportReportResponse {
+	return dtos.ImportReportResponse{
+		DryRun:           report.DryRun,
+		TeamsCreated:     report.TeamsCreated,
+		TeamsUpdated:     report.TeamsUpdated,
+		UsersCreated:     report.UsersCreated,
+		UsersUpdated:     report.UsersUpdated,
+		UsersDeactivated: report.UsersDeactivated,
+	}
+}
+
+// ReadOrgCSV parses rows of team,username,email,is_active. A row with only the team
+// column filled declares a team without members.
+func ReadOrgCSV(r io.Reader) (dtos.OrgDocument, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(orgCSVHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return dtos.OrgDocument{}, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	for i, column := range orgCSVHeader {
+		if strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return dtos.OrgDocument{}, fmt.Errorf("%w: header must be %s", ErrInvalidCSV, strings.Join(orgCSVHeader, ","))
+		}
+	}
+
+	doc := dtos.OrgDocument{Teams: make([]dtos.OrgTeam, 0)}
+	index := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return dtos.OrgDocument{}, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+
+		name := strings.TrimSpace(record[0])
+		i, ok := index[name]
+		if !ok {
+			i = len(doc.Teams)
+			index[name] = i
+			doc.Teams = append(doc.Teams, dtos.OrgTeam{Name: name, Members: make([]dtos.OrgMember, 0)})
+		}
+
+		username, email, active := strings.TrimSpace(record[1]), strings.TrimSpace(record[2]), strings.TrimSpace(record[3])
+		if username == "" && email == "" && active == "" {
+			continue
+		}
+
+		isActive := true
+		if active != "" {
+			isActive, err = strconv.ParseBool(active)
+			if err != nil {
+				line, _ := reader.FieldPos(3)
+				return dtos.OrgDocument{}, fmt.Errorf("%w: line %d: is_active must be true or false", ErrInvalidCSV, line)
+			}
+		}
+
+		doc.Teams[i].Members = append(doc.Teams[i].Members, dtos.OrgMember{
+			Username: username,
+			Email:    email,
+			IsActive: isActive,
+		})
+	}
+
+	return doc, nil
+}
+
+func WriteOrgCSV(w io.Writer, doc dtos.OrgDocument) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(orgCSVHeader); err != nil {
+		return err
+	}
+
+	for _, team := range doc.Teams {
+		if len(team.Members) == 0 {
+			if err := writer.Write([]string{team.Name, "", "", ""}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, member := range team.Members {
+			record := []string{team.Name, member.Username, member.Email, strconv.FormatBool(member.IsActive)}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadOrgYAML parses the org chart kept in git. Members without is_active are active.
+func ReadOrgYAML(r io.Reader) (dtos.OrgDocument, error) {
+	var raw yamlOrgDocument
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil {
+		return dtos.OrgDocument{}, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+
+	doc := dtos.OrgDocument{Teams: make([]dtos.OrgTeam, len(raw.Teams))}
+	for i, team := range raw.Teams {
+		members := make([]dtos.OrgMember, len(team.Members))
+		for j, member := range team.Members {
+			members[j] = dtos.OrgMember{
+				Username: member.Username,
+				Email:    member.Email,
+				IsActive: member.IsActive == nil || *member.IsActive,
+			}
+		}
+		doc.Teams[i] = dtos.OrgTeam{Name: team.Name, Members: members}
+	}
+
+	return doc, nil
+}
+
+func ToSyncResponse(plan *models.SyncPlan, applied bool, reassignments []*models.Reassignment) dtos.SyncResponse {
+	changes := make([]dtos.SyncChangeResponse, len(plan.Changes))
+	for i, change := range plan.Changes {
+		changes[i] = dtos.SyncChangeResponse{
+			Action:   string(change.Action),
+			Email:    change.Email,
+			Username: change.Username,
+			IsActive: change.IsActive,
+			FromTeam: change.FromTeam,
+			ToTeam:   change.ToTeam,
+		}
+		if change.UserID != 0 {
+			id := dtos.NewID(change.UserID)
+			changes[i].UserID = &id
+		}
+	}
+
+	return dtos.SyncResponse{
+		Applied:       applied,
+		Changes:       changes,
+		Reassignments: ToReassignmentResponses(reassignments),
+	}
+}
+
+func ToReassignmentResponses(reassignments []*models.Reassignment) []dtos.ReassignmentResponse {
+	responses := make([]dtos.ReassignmentResponse, len(reassignments))
+	for i, reassignment := range reassignments {
+		responses[i] = dtos.ReassignmentResponse{
+			PullRequestID: dtos.NewID(reassignment.PullRequestID),
+			FromUserID:    dtos.NewID(reassignment.FromUserID),
+			Outcome:       string(reassignment.Outcome),
+		}
+		if reassignment.ToUserID != 0 {
+			id := dtos.NewID(reassignment.ToUserID)
+			responses[i].ToUserID = &id
+		}
+	}
+	return responses
+}
@@ -9,11 +9,14 @@ import (
 func ToPullRequestResponse(pr *models.PullRequest) *dtos.PullRequestResponse {
 	authorResponse := UserToResponse(pr.Author)
 	response := &dtos.PullRequestResponse{
-		ID:        pr.ID,
+		ID:        dtos.NewID(pr.ID),
 		Name:      pr.Name,
 		Status:    string(pr.Status),
 		Author:    &authorResponse,
 		Reviewers: make([]*dtos.UserResponse, len(pr.Reviewers)),
+		Paths:     nonNilStrings(pr.Paths),
+		Labels:    nonNilStrings(pr.Labels),
+		Size:      pr.Size,
 		CreatedAt: pr.CreatedAt,
 	}
 
@@ -29,6 +32,23 @@ func ToPullRequestResponse(pr *models.PullRequest) *dtos.PullRequestResponse {
 	return response
 }
 
+// ToPullRequestResponseWithLogins attaches the reviewers' code host logins so callers can request reviews there.
+func ToPullRequestResponseWithLogins(pr *models.PullRequest, identities []*models.UserIdentity) *dtos.PullRequestResponse {
+	response := ToPullRequestResponse(pr)
+	for i, reviewer := range pr.Reviewers {
+		response.Reviewers[i].ExternalLogins = externalLogins(reviewer.ID, identities)
+	}
+	return response
+}
+
+func ReviewerIDs(pr *models.PullRequest) []int {
+	ids := make([]int, len(pr.Reviewers))
+	for i, reviewer := range pr.Reviewers {
+		ids[i] = reviewer.ID
+	}
+	return ids
+}
+
 func ToPullRequestListResponse(prs []*models.PullRequest) *dtos.PullRequestListResponse {
 	responses := make([]*dtos.PullRequestResponse, len(prs))
 	for i, pr := range prs {
@@ -41,19 +61,24 @@ func ToPullRequestListResponse(prs []*models.PullRequest) *dtos.PullRequestListR
 	}
 }
 
-func ToPullRequestModel(req *dtos.CreatePullRequestRequest, author *models.User) *models.PullRequest {
+func ToPullRequestModel(req *dtos.CreatePullRequestRequest, author *models.User, createdAt time.Time) *models.PullRequest {
 	pr := &models.PullRequest{
 		Name:      req.Name,
 		Status:    models.StatusOpen,
 		Author:    author,
 		Reviewers: make([]*models.User, 0),
-		CreatedAt: time.Now(),
+		Paths:     req.Paths,
+		Labels:    req.Labels,
+		Size:      req.Size,
+		CreatedAt: createdAt,
 	}
 
 	return pr
 }
 
-func UpdatePullRequestFromRequest(pr *models.PullRequest, req *dtos.UpdatePullRequestRequest) {
-	pr.Name = req.Name
-	pr.Status = models.PRStatus(req.Status)
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func PullRequestReviewsToResponse(reviews *models.PullRequestReviews) dtos.PullRequestReviewsResponse {
+	return dtos.PullRequestReviewsResponse{
+		PullRequestID: dtos.NewID(reviews.PullRequestID),
+		Reviews:       ReviewAssignmentsToResponse(reviews.Assignments),
+		Approvals: dtos.ApprovalStatusResponse{
+			Required: reviews.Approvals.Required,
+			Approved: reviews.Approvals.Approved,
+		},
+	}
+}
+
+func ApprovalPolicyRequestToDomain(teamID int, req dtos.ApprovalPolicyRequest) *models.ApprovalPolicy {
+	return &models.ApprovalPolicy{TeamID: teamID, RequiredApprovals: req.RequiredApprovals}
+}
+
+func ApprovalPolicyToResponse(policy *models.ApprovalPolicy) dtos.ApprovalPolicyResponse {
+	return dtos.ApprovalPolicyResponse{
+		TeamID:            dtos.NewID(policy.TeamID),
+		RequiredApprovals: policy.RequiredApprovals,
+	}
+}
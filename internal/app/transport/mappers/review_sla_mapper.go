@@ -0,0 +1,55 @@
+package mappers
+
+import (
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func ReviewSLARequestToDomain(teamID int, req dtos.ReviewSLARequest) *models.ReviewSLA {
+	action := models.SLAAction(req.Action)
+	if action == "" {
+		action = models.SLAActionAddReviewer
+	}
+	return &models.ReviewSLA{TeamID: teamID, Hours: req.Hours, Action: action}
+}
+
+func ReviewSLAToResponse(sla *models.ReviewSLA) dtos.ReviewSLAResponse {
+	return dtos.ReviewSLAResponse{
+		TeamID: dtos.NewID(sla.TeamID),
+		Hours:  sla.Hours,
+		Action: string(sla.Action),
+	}
+}
+
+func ReviewAssignmentsToResponse(assignments []*models.ReviewAssignment) []dtos.ReviewAssignmentResponse {
+	response := make([]dtos.ReviewAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		response = append(response, dtos.ReviewAssignmentResponse{
+			ReviewerID:     dtos.NewID(assignment.ReviewerID),
+			State:          string(assignment.State),
+			AssignedAt:     assignment.AssignedAt,
+			ReviewedAt:     assignment.ReviewedAt,
+			StateChangedAt: assignment.StateChangedAt,
+		})
+	}
+	return response
+}
+
+func PullRequestEventsToResponse(events []*models.PullRequestEvent) []dtos.PullRequestEventResponse {
+	response := make([]dtos.PullRequestEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, PullRequestEventToResponse(event))
+	}
+	return response
+}
+
+func PullRequestEventToResponse(event *models.PullRequestEvent) dtos.PullRequestEventResponse {
+	return dtos.PullRequestEventResponse{
+		ID:             dtos.NewID(event.ID),
+		PullRequestID:  dtos.NewID(event.PullRequestID),
+		Kind:           string(event.Kind),
+		UserID:         optionalID(event.UserID),
+		PreviousUserID: optionalID(event.PreviousUserID),
+		CreatedAt:      event.CreatedAt,
+	}
+}
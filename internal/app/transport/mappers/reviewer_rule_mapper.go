@@ -0,0 +1,103 @@
+package mappers
+
+import (
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+	"sort"
+)
+
+func CreateReviewerRuleRequestToDomain(teamID int, req dtos.CreateReviewerRuleRequest) *models.ReviewerRule {
+	rule := models.NewReviewerRule(teamID, models.RuleKind(req.Kind))
+	rule.ReviewerID = req.ReviewerID.Int()
+	rule.AuthorID = req.AuthorID.Int()
+	rule.Tag = req.Tag
+	rule.AuthorTag = req.AuthorTag
+	return rule
+}
+
+func ReviewerRuleToResponse(rule *models.ReviewerRule) dtos.ReviewerRuleResponse {
+	return dtos.ReviewerRuleResponse{
+		ID:         dtos.NewID(rule.ID),
+		TeamID:     dtos.NewID(rule.TeamID),
+		Kind:       string(rule.Kind),
+		ReviewerID: optionalID(rule.ReviewerID),
+		AuthorID:   optionalID(rule.AuthorID),
+		Tag:        rule.Tag,
+		AuthorTag:  rule.AuthorTag,
+	}
+}
+
+func ReviewerRulesToResponse(rules models.ReviewerRules) []dtos.ReviewerRuleResponse {
+	responses := make([]dtos.ReviewerRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, ReviewerRuleToResponse(rule))
+	}
+	return responses
+}
+
+func ToCandidateCheckResponse(check *models.CandidateCheck) dtos.CandidateCheckResponse {
+	return dtos.CandidateCheckResponse{
+		AuthorID:     dtos.NewID(check.AuthorID),
+		CandidateID:  dtos.NewID(check.CandidateID),
+		Eligible:     check.Eligible,
+		Preferred:    check.Preferred,
+		Reasons:      check.Reasons,
+		RequiredTags: check.RequiredTags,
+		ProvidedTags: check.ProvidedTags,
+	}
+}
+
+func ToUserTagsResponse(userID int, tags []string) dtos.UserTagsResponse {
+	if tags == nil {
+		tags = []string{}
+	}
+	return dtos.UserTagsResponse{
+		UserID: dtos.NewID(userID),
+		Tags:   tags,
+	}
+}
+
+func optionalID(value int) *dtos.ID {
+	if value == 0 {
+		return nil
+	}
+	id := dtos.NewID(value)
+	return &id
+}
+
+func CreateReviewPatternRequestToDomain(teamID int, req dtos.CreateReviewPatternRequest) *models.ReviewPattern {
+	return models.NewReviewPattern(teamID, req.Pattern, req.Label, req.Tag)
+}
+
+func ReviewPatternToResponse(pattern *models.ReviewPattern) dtos.ReviewPatternResponse {
+	return dtos.ReviewPatternResponse{
+		ID:      dtos.NewID(pattern.ID),
+		TeamID:  dtos.NewID(pattern.TeamID),
+		Pattern: pattern.Pattern,
+		Label:   pattern.Label,
+		Tag:     pattern.Tag,
+	}
+}
+
+func ReviewPatternsToResponse(patterns models.ReviewPatterns) []dtos.ReviewPatternResponse {
+	responses := make([]dtos.ReviewPatternResponse, 0, len(patterns))
+	for _, pattern := range patterns {
+		responses = append(responses, ReviewPatternToResponse(pattern))
+	}
+	return responses
+}
+
+func ReviewLoadToResponse(load *models.TeamReviewLoad) dtos.ReviewLoadResponse {
+	loads := make([]dtos.MemberLoadResponse, 0, len(load.Loads))
+	for userID, value := range load.Loads {
+		loads = append(loads, dtos.MemberLoadResponse{UserID: dtos.NewID(userID), Load: value})
+	}
+	sort.Slice(loads, func(i, j int) bool {
+		return loads[i].UserID.Int() < loads[j].UserID.Int()
+	})
+	return dtos.ReviewLoadResponse{
+		TeamID:        dtos.NewID(load.TeamID),
+		HalfLifeHours: int(load.HalfLife.Hours()),
+		Loads:         loads,
+	}
+}
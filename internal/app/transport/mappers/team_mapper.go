@@ -9,14 +9,14 @@ func ToTeamResponse(team *models.Team) dtos.TeamResponse {
 	memberResponses := make([]dtos.TeamMemberResponse, 0, len(team.Members))
 	for _, member := range team.Members {
 		memberResponses = append(memberResponses, dtos.TeamMemberResponse{
-			UserID:   member.UserID,
+			UserID:   dtos.NewID(member.UserID),
 			Username: member.Username,
 			IsActive: member.IsActive,
 		})
 	}
 
 	return dtos.TeamResponse{
-		ID:      team.ID,
+		ID:      dtos.NewID(team.ID),
 		Name:    team.Name,
 		Members: memberResponses,
 	}
@@ -38,7 +38,7 @@ func ToTeamModel(req dtos.CreateTeamRequest) *models.Team {
 	team := models.NewTeam(req.Name)
 
 	for _, memberReq := range req.Members {
-		member := models.NewTeamMember(memberReq.UserID, memberReq.Username, memberReq.IsActive)
+		member := models.NewTeamMember(memberReq.UserID.Int(), memberReq.Username, memberReq.IsActive)
 		team.Members[member.UserID] = member
 	}
 
@@ -50,7 +50,7 @@ func UpdateTeamFromRequest(team *models.Team, req dtos.UpdateTeamRequest) {
 
 	team.Members = make(map[int]*models.TeamMember)
 	for _, memberReq := range req.Members {
-		member := models.NewTeamMember(memberReq.UserID, memberReq.Username, memberReq.IsActive)
+		member := models.NewTeamMember(memberReq.UserID.Int(), memberReq.Username, memberReq.IsActive)
 		team.Members[member.UserID] = member
 	}
 }
@@ -3,45 +3,107 @@ package mappers
 import (
 	"reviewer-assignment-service/internal/app/transport/dtos"
 	"reviewer-assignment-service/internal/domain/models"
-	"strconv"
 )
 
 func UserToResponse(user *models.User) dtos.UserResponse {
 	return dtos.UserResponse{
-		UserID:   strconv.Itoa(user.ID),
+		UserID:   dtos.NewID(user.ID),
 		Username: user.Name,
 		TeamName: user.TeamName,
 		IsActive: user.IsActive,
 	}
 }
 
-func UserToResponseWithPRs(userID string, prs []*models.PullRequest) dtos.UserPRsResponse {
-	var prResponses []dtos.PRShortResponse
+func UserToResponseWithPRs(userID int, prs []*models.PullRequest) dtos.UserPRsResponse {
+	prResponses := make([]dtos.PRShortResponse, 0, len(prs))
 	for _, pr := range prs {
 		prResponses = append(prResponses, dtos.PRShortResponse{
-			PullRequestID:   strconv.Itoa(pr.ID),
+			PullRequestID:   dtos.NewID(pr.ID),
 			PullRequestName: pr.Name,
-			AuthorID:        strconv.Itoa(pr.Author.ID),
+			AuthorID:        dtos.NewID(pr.Author.ID),
 			Status:          string(pr.Status),
 		})
 	}
 
 	return dtos.UserPRsResponse{
-		UserID:       userID,
+		UserID:       dtos.NewID(userID),
 		PullRequests: prResponses,
 	}
 }
 
 func CreateUserRequestToDomain(req dtos.CreateUserRequest) *models.User {
 	user := models.NewUser(req.Username, req.Email, req.IsActive, req.TeamName)
+	if req.WorkingHours != nil {
+		user.WorkingHours = WorkingHoursToDomain(*req.WorkingHours)
+	}
 	return user
 }
 
 func UserToDetailedResponse(user *models.User) dtos.UserResponse {
-	return dtos.UserResponse{
-		UserID:   strconv.Itoa(user.ID),
+	response := dtos.UserResponse{
+		UserID:   dtos.NewID(user.ID),
 		Username: user.Name,
 		TeamName: user.TeamName,
 		IsActive: user.IsActive,
 	}
+	if user.WorkingHours.EndHour != 0 {
+		response.WorkingHours = &dtos.WorkingHours{
+			Timezone:  user.WorkingHours.Timezone,
+			StartHour: user.WorkingHours.StartHour,
+			EndHour:   user.WorkingHours.EndHour,
+		}
+	}
+	return response
+}
+
+func WorkingHoursToDomain(req dtos.WorkingHours) models.WorkingHours {
+	return models.WorkingHours{
+		Timezone:  req.Timezone,
+		StartHour: req.StartHour,
+		EndHour:   req.EndHour,
+	}
+}
+
+func UserIdentityToResponse(identity *models.UserIdentity) dtos.UserIdentityResponse {
+	return dtos.UserIdentityResponse{
+		ID:       dtos.NewID(identity.ID),
+		Provider: string(identity.Provider),
+		Login:    identity.Login,
+		UserID:   dtos.NewID(identity.UserID),
+	}
+}
+
+func UserIdentitiesToResponse(identities []*models.UserIdentity) []dtos.UserIdentityResponse {
+	responses := make([]dtos.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		responses = append(responses, UserIdentityToResponse(identity))
+	}
+	return responses
+}
+
+func CreateUserIdentityRequestToDomain(userID int, req dtos.CreateUserIdentityRequest) *models.UserIdentity {
+	return models.NewUserIdentity(models.VCSProvider(req.Provider), req.Login, userID)
+}
+
+func externalLogins(userID int, identities []*models.UserIdentity) map[string]string {
+	var logins map[string]string
+	for _, identity := range identities {
+		if identity.UserID != userID {
+			continue
+		}
+		if logins == nil {
+			logins = make(map[string]string)
+		}
+		logins[string(identity.Provider)] = identity.Login
+	}
+	return logins
+}
+
+func ToMoveUserResponse(move *models.TeamMove) dtos.MoveUserResponse {
+	return dtos.MoveUserResponse{
+		User:     UserToResponse(move.User),
+		FromTeam: move.FromTeam,
+		ToTeam:   move.ToTeam,
+		Reviews:  ToReassignmentResponses(move.Reviews),
+	}
 }
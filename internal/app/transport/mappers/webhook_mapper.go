@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func ToWebhookResponse(event *models.VCSEvent, result *models.VCSEventResult, identities []*models.UserIdentity) *dtos.WebhookResponse {
+	response := &dtos.WebhookResponse{
+		Outcome: string(result.Outcome),
+		Action:  string(event.Action),
+	}
+
+	if result.PullRequest != nil {
+		response.PullRequest = ToPullRequestResponseWithLogins(result.PullRequest, identities)
+	}
+
+	return response
+}
+
+func ToIgnoredWebhookResponse() *dtos.WebhookResponse {
+	return &dtos.WebhookResponse{Outcome: string(models.VCSEventIgnored)}
+}
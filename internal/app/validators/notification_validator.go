@@ -0,0 +1,55 @@
+package validators
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+const maxNotificationTargetLength = 500
+
+// ValidateNotificationPreferencesRequest also reduces email targets to the bare address, since
+// that is what the mail is sent to.
+func ValidateNotificationPreferencesRequest(req *dtos.NotificationPreferencesRequest) error {
+	seen := make(map[string]bool, len(req.Preferences))
+	for i := range req.Preferences {
+		preference := &req.Preferences[i]
+		channel := models.NotificationChannel(preference.Channel)
+		if !channel.IsValid() {
+			return NewValidationError("invalid channel. Must be 'email', 'slack' or 'webhook'")
+		}
+		if seen[preference.Channel] {
+			return NewValidationError(fmt.Sprintf("channel %s is listed more than once", preference.Channel))
+		}
+		seen[preference.Channel] = true
+
+		for _, kind := range preference.Kinds {
+			if !models.PullRequestEventKind(kind).Streamed() {
+				return NewValidationError("invalid kind. Must be 'reviewer_assigned', 'reviewer_unassigned' or 'merged'")
+			}
+		}
+
+		if len(preference.Target) > maxNotificationTargetLength {
+			return NewValidationError(fmt.Sprintf("target must be at most %d characters", maxNotificationTargetLength))
+		}
+		switch channel {
+		case models.ChannelEmail:
+			if preference.Target == "" {
+				continue
+			}
+			address, err := mail.ParseAddress(preference.Target)
+			if err != nil {
+				return NewValidationError("email target must be a valid email address")
+			}
+			preference.Target = address.Address
+		default:
+			target, err := url.Parse(preference.Target)
+			if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+				return NewValidationError(fmt.Sprintf("%s target must be an http or https URL", channel))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package validators
+
+import (
+	"fmt"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"strings"
+)
+
+func ValidateOrgDocument(doc *dtos.OrgDocument) error {
+	if len(doc.Teams) == 0 {
+		return NewValidationError("document must contain at least one team")
+	}
+
+	for i, team := range doc.Teams {
+		if err := ValidateTeamName(team.Name); err != nil {
+			return NewValidationError(fmt.Sprintf("team %d: %s", i, err.Error()))
+		}
+
+		for j, member := range team.Members {
+			if strings.TrimSpace(member.Username) == "" {
+				return NewValidationError(fmt.Sprintf("team %q member %d: username is required", team.Name, j))
+			}
+			if err := ValidateEmail(member.Email); err != nil {
+				return NewValidationError(fmt.Sprintf("team %q member %d: %s", team.Name, j, err.Error()))
+			}
+		}
+	}
+
+	return nil
+}
@@ -16,7 +16,7 @@ func ValidateCreatePullRequestRequest(req *dtos.CreatePullRequestRequest) error
 		return NewValidationError("pull request name must be between 2 and 200 characters")
 	}
 
-	if req.AuthorID <= 0 {
+	if req.AuthorID.Int() <= 0 {
 		return NewValidationError("author_id must be positive")
 	}
 
@@ -24,9 +24,31 @@ func ValidateCreatePullRequestRequest(req *dtos.CreatePullRequestRequest) error
 		return NewValidationError("cannot assign more than 2 reviewers")
 	}
 
+	if len(req.Paths) > maxPaths {
+		return NewValidationError("cannot list more than 1000 paths")
+	}
+
+	for _, path := range req.Paths {
+		if strings.TrimSpace(path) == "" {
+			return NewValidationError("paths must not be empty")
+		}
+	}
+
+	for _, label := range req.Labels {
+		if strings.TrimSpace(label) == "" {
+			return NewValidationError("labels must not be empty")
+		}
+	}
+
+	if req.Size != nil && *req.Size < 0 {
+		return NewValidationError("size must not be negative")
+	}
+
 	return nil
 }
 
+const maxPaths = 1000
+
 func ValidateUpdatePullRequestRequest(req *dtos.UpdatePullRequestRequest) error {
 	if strings.TrimSpace(req.Name) == "" {
 		return NewValidationError("pull request name is required")
@@ -39,9 +61,10 @@ func ValidateUpdatePullRequestRequest(req *dtos.UpdatePullRequestRequest) error
 	validStatuses := map[models.PRStatus]bool{
 		models.StatusOpen:   true,
 		models.StatusMerged: true,
+		models.StatusClosed: true,
 	}
 	if !validStatuses[models.PRStatus(req.Status)] {
-		return NewValidationError("invalid status. Must be 'OPEN' or 'MERGED'")
+		return NewValidationError("invalid status. Must be 'OPEN', 'MERGED' or 'CLOSED'")
 	}
 
 	if len(req.Reviewers) > 2 {
@@ -52,14 +75,14 @@ func ValidateUpdatePullRequestRequest(req *dtos.UpdatePullRequestRequest) error
 }
 
 func ValidateReassignReviewersRequest(req *dtos.ReassignReviewersRequest) error {
-	if req.OldReviewerID <= 0 {
+	if req.OldReviewerID.Int() <= 0 {
 		return NewValidationError("old_reviewer_id must be positive")
 	}
 	return nil
 }
 
 func ValidateAddReviewerRequest(req *dtos.AddReviewerRequest) error {
-	if req.ReviewerID <= 0 {
+	if req.ReviewerID.Int() <= 0 {
 		return NewValidationError("reviewer_id must be positive")
 	}
 	return nil
@@ -120,9 +143,10 @@ func ValidateStatus(status string) error {
 	validStatuses := map[string]bool{
 		"OPEN":   true,
 		"MERGED": true,
+		"CLOSED": true,
 	}
 	if !validStatuses[status] {
-		return NewValidationError("invalid status. Must be 'OPEN' or 'MERGED'")
+		return NewValidationError("invalid status. Must be 'OPEN', 'MERGED' or 'CLOSED'")
 	}
 	return nil
 }
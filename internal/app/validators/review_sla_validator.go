@@ -0,0 +1,24 @@
+package validators
+
+import (
+	"fmt"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func ValidateReviewSLARequest(req *dtos.ReviewSLARequest) error {
+	if req.Hours < 0 || req.Hours > models.MaxReviewSLAHours {
+		return NewValidationError(fmt.Sprintf("hours must be between 0 and %d", models.MaxReviewSLAHours))
+	}
+	if req.Action != "" && !models.SLAAction(req.Action).IsValid() {
+		return NewValidationError("invalid action. Must be 'add_reviewer' or 'reassign'")
+	}
+	return nil
+}
+
+func ValidateAcknowledgeReviewRequest(req *dtos.AcknowledgeReviewRequest) error {
+	if req.ReviewerID.Int() <= 0 {
+		return NewValidationError("reviewer_id must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package validators
+
+import (
+	"fmt"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+func ValidateSubmitReviewRequest(req *dtos.SubmitReviewRequest) error {
+	if req.ReviewerID.Int() <= 0 {
+		return NewValidationError("reviewer_id must be positive")
+	}
+	if !models.ReviewState(req.State).IsVerdict() {
+		return NewValidationError("invalid state. Must be 'approved', 'changes_requested' or 'dismissed'")
+	}
+	return nil
+}
+
+func ValidateApprovalPolicyRequest(req *dtos.ApprovalPolicyRequest) error {
+	if req.RequiredApprovals < 0 || req.RequiredApprovals > models.MaxReviewers {
+		return NewValidationError(fmt.Sprintf("required_approvals must be between 0 and %d", models.MaxReviewers))
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+	"strconv"
+	"strings"
+)
+
+const maxTagLength = 50
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+func ValidateCreateReviewerRuleRequest(req *dtos.CreateReviewerRuleRequest) error {
+	kind := models.RuleKind(req.Kind)
+	if !kind.IsValid() {
+		return NewValidationError("invalid kind. Must be 'never_assign', 'require_tag', 'prefer' or 'prefer_working_hours'")
+	}
+
+	switch kind {
+	case models.RuleNeverAssign, models.RulePrefer:
+		if req.ReviewerID.Int() <= 0 || req.AuthorID.Int() <= 0 {
+			return NewValidationError("reviewer_id and author_id are required for " + req.Kind)
+		}
+		if req.ReviewerID.Int() == req.AuthorID.Int() {
+			return NewValidationError("reviewer_id and author_id must differ")
+		}
+		if req.Tag != "" || req.AuthorTag != "" {
+			return NewValidationError("tag and author_tag are only allowed for require_tag")
+		}
+	case models.RuleRequireTag:
+		if req.ReviewerID.Int() != 0 || req.AuthorID.Int() != 0 {
+			return NewValidationError("reviewer_id and author_id are not allowed for require_tag")
+		}
+		if err := ValidateTag(req.Tag); err != nil {
+			return err
+		}
+		if req.AuthorTag != "" {
+			return ValidateTag(req.AuthorTag)
+		}
+	case models.RulePreferWorkingHours:
+		if req.ReviewerID.Int() != 0 || req.AuthorID.Int() != 0 || req.Tag != "" || req.AuthorTag != "" {
+			return NewValidationError("prefer_working_hours takes no reviewer_id, author_id or tags")
+		}
+	}
+
+	return nil
+}
+
+func ValidateCreateReviewPatternRequest(req *dtos.CreateReviewPatternRequest) error {
+	if (req.Pattern == "") == (req.Label == "") {
+		return NewValidationError("exactly one of pattern and label is required")
+	}
+
+	if req.Pattern != "" && (len(req.Pattern) > 255 || strings.ContainsAny(req.Pattern, " \t\n")) {
+		return NewValidationError("pattern must be up to 255 characters without whitespace")
+	}
+
+	if len(req.Label) > 100 {
+		return NewValidationError("label must be up to 100 characters")
+	}
+
+	return ValidateTag(req.Tag)
+}
+
+func ValidateReviewHalfLifeRequest(req *dtos.ReviewHalfLifeRequest) error {
+	minHours, maxHours := int(models.MinReviewHalfLife.Hours()), int(models.MaxReviewHalfLife.Hours())
+	if req.HalfLifeHours < minHours || req.HalfLifeHours > maxHours {
+		return NewValidationError(fmt.Sprintf("half_life_hours must be between %d and %d", minHours, maxHours))
+	}
+	return nil
+}
+
+func ValidateRuleID(ruleIDStr string) (int, error) {
+	ruleID, err := strconv.Atoi(ruleIDStr)
+	if err != nil {
+		return 0, NewValidationError("rule id must be a valid number")
+	}
+
+	if ruleID <= 0 {
+		return 0, NewValidationError("rule id must be positive")
+	}
+
+	return ruleID, nil
+}
+
+func ValidatePatternID(patternIDStr string) (int, error) {
+	patternID, err := strconv.Atoi(patternIDStr)
+	if err != nil {
+		return 0, NewValidationError("pattern id must be a valid number")
+	}
+
+	if patternID <= 0 {
+		return 0, NewValidationError("pattern id must be positive")
+	}
+
+	return patternID, nil
+}
+
+func ValidateCandidateID(candidateIDStr string) (int, error) {
+	if candidateIDStr == "" {
+		return 0, NewValidationError("candidate id is required")
+	}
+
+	candidateID, err := strconv.Atoi(candidateIDStr)
+	if err != nil {
+		return 0, NewValidationError("candidate id must be a valid number")
+	}
+
+	if candidateID <= 0 {
+		return 0, NewValidationError("candidate id must be positive")
+	}
+
+	return candidateID, nil
+}
+
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return NewValidationError("tag is required")
+	}
+
+	if len(tag) > maxTagLength || !tagPattern.MatchString(tag) {
+		return NewValidationError("tag must be up to 50 lowercase letters, digits, '-' or '_'")
+	}
+
+	return nil
+}
+
+func ValidateUserTagsRequest(req *dtos.UserTagsRequest) error {
+	if req.Tags == nil {
+		return NewValidationError("tags is required")
+	}
+
+	for _, tag := range req.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,7 +16,7 @@ func ValidateCreateTeamRequest(req *dtos.CreateTeamRequest) error {
 	}
 
 	for _, member := range req.Members {
-		if member.UserID <= 0 {
+		if member.UserID.Int() <= 0 {
 			return NewValidationError("member %d: user_id must be positive")
 		}
 		if strings.TrimSpace(member.Username) == "" {
@@ -40,7 +40,7 @@ func ValidateUpdateTeamRequest(req *dtos.UpdateTeamRequest) error {
 	}
 
 	for _, member := range req.Members {
-		if member.UserID <= 0 {
+		if member.UserID.Int() <= 0 {
 			return NewValidationError("member %d: user_id must be positive")
 		}
 		if strings.TrimSpace(member.Username) == "" {
@@ -52,7 +52,7 @@ func ValidateUpdateTeamRequest(req *dtos.UpdateTeamRequest) error {
 }
 
 func ValidateAddMemberRequest(req *dtos.AddMemberRequest) error {
-	if req.UserID <= 0 {
+	if req.UserID.Int() <= 0 {
 		return NewValidationError("user_id must be positive")
 	}
 	return nil
@@ -2,32 +2,50 @@ package validators
 
 import (
 	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func ValidateSetUserActiveRequest(req *dtos.SetUserActiveRequest) error {
-	if req.UserID == "" {
-		return NewValidationError("user_id is required")
+	if req.UserID.Int() <= 0 {
+		return NewValidationError("user_id must be positive")
 	}
 
-	if _, err := strconv.Atoi(req.UserID); err != nil {
-		return NewValidationError("user_id must be a valid number")
+	return nil
+}
+
+func ValidateUserID(userIDStr string) (int, error) {
+	if userIDStr == "" {
+		return 0, NewValidationError("user_id is required")
 	}
 
-	return nil
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return 0, NewValidationError("user_id must be a valid number")
+	}
+
+	if userID <= 0 {
+		return 0, NewValidationError("user_id must be positive")
+	}
+
+	return userID, nil
 }
 
-func ValidateUserID(userID string) error {
-	if userID == "" {
-		return NewValidationError("user_id is required")
+// ValidateLastEventID parses the Last-Event-ID header of a reconnecting event stream; an
+// empty header is 0.
+func ValidateLastEventID(lastEventID string) (int, error) {
+	if lastEventID == "" {
+		return 0, nil
 	}
 
-	if _, err := strconv.Atoi(userID); err != nil {
-		return NewValidationError("user_id must be a valid number")
+	id, err := strconv.Atoi(lastEventID)
+	if err != nil || id < 0 {
+		return 0, NewValidationError("Last-Event-ID must be a non-negative number")
 	}
 
-	return nil
+	return id, nil
 }
 
 func ValidateCreateUserRequest(req *dtos.CreateUserRequest) error {
@@ -47,6 +65,26 @@ func ValidateCreateUserRequest(req *dtos.CreateUserRequest) error {
 		return NewValidationError("team_name is required")
 	}
 
+	if req.WorkingHours != nil {
+		return ValidateWorkingHours(req.WorkingHours)
+	}
+
+	return nil
+}
+
+func ValidateWorkingHours(req *dtos.WorkingHours) error {
+	if req.Timezone == "" || req.Timezone == "Local" {
+		return NewValidationError("timezone must be an IANA time zone name, e.g. Europe/Moscow")
+	}
+
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return NewValidationError("timezone must be an IANA time zone name, e.g. Europe/Moscow")
+	}
+
+	if req.StartHour < 0 || req.EndHour > 24 || req.StartHour >= req.EndHour {
+		return NewValidationError("working hours must satisfy 0 <= start_hour < end_hour <= 24")
+	}
+
 	return nil
 }
 
@@ -63,12 +101,8 @@ func ValidateGetUserByEmailRequest(req *dtos.GetUserByEmailRequest) error {
 }
 
 func ValidateDeactivateUserRequest(req *dtos.DeactivateUserRequest) error {
-	if req.UserID == "" {
-		return NewValidationError("user_id is required")
-	}
-
-	if _, err := strconv.Atoi(req.UserID); err != nil {
-		return NewValidationError("user_id must be a valid number")
+	if req.UserID.Int() <= 0 {
+		return NewValidationError("user_id must be positive")
 	}
 
 	return nil
@@ -86,6 +120,55 @@ func ValidateEmail(email string) error {
 	return nil
 }
 
+func ValidateIdentityID(identityIDStr string) (int, error) {
+	identityID, err := strconv.Atoi(identityIDStr)
+	if err != nil {
+		return 0, NewValidationError("identity id must be a valid number")
+	}
+
+	if identityID <= 0 {
+		return 0, NewValidationError("identity id must be positive")
+	}
+
+	return identityID, nil
+}
+
+func ValidateProvider(provider string) error {
+	if !models.VCSProvider(provider).IsValid() {
+		return NewValidationError("invalid provider. Must be 'github' or 'gitlab'")
+	}
+
+	return nil
+}
+
+func ValidateExternalLogin(login string) error {
+	if login == "" {
+		return NewValidationError("login is required")
+	}
+
+	if strings.ContainsAny(login, " \t\n/") {
+		return NewValidationError("login must not contain whitespace or slashes")
+	}
+
+	return nil
+}
+
+func ValidateCreateUserIdentityRequest(req *dtos.CreateUserIdentityRequest) error {
+	if err := ValidateProvider(req.Provider); err != nil {
+		return err
+	}
+
+	return ValidateExternalLogin(req.Login)
+}
+
+func ValidateUpdateUserIdentityRequest(req *dtos.UpdateUserIdentityRequest) error {
+	return ValidateExternalLogin(req.Login)
+}
+
+func ValidateMoveUserRequest(req *dtos.MoveUserRequest) error {
+	return ValidateTeamName(req.TeamName)
+}
+
 type ValidationError struct {
 	Message string
 }
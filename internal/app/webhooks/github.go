@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/domain/models"
+	"strconv"
+)
+
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+type githubLogin struct {
+	Login string `json:"login"`
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title              string        `json:"title"`
+		Merged             bool          `json:"merged"`
+		User               githubLogin   `json:"user"`
+		RequestedReviewers []githubLogin `json:"requested_reviewers"`
+		Labels             []githubLabel `json:"labels"`
+		Additions          *int          `json:"additions"`
+		Deletions          *int          `json:"deletions"`
+	} `json:"pull_request"`
+	RequestedReviewer *githubLogin `json:"requested_reviewer"`
+	Repository        struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParseGitHub verifies the X-Hub-Signature-256 header and maps a pull_request delivery onto a VCSEvent.
+func ParseGitHub(header http.Header, body []byte, secret string) (*models.VCSEvent, error) {
+	if !validHMACSHA256(body, secret, header.Get(GitHubSignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+	if header.Get(GitHubEventHeader) != "pull_request" {
+		return nil, ErrIgnoredEvent
+	}
+
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	if payload.Repository.FullName == "" || payload.Number <= 0 {
+		return nil, ErrInvalidPayload
+	}
+
+	event := &models.VCSEvent{
+		Provider:       models.ProviderGitHub,
+		ExternalID:     payload.Repository.FullName + "#" + strconv.Itoa(payload.Number),
+		Title:          payload.PullRequest.Title,
+		AuthorLogin:    payload.PullRequest.User.Login,
+		ReviewerLogins: make([]string, 0),
+	}
+
+	switch payload.Action {
+	case "opened":
+		event.Action = models.VCSActionOpened
+		for _, reviewer := range payload.PullRequest.RequestedReviewers {
+			event.ReviewerLogins = append(event.ReviewerLogins, reviewer.Login)
+		}
+		for _, label := range payload.PullRequest.Labels {
+			event.Labels = append(event.Labels, label.Name)
+		}
+		if payload.PullRequest.Additions != nil && payload.PullRequest.Deletions != nil {
+			size := *payload.PullRequest.Additions + *payload.PullRequest.Deletions
+			event.Size = &size
+		}
+	case "closed":
+		event.Action = models.VCSActionClosed
+		if payload.PullRequest.Merged {
+			event.Action = models.VCSActionMerged
+		}
+	case "reopened":
+		event.Action = models.VCSActionReopened
+	case "review_requested":
+		// team review requests carry requested_team instead of requested_reviewer
+		if payload.RequestedReviewer == nil {
+			return nil, ErrIgnoredEvent
+		}
+		event.Action = models.VCSActionReviewRequested
+		event.ReviewerLogins = append(event.ReviewerLogins, payload.RequestedReviewer.Login)
+	default:
+		return nil, ErrIgnoredEvent
+	}
+
+	return event, nil
+}
@@ -0,0 +1,104 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"reviewer-assignment-service/internal/domain/models"
+	"slices"
+	"strconv"
+)
+
+const (
+	GitLabEventHeader = "X-Gitlab-Event"
+	GitLabTokenHeader = "X-Gitlab-Token"
+)
+
+type gitlabUser struct {
+	Username string `json:"username"`
+}
+
+type gitlabLabel struct {
+	Title string `json:"title"`
+}
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind       string     `json:"object_kind"`
+	User             gitlabUser `json:"user"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	Reviewers []gitlabUser  `json:"reviewers"`
+	Labels    []gitlabLabel `json:"labels"`
+	Changes   struct {
+		Reviewers *struct {
+			Previous []gitlabUser `json:"previous"`
+			Current  []gitlabUser `json:"current"`
+		} `json:"reviewers"`
+	} `json:"changes"`
+}
+
+// ParseGitLab checks the shared X-Gitlab-Token and maps a Merge Request Hook delivery onto a VCSEvent.
+func ParseGitLab(header http.Header, body []byte, token string) (*models.VCSEvent, error) {
+	if token == "" || subtle.ConstantTimeCompare([]byte(header.Get(GitLabTokenHeader)), []byte(token)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+	if header.Get(GitLabEventHeader) != "Merge Request Hook" {
+		return nil, ErrIgnoredEvent
+	}
+
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	if payload.ObjectKind != "merge_request" || payload.Project.PathWithNamespace == "" || payload.ObjectAttributes.IID <= 0 {
+		return nil, ErrInvalidPayload
+	}
+
+	event := &models.VCSEvent{
+		Provider:       models.ProviderGitLab,
+		ExternalID:     payload.Project.PathWithNamespace + "!" + strconv.Itoa(payload.ObjectAttributes.IID),
+		Title:          payload.ObjectAttributes.Title,
+		AuthorLogin:    payload.User.Username,
+		ReviewerLogins: make([]string, 0),
+	}
+
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		event.Action = models.VCSActionOpened
+		for _, reviewer := range payload.Reviewers {
+			event.ReviewerLogins = append(event.ReviewerLogins, reviewer.Username)
+		}
+		for _, label := range payload.Labels {
+			event.Labels = append(event.Labels, label.Title)
+		}
+	case "close":
+		event.Action = models.VCSActionClosed
+	case "merge":
+		event.Action = models.VCSActionMerged
+	case "reopen":
+		event.Action = models.VCSActionReopened
+	case "update":
+		if payload.Changes.Reviewers == nil {
+			return nil, ErrIgnoredEvent
+		}
+		for _, reviewer := range payload.Changes.Reviewers.Current {
+			if !slices.Contains(payload.Changes.Reviewers.Previous, reviewer) {
+				event.ReviewerLogins = append(event.ReviewerLogins, reviewer.Username)
+			}
+		}
+		if len(event.ReviewerLogins) == 0 {
+			return nil, ErrIgnoredEvent
+		}
+		event.Action = models.VCSActionReviewRequested
+	default:
+		return nil, ErrIgnoredEvent
+	}
+
+	return event, nil
+}
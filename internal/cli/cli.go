@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const usage = `Usage: reviewerctl [--url URL] [-o table|json] [--timeout 10s] <group> <command> [args]
+
+Commands:
+  users list [--team NAME]
+  users create --name NAME --email EMAIL --team NAME [--inactive]
+  users deactivate USER_ID
+  users move USER_ID --team NAME [--reassign-reviews]
+  teams show TEAM_ID|TEAM_NAME
+  teams add-member TEAM_ID|TEAM_NAME --user USER_ID
+  prs create --name NAME --author USER_ID [--size LINES] [--reviewer USER_ID]...
+  prs reassign PR_ID --old-reviewer USER_ID
+  prs merge PR_ID
+  prs ack PR_ID --reviewer USER_ID
+  prs review PR_ID --reviewer USER_ID --state approved|changes_requested|dismissed
+  prs list --reviewer USER_ID | --author USER_ID
+  org sync -f FILE.yaml [--apply]
+
+Environment:
+  REVIEWERCTL_URL, REVIEWERCTL_OUTPUT, REVIEWERCTL_TIMEOUT
+`
+
+type env struct {
+	cfg    *Config
+	stdout io.Writer
+	client *Client
+	out    *printer
+}
+
+type command func(e *env, args []string) error
+
+var commands = map[string]map[string]command{
+	"users": {
+		"list":       usersList,
+		"create":     usersCreate,
+		"deactivate": usersDeactivate,
+		"move":       usersMove,
+	},
+	"teams": {
+		"show":       teamsShow,
+		"add-member": teamsAddMember,
+	},
+	"prs": {
+		"create":   prsCreate,
+		"reassign": prsReassign,
+		"merge":    prsMerge,
+		"ack":      prsAck,
+		"review":   prsReview,
+		"list":     prsList,
+	},
+	"org": {
+		"sync": orgSync,
+	},
+}
+
+var errHelp = errors.New("help requested")
+
+// Run executes reviewerctl with the given arguments and returns the process exit code.
+func Run(args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	cfg := LoadConfig(getenv)
+
+	global := newFlagSet("reviewerctl", cfg)
+	groupName, rest, err := nextWord(global, args)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	group, ok := commands[groupName]
+	if !ok {
+		return fail(stderr, usageErrorf("unknown group "+strconv.Quote(groupName)))
+	}
+
+	commandName, rest, err := nextWord(global, rest)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	cmd, ok := group[commandName]
+	if !ok {
+		return fail(stderr, usageErrorf(fmt.Sprintf("unknown command %q, %s supports: %s", commandName, groupName, strings.Join(commandNames(group), ", "))))
+	}
+
+	e := &env{cfg: cfg, stdout: stdout}
+	return fail(stderr, cmd(e, rest))
+}
+
+func (e *env) connect() error {
+	if err := e.cfg.validate(); err != nil {
+		return err
+	}
+	e.client = NewClient(e.cfg.URL, &http.Client{Timeout: e.cfg.Timeout})
+	e.out = &printer{out: e.stdout, format: e.cfg.Output}
+	return nil
+}
+
+func (e *env) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.connect(); err != nil {
+		return nil, err
+	}
+	return positional, nil
+}
+
+func fail(stderr io.Writer, err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errHelp):
+		fmt.Fprint(stderr, usage)
+		return ExitOK
+	}
+
+	fmt.Fprintln(stderr, "error:", err)
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprint(stderr, usage)
+	}
+	return ExitCode(err)
+}
+
+func newFlagSet(name string, cfg *Config) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg.bind(fs)
+	return fs
+}
+
+// nextWord consumes global flags up to the next group or command name.
+func nextWord(fs *flag.FlagSet, args []string) (string, []string, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return "", nil, errHelp
+		}
+		return "", nil, usageErrorf(err.Error())
+	}
+	if fs.NArg() == 0 {
+		return "", nil, usageErrorf("expected <group> <command>")
+	}
+	return fs.Arg(0), fs.Args()[1:], nil
+}
+
+// parseFlags allows flags before and after positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, errHelp
+			}
+			return nil, usageErrorf(err.Error())
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func commandNames(group map[string]command) []string {
+	names := make([]string, 0, len(group))
+	for name := range group {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func exactlyOne(positional []string, name string) (string, error) {
+	if len(positional) != 1 {
+		return "", usageErrorf("expected exactly one " + name)
+	}
+	return positional[0], nil
+}
+
+func parseID(value, name string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, usageErrorf(name + " must be a positive integer")
+	}
+	return id, nil
+}
+
+type idList []int
+
+func (l *idList) String() string {
+	values := make([]string, len(*l))
+	for i, id := range *l {
+		values[i] = strconv.Itoa(id)
+	}
+	return strings.Join(values, ",")
+}
+
+func (l *idList) Set(value string) error {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return errors.New("must be a positive integer")
+	}
+	*l = append(*l, id)
+	return nil
+}
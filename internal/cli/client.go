@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"strings"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// APIError is an error answer of the service in the shape produced by response_errors.SendError.
+type APIError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s (HTTP %d)", e.Code, e.Message, e.Status)
+}
+
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return "request failed: " + e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+func (c *Client) Get(path string, query url.Values, out interface{}) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return c.do(http.MethodGet, path, nil, nil, out)
+}
+
+// GetETag is Get that also returns the ETag of the answer, for a later PutIfMatch.
+func (c *Client) GetETag(path string, out interface{}) (string, error) {
+	header, err := c.send(http.MethodGet, path, nil, nil, out)
+	if err != nil {
+		return "", err
+	}
+	return header.Get("ETag"), nil
+}
+
+func (c *Client) Post(path string, body, out interface{}) error {
+	return c.do(http.MethodPost, path, nil, body, out)
+}
+
+func (c *Client) Put(path string, body, out interface{}) error {
+	return c.do(http.MethodPut, path, nil, body, out)
+}
+
+// PostIfMatch and PutIfMatch send the If-Match the service requires on changes to pull requests
+// and teams: the ETag the change is based on, or * for whichever version is current.
+func (c *Client) PostIfMatch(path, etag string, body, out interface{}) error {
+	return c.do(http.MethodPost, path, http.Header{"If-Match": {etag}}, body, out)
+}
+
+func (c *Client) PutIfMatch(path, etag string, body, out interface{}) error {
+	return c.do(http.MethodPut, path, http.Header{"If-Match": {etag}}, body, out)
+}
+
+// PostRaw sends a body that is already encoded, e.g. a YAML document read from disk.
+func (c *Client) PostRaw(path, contentType string, body []byte, out interface{}) error {
+	_, err := c.send(http.MethodPost, path, http.Header{"Content-Type": {contentType}}, bytes.NewReader(body), out)
+	return err
+}
+
+func (c *Client) do(method, path string, header http.Header, body, out interface{}) error {
+	if body == nil {
+		_, err := c.send(method, path, header, nil, out)
+		return err
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	_, err = c.send(method, path, header, bytes.NewReader(payload), out)
+	return err
+}
+
+// send returns the headers of a successful answer.
+func (c *Client) send(method, path string, header http.Header, body io.Reader, out interface{}) (http.Header, error) {
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, decodeAPIError(resp.StatusCode, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, &TransportError{Err: fmt.Errorf("decode response: %w", err)}
+	}
+	return resp.Header, nil
+}
+
+func decodeAPIError(status int, data []byte) error {
+	var body response_errors.ErrorResponse
+	if err := json.Unmarshal(data, &body); err != nil || body.Error.Code == "" {
+		return &APIError{Status: status, Code: "HTTP_ERROR", Message: http.StatusText(status)}
+	}
+	return &APIError{Status: status, Code: body.Error.Code, Message: body.Error.Message}
+}
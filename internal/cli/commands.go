@@ -0,0 +1,385 @@
+package cli
+
+import (
+	"flag"
+	"net/url"
+	"os"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"strconv"
+)
+
+type userEnvelope struct {
+	User dtos.UserResponse `json:"user"`
+}
+
+type userListEnvelope struct {
+	Users []dtos.UserResponse `json:"users"`
+}
+
+func usersList(e *env, args []string) error {
+	fs := newFlagSet("users list", e.cfg)
+	team := fs.String("team", "", "only show members of this team")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+
+	var resp userListEnvelope
+	if err := e.client.Get("/users", nil, &resp); err != nil {
+		return err
+	}
+
+	users := make([]dtos.UserResponse, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		if *team == "" || user.TeamName == *team {
+			users = append(users, user)
+		}
+	}
+
+	return e.out.print(userListEnvelope{Users: users}, userTable(users...))
+}
+
+func usersCreate(e *env, args []string) error {
+	fs := newFlagSet("users create", e.cfg)
+	req := dtos.CreateUserRequest{}
+	fs.StringVar(&req.Username, "name", "", "username")
+	fs.StringVar(&req.Email, "email", "", "email")
+	fs.StringVar(&req.TeamName, "team", "", "team name")
+	inactive := fs.Bool("inactive", false, "create the user as inactive")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+	if req.Username == "" || req.Email == "" || req.TeamName == "" {
+		return usageErrorf("--name, --email and --team are required")
+	}
+	req.IsActive = !*inactive
+
+	var resp userEnvelope
+	if err := e.client.Post("/users", req, &resp); err != nil {
+		return err
+	}
+	return e.out.print(resp, userTable(resp.User))
+}
+
+func usersDeactivate(e *env, args []string) error {
+	fs := newFlagSet("users deactivate", e.cfg)
+	positional, err := e.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	value, err := exactlyOne(positional, "USER_ID")
+	if err != nil {
+		return err
+	}
+	userID, err := parseID(value, "USER_ID")
+	if err != nil {
+		return err
+	}
+
+	var resp userEnvelope
+	if err := e.client.Post("/users/deactivate", dtos.DeactivateUserRequest{UserID: dtos.LegacyID{ID: dtos.NewID(userID)}}, &resp); err != nil {
+		return err
+	}
+	return e.out.print(resp, userTable(resp.User))
+}
+
+func usersMove(e *env, args []string) error {
+	fs := newFlagSet("users move", e.cfg)
+	req := dtos.MoveUserRequest{}
+	fs.StringVar(&req.TeamName, "team", "", "target team name")
+	fs.BoolVar(&req.ReassignReviews, "reassign-reviews", false, "hand open reviews over to the old team")
+	positional, err := e.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	value, err := exactlyOne(positional, "USER_ID")
+	if err != nil {
+		return err
+	}
+	userID, err := parseID(value, "USER_ID")
+	if err != nil {
+		return err
+	}
+	if req.TeamName == "" {
+		return usageErrorf("--team is required")
+	}
+
+	var resp dtos.MoveUserResponse
+	if err := e.client.Post("/users/"+strconv.Itoa(userID)+"/move", req, &resp); err != nil {
+		return err
+	}
+	return e.out.print(resp, moveTable(resp))
+}
+
+func teamsShow(e *env, args []string) error {
+	fs := newFlagSet("teams show", e.cfg)
+	positional, err := e.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	ref, err := exactlyOne(positional, "TEAM_ID or TEAM_NAME")
+	if err != nil {
+		return err
+	}
+
+	team, _, err := e.getTeam(ref)
+	if err != nil {
+		return err
+	}
+	return e.out.print(team, teamTable(*team))
+}
+
+func teamsAddMember(e *env, args []string) error {
+	fs := newFlagSet("teams add-member", e.cfg)
+	var userID int
+	fs.IntVar(&userID, "user", 0, "id of the user to add")
+	positional, err := e.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	ref, err := exactlyOne(positional, "TEAM_ID or TEAM_NAME")
+	if err != nil {
+		return err
+	}
+	if userID <= 0 {
+		return usageErrorf("--user must be a positive integer")
+	}
+
+	team, etag, err := e.getTeam(ref)
+	if err != nil {
+		return err
+	}
+
+	req := dtos.UpdateTeamRequest{
+		Name:    team.Name,
+		Members: make([]dtos.CreateTeamMemberRequest, 0, len(team.Members)+1),
+	}
+	for _, member := range team.Members {
+		if member.UserID.Int() == userID {
+			return &APIError{Status: 409, Code: "MEMBER_ALREADY_IN_TEAM", Message: "User is already a member of this team"}
+		}
+		req.Members = append(req.Members, dtos.CreateTeamMemberRequest{
+			UserID:   member.UserID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		})
+	}
+
+	var user userEnvelope
+	if err := e.client.Get("/users/"+strconv.Itoa(userID), nil, &user); err != nil {
+		return err
+	}
+	req.Members = append(req.Members, dtos.CreateTeamMemberRequest{
+		UserID:   user.User.UserID,
+		Username: user.User.Username,
+		IsActive: user.User.IsActive,
+	})
+
+	var updated dtos.TeamResponse
+	if err := e.client.PutIfMatch("/teams/"+team.ID.String(), etag, req, &updated); err != nil {
+		return err
+	}
+	return e.out.print(updated, teamTable(updated))
+}
+
+// getTeam returns the team with its ETag.
+func (e *env) getTeam(ref string) (*dtos.TeamResponse, string, error) {
+	path := "/teams/by-name/" + url.PathEscape(ref)
+	if id, err := strconv.Atoi(ref); err == nil && id > 0 {
+		path = "/teams/" + ref
+	}
+
+	var team dtos.TeamResponse
+	etag, err := e.client.GetETag(path, &team)
+	if err != nil {
+		return nil, "", err
+	}
+	return &team, etag, nil
+}
+
+func prsCreate(e *env, args []string) error {
+	fs := newFlagSet("prs create", e.cfg)
+	var name string
+	var authorID int
+	var reviewers idList
+	var size int
+	fs.StringVar(&name, "name", "", "pull request title")
+	fs.IntVar(&authorID, "author", 0, "author user id")
+	fs.Var(&reviewers, "reviewer", "reviewer user id, repeatable; omit to let the service pick")
+	fs.IntVar(&size, "size", -1, "lines changed, weighs the PR in reviewer load")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+	if name == "" || authorID <= 0 {
+		return usageErrorf("--name and --author are required")
+	}
+
+	req := dtos.CreatePullRequestRequest{
+		Name:      name,
+		AuthorID:  dtos.NewID(authorID),
+		Reviewers: dtos.NewIDs(reviewers),
+	}
+	if size >= 0 {
+		req.Size = &size
+	}
+
+	var pr dtos.PullRequestResponse
+	if err := e.client.Post("/pull-requests", req, &pr); err != nil {
+		return err
+	}
+	return e.out.print(pr, pullRequestTable(&pr))
+}
+
+func prsReassign(e *env, args []string) error {
+	fs := newFlagSet("prs reassign", e.cfg)
+	var oldReviewerID int
+	fs.IntVar(&oldReviewerID, "old-reviewer", 0, "id of the reviewer to replace")
+	prID, err := e.parsePullRequestID(fs, args)
+	if err != nil {
+		return err
+	}
+	if oldReviewerID <= 0 {
+		return usageErrorf("--old-reviewer must be a positive integer")
+	}
+
+	req := dtos.ReassignReviewersRequest{OldReviewerID: dtos.NewID(oldReviewerID)}
+
+	var pr dtos.PullRequestResponse
+	// Reassignment and merging act on the pull request as it is when they arrive, so there is no
+	// version to pass along.
+	if err := e.client.PostIfMatch("/pull-requests/"+prID+"/reassign", "*", req, &pr); err != nil {
+		return err
+	}
+	return e.out.print(pr, pullRequestTable(&pr))
+}
+
+type assignmentsEnvelope struct {
+	Assignments []dtos.ReviewAssignmentResponse `json:"assignments"`
+}
+
+func prsAck(e *env, args []string) error {
+	fs := newFlagSet("prs ack", e.cfg)
+	var reviewerID int
+	fs.IntVar(&reviewerID, "reviewer", 0, "id of the reviewer who has reviewed the PR")
+	prID, err := e.parsePullRequestID(fs, args)
+	if err != nil {
+		return err
+	}
+	if reviewerID <= 0 {
+		return usageErrorf("--reviewer must be a positive integer")
+	}
+
+	req := dtos.AcknowledgeReviewRequest{ReviewerID: dtos.NewID(reviewerID)}
+
+	var resp assignmentsEnvelope
+	if err := e.client.Post("/pull-requests/"+prID+"/ack", req, &resp); err != nil {
+		return err
+	}
+	return e.out.print(resp, assignmentTable(resp.Assignments))
+}
+
+func prsReview(e *env, args []string) error {
+	fs := newFlagSet("prs review", e.cfg)
+	var reviewerID int
+	var state string
+	fs.IntVar(&reviewerID, "reviewer", 0, "id of the reviewer submitting the verdict")
+	fs.StringVar(&state, "state", "", "approved, changes_requested or dismissed")
+	prID, err := e.parsePullRequestID(fs, args)
+	if err != nil {
+		return err
+	}
+	if reviewerID <= 0 || state == "" {
+		return usageErrorf("--reviewer and --state are required")
+	}
+
+	req := dtos.SubmitReviewRequest{ReviewerID: dtos.NewID(reviewerID), State: state}
+
+	var reviews dtos.PullRequestReviewsResponse
+	if err := e.client.Post("/pull-requests/"+prID+"/reviews", req, &reviews); err != nil {
+		return err
+	}
+	return e.out.print(reviews, reviewsTable(reviews))
+}
+
+func prsMerge(e *env, args []string) error {
+	fs := newFlagSet("prs merge", e.cfg)
+	prID, err := e.parsePullRequestID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var pr dtos.PullRequestResponse
+	if err := e.client.PostIfMatch("/pull-requests/"+prID+"/merge", "*", nil, &pr); err != nil {
+		return err
+	}
+	return e.out.print(pr, pullRequestTable(&pr))
+}
+
+func prsList(e *env, args []string) error {
+	fs := newFlagSet("prs list", e.cfg)
+	var reviewerID, authorID int
+	fs.IntVar(&reviewerID, "reviewer", 0, "list pull requests assigned to this user")
+	fs.IntVar(&authorID, "author", 0, "list pull requests opened by this user")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+
+	var path string
+	switch {
+	case reviewerID > 0 && authorID > 0, reviewerID <= 0 && authorID <= 0:
+		return usageErrorf("exactly one of --reviewer or --author is required")
+	case reviewerID > 0:
+		path = "/pull-requests/reviewer/" + strconv.Itoa(reviewerID)
+	default:
+		path = "/pull-requests/author/" + strconv.Itoa(authorID)
+	}
+
+	var resp dtos.PullRequestListResponse
+	if err := e.client.Get(path, nil, &resp); err != nil {
+		return err
+	}
+	return e.out.print(resp, pullRequestTable(resp.PullRequests...))
+}
+
+func (e *env) parsePullRequestID(fs *flag.FlagSet, args []string) (string, error) {
+	positional, err := e.parse(fs, args)
+	if err != nil {
+		return "", err
+	}
+	value, err := exactlyOne(positional, "PR_ID")
+	if err != nil {
+		return "", err
+	}
+	prID, err := parseID(value, "PR_ID")
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(prID), nil
+}
+
+func orgSync(e *env, args []string) error {
+	fs := newFlagSet("org sync", e.cfg)
+	file := fs.String("f", "", "YAML file with the desired teams and members")
+	apply := fs.Bool("apply", false, "apply the plan instead of only printing it")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return usageErrorf("-f is required")
+	}
+
+	document, err := os.ReadFile(*file)
+	if err != nil {
+		return usageErrorf(err.Error())
+	}
+
+	path := "/sync"
+	if *apply {
+		path += "?" + url.Values{"apply": {"true"}}.Encode()
+	}
+
+	var resp dtos.SyncResponse
+	if err := e.client.PostRaw(path, "application/yaml", document, &resp); err != nil {
+		return err
+	}
+	return e.out.print(resp, syncTable(resp))
+}
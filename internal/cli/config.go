@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"flag"
+	"time"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+type Config struct {
+	URL     string
+	Output  string
+	Timeout time.Duration
+}
+
+// LoadConfig reads defaults from the environment; flags parsed later override them.
+func LoadConfig(getenv func(string) string) *Config {
+	cfg := &Config{
+		URL:     "http://localhost:8080",
+		Output:  OutputTable,
+		Timeout: 10 * time.Second,
+	}
+
+	if value := getenv("REVIEWERCTL_URL"); value != "" {
+		cfg.URL = value
+	}
+	if value := getenv("REVIEWERCTL_OUTPUT"); value != "" {
+		cfg.Output = value
+	}
+	if value := getenv("REVIEWERCTL_TIMEOUT"); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil {
+			cfg.Timeout = timeout
+		}
+	}
+
+	return cfg
+}
+
+func (c *Config) bind(fs *flag.FlagSet) {
+	fs.StringVar(&c.URL, "url", c.URL, "service base URL (env REVIEWERCTL_URL)")
+	fs.StringVar(&c.Output, "o", c.Output, "output format: table or json (env REVIEWERCTL_OUTPUT)")
+	fs.DurationVar(&c.Timeout, "timeout", c.Timeout, "request timeout (env REVIEWERCTL_TIMEOUT)")
+}
+
+func (c *Config) validate() error {
+	if c.Output != OutputTable && c.Output != OutputJSON {
+		return usageErrorf("output must be 'table' or 'json'")
+	}
+	if c.URL == "" {
+		return usageErrorf("url is required")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cli
+
+import "errors"
+
+const (
+	ExitOK        = 0
+	ExitFailure   = 1
+	ExitUsage     = 2
+	ExitTransport = 3
+)
+
+// exitCodes maps the codes emitted by response_errors.HandleServiceError to process exit codes.
+// The tens digit is the category: 1x not found, 2x conflict, 3x rejected input, 4x worth retrying.
+var exitCodes = map[string]int{
+	"USER_NOT_FOUND":        10,
+	"NOT_FOUND":             10,
+	"TEAM_NOT_FOUND":        11,
+	"PR_NOT_FOUND":          12,
+	"REVIEWER_NOT_FOUND":    13,
+	"MEMBER_NOT_IN_TEAM":    14,
+	"IDENTITY_NOT_FOUND":    15,
+	"RULE_NOT_FOUND":        16,
+	"PATTERN_NOT_FOUND":     17,
+	"REVIEWER_NOT_ASSIGNED": 18,
+
+	"USER_ALREADY_EXISTS":        20,
+	"TEAM_ALREADY_EXISTS":        21,
+	"PR_ALREADY_EXISTS":          22,
+	"PR_ALREADY_MERGED":          23,
+	"PR_CLOSED":                  24,
+	"REVIEWER_ALREADY_ASSIGNED":  25,
+	"MEMBER_ALREADY_IN_TEAM":     26,
+	"IDENTITY_ALREADY_EXISTS":    27,
+	"EXTERNAL_PR_ALREADY_EXISTS": 28,
+	"NOT_ENOUGH_APPROVALS":       29,
+
+	"VALIDATION_ERROR":      30,
+	"INVALID_REQUEST":       30,
+	"INVALID_JSON":          30,
+	"INVALID_CSV":           30,
+	"INVALID_YAML":          30,
+	"BAD_REQUEST":           30,
+	"PRECONDITION_REQUIRED": 30,
+	"TOO_MANY_REVIEWERS":    31,
+	"AUTHOR_NOT_IN_TEAM":    32,
+	"UNKNOWN_EXTERNAL_USER": 33,
+	"INVALID_SIGNATURE":     34,
+	"INVALID_DOCUMENT":      35,
+	"RULE_USER_NOT_IN_TEAM": 36,
+
+	"REQUIRED_REVIEWER_UNAVAILABLE": 37,
+	"IDEMPOTENCY_KEY_REUSED":        38,
+	"INVALID_STATUS_CHANGE":         39,
+
+	"CONFLICTING_UPDATE":          40,
+	"IDEMPOTENCY_KEY_IN_PROGRESS": 41,
+	"RATE_LIMITED":                42,
+}
+
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return ExitTransport
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if code, ok := exitCodes[apiErr.Code]; ok {
+			return code
+		}
+	}
+
+	return ExitFailure
+}
+
+type UsageError struct {
+	Message string
+}
+
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+func usageErrorf(message string) error {
+	return &UsageError{Message: message}
+}
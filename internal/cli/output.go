@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func (p *printer) print(value interface{}, table func(w *tabwriter.Writer)) error {
+	if p.format == OutputJSON {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+func row(w io.Writer, columns ...interface{}) {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = fmt.Sprint(column)
+	}
+	fmt.Fprintln(w, strings.Join(values, "\t"))
+}
+
+func userTable(users ...dtos.UserResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		row(w, "ID", "USERNAME", "TEAM", "ACTIVE")
+		for _, user := range users {
+			row(w, user.UserID, user.Username, user.TeamName, user.IsActive)
+		}
+	}
+}
+
+func teamTable(team dtos.TeamResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		row(w, "TEAM", team.Name, "ID", team.ID)
+		row(w, "USER ID", "USERNAME", "ACTIVE")
+		for _, member := range team.Members {
+			row(w, member.UserID, member.Username, member.IsActive)
+		}
+	}
+}
+
+func pullRequestTable(prs ...*dtos.PullRequestResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		row(w, "ID", "NAME", "STATUS", "AUTHOR", "REVIEWERS")
+		for _, pr := range prs {
+			reviewers := make([]string, len(pr.Reviewers))
+			for i, reviewer := range pr.Reviewers {
+				reviewers[i] = reviewer.Username
+			}
+			author := ""
+			if pr.Author != nil {
+				author = pr.Author.Username
+			}
+			row(w, pr.ID, pr.Name, pr.Status, author, strings.Join(reviewers, ","))
+		}
+	}
+}
+
+func assignmentTable(assignments []dtos.ReviewAssignmentResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		row(w, "REVIEWER", "STATE", "ASSIGNED", "REVIEWED")
+		for _, assignment := range assignments {
+			reviewed := "-"
+			if assignment.ReviewedAt != nil {
+				reviewed = assignment.ReviewedAt.Format(time.RFC3339)
+			}
+			row(w, assignment.ReviewerID, assignment.State, assignment.AssignedAt.Format(time.RFC3339), reviewed)
+		}
+	}
+}
+
+func reviewsTable(reviews dtos.PullRequestReviewsResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		assignmentTable(reviews.Reviews)(w)
+		row(w, "APPROVALS", fmt.Sprintf("%d/%d", reviews.Approvals.Approved, reviews.Approvals.Required))
+	}
+}
+
+func moveTable(resp dtos.MoveUserResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		row(w, "USER", resp.User.Username, "FROM", resp.FromTeam, "TO", resp.ToTeam)
+		reassignmentRows(w, resp.Reviews)
+	}
+}
+
+func reassignmentRows(w io.Writer, reassignments []dtos.ReassignmentResponse) {
+	row(w, "PR", "FROM USER", "TO USER", "OUTCOME")
+	for _, reassignment := range reassignments {
+		to := "-"
+		if reassignment.ToUserID != nil {
+			to = reassignment.ToUserID.String()
+		}
+		row(w, reassignment.PullRequestID, reassignment.FromUserID, to, reassignment.Outcome)
+	}
+}
+
+func syncTable(resp dtos.SyncResponse) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		row(w, "ACTION", "EMAIL", "USERNAME", "FROM", "TO")
+		for _, change := range resp.Changes {
+			row(w, change.Action, change.Email, change.Username, change.FromTeam, change.ToTeam)
+		}
+		if len(resp.Changes) == 0 {
+			row(w, "(no changes)")
+		}
+		if !resp.Applied {
+			return
+		}
+
+		row(w)
+		reassignmentRows(w, resp.Reassignments)
+	}
+}
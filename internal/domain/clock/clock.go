@@ -0,0 +1,14 @@
+package clock
+
+import "time"
+
+// Clock is where domain logic reads the current time, so tests can pin it.
+type Clock interface {
+	Now() time.Time
+}
+
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
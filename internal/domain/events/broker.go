@@ -0,0 +1,99 @@
+// Package events fans recorded pull request events out to in-process subscribers.
+package events
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"sync"
+)
+
+// SubscriptionBuffer is how many events a subscriber may fall behind before it is dropped.
+const SubscriptionBuffer = 64
+
+// Broker delivers each published event to the subscribers of the user it concerns. A subscriber
+// that falls behind is dropped rather than blocking the publisher: its channel is closed and it
+// is expected to catch up from the event log. A nil *Broker drops everything.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[int]map[*Subscription]struct{}
+	closed      bool
+}
+
+type Subscription struct {
+	broker *Broker
+	userID int
+	events chan *models.PullRequestEvent
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int]map[*Subscription]struct{})}
+}
+
+// Subscribe returns a subscription for the user's events. On a closed broker the subscription
+// is closed right away.
+func (b *Broker) Subscribe(userID int) *Subscription {
+	sub := &Subscription{broker: b, userID: userID, events: make(chan *models.PullRequestEvent, SubscriptionBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.events)
+		return sub
+	}
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[userID][sub] = struct{}{}
+	return sub
+}
+
+func (b *Broker) Publish(events ...*models.PullRequestEvent) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, event := range events {
+		for sub := range b.subscribers[event.UserID] {
+			select {
+			case sub.events <- event:
+			default:
+				b.remove(sub)
+			}
+		}
+	}
+}
+
+// Close ends every subscription, so that open streams return during server shutdown.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			b.remove(sub)
+		}
+	}
+	b.closed = true
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	subs, ok := b.subscribers[sub.userID]
+	if _, subscribed := subs[sub]; !ok || !subscribed {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.userID)
+	}
+	close(sub.events)
+}
+
+// Events is closed when the subscriber is dropped, closed, or the broker shuts down.
+func (s *Subscription) Events() <-chan *models.PullRequestEvent {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
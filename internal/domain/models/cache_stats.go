@@ -0,0 +1,9 @@
+package models
+
+// CacheStats counts the lookups a cache answered itself and the ones it passed on.
+type CacheStats struct {
+	Name    string
+	Hits    int64
+	Misses  int64
+	Entries int
+}
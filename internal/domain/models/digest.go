@@ -0,0 +1,41 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Digest is a user's daily summary: the open PRs they review and their own open PRs that still
+// lack approvals, each with how long it has been waiting.
+type Digest struct {
+	User        *User
+	GeneratedAt time.Time
+	Reviewing   []*DigestItem
+	Authored    []*DigestItem
+}
+
+// DigestItem is a PR in a digest. Since is when the reviewer was assigned for Reviewing, and when
+// the PR was opened for Authored; State is the user's own review state and is only set for
+// Reviewing.
+type DigestItem struct {
+	PullRequest *PullRequest
+	State       ReviewState
+	Since       time.Time
+	Waiting     time.Duration
+}
+
+func NewDigestItem(pr *PullRequest, state ReviewState, since, now time.Time) *DigestItem {
+	return &DigestItem{PullRequest: pr, State: state, Since: since, Waiting: now.Sub(since)}
+}
+
+// Empty digests are not sent.
+func (d *Digest) Empty() bool {
+	return len(d.Reviewing) == 0 && len(d.Authored) == 0
+}
+
+// Sort puts the longest waiting PRs first.
+func (d *Digest) Sort() {
+	for _, items := range [][]*DigestItem{d.Reviewing, d.Authored} {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Waiting > items[j].Waiting })
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// IdempotencyKey is a client-chosen key for a mutating request together with the response the
+// first request got, so that retries with the same key are answered without running it again.
+type IdempotencyKey struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Headers     http.Header
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+func NewIdempotencyKey(key, requestHash string, createdAt time.Time) *IdempotencyKey {
+	return &IdempotencyKey{Key: key, RequestHash: requestHash, CreatedAt: createdAt}
+}
+
+// Completed tells a stored response from a key whose first request is still running.
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
+
+func (k *IdempotencyKey) Expired(now time.Time, ttl time.Duration) bool {
+	return !now.Before(k.CreatedAt.Add(ttl))
+}
+
+const MaxIdempotencyKeyLength = 255
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelEmail   NotificationChannel = "email"
+	ChannelSlack   NotificationChannel = "slack"
+	ChannelWebhook NotificationChannel = "webhook"
+)
+
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case ChannelEmail, ChannelSlack, ChannelWebhook:
+		return true
+	}
+	return false
+}
+
+// NotificationPreference is one channel a user is notified on, the event kinds they want there
+// and whether the daily digest goes there too. Target is the address or webhook URL, and for
+// email defaults to the user's address when empty.
+type NotificationPreference struct {
+	UserID  int
+	Channel NotificationChannel
+	Target  string
+	Kinds   []PullRequestEventKind
+	Digest  bool
+}
+
+// DefaultNotificationPreferences apply to users who have not saved any: an email to their own
+// address when they are assigned a review, and the daily digest.
+func DefaultNotificationPreferences(userID int) []*NotificationPreference {
+	return []*NotificationPreference{
+		{UserID: userID, Channel: ChannelEmail, Kinds: []PullRequestEventKind{EventReviewerAssigned}, Digest: true},
+	}
+}
+
+func (p *NotificationPreference) Wants(kind PullRequestEventKind) bool {
+	return slices.Contains(p.Kinds, kind)
+}
+
+// NotificationDigest is the kind of notifications that carry a daily digest rather than an event;
+// they have no EventID or PullRequestID.
+const NotificationDigest PullRequestEventKind = "digest"
+
+const MaxNotificationAttempts = 6
+
+// NotificationRetryDelay is the wait after the first failed attempt; it doubles with each retry.
+const NotificationRetryDelay = time.Minute
+
+// Notification is a rendered message waiting in the delivery queue. HTML is an optional
+// alternative to Body for channels that can show it. NextAttemptAt is nil once it was sent or
+// gave up after MaxNotificationAttempts.
+type Notification struct {
+	ID            int
+	EventID       int
+	Kind          PullRequestEventKind
+	PullRequestID int
+	UserID        int
+	Channel       NotificationChannel
+	Target        string
+	Subject       string
+	Body          string
+	HTML          string
+	Attempts      int
+	NextAttemptAt *time.Time
+	SentAt        *time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+func (n *Notification) SetId(id int) {
+	n.ID = id
+}
+
+func (n *Notification) MarkSent(at time.Time) {
+	n.Attempts++
+	n.SentAt = &at
+	n.NextAttemptAt = nil
+	n.LastError = ""
+}
+
+// MarkFailed schedules the next attempt with exponential backoff, or gives up after
+// MaxNotificationAttempts.
+func (n *Notification) MarkFailed(at time.Time, err error) {
+	n.Attempts++
+	n.LastError = err.Error()
+	if n.Attempts >= MaxNotificationAttempts {
+		n.NextAttemptAt = nil
+		return
+	}
+	next := at.Add(NotificationRetryDelay << (n.Attempts - 1))
+	n.NextAttemptAt = &next
+}
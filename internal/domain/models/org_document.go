@@ -0,0 +1,44 @@
+package models
+
+import "errors"
+
+// OrgDocument describes teams and their members for bulk import and export.
+type OrgDocument struct {
+	Teams []*OrgTeam `json:"teams"`
+}
+
+type OrgTeam struct {
+	Name    string       `json:"name"`
+	Members []*OrgMember `json:"members"`
+}
+
+type OrgMember struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	IsActive bool   `json:"is_active"`
+}
+
+type ImportReport struct {
+	DryRun           bool     `json:"dry_run"`
+	TeamsCreated     []string `json:"teams_created"`
+	TeamsUpdated     []string `json:"teams_updated"`
+	UsersCreated     []string `json:"users_created"`
+	UsersUpdated     []string `json:"users_updated"`
+	UsersDeactivated []string `json:"users_deactivated"`
+}
+
+func NewImportReport(dryRun bool) *ImportReport {
+	return &ImportReport{
+		DryRun:           dryRun,
+		TeamsCreated:     make([]string, 0),
+		TeamsUpdated:     make([]string, 0),
+		UsersCreated:     make([]string, 0),
+		UsersUpdated:     make([]string, 0),
+		UsersDeactivated: make([]string, 0),
+	}
+}
+
+var (
+	ErrDuplicateTeamInDocument  = errors.New("team is listed more than once")
+	ErrDuplicateEmailInDocument = errors.New("email is listed more than once")
+)
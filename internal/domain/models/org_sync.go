@@ -0,0 +1,68 @@
+package models
+
+type SyncAction string
+
+const (
+	SyncCreateTeam     SyncAction = "create_team"
+	SyncAddUser        SyncAction = "add_user"
+	SyncMoveUser       SyncAction = "move_user"
+	SyncUpdateUser     SyncAction = "update_user"
+	SyncDeactivateUser SyncAction = "deactivate_user"
+)
+
+// SyncChange is one step needed to bring the database to the desired OrgDocument.
+// UserID is zero for users that do not exist yet.
+type SyncChange struct {
+	Action   SyncAction
+	UserID   int
+	Email    string
+	Username string
+	IsActive bool
+	FromTeam string
+	ToTeam   string
+}
+
+// ReleasesReviews reports whether the user stops reviewing in FromTeam after the change.
+func (c *SyncChange) ReleasesReviews() bool {
+	return c.Action == SyncMoveUser || c.Action == SyncDeactivateUser ||
+		(c.Action == SyncUpdateUser && !c.IsActive)
+}
+
+type SyncPlan struct {
+	Changes []*SyncChange
+}
+
+func (p *SyncPlan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+type ReassignmentOutcome string
+
+const (
+	ReassignmentDone        ReassignmentOutcome = "reassigned"
+	ReassignmentNoCandidate ReassignmentOutcome = "no_candidate"
+	ReassignmentKept        ReassignmentOutcome = "kept"
+	ReassignmentFailed      ReassignmentOutcome = "failed"
+)
+
+type Reassignment struct {
+	PullRequestID int
+	FromUserID    int
+	ToUserID      int
+	Outcome       ReassignmentOutcome
+}
+
+type SyncResult struct {
+	Plan          *SyncPlan
+	Applied       bool
+	Reassignments []*Reassignment
+}
+
+// TeamMove is the result of moving a user to another team; Reviews lists the open PRs
+// the user was reviewing when the move happened.
+type TeamMove struct {
+	User     *User
+	FromTeam string
+	ToTeam   string
+	Reviews  []*Reassignment
+}
@@ -11,8 +11,12 @@ type PullRequest struct {
 	Status    PRStatus  `json:"status"`
 	Author    *User     `json:"author"`
 	Reviewers []*User   `json:"reviewers"`
+	Paths     []string  `json:"paths"`
+	Labels    []string  `json:"labels"`
+	Size      *int      `json:"size,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	MergedAt  time.Time `json:"merged_at"`
+	Version   int       `json:"version"`
 }
 
 type PRStatus string
@@ -20,9 +24,12 @@ type PRStatus string
 const (
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
-func NewPullRequest(name string, author *User, team *Team) (*PullRequest, error) {
+const MaxReviewers = 2
+
+func NewPullRequest(name string, author *User, team *Team, createdAt time.Time) (*PullRequest, error) {
 	if !team.IsMemberInTeam(author.ID) {
 		return nil, ErrAuthorNotInTeam
 	}
@@ -30,8 +37,8 @@ func NewPullRequest(name string, author *User, team *Team) (*PullRequest, error)
 		Name:      name,
 		Status:    StatusOpen,
 		Author:    author,
-		Reviewers: make([]*User, 0, 2),
-		CreatedAt: time.Now(),
+		Reviewers: make([]*User, 0, MaxReviewers),
+		CreatedAt: createdAt,
 	}, nil
 }
 
@@ -42,6 +49,53 @@ func (pr *PullRequest) CanModifyReviewers() bool {
 func (pr *PullRequest) SetStatusMerged() {
 	pr.Status = StatusMerged
 }
+
+func (pr *PullRequest) SetStatusClosed() {
+	pr.Status = StatusClosed
+}
+
+// Merge marks an open pull request merged once it has the approvals its team requires.
+func (pr *PullRequest) Merge(approvals ApprovalStatus, mergedAt time.Time) error {
+	if pr.Status != StatusOpen {
+		return pr.lockedError()
+	}
+	if err := approvals.Check(); err != nil {
+		return err
+	}
+	pr.Status = StatusMerged
+	pr.MergedAt = mergedAt
+	return nil
+}
+
+func (pr *PullRequest) Close() error {
+	if pr.Status == StatusMerged {
+		return ErrPRAlreadyMerged
+	}
+	pr.Status = StatusClosed
+	return nil
+}
+
+// ChangeStatus opens or closes the pull request; it can only become merged through Merge.
+func (pr *PullRequest) ChangeStatus(status PRStatus) error {
+	if status == pr.Status {
+		return nil
+	}
+	switch status {
+	case StatusOpen:
+		return pr.Reopen()
+	case StatusClosed:
+		return pr.Close()
+	}
+	return ErrInvalidStatusChange
+}
+
+func (pr *PullRequest) Reopen() error {
+	if pr.Status == StatusMerged {
+		return ErrPRAlreadyMerged
+	}
+	pr.Status = StatusOpen
+	return nil
+}
 func (pr *PullRequest) SetMergedAt(mergedAt time.Time) {
 	pr.MergedAt = mergedAt
 }
@@ -50,19 +104,26 @@ func (pr *PullRequest) SetId(id int) {
 	pr.ID = id
 }
 
+func (pr *PullRequest) HasReviewer(userID int) bool {
+	for _, reviewer := range pr.Reviewers {
+		if reviewer.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
 func (pr *PullRequest) AddReviewer(reviewer *User) error {
 	if !pr.CanModifyReviewers() {
-		return ErrPRAlreadyMerged
+		return pr.lockedError()
 	}
 
-	if len(pr.Reviewers) >= 2 {
-		return ErrTooManyReviewers
+	if pr.HasReviewer(reviewer.ID) {
+		return ErrReviewerAlreadyAssigned
 	}
 
-	for _, r := range pr.Reviewers {
-		if r.ID == reviewer.ID {
-			return ErrReviewerAlreadyAssigned
-		}
+	if len(pr.Reviewers) >= MaxReviewers {
+		return ErrTooManyReviewers
 	}
 
 	pr.Reviewers = append(pr.Reviewers, reviewer)
@@ -71,7 +132,7 @@ func (pr *PullRequest) AddReviewer(reviewer *User) error {
 
 func (pr *PullRequest) RemoveReviewer(reviewerID int) error {
 	if !pr.CanModifyReviewers() {
-		return ErrPRAlreadyMerged
+		return pr.lockedError()
 	}
 
 	for i, reviewer := range pr.Reviewers {
@@ -83,6 +144,39 @@ func (pr *PullRequest) RemoveReviewer(reviewerID int) error {
 	return ErrReviewerNotFound
 }
 
+// SetReviewers makes reviewers the pull request's reviewers and returns who was added and who
+// removed. Reviewers on both lists stay assigned, so a pull request that is not open can be given
+// the reviewers it already has.
+func (pr *PullRequest) SetReviewers(reviewers []*User) (added, removed []*User, err error) {
+	wanted := make(map[int]bool, len(reviewers))
+	for _, reviewer := range reviewers {
+		if wanted[reviewer.ID] {
+			return nil, nil, ErrReviewerAlreadyAssigned
+		}
+		wanted[reviewer.ID] = true
+		if !pr.HasReviewer(reviewer.ID) {
+			added = append(added, reviewer)
+		}
+	}
+	for _, reviewer := range pr.Reviewers {
+		if !wanted[reviewer.ID] {
+			removed = append(removed, reviewer)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil, nil, nil
+	}
+	if !pr.CanModifyReviewers() {
+		return nil, nil, pr.lockedError()
+	}
+	if len(reviewers) > MaxReviewers {
+		return nil, nil, ErrTooManyReviewers
+	}
+
+	pr.Reviewers = append(make([]*User, 0, MaxReviewers), reviewers...)
+	return added, removed, nil
+}
+
 func (pr *PullRequest) ReplaceReviewer(oldReviewerID int, newReviewer *User) error {
 	if err := pr.RemoveReviewer(oldReviewerID); err != nil {
 		return err
@@ -90,10 +184,19 @@ func (pr *PullRequest) ReplaceReviewer(oldReviewerID int, newReviewer *User) err
 	return pr.AddReviewer(newReviewer)
 }
 
+func (pr *PullRequest) lockedError() error {
+	if pr.Status == StatusClosed {
+		return ErrPRClosed
+	}
+	return ErrPRAlreadyMerged
+}
+
 var (
 	ErrAuthorNotInTeam         = errors.New("author not in team")
 	ErrReviewerNotFound        = errors.New("reviewer not found")
 	ErrPRAlreadyMerged         = errors.New("pull request already merged")
+	ErrPRClosed                = errors.New("pull request closed")
 	ErrReviewerAlreadyAssigned = errors.New("reviewer already assigned")
 	ErrTooManyReviewers        = errors.New("too many reviewers")
+	ErrInvalidStatusChange     = errors.New("invalid status change")
 )
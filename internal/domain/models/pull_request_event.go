@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type PullRequestEventKind string
+
+const (
+	EventReviewed            PullRequestEventKind = "reviewed"
+	EventReviewApproved      PullRequestEventKind = "review_approved"
+	EventChangesRequested    PullRequestEventKind = "review_changes_requested"
+	EventReviewDismissed     PullRequestEventKind = "review_dismissed"
+	EventSLAReviewerAdded    PullRequestEventKind = "sla_reviewer_added"
+	EventSLAReviewerReplaced PullRequestEventKind = "sla_reviewer_replaced"
+	EventReviewerAssigned    PullRequestEventKind = "reviewer_assigned"
+	EventReviewerUnassigned  PullRequestEventKind = "reviewer_unassigned"
+	EventMerged              PullRequestEventKind = "merged"
+)
+
+// Streamed reports whether the user the event concerns is told about it, on their event stream
+// and through their notification channels.
+func (k PullRequestEventKind) Streamed() bool {
+	return k == EventReviewerAssigned || k == EventReviewerUnassigned || k == EventMerged
+}
+
+// PullRequestEvent records something that happened to a PR's review. UserID is the reviewer it
+// concerns and PreviousUserID the one they replaced, 0 when there is none.
+type PullRequestEvent struct {
+	ID             int
+	PullRequestID  int
+	Kind           PullRequestEventKind
+	UserID         int
+	PreviousUserID int
+	CreatedAt      time.Time
+}
+
+func NewPullRequestEvent(prID int, kind PullRequestEventKind, userID int, createdAt time.Time) *PullRequestEvent {
+	return &PullRequestEvent{
+		PullRequestID: prID,
+		Kind:          kind,
+		UserID:        userID,
+		CreatedAt:     createdAt,
+	}
+}
+
+func (e *PullRequestEvent) SetId(id int) {
+	e.ID = id
+}
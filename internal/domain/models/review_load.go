@@ -0,0 +1,64 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultReviewHalfLife = 7 * 24 * time.Hour
+	MinReviewHalfLife     = time.Hour
+	MaxReviewHalfLife     = 365 * 24 * time.Hour
+)
+
+// ReviewLoads maps a user ID to the exponentially decayed sum of the sizes of the PRs they review.
+type ReviewLoads map[int]float64
+
+// TeamReviewLoad is a team's half-life with the current load of each of its members.
+type TeamReviewLoad struct {
+	TeamID   int
+	HalfLife time.Duration
+	Loads    ReviewLoads
+}
+
+// ReviewWeight is what one review adds to the load after age has passed: its size in lines,
+// at least 1 so that PRs without a size still count, halved every halfLife.
+func ReviewWeight(size *int, age, halfLife time.Duration) float64 {
+	lines := 1
+	if size != nil && *size > 1 {
+		lines = *size
+	}
+	if age < 0 {
+		age = 0
+	}
+	return float64(lines) * math.Pow(0.5, age.Hours()/halfLife.Hours())
+}
+
+func (l ReviewLoads) Add(userID int, size *int, age, halfLife time.Duration) {
+	l[userID] += ReviewWeight(size, age, halfLife)
+}
+
+// RankCandidates orders candidates by how many of the wanted tags they have and then by load,
+// least loaded first; ties keep the incoming order.
+func RankCandidates(candidates []*User, loads ReviewLoads, tags map[int][]string, wanted []string) []*User {
+	matches := make(map[int]int, len(candidates))
+	for _, candidate := range candidates {
+		for _, tag := range wanted {
+			if hasTag(tags[candidate.ID], tag) {
+				matches[candidate.ID]++
+			}
+		}
+	}
+
+	ranked := make([]*User, len(candidates))
+	copy(ranked, candidates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i].ID, ranked[j].ID
+		if matches[a] != matches[b] {
+			return matches[a] > matches[b]
+		}
+		return loads[a] < loads[b]
+	})
+	return ranked
+}
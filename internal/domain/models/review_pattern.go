@@ -0,0 +1,95 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ReviewPattern routes a PR to reviewers with Tag when one of its changed paths matches Pattern
+// (CODEOWNERS syntax) or it carries Label.
+type ReviewPattern struct {
+	ID      int
+	TeamID  int
+	Pattern string
+	Label   string
+	Tag     string
+}
+
+func NewReviewPattern(teamID int, pattern, label, tag string) *ReviewPattern {
+	return &ReviewPattern{
+		TeamID:  teamID,
+		Pattern: pattern,
+		Label:   label,
+		Tag:     tag,
+	}
+}
+
+func (p *ReviewPattern) SetId(id int) {
+	p.ID = id
+}
+
+func (p *ReviewPattern) Matches(paths, labels []string) bool {
+	if p.Label != "" {
+		for _, label := range labels {
+			if strings.EqualFold(label, p.Label) {
+				return true
+			}
+		}
+		return false
+	}
+
+	re := patternRegexp(p.Pattern)
+	for _, path := range paths {
+		if re.MatchString(strings.TrimPrefix(path, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+type ReviewPatterns []*ReviewPattern
+
+// MatchingTags lists the tags of all patterns matching the PR, in pattern order.
+func (patterns ReviewPatterns) MatchingTags(paths, labels []string) []string {
+	tags := make([]string, 0)
+	for _, pattern := range patterns {
+		if !hasTag(tags, pattern.Tag) && pattern.Matches(paths, labels) {
+			tags = append(tags, pattern.Tag)
+		}
+	}
+	return tags
+}
+
+// patternRegexp translates a CODEOWNERS pattern: a leading or inner slash anchors it to the
+// repository root, "*" and "?" stay within a path segment, "**" spans segments and a match on a
+// directory covers everything below it.
+func patternRegexp(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type SLAAction string
+
+const (
+	SLAActionAddReviewer SLAAction = "add_reviewer"
+	SLAActionReassign    SLAAction = "reassign"
+)
+
+func (a SLAAction) IsValid() bool {
+	switch a {
+	case SLAActionAddReviewer, SLAActionReassign:
+		return true
+	}
+	return false
+}
+
+const MaxReviewSLAHours = 720
+
+// ReviewSLA is how many working hours a team's reviewers have for the first review of a PR
+// before it is escalated with Action. Zero Hours turns escalation off.
+type ReviewSLA struct {
+	TeamID int
+	Hours  int
+	Action SLAAction
+}
+
+func (s *ReviewSLA) Enabled() bool {
+	return s.Hours > 0
+}
+
+type ReviewAssignment struct {
+	PullRequestID  int
+	ReviewerID     int
+	State          ReviewState
+	AssignedAt     time.Time
+	ReviewedAt     *time.Time
+	StateChangedAt *time.Time
+}
+
+// PendingReview is an open PR of a team with an SLA that none of its reviewers has reviewed yet.
+// AuthorHours and ReviewerHours are the working hours of its author and of each assigned reviewer.
+type PendingReview struct {
+	PullRequestID int
+	CreatedAt     time.Time
+	SLA           ReviewSLA
+	Assignments   []*ReviewAssignment
+	AuthorHours   WorkingHours
+	ReviewerHours map[int]WorkingHours
+}
+
+// Overdue reports whether even the most recently assigned reviewer has been waiting for the SLA
+// within their working hours. A PR without reviewers is measured from its creation in the working
+// hours of its author.
+func (p *PendingReview) Overdue(now time.Time) bool {
+	if !p.SLA.Enabled() {
+		return false
+	}
+	since, hours := p.CreatedAt, p.AuthorHours
+	for _, assignment := range p.Assignments {
+		if assignment.ReviewedAt != nil {
+			return false
+		}
+		if !assignment.AssignedAt.Before(since) {
+			since, hours = assignment.AssignedAt, p.ReviewerHours[assignment.ReviewerID]
+		}
+	}
+	return hours.Between(since, now) >= time.Duration(p.SLA.Hours)*time.Hour
+}
+
+// Stale returns the reviewer who has been waiting the longest, nil when there is none.
+func (p *PendingReview) Stale() *ReviewAssignment {
+	var stale *ReviewAssignment
+	for _, assignment := range p.Assignments {
+		if stale == nil || assignment.AssignedAt.Before(stale.AssignedAt) {
+			stale = assignment
+		}
+	}
+	return stale
+}
+
+var ErrReviewerNotAssigned = errors.New("user is not a reviewer of the pull request")
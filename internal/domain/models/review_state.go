@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+type ReviewState string
+
+const (
+	ReviewPending          ReviewState = "pending"
+	ReviewApproved         ReviewState = "approved"
+	ReviewChangesRequested ReviewState = "changes_requested"
+	ReviewDismissed        ReviewState = "dismissed"
+)
+
+// IsVerdict reports whether a reviewer can submit the state; pending is only the initial one.
+func (s ReviewState) IsVerdict() bool {
+	switch s {
+	case ReviewApproved, ReviewChangesRequested, ReviewDismissed:
+		return true
+	}
+	return false
+}
+
+// CountsAsReview reports whether the state means the reviewer has looked at the PR.
+func (s ReviewState) CountsAsReview() bool {
+	return s == ReviewApproved || s == ReviewChangesRequested
+}
+
+func (s ReviewState) EventKind() PullRequestEventKind {
+	switch s {
+	case ReviewApproved:
+		return EventReviewApproved
+	case ReviewChangesRequested:
+		return EventChangesRequested
+	}
+	return EventReviewDismissed
+}
+
+// ApprovalPolicy is how many of the current reviewers must approve a team's PR before it is merged.
+type ApprovalPolicy struct {
+	TeamID            int
+	RequiredApprovals int
+}
+
+type ApprovalStatus struct {
+	Required int
+	Approved int
+}
+
+func (s ApprovalStatus) Check() error {
+	if s.Approved < s.Required {
+		return fmt.Errorf("%w: %d of %d", ErrNotEnoughApprovals, s.Approved, s.Required)
+	}
+	return nil
+}
+
+type PullRequestReviews struct {
+	PullRequestID int
+	Assignments   []*ReviewAssignment
+	Approvals     ApprovalStatus
+}
+
+// CheckReviewable rejects verdicts on PRs that are no longer open.
+func (pr *PullRequest) CheckReviewable() error {
+	if pr.CanModifyReviewers() {
+		return nil
+	}
+	return pr.lockedError()
+}
+
+var ErrNotEnoughApprovals = errors.New("not enough approvals")
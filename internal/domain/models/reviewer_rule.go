@@ -0,0 +1,230 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type RuleKind string
+
+const (
+	RuleNeverAssign RuleKind = "never_assign"
+	RuleRequireTag  RuleKind = "require_tag"
+	RulePrefer      RuleKind = "prefer"
+	// RulePreferWorkingHours is a team-wide rule without users or tags.
+	RulePreferWorkingHours RuleKind = "prefer_working_hours"
+)
+
+func (k RuleKind) IsValid() bool {
+	switch k {
+	case RuleNeverAssign, RuleRequireTag, RulePrefer, RulePreferWorkingHours:
+		return true
+	}
+	return false
+}
+
+// ReviewerRule is a per-team constraint on reviewer selection:
+//   - never_assign: ReviewerID and AuthorID never review each other;
+//   - require_tag: at least one reviewer has Tag, for authors with AuthorTag or for everyone if it is empty;
+//   - prefer: ReviewerID goes first when AuthorID opens a PR;
+//   - prefer_working_hours: candidates within their working hours go before the rest.
+type ReviewerRule struct {
+	ID         int
+	TeamID     int
+	Kind       RuleKind
+	ReviewerID int
+	AuthorID   int
+	Tag        string
+	AuthorTag  string
+}
+
+func NewReviewerRule(teamID int, kind RuleKind) *ReviewerRule {
+	return &ReviewerRule{
+		TeamID: teamID,
+		Kind:   kind,
+	}
+}
+
+func (r *ReviewerRule) SetId(id int) {
+	r.ID = id
+}
+
+func (r *ReviewerRule) forbids(authorID, candidateID int) bool {
+	return r.Kind == RuleNeverAssign &&
+		((r.ReviewerID == candidateID && r.AuthorID == authorID) || (r.ReviewerID == authorID && r.AuthorID == candidateID))
+}
+
+func (r *ReviewerRule) appliesToAuthor(authorTags []string) bool {
+	return r.Kind == RuleRequireTag && (r.AuthorTag == "" || hasTag(authorTags, r.AuthorTag))
+}
+
+type ReviewerRules []*ReviewerRule
+
+// CandidateCheck explains whether a user may review PRs of an author.
+type CandidateCheck struct {
+	AuthorID    int
+	CandidateID int
+	Eligible    bool
+	Preferred   bool
+	Reasons     []string
+	// RequiredTags are the tags some reviewer of this author must have; ProvidedTags is the
+	// subset the candidate covers.
+	RequiredTags []string
+	ProvidedTags []string
+}
+
+// Check explains the decision SelectReviewers makes for one candidate.
+func (rules ReviewerRules) Check(author, candidate *User, tags map[int][]string) *CandidateCheck {
+	check := &CandidateCheck{
+		AuthorID:     author.ID,
+		CandidateID:  candidate.ID,
+		Reasons:      make([]string, 0),
+		RequiredTags: rules.RequiredTags(tags[author.ID]),
+		ProvidedTags: make([]string, 0),
+	}
+
+	if candidate.ID == author.ID {
+		check.Reasons = append(check.Reasons, "candidate is the author")
+	}
+	if !candidate.IsActive {
+		check.Reasons = append(check.Reasons, "candidate is inactive")
+	}
+	if candidate.TeamName != author.TeamName {
+		check.Reasons = append(check.Reasons, fmt.Sprintf("candidate is in team %q, author is in team %q", candidate.TeamName, author.TeamName))
+	}
+	for _, rule := range rules {
+		if rule.forbids(author.ID, candidate.ID) {
+			check.Reasons = append(check.Reasons, fmt.Sprintf("rule %d: users %d and %d never review each other", rule.ID, rule.ReviewerID, rule.AuthorID))
+		}
+	}
+	for _, tag := range check.RequiredTags {
+		if hasTag(tags[candidate.ID], tag) {
+			check.ProvidedTags = append(check.ProvidedTags, tag)
+		}
+	}
+
+	check.Eligible = len(check.Reasons) == 0
+	check.Preferred = check.Eligible && rules.prefers(author.ID, candidate.ID)
+	return check
+}
+
+func (rules ReviewerRules) RequiredTags(authorTags []string) []string {
+	required := make([]string, 0)
+	for _, rule := range rules {
+		if rule.appliesToAuthor(authorTags) && !hasTag(required, rule.Tag) {
+			required = append(required, rule.Tag)
+		}
+	}
+	return required
+}
+
+func (rules ReviewerRules) forbids(authorID, candidateID int) bool {
+	for _, rule := range rules {
+		if rule.forbids(authorID, candidateID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rules ReviewerRules) prefers(authorID, candidateID int) bool {
+	for _, rule := range rules {
+		if rule.Kind == RulePrefer && rule.AuthorID == authorID && rule.ReviewerID == candidateID {
+			return true
+		}
+	}
+	return false
+}
+
+func (rules ReviewerRules) PrefersWorkingHours() bool {
+	for _, rule := range rules {
+		if rule.Kind == RulePreferWorkingHours {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectReviewers picks up to slots reviewers for a PR of author that already has assigned
+// reviewers. Forbidden pairs are skipped, preferred reviewers go first and every required tag
+// that the assigned reviewers do not cover takes a slot before the rest are filled in
+// candidate order.
+func (rules ReviewerRules) SelectReviewers(
+	author *User,
+	assigned []*User,
+	candidates []*User,
+	tags map[int][]string,
+	slots int,
+) ([]*User, error) {
+	eligible := make([]*User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID == author.ID || containsUser(assigned, candidate.ID) || rules.forbids(author.ID, candidate.ID) {
+			continue
+		}
+		eligible = append(eligible, candidate)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return rules.prefers(author.ID, eligible[i].ID) && !rules.prefers(author.ID, eligible[j].ID)
+	})
+
+	chosen := make([]*User, 0, slots)
+	for _, tag := range rules.RequiredTags(tags[author.ID]) {
+		if coversTag(assigned, tags, tag) || coversTag(chosen, tags, tag) {
+			continue
+		}
+		var match *User
+		for _, candidate := range eligible {
+			if !containsUser(chosen, candidate.ID) && hasTag(tags[candidate.ID], tag) {
+				match = candidate
+				break
+			}
+		}
+		if match == nil || len(chosen) >= slots {
+			return nil, fmt.Errorf("%w: %s", ErrRequiredReviewerUnavailable, tag)
+		}
+		chosen = append(chosen, match)
+	}
+
+	for _, candidate := range eligible {
+		if len(chosen) >= slots {
+			break
+		}
+		if !containsUser(chosen, candidate.ID) {
+			chosen = append(chosen, candidate)
+		}
+	}
+
+	return chosen, nil
+}
+
+func coversTag(users []*User, tags map[int][]string, tag string) bool {
+	for _, user := range users {
+		if hasTag(tags[user.ID], tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUser(users []*User, id int) bool {
+	for _, user := range users {
+		if user.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrRequiredReviewerUnavailable = errors.New("no active candidate has the required tag")
+
+var ErrRuleUserNotInTeam = errors.New("rule refers to a user outside the team")
@@ -6,6 +6,7 @@ type Team struct {
 	ID      int                 `json:"id"`
 	Name    string              `json:"name"`
 	Members map[int]*TeamMember `json:"members"`
+	Version int                 `json:"version"`
 }
 
 func NewTeam(name string) *Team {
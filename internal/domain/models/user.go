@@ -1,19 +1,27 @@
 package models
 
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
 type User struct {
-	ID       int    `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	Email        string       `json:"email"`
+	IsActive     bool         `json:"is_active"`
+	TeamName     string       `json:"team_name"`
+	WorkingHours WorkingHours `json:"working_hours"`
 }
 
 func NewUser(name, email string, isActive bool, teamName string) *User {
 	return &User{
-		Name:     name,
-		Email:    email,
-		IsActive: isActive,
-		TeamName: teamName,
+		Name:         name,
+		Email:        email,
+		IsActive:     isActive,
+		TeamName:     teamName,
+		WorkingHours: DefaultWorkingHours,
 	}
 }
 
@@ -32,3 +40,91 @@ func (u *User) UpdateEmail(email string) {
 func (u *User) UpdateIsActive(isActive bool) {
 	u.IsActive = isActive
 }
+
+// WorkingHours is when a user reviews: weekdays from StartHour to EndHour in Timezone,
+// an IANA zone name. Shifts do not cross midnight: stored hours always satisfy
+// 0 <= StartHour < EndHour <= 24, so EndHour is 0 only in the zero value, which users
+// loaded without their working hours have and which means always available.
+type WorkingHours struct {
+	Timezone  string `json:"timezone"`
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
+}
+
+var DefaultWorkingHours = WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 18}
+
+// Contains reports whether t falls within the working hours.
+func (h WorkingHours) Contains(t time.Time) bool {
+	if h.EndHour == 0 {
+		return true
+	}
+	local := t.In(h.location())
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	return local.Hour() >= h.StartHour && local.Hour() < h.EndHour
+}
+
+// Between is the part of [from, to) that falls within the working hours, counted day by day in
+// the user's time zone.
+func (h WorkingHours) Between(from, to time.Time) time.Duration {
+	if !from.Before(to) {
+		return 0
+	}
+	if h.EndHour == 0 {
+		return to.Sub(from)
+	}
+	location := h.location()
+	local := from.In(location)
+	var total time.Duration
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), h.StartHour, 0, 0, 0, location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), h.EndHour, 0, 0, 0, location)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if start.Before(end) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// locations keeps every zone loaded so far: time.LoadLocation reads the zone database on each
+// call, while Contains runs for every candidate of every assignment.
+var locations sync.Map
+
+// location is the zone named by Timezone, loaded once per zone; unknown names fall back to UTC.
+func (h WorkingHours) location() *time.Location {
+	if location, ok := locations.Load(h.Timezone); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	locations.Store(h.Timezone, location)
+	return location
+}
+
+// PreferWorking moves the candidates who are within their working hours at now to the front,
+// keeping the order within both groups.
+func PreferWorking(candidates []*User, now time.Time) []*User {
+	working := make(map[int]bool, len(candidates))
+	for _, candidate := range candidates {
+		working[candidate.ID] = candidate.WorkingHours.Contains(now)
+	}
+
+	ranked := make([]*User, len(candidates))
+	copy(ranked, candidates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return working[ranked[i].ID] && !working[ranked[j].ID]
+	})
+	return ranked
+}
@@ -0,0 +1,24 @@
+package models
+
+type UserIdentity struct {
+	ID       int         `json:"id"`
+	Provider VCSProvider `json:"provider"`
+	Login    string      `json:"login"`
+	UserID   int         `json:"user_id"`
+}
+
+func NewUserIdentity(provider VCSProvider, login string, userID int) *UserIdentity {
+	return &UserIdentity{
+		Provider: provider,
+		Login:    login,
+		UserID:   userID,
+	}
+}
+
+func (i *UserIdentity) SetId(id int) {
+	i.ID = id
+}
+
+func (i *UserIdentity) UpdateLogin(login string) {
+	i.Login = login
+}
@@ -0,0 +1,69 @@
+package models
+
+import "errors"
+
+type VCSProvider string
+
+const (
+	ProviderGitHub VCSProvider = "github"
+	ProviderGitLab VCSProvider = "gitlab"
+)
+
+type VCSAction string
+
+const (
+	VCSActionOpened          VCSAction = "opened"
+	VCSActionClosed          VCSAction = "closed"
+	VCSActionMerged          VCSAction = "merged"
+	VCSActionReopened        VCSAction = "reopened"
+	VCSActionReviewRequested VCSAction = "review_requested"
+)
+
+type VCSEvent struct {
+	Provider       VCSProvider `json:"provider"`
+	Action         VCSAction   `json:"action"`
+	ExternalID     string      `json:"external_id"`
+	Title          string      `json:"title"`
+	AuthorLogin    string      `json:"author_login"`
+	ReviewerLogins []string    `json:"reviewer_logins"`
+	Labels         []string    `json:"labels"`
+	Size           *int        `json:"size,omitempty"`
+}
+
+type VCSEventOutcome string
+
+const (
+	VCSEventApplied   VCSEventOutcome = "applied"
+	VCSEventDuplicate VCSEventOutcome = "duplicate"
+	VCSEventIgnored   VCSEventOutcome = "ignored"
+)
+
+// VCSEventResult is what an event did. Warning is set when a step after the pull request was
+// stored failed, so the delivery is still acknowledged instead of being retried by the code host.
+type VCSEventResult struct {
+	Outcome     VCSEventOutcome `json:"outcome"`
+	PullRequest *PullRequest    `json:"pull_request"`
+	Warning     error           `json:"-"`
+}
+
+type ExternalPullRequest struct {
+	Provider      VCSProvider `json:"provider"`
+	ExternalID    string      `json:"external_id"`
+	PullRequestID int         `json:"pull_request_id"`
+}
+
+func NewExternalPullRequest(provider VCSProvider, externalID string, pullRequestID int) *ExternalPullRequest {
+	return &ExternalPullRequest{
+		Provider:      provider,
+		ExternalID:    externalID,
+		PullRequestID: pullRequestID,
+	}
+}
+
+var (
+	ErrUnknownExternalUser = errors.New("external user is not mapped to a user")
+)
+
+func (p VCSProvider) IsValid() bool {
+	return p == ProviderGitHub || p == ProviderGitLab
+}
@@ -0,0 +1,10 @@
+package models
+
+import "errors"
+
+var (
+	// ErrConflictingUpdate means the pull request or team changed after the caller read it.
+	ErrConflictingUpdate = errors.New("resource was modified by another request")
+	// ErrVersionRequired means a change to a pull request or team did not say which version it is based on.
+	ErrVersionRequired = errors.New("version of the change is required")
+)
@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"reviewer-assignment-service/internal/domain/models"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var digestFuncs = map[string]any{"waiting": formatWaiting}
+
+var (
+	digestSubject = template.Must(template.New("subject").Parse(
+		`Review digest: {{len .Reviewing}} to review, {{len .Authored}} waiting on reviewers`))
+
+	digestText = template.Must(template.New("text").Funcs(digestFuncs).Parse(`Hi {{.User.Name}},
+{{if .Reviewing}}
+Waiting for your review:
+{{range .Reviewing}}- "{{.PullRequest.Name}}" (#{{.PullRequest.ID}}){{with .PullRequest.Author}} by {{.Name}}{{end}}, assigned {{waiting .Waiting}} ago, {{.State}}
+{{end}}{{end}}{{if .Authored}}
+Your PRs waiting on reviewers:
+{{range .Authored}}- "{{.PullRequest.Name}}" (#{{.PullRequest.ID}}), opened {{waiting .Waiting}} ago
+{{end}}{{end}}`))
+
+	digestHTML = htmltemplate.Must(htmltemplate.New("html").Funcs(digestFuncs).Parse(`<!DOCTYPE html>
+<html>
+<body>
+<p>Hi {{.User.Name}},</p>
+{{- if .Reviewing}}
+<h3>Waiting for your review</h3>
+<ul>
+{{- range .Reviewing}}
+<li><b>{{.PullRequest.Name}}</b> (#{{.PullRequest.ID}}){{with .PullRequest.Author}} by {{.Name}}{{end}}, assigned {{waiting .Waiting}} ago, {{.State}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Authored}}
+<h3>Your PRs waiting on reviewers</h3>
+<ul>
+{{- range .Authored}}
+<li><b>{{.PullRequest.Name}}</b> (#{{.PullRequest.ID}}), opened {{waiting .Waiting}} ago</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+)
+
+// RenderDigest returns the subject and the plain text and HTML bodies of a digest.
+func RenderDigest(digest *models.Digest) (string, string, string, error) {
+	var subject, text, html strings.Builder
+	if err := digestSubject.Execute(&subject, digest); err != nil {
+		return "", "", "", err
+	}
+	if err := digestText.Execute(&text, digest); err != nil {
+		return "", "", "", err
+	}
+	if err := digestHTML.Execute(&html, digest); err != nil {
+		return "", "", "", err
+	}
+	return subject.String(), text.String(), html.String(), nil
+}
+
+// formatWaiting rounds to the two largest units, e.g. 2d 3h or 45m.
+func formatWaiting(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -0,0 +1,73 @@
+// Package notifications renders pull request events into messages for the notification channels.
+package notifications
+
+import (
+	"fmt"
+	"reviewer-assignment-service/internal/domain/models"
+	"strings"
+	"text/template"
+)
+
+// Notifier delivers a rendered notification over one channel; an error means the delivery is
+// retried later.
+type Notifier interface {
+	Send(notification *models.Notification) error
+}
+
+// Message is what the templates see: the PR, the user being notified and, on a reassignment,
+// the reviewer they replaced.
+type Message struct {
+	PullRequest *models.PullRequest
+	Recipient   *models.User
+	Previous    *models.User
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+var templates = map[models.PullRequestEventKind]messageTemplate{
+	models.EventReviewerAssigned: parse(
+		`Review requested: {{.PullRequest.Name}}`,
+		`Hi {{.Recipient.Name}},
+
+you were assigned to review "{{.PullRequest.Name}}" (#{{.PullRequest.ID}}){{with .PullRequest.Author}} by {{.Name}}{{end}}.
+{{- with .Previous}} You take over from {{.Name}}.{{end}}
+`),
+	models.EventReviewerUnassigned: parse(
+		`Review reassigned: {{.PullRequest.Name}}`,
+		`Hi {{.Recipient.Name}},
+
+you are no longer a reviewer of "{{.PullRequest.Name}}" (#{{.PullRequest.ID}}); it was reassigned to someone else.
+`),
+	models.EventMerged: parse(
+		`Merged: {{.PullRequest.Name}}`,
+		`Hi {{.Recipient.Name}},
+
+"{{.PullRequest.Name}}" (#{{.PullRequest.ID}}), which you were reviewing, has been merged.
+`),
+}
+
+func parse(subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// Render returns the subject and body for an event of the given kind.
+func Render(kind models.PullRequestEventKind, message Message) (string, string, error) {
+	tmpl, ok := templates[kind]
+	if !ok {
+		return "", "", fmt.Errorf("no notification template for %q", kind)
+	}
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, message); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, message); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
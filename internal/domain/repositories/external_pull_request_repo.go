@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+type ExternalPullRequestRepository interface {
+	Add(link *models.ExternalPullRequest) error
+	GetByExternalID(provider models.VCSProvider, externalID string) (*models.ExternalPullRequest, error)
+}
+
+var (
+	ErrExternalPullRequestNotFound      = errors.New("external pull request not found")
+	ErrExternalPullRequestAlreadyExists = errors.New("external pull request already exists")
+)
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+type IdempotencyKeyRepository interface {
+	// Reserve stores a key without a response and reports false if the key is already taken.
+	Reserve(key *models.IdempotencyKey) (bool, error)
+	Get(key string) (*models.IdempotencyKey, error)
+	Complete(key *models.IdempotencyKey) error
+	Delete(key string) error
+}
+
+var ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"time"
+)
+
+type NotificationPreferenceRepository interface {
+	// GetByUserIDs returns the saved preferences by user; users who saved none are absent.
+	GetByUserIDs(userIDs []int) (map[int][]*models.NotificationPreference, error)
+	Replace(userID int, preferences []*models.NotificationPreference) error
+}
+
+type NotificationRepository interface {
+	// Add queues the notification unless one for the same event and channel already exists, or
+	// for a digest, one for the same user, channel and day.
+	Add(notification *models.Notification) error
+	// ClaimDue returns up to limit notifications due by now and moves their next attempt to
+	// leaseUntil, so that another worker does not pick them up while they are being sent.
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.Notification, error)
+	// Save stores the outcome of a delivery attempt.
+	Save(notification *models.Notification) error
+}
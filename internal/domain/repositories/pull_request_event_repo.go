@@ -0,0 +1,10 @@
+package repositories
+
+import "reviewer-assignment-service/internal/domain/models"
+
+type PullRequestEventRepository interface {
+	Add(event *models.PullRequestEvent) error
+	GetByPullRequestID(prID int) ([]*models.PullRequestEvent, error)
+	// GetByUserID returns the events concerning the user with an id above afterID, oldest first.
+	GetByUserID(userID, afterID int) ([]*models.PullRequestEvent, error)
+}
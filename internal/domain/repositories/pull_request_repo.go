@@ -12,7 +12,16 @@ type PullRequestRepository interface {
 	GetByStatus(status models.PRStatus) ([]*models.PullRequest, error)
 	GetByAuthorID(authorID int) ([]*models.PullRequest, error)
 	GetByReviewerID(reviewerID int) ([]*models.PullRequest, error)
+	// GetByReviewerIDs groups the pull requests by each of the given reviewers; a pull request
+	// reviewed by several of them is shared between their lists.
+	GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error)
 	Update(pr *models.PullRequest) error
+	ReviewerCandidateRepository
+}
+
+type ReviewerCandidateRepository interface {
+	// FindPossibleReviewers lists the active members of the author's team other than the author,
+	// ordered by id.
 	FindPossibleReviewers(author *models.User) ([]*models.User, error)
 }
 
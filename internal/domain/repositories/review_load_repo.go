@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"time"
+)
+
+type ReviewLoadRepository interface {
+	GetByTeamName(teamName string, now time.Time) (models.ReviewLoads, error)
+	GetHalfLife(teamID int) (time.Duration, error)
+	SetHalfLife(teamID int, halfLife time.Duration) error
+}
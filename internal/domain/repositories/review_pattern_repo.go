@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+type ReviewPatternRepository interface {
+	Add(pattern *models.ReviewPattern) error
+	GetByID(id int) (*models.ReviewPattern, error)
+	GetByTeamID(teamID int) (models.ReviewPatterns, error)
+	GetByTeamName(teamName string) (models.ReviewPatterns, error)
+	Delete(id int) error
+}
+
+var ErrReviewPatternNotFound = errors.New("review pattern not found")
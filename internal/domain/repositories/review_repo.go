@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"time"
+)
+
+type ReviewRepository interface {
+	GetByPullRequestID(prID int) ([]*models.ReviewAssignment, error)
+	SetState(prID, reviewerID int, state models.ReviewState, at time.Time) error
+	GetApprovals(prID int) (*models.ApprovalStatus, error)
+	GetPolicy(teamID int) (*models.ApprovalPolicy, error)
+	SavePolicy(policy *models.ApprovalPolicy) error
+}
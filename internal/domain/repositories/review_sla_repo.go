@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"time"
+)
+
+type ReviewSLARepository interface {
+	GetByTeamID(teamID int) (*models.ReviewSLA, error)
+	Save(sla *models.ReviewSLA) error
+	// FindPending returns the open PRs with nothing reviewed whose newest assignment is at least
+	// the team's SLA old by the wall clock; business hours are left to the caller.
+	FindPending(now time.Time) ([]*models.PendingReview, error)
+	GetAssignments(prID int) ([]*models.ReviewAssignment, error)
+	MarkReviewed(prID, reviewerID int, at time.Time) error
+}
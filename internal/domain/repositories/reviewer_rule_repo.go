@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+type ReviewerRuleRepository interface {
+	Add(rule *models.ReviewerRule) error
+	GetByID(id int) (*models.ReviewerRule, error)
+	GetByTeamID(teamID int) (models.ReviewerRules, error)
+	GetByTeamName(teamName string) (models.ReviewerRules, error)
+	Delete(id int) error
+}
+
+var ErrReviewerRuleNotFound = errors.New("reviewer rule not found")
@@ -0,0 +1,13 @@
+package repositories
+
+type Repositories struct {
+	Users UserRepository
+	Teams TeamRepository
+
+	PullRequests         PullRequestRepository
+	ExternalPullRequests ExternalPullRequestRepository
+}
+
+type TransactionManager interface {
+	WithinTransaction(fn func(repos Repositories) error) error
+}
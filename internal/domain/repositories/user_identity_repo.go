@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+)
+
+type UserIdentityRepository interface {
+	Add(identity *models.UserIdentity) error
+	GetByID(id int) (*models.UserIdentity, error)
+	GetByUserID(userID int) ([]*models.UserIdentity, error)
+	GetByUserIDs(userIDs []int) ([]*models.UserIdentity, error)
+	GetByLogin(provider models.VCSProvider, login string) (*models.UserIdentity, error)
+	Update(identity *models.UserIdentity) error
+	Delete(id int) error
+}
+
+var (
+	ErrUserIdentityNotFound      = errors.New("user identity not found")
+	ErrUserIdentityAlreadyExists = errors.New("user identity already exists")
+)
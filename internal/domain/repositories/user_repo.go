@@ -8,12 +8,14 @@ import (
 type UserRepository interface {
 	Add(user *models.User) error
 	GetByID(id int) (*models.User, error)
+	GetByIDs(ids []int) ([]*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetAll() ([]*models.User, error)
 	GetActiveUsers() ([]*models.User, error)
 	GetWithFilters(teamName string, isActive bool) ([]*models.User, error)
 	Update(user *models.User) error
 	Deactivate(userID int) error
+	SetWorkingHours(userID int, hours models.WorkingHours) error
 }
 
 var (
@@ -0,0 +1,7 @@
+package repositories
+
+type UserTagRepository interface {
+	GetByUserID(userID int) ([]string, error)
+	GetByUserIDs(userIDs []int) (map[int][]string, error)
+	Replace(userID int, tags []string) error
+}
@@ -0,0 +1,112 @@
+package impl
+
+import (
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+)
+
+type DigestServiceImpl struct {
+	userRepository        repositories.UserRepository
+	pullRequestRepository repositories.PullRequestRepository
+	reviewRepository      repositories.ReviewRepository
+	notificationService   services.NotificationService
+	clock                 clock.Clock
+}
+
+func NewDigestService(
+	userRepository repositories.UserRepository,
+	pullRequestRepository repositories.PullRequestRepository,
+	reviewRepository repositories.ReviewRepository,
+	notificationService services.NotificationService,
+	clock clock.Clock,
+) *DigestServiceImpl {
+	return &DigestServiceImpl{
+		userRepository:        userRepository,
+		pullRequestRepository: pullRequestRepository,
+		reviewRepository:      reviewRepository,
+		notificationService:   notificationService,
+		clock:                 clock,
+	}
+}
+
+func (s *DigestServiceImpl) Build(userID int) (*models.Digest, error) {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.build(user, s.clock.Now())
+}
+
+func (s *DigestServiceImpl) Send(now time.Time) (int, error) {
+	users, err := s.userRepository.GetActiveUsers()
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, user := range users {
+		digest, err := s.build(user, now)
+		if err != nil {
+			return queued, err
+		}
+		if digest.Empty() {
+			continue
+		}
+		if err := s.notificationService.EnqueueDigest(digest); err != nil {
+			return queued, err
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// build lists the open PRs the user reviews, waiting since they were assigned, and their own open
+// PRs that do not have enough approvals yet, waiting since they were opened.
+func (s *DigestServiceImpl) build(user *models.User, now time.Time) (*models.Digest, error) {
+	digest := &models.Digest{User: user, GeneratedAt: now}
+
+	reviewing, err := s.pullRequestRepository.GetByReviewerID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range reviewing {
+		if pr.Status != models.StatusOpen {
+			continue
+		}
+		assignments, err := s.reviewRepository.GetByPullRequestID(pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		state, since := models.ReviewPending, pr.CreatedAt
+		for _, assignment := range assignments {
+			if assignment.ReviewerID == user.ID {
+				state, since = assignment.State, assignment.AssignedAt
+			}
+		}
+		digest.Reviewing = append(digest.Reviewing, models.NewDigestItem(pr, state, since, now))
+	}
+
+	authored, err := s.pullRequestRepository.GetByAuthorID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range authored {
+		if pr.Status != models.StatusOpen {
+			continue
+		}
+		approvals, err := s.reviewRepository.GetApprovals(pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		if approvals.Check() == nil {
+			continue
+		}
+		digest.Authored = append(digest.Authored, models.NewDigestItem(pr, "", pr.CreatedAt, now))
+	}
+
+	digest.Sort()
+	return digest, nil
+}
@@ -0,0 +1,68 @@
+package impl
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"time"
+)
+
+type IdempotencyServiceImpl struct {
+	keyRepository repositories.IdempotencyKeyRepository
+	ttl           time.Duration
+	clock         clock.Clock
+}
+
+func NewIdempotencyService(keyRepository repositories.IdempotencyKeyRepository, ttl time.Duration, clock clock.Clock) *IdempotencyServiceImpl {
+	return &IdempotencyServiceImpl{
+		keyRepository: keyRepository,
+		ttl:           ttl,
+		clock:         clock,
+	}
+}
+
+// Begin takes over keys older than the TTL. The second attempt covers a key that expired or was
+// released between Reserve and Get.
+func (s *IdempotencyServiceImpl) Begin(key, requestHash string) (*models.IdempotencyKey, error) {
+	now := s.clock.Now()
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.keyRepository.Reserve(models.NewIdempotencyKey(key, requestHash, now))
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		stored, err := s.keyRepository.Get(key)
+		if errors.Is(err, repositories.ErrIdempotencyKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if stored.Expired(now, s.ttl) {
+			if err := s.keyRepository.Delete(key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if stored.RequestHash != requestHash {
+			return nil, models.ErrIdempotencyKeyReused
+		}
+		if !stored.Completed() {
+			return nil, models.ErrIdempotencyKeyInProgress
+		}
+		return stored, nil
+	}
+	return nil, models.ErrIdempotencyKeyInProgress
+}
+
+func (s *IdempotencyServiceImpl) Complete(key *models.IdempotencyKey) error {
+	return s.keyRepository.Complete(key)
+}
+
+func (s *IdempotencyServiceImpl) Release(key string) error {
+	return s.keyRepository.Delete(key)
+}
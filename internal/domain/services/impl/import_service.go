@@ -0,0 +1,227 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"sort"
+	"strings"
+)
+
+type ImportServiceImpl struct {
+	transactions repositories.TransactionManager
+}
+
+func NewImportService(transactions repositories.TransactionManager) *ImportServiceImpl {
+	return &ImportServiceImpl{
+		transactions: transactions,
+	}
+}
+
+// Import creates missing teams and users, updates changed ones and deactivates members of
+// imported teams that are absent from the document. Everything runs in one transaction;
+// in dry-run mode only the report is built.
+func (s *ImportServiceImpl) Import(doc *models.OrgDocument, dryRun bool) (*models.ImportReport, error) {
+	if err := checkDocument(doc); err != nil {
+		return nil, err
+	}
+
+	report := models.NewImportReport(dryRun)
+	err := s.transactions.WithinTransaction(func(repos repositories.Repositories) error {
+		existing, err := repos.Users.GetAll()
+		if err != nil {
+			return err
+		}
+		usersByEmail := make(map[string]*models.User, len(existing))
+		for _, user := range existing {
+			usersByEmail[strings.ToLower(user.Email)] = user
+		}
+
+		for _, orgTeam := range doc.Teams {
+			if err := importTeam(repos, orgTeam, usersByEmail, existing, report, dryRun); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func importTeam(
+	repos repositories.Repositories,
+	orgTeam *models.OrgTeam,
+	usersByEmail map[string]*models.User,
+	existing []*models.User,
+	report *models.ImportReport,
+	dryRun bool,
+) error {
+	team, err := repos.Teams.GetByName(orgTeam.Name)
+	created := false
+	switch {
+	case errors.Is(err, repositories.ErrTeamNotFoundInPersistence):
+		team = models.NewTeam(orgTeam.Name)
+		created = true
+		report.TeamsCreated = append(report.TeamsCreated, orgTeam.Name)
+		if !dryRun {
+			if err := repos.Teams.Add(team); err != nil {
+				return err
+			}
+		}
+	case err != nil:
+		return err
+	}
+
+	members := make(map[int]*models.TeamMember, len(orgTeam.Members))
+	listed := make(map[string]bool, len(orgTeam.Members))
+	membershipChanged := false
+
+	for _, orgMember := range orgTeam.Members {
+		email := strings.ToLower(orgMember.Email)
+		listed[email] = true
+
+		user, ok := usersByEmail[email]
+		if !ok {
+			user = models.NewUser(orgMember.Username, orgMember.Email, orgMember.IsActive, orgTeam.Name)
+			report.UsersCreated = append(report.UsersCreated, orgMember.Email)
+			membershipChanged = true
+			if dryRun {
+				continue
+			}
+			if err := repos.Users.Add(user); err != nil {
+				return err
+			}
+		} else if user.Name != orgMember.Username || user.TeamName != orgTeam.Name || user.IsActive != orgMember.IsActive {
+			report.UsersUpdated = append(report.UsersUpdated, user.Email)
+			previousTeam := user.TeamName
+			if !dryRun {
+				user.UpdateName(orgMember.Username)
+				user.TeamName = orgTeam.Name
+				user.UpdateIsActive(orgMember.IsActive)
+				if err := repos.Users.Update(user); err != nil {
+					return err
+				}
+				if err := leaveTeam(repos, previousTeam, orgTeam.Name, user.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if member, ok := team.Members[user.ID]; !ok || member.IsActive != orgMember.IsActive || member.Username != orgMember.Username {
+			membershipChanged = true
+		}
+		members[user.ID] = models.NewTeamMember(user.ID, orgMember.Username, orgMember.IsActive)
+	}
+
+	for _, user := range existing {
+		if user.TeamName != orgTeam.Name || listed[strings.ToLower(user.Email)] {
+			continue
+		}
+		if user.IsActive {
+			report.UsersDeactivated = append(report.UsersDeactivated, user.Email)
+			membershipChanged = true
+			if !dryRun {
+				if err := repos.Users.Deactivate(user.ID); err != nil {
+					return err
+				}
+			}
+		}
+		members[user.ID] = models.NewTeamMember(user.ID, user.Name, false)
+	}
+
+	if !created && membershipChanged {
+		report.TeamsUpdated = append(report.TeamsUpdated, orgTeam.Name)
+	}
+	if dryRun || !membershipChanged {
+		return nil
+	}
+
+	team.Members = members
+	return repos.Teams.Update(team)
+}
+
+func leaveTeam(repos repositories.Repositories, previousTeam, newTeam string, userID int) error {
+	if previousTeam == "" || previousTeam == newTeam {
+		return nil
+	}
+	team, err := repos.Teams.GetByName(previousTeam)
+	if errors.Is(err, repositories.ErrTeamNotFoundInPersistence) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = repos.Teams.RemoveUserFromTeam(team.ID, userID)
+	if errors.Is(err, repositories.ErrUserNotInTeam) {
+		return nil
+	}
+	return err
+}
+
+func (s *ImportServiceImpl) Export() (*models.OrgDocument, error) {
+	doc := &models.OrgDocument{Teams: make([]*models.OrgTeam, 0)}
+
+	err := s.transactions.WithinTransaction(func(repos repositories.Repositories) error {
+		teams, err := repos.Teams.GetAll()
+		if err != nil {
+			return err
+		}
+		users, err := repos.Users.GetAll()
+		if err != nil {
+			return err
+		}
+
+		byTeam := make(map[string]*models.OrgTeam, len(teams))
+		for _, team := range teams {
+			orgTeam := &models.OrgTeam{Name: team.Name, Members: make([]*models.OrgMember, 0)}
+			byTeam[team.Name] = orgTeam
+			doc.Teams = append(doc.Teams, orgTeam)
+		}
+		for _, user := range users {
+			orgTeam, ok := byTeam[user.TeamName]
+			if !ok {
+				continue
+			}
+			orgTeam.Members = append(orgTeam.Members, &models.OrgMember{
+				Username: user.Name,
+				Email:    user.Email,
+				IsActive: user.IsActive,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(doc.Teams, func(i, j int) bool { return doc.Teams[i].Name < doc.Teams[j].Name })
+	for _, team := range doc.Teams {
+		sort.Slice(team.Members, func(i, j int) bool { return team.Members[i].Email < team.Members[j].Email })
+	}
+
+	return doc, nil
+}
+
+func checkDocument(doc *models.OrgDocument) error {
+	teams := make(map[string]bool, len(doc.Teams))
+	emails := make(map[string]bool)
+	for _, team := range doc.Teams {
+		if teams[team.Name] {
+			return fmt.Errorf("%w: %s", models.ErrDuplicateTeamInDocument, team.Name)
+		}
+		teams[team.Name] = true
+
+		for _, member := range team.Members {
+			email := strings.ToLower(member.Email)
+			if emails[email] {
+				return fmt.Errorf("%w: %s", models.ErrDuplicateEmailInDocument, member.Email)
+			}
+			emails[email] = true
+		}
+	}
+	return nil
+}
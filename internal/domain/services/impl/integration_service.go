@@ -0,0 +1,225 @@
+package impl
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+type IntegrationServiceImpl struct {
+	prService    services.PullRequestService
+	userService  services.UserService
+	externalRepo repositories.ExternalPullRequestRepository
+	transactions repositories.TransactionManager
+	clock        clock.Clock
+}
+
+func NewIntegrationService(
+	prService services.PullRequestService,
+	userService services.UserService,
+	externalRepo repositories.ExternalPullRequestRepository,
+	transactions repositories.TransactionManager,
+	clock clock.Clock,
+) *IntegrationServiceImpl {
+	return &IntegrationServiceImpl{
+		prService:    prService,
+		userService:  userService,
+		externalRepo: externalRepo,
+		transactions: transactions,
+		clock:        clock,
+	}
+}
+
+func (s *IntegrationServiceImpl) HandleEvent(event *models.VCSEvent) (*models.VCSEventResult, error) {
+	if event.Action == models.VCSActionOpened {
+		return s.open(event)
+	}
+
+	link, err := s.externalRepo.GetByExternalID(event.Provider, event.ExternalID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrExternalPullRequestNotFound) {
+			return &models.VCSEventResult{Outcome: models.VCSEventIgnored}, nil
+		}
+		return nil, err
+	}
+
+	pr, err := s.prService.GetByID(link.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch event.Action {
+	case models.VCSActionClosed:
+		return s.close(pr)
+	case models.VCSActionMerged:
+		return s.merge(pr)
+	case models.VCSActionReopened:
+		return s.reopen(pr)
+	case models.VCSActionReviewRequested:
+		return s.requestReview(pr, event.Provider, event.ReviewerLogins)
+	}
+
+	return &models.VCSEventResult{Outcome: models.VCSEventIgnored, PullRequest: pr}, nil
+}
+
+// open creates the pull request on first delivery; redeliveries hit the mapping and are reported as duplicates.
+// The pull request and its mapping are stored in one transaction, so of two deliveries racing past the lookup
+// only one commits and the other reports the pull request the first one created.
+func (s *IntegrationServiceImpl) open(event *models.VCSEvent) (*models.VCSEventResult, error) {
+	result, err := s.existing(event)
+	if !errors.Is(err, repositories.ErrExternalPullRequestNotFound) {
+		return result, err
+	}
+
+	author, err := s.resolveUser(event.Provider, event.AuthorLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &models.PullRequest{
+		Name:      event.Title,
+		Status:    models.StatusOpen,
+		Author:    author,
+		Reviewers: make([]*models.User, 0, models.MaxReviewers),
+		Labels:    event.Labels,
+		Size:      event.Size,
+		CreatedAt: s.clock.Now(),
+	}
+
+	reviewers, err := s.reviewers(pr, event.Provider, event.ReviewerLogins)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactions.WithinTransaction(func(repos repositories.Repositories) error {
+		if err := repos.PullRequests.Add(pr); err != nil {
+			return err
+		}
+		return repos.ExternalPullRequests.Add(models.NewExternalPullRequest(event.Provider, event.ExternalID, pr.ID))
+	})
+	if errors.Is(err, repositories.ErrExternalPullRequestAlreadyExists) {
+		return s.existing(event)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Reviewers are added through the service after the commit, so that they are told like any other.
+	// The pull request is stored by now, so a failure is reported along with it: an error would make
+	// the code host redeliver the event, and the redelivery would only find a duplicate.
+	if len(reviewers) > 0 {
+		err = s.prService.AddReviewers(pr, reviewers...)
+	} else {
+		err = s.prService.AssignReviewers(pr)
+	}
+	if err != nil {
+		if stored, getErr := s.prService.GetByID(pr.ID); getErr == nil {
+			pr = stored
+		}
+		return &models.VCSEventResult{Outcome: models.VCSEventApplied, PullRequest: pr, Warning: err}, nil
+	}
+
+	return &models.VCSEventResult{Outcome: models.VCSEventApplied, PullRequest: pr}, nil
+}
+
+// existing reports the pull request already created for the event's external id as a duplicate.
+func (s *IntegrationServiceImpl) existing(event *models.VCSEvent) (*models.VCSEventResult, error) {
+	link, err := s.externalRepo.GetByExternalID(event.Provider, event.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := s.prService.GetByID(link.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.VCSEventResult{Outcome: models.VCSEventDuplicate, PullRequest: pr}, nil
+}
+
+func (s *IntegrationServiceImpl) close(pr *models.PullRequest) (*models.VCSEventResult, error) {
+	if pr.Status != models.StatusOpen {
+		return &models.VCSEventResult{Outcome: models.VCSEventDuplicate, PullRequest: pr}, nil
+	}
+	pr.SetStatusClosed()
+	if err := s.prService.Update(pr); err != nil {
+		return nil, err
+	}
+	return &models.VCSEventResult{Outcome: models.VCSEventApplied, PullRequest: pr}, nil
+}
+
+func (s *IntegrationServiceImpl) merge(pr *models.PullRequest) (*models.VCSEventResult, error) {
+	if pr.Status == models.StatusMerged {
+		return &models.VCSEventResult{Outcome: models.VCSEventDuplicate, PullRequest: pr}, nil
+	}
+	if err := s.prService.MarkMerged(pr); err != nil {
+		return nil, err
+	}
+	return &models.VCSEventResult{Outcome: models.VCSEventApplied, PullRequest: pr}, nil
+}
+
+func (s *IntegrationServiceImpl) reopen(pr *models.PullRequest) (*models.VCSEventResult, error) {
+	if pr.Status == models.StatusOpen {
+		return &models.VCSEventResult{Outcome: models.VCSEventDuplicate, PullRequest: pr}, nil
+	}
+	if err := pr.Reopen(); err != nil {
+		return nil, err
+	}
+	if err := s.prService.Update(pr); err != nil {
+		return nil, err
+	}
+	return &models.VCSEventResult{Outcome: models.VCSEventApplied, PullRequest: pr}, nil
+}
+
+func (s *IntegrationServiceImpl) requestReview(pr *models.PullRequest, provider models.VCSProvider, logins []string) (*models.VCSEventResult, error) {
+	reviewers, err := s.reviewers(pr, provider, logins)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewers) == 0 {
+		return &models.VCSEventResult{Outcome: models.VCSEventDuplicate, PullRequest: pr}, nil
+	}
+	if err := s.prService.AddReviewers(pr, reviewers...); err != nil {
+		return nil, err
+	}
+	return &models.VCSEventResult{Outcome: models.VCSEventApplied, PullRequest: pr}, nil
+}
+
+// reviewers resolves the logins to the users that can be added to the pull request. Unknown
+// logins, the author, reviewers already assigned and those past MaxReviewers are skipped.
+func (s *IntegrationServiceImpl) reviewers(pr *models.PullRequest, provider models.VCSProvider, logins []string) ([]*models.User, error) {
+	var reviewers []*models.User
+	seen := make(map[int]bool, len(logins))
+	for _, login := range logins {
+		if len(pr.Reviewers)+len(reviewers) >= models.MaxReviewers {
+			break
+		}
+		reviewer, err := s.resolveUser(provider, login)
+		if errors.Is(err, models.ErrUnknownExternalUser) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if reviewer.ID == pr.Author.ID || pr.HasReviewer(reviewer.ID) || seen[reviewer.ID] {
+			continue
+		}
+		seen[reviewer.ID] = true
+		reviewers = append(reviewers, reviewer)
+	}
+	return reviewers, nil
+}
+
+// resolveUser finds the user a code host login is mapped to in user_identities. Logins without a
+// mapping are unknown, even when they match a user's name.
+func (s *IntegrationServiceImpl) resolveUser(provider models.VCSProvider, login string) (*models.User, error) {
+	if login == "" {
+		return nil, models.ErrUnknownExternalUser
+	}
+
+	user, err := s.userService.GetByExternalLogin(provider, login)
+	if errors.Is(err, repositories.ErrUserIdentityNotFound) {
+		return nil, models.ErrUnknownExternalUser
+	}
+	return user, err
+}
@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+type MembershipServiceImpl struct {
+	transactions       repositories.TransactionManager
+	pullRequestService services.PullRequestService
+}
+
+func NewMembershipService(
+	transactions repositories.TransactionManager,
+	pullRequestService services.PullRequestService,
+) *MembershipServiceImpl {
+	return &MembershipServiceImpl{
+		transactions:       transactions,
+		pullRequestService: pullRequestService,
+	}
+}
+
+// MoveUser changes users.team_name and the team_members rows in one transaction. Open reviews
+// stay with the user unless reassignReviews is set, then each goes to another member of its
+// author's team after the move is committed.
+func (s *MembershipServiceImpl) MoveUser(userID int, teamName string, reassignReviews bool) (*models.TeamMove, error) {
+	move := &models.TeamMove{ToTeam: teamName}
+	err := s.transactions.WithinTransaction(func(repos repositories.Repositories) error {
+		user, err := repos.Users.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if user.TeamName == teamName {
+			return models.ErrMemberAlreadyInTeam
+		}
+		target, err := repos.Teams.GetByName(teamName)
+		if err != nil {
+			return err
+		}
+
+		move.FromTeam = user.TeamName
+		if err := leaveTeam(repos, user.TeamName, teamName, user.ID); err != nil {
+			return err
+		}
+
+		user.TeamName = teamName
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+
+		// A stale row would make the insert fail and abort the whole transaction.
+		err = repos.Teams.RemoveUserFromTeam(target.ID, user.ID)
+		if err != nil && !errors.Is(err, repositories.ErrUserNotInTeam) {
+			return err
+		}
+		if err := repos.Teams.AddUserToTeam(target.ID, user.ID); err != nil {
+			return err
+		}
+
+		move.User = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	move.Reviews, err = handOverReviews(s.pullRequestService, move.User, reassignReviews)
+	if err != nil {
+		return move, err
+	}
+
+	return move, nil
+}
+
+// handOverReviews walks the open PRs reviewed by oldReviewer and either reports them as kept
+// or asks PullRequestService for a replacement. It runs after the change that releases the
+// reviews is committed, so a PR that cannot be reassigned is reported as failed instead of
+// failing the whole call; it can be reassigned by hand later.
+func handOverReviews(
+	pullRequestService services.PullRequestService,
+	oldReviewer *models.User,
+	reassign bool,
+) ([]*models.Reassignment, error) {
+	prs, err := pullRequestService.GetByReviewerID(oldReviewer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reassignments := make([]*models.Reassignment, 0)
+	for _, pr := range prs {
+		if !pr.CanModifyReviewers() {
+			continue
+		}
+
+		reassignment := &models.Reassignment{PullRequestID: pr.ID, FromUserID: oldReviewer.ID}
+		if !reassign {
+			reassignment.ToUserID = oldReviewer.ID
+			reassignment.Outcome = models.ReassignmentKept
+			reassignments = append(reassignments, reassignment)
+			continue
+		}
+
+		err := pullRequestService.ReassignReviewers(pr, oldReviewer)
+		switch {
+		case errors.Is(err, models.ErrReviewerNotFound):
+			reassignment.Outcome = models.ReassignmentNoCandidate
+		case err != nil:
+			reassignment.Outcome = models.ReassignmentFailed
+		default:
+			reassignment.Outcome = models.ReassignmentDone
+			// The replacement is only left out of the report if it cannot be read back.
+			if updated, err := pullRequestService.GetByID(pr.ID); err == nil {
+				reassignment.ToUserID = addedReviewer(pr, updated)
+			}
+		}
+		reassignments = append(reassignments, reassignment)
+	}
+
+	return reassignments, nil
+}
+
+func addedReviewer(before, after *models.PullRequest) int {
+	for _, reviewer := range after.Reviewers {
+		if !before.HasReviewer(reviewer.ID) {
+			return reviewer.ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,223 @@
+package impl
+
+import (
+	"fmt"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/notifications"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"time"
+)
+
+const (
+	// notificationLease is how long a claimed notification is hidden from other workers.
+	notificationLease     = 5 * time.Minute
+	notificationBatchSize = 100
+)
+
+type NotificationServiceImpl struct {
+	userRepository         repositories.UserRepository
+	preferenceRepository   repositories.NotificationPreferenceRepository
+	notificationRepository repositories.NotificationRepository
+	notifiers              map[models.NotificationChannel]notifications.Notifier
+	clock                  clock.Clock
+}
+
+// NewNotificationService sends over the channels in notifiers; preferences for other channels
+// are kept but nothing is queued for them.
+func NewNotificationService(
+	userRepository repositories.UserRepository,
+	preferenceRepository repositories.NotificationPreferenceRepository,
+	notificationRepository repositories.NotificationRepository,
+	notifiers map[models.NotificationChannel]notifications.Notifier,
+	clock clock.Clock,
+) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		userRepository:         userRepository,
+		preferenceRepository:   preferenceRepository,
+		notificationRepository: notificationRepository,
+		notifiers:              notifiers,
+		clock:                  clock,
+	}
+}
+
+func (s *NotificationServiceImpl) GetPreferences(userID int) ([]*models.NotificationPreference, error) {
+	if _, err := s.userRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	saved, err := s.preferenceRepository.GetByUserIDs([]int{userID})
+	if err != nil {
+		return nil, err
+	}
+	return preferencesOf(saved, userID), nil
+}
+
+func (s *NotificationServiceImpl) SetPreferences(userID int, preferences []*models.NotificationPreference) ([]*models.NotificationPreference, error) {
+	if _, err := s.userRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	for _, preference := range preferences {
+		preference.UserID = userID
+	}
+	if err := s.preferenceRepository.Replace(userID, preferences); err != nil {
+		return nil, err
+	}
+	if len(preferences) == 0 {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+func (s *NotificationServiceImpl) Enqueue(pr *models.PullRequest, events ...*models.PullRequestEvent) error {
+	userIDs := make([]int, 0, len(events))
+	for _, event := range events {
+		if event.Kind.Streamed() {
+			userIDs = append(userIDs, event.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	saved, err := s.preferenceRepository.GetByUserIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	users := make(map[int]*models.User)
+	user := func(id int) (*models.User, error) {
+		if id == 0 {
+			return nil, nil
+		}
+		if users[id] == nil {
+			found, err := s.userRepository.GetByID(id)
+			if err != nil {
+				return nil, err
+			}
+			users[id] = found
+		}
+		return users[id], nil
+	}
+
+	now := s.clock.Now()
+	for _, event := range events {
+		if !event.Kind.Streamed() {
+			continue
+		}
+		for _, preference := range preferencesOf(saved, event.UserID) {
+			if _, ok := s.notifiers[preference.Channel]; !ok || !preference.Wants(event.Kind) {
+				continue
+			}
+			recipient, err := user(event.UserID)
+			if err != nil {
+				return err
+			}
+			previous, err := user(event.PreviousUserID)
+			if err != nil {
+				return err
+			}
+			target := targetOf(preference, recipient)
+			if target == "" {
+				continue
+			}
+			subject, body, err := notifications.Render(event.Kind, notifications.Message{PullRequest: pr, Recipient: recipient, Previous: previous})
+			if err != nil {
+				return err
+			}
+			notification := &models.Notification{
+				EventID:       event.ID,
+				Kind:          event.Kind,
+				PullRequestID: event.PullRequestID,
+				UserID:        event.UserID,
+				Channel:       preference.Channel,
+				Target:        target,
+				Subject:       subject,
+				Body:          body,
+				NextAttemptAt: &now,
+				CreatedAt:     now,
+			}
+			if err := s.notificationRepository.Add(notification); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *NotificationServiceImpl) EnqueueDigest(digest *models.Digest) error {
+	saved, err := s.preferenceRepository.GetByUserIDs([]int{digest.User.ID})
+	if err != nil {
+		return err
+	}
+
+	generatedAt := digest.GeneratedAt
+	var subject, text, html string
+	for _, preference := range preferencesOf(saved, digest.User.ID) {
+		if _, ok := s.notifiers[preference.Channel]; !ok || !preference.Digest {
+			continue
+		}
+		target := targetOf(preference, digest.User)
+		if target == "" {
+			continue
+		}
+		if subject == "" {
+			if subject, text, html, err = notifications.RenderDigest(digest); err != nil {
+				return err
+			}
+		}
+		notification := &models.Notification{
+			Kind:          models.NotificationDigest,
+			UserID:        digest.User.ID,
+			Channel:       preference.Channel,
+			Target:        target,
+			Subject:       subject,
+			Body:          text,
+			HTML:          html,
+			NextAttemptAt: &generatedAt,
+			CreatedAt:     generatedAt,
+		}
+		if err := s.notificationRepository.Add(notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliver sends one batch. A failed send is retried with backoff instead of failing the batch;
+// only storing the outcome can fail it.
+func (s *NotificationServiceImpl) Deliver(now time.Time) (int, error) {
+	due, err := s.notificationRepository.ClaimDue(now, now.Add(notificationLease), notificationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, notification := range due {
+		notifier, ok := s.notifiers[notification.Channel]
+		if !ok {
+			notification.MarkFailed(now, fmt.Errorf("channel %q is not configured", notification.Channel))
+		} else if err := notifier.Send(notification); err != nil {
+			notification.MarkFailed(now, err)
+		} else {
+			notification.MarkSent(now)
+			sent++
+		}
+		if err := s.notificationRepository.Save(notification); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+func preferencesOf(saved map[int][]*models.NotificationPreference, userID int) []*models.NotificationPreference {
+	if preferences, ok := saved[userID]; ok {
+		return preferences
+	}
+	return models.DefaultNotificationPreferences(userID)
+}
+
+func targetOf(preference *models.NotificationPreference, recipient *models.User) string {
+	if preference.Target == "" && preference.Channel == models.ChannelEmail {
+		return recipient.Email
+	}
+	return preference.Target
+}
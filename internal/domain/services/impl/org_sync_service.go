@@ -0,0 +1,218 @@
+package impl
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+	"sort"
+	"strings"
+)
+
+type OrgSyncServiceImpl struct {
+	transactions       repositories.TransactionManager
+	pullRequestService services.PullRequestService
+}
+
+func NewOrgSyncService(
+	transactions repositories.TransactionManager,
+	pullRequestService services.PullRequestService,
+) *OrgSyncServiceImpl {
+	return &OrgSyncServiceImpl{
+		transactions:       transactions,
+		pullRequestService: pullRequestService,
+	}
+}
+
+// Plan compares the desired document with the database. Unlike Import, users that are
+// missing from the document are deactivated whatever team they are in.
+func (s *OrgSyncServiceImpl) Plan(doc *models.OrgDocument) (*models.SyncPlan, error) {
+	if err := checkDocument(doc); err != nil {
+		return nil, err
+	}
+
+	var plan *models.SyncPlan
+	err := s.transactions.WithinTransaction(func(repos repositories.Repositories) error {
+		var err error
+		plan, err = planSync(repos, doc)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Apply executes the plan in one transaction and then hands the open reviews of moved and
+// deactivated users over to other members of each PR author's team.
+func (s *OrgSyncServiceImpl) Apply(doc *models.OrgDocument) (*models.SyncResult, error) {
+	if err := checkDocument(doc); err != nil {
+		return nil, err
+	}
+
+	result := &models.SyncResult{Reassignments: make([]*models.Reassignment, 0)}
+	err := s.transactions.WithinTransaction(func(repos repositories.Repositories) error {
+		plan, err := planSync(repos, doc)
+		if err != nil {
+			return err
+		}
+		result.Plan = plan
+		return applySync(repos, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	for _, change := range result.Plan.Changes {
+		if !change.ReleasesReviews() {
+			continue
+		}
+		oldReviewer := &models.User{
+			ID:    change.UserID,
+			Name:  change.Username,
+			Email: change.Email,
+		}
+		reassignments, err := handOverReviews(s.pullRequestService, oldReviewer, true)
+		if err != nil {
+			return result, err
+		}
+		result.Reassignments = append(result.Reassignments, reassignments...)
+	}
+
+	return result, nil
+}
+
+func planSync(repos repositories.Repositories, doc *models.OrgDocument) (*models.SyncPlan, error) {
+	users, err := repos.Users.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	teams, err := repos.Teams.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	existingTeams := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		existingTeams[team.Name] = true
+	}
+	usersByEmail := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		usersByEmail[strings.ToLower(user.Email)] = user
+	}
+
+	plan := &models.SyncPlan{Changes: make([]*models.SyncChange, 0)}
+	listed := make(map[string]bool)
+	for _, team := range doc.Teams {
+		if !existingTeams[team.Name] {
+			plan.Changes = append(plan.Changes, &models.SyncChange{Action: models.SyncCreateTeam, ToTeam: team.Name})
+		}
+
+		for _, member := range team.Members {
+			email := strings.ToLower(member.Email)
+			listed[email] = true
+
+			change := &models.SyncChange{
+				Email:    member.Email,
+				Username: member.Username,
+				IsActive: member.IsActive,
+				ToTeam:   team.Name,
+			}
+			user, ok := usersByEmail[email]
+			switch {
+			case !ok:
+				change.Action = models.SyncAddUser
+			case user.TeamName != team.Name:
+				change.Action = models.SyncMoveUser
+			case user.Name != member.Username || user.IsActive != member.IsActive:
+				change.Action = models.SyncUpdateUser
+			default:
+				continue
+			}
+			if ok {
+				change.UserID = user.ID
+				change.FromTeam = user.TeamName
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	removed := make([]*models.User, 0)
+	for _, user := range users {
+		if user.IsActive && !listed[strings.ToLower(user.Email)] {
+			removed = append(removed, user)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Email < removed[j].Email })
+	for _, user := range removed {
+		plan.Changes = append(plan.Changes, &models.SyncChange{
+			Action:   models.SyncDeactivateUser,
+			UserID:   user.ID,
+			Email:    user.Email,
+			Username: user.Name,
+			FromTeam: user.TeamName,
+		})
+	}
+
+	return plan, nil
+}
+
+func applySync(repos repositories.Repositories, plan *models.SyncPlan) error {
+	touched := make(map[string]bool)
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case models.SyncCreateTeam:
+			if err := repos.Teams.Add(models.NewTeam(change.ToTeam)); err != nil {
+				return err
+			}
+		case models.SyncAddUser:
+			user := models.NewUser(change.Username, change.Email, change.IsActive, change.ToTeam)
+			if err := repos.Users.Add(user); err != nil {
+				return err
+			}
+			change.UserID = user.ID
+		case models.SyncMoveUser, models.SyncUpdateUser:
+			user := &models.User{
+				ID:       change.UserID,
+				Name:     change.Username,
+				Email:    change.Email,
+				IsActive: change.IsActive,
+				TeamName: change.ToTeam,
+			}
+			if err := repos.Users.Update(user); err != nil {
+				return err
+			}
+		case models.SyncDeactivateUser:
+			if err := repos.Users.Deactivate(change.UserID); err != nil {
+				return err
+			}
+		}
+		touched[change.FromTeam] = true
+		touched[change.ToTeam] = true
+	}
+	delete(touched, "")
+
+	names := make([]string, 0, len(touched))
+	for name := range touched {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Team membership is derived from users.team_name, so rewriting the team refreshes team_members.
+	for _, name := range names {
+		team, err := repos.Teams.GetByName(name)
+		if errors.Is(err, repositories.ErrTeamNotFoundInPersistence) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := repos.Teams.Update(team); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,23 +1,60 @@
 package impl
 
 import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/events"
 	"reviewer-assignment-service/internal/domain/models"
 	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
 	"time"
 )
 
 type PullRequestServiceImpl struct {
-	pullRequestRepository repositories.PullRequestRepository
+	pullRequestRepository   repositories.PullRequestRepository
+	reviewerRuleRepository  repositories.ReviewerRuleRepository
+	userTagRepository       repositories.UserTagRepository
+	reviewPatternRepository repositories.ReviewPatternRepository
+	reviewLoadRepository    repositories.ReviewLoadRepository
+	reviewRepository        repositories.ReviewRepository
+	eventRepository         repositories.PullRequestEventRepository
+	broker                  *events.Broker
+	notificationService     services.NotificationService
+	clock                   clock.Clock
 }
 
-func NewPullRequestService(pullRequestRepository repositories.PullRequestRepository) *PullRequestServiceImpl {
+func NewPullRequestService(
+	pullRequestRepository repositories.PullRequestRepository,
+	reviewerRuleRepository repositories.ReviewerRuleRepository,
+	userTagRepository repositories.UserTagRepository,
+	reviewPatternRepository repositories.ReviewPatternRepository,
+	reviewLoadRepository repositories.ReviewLoadRepository,
+	reviewRepository repositories.ReviewRepository,
+	eventRepository repositories.PullRequestEventRepository,
+	broker *events.Broker,
+	notificationService services.NotificationService,
+	clock clock.Clock,
+) *PullRequestServiceImpl {
 	return &PullRequestServiceImpl{
-		pullRequestRepository: pullRequestRepository,
+		pullRequestRepository:   pullRequestRepository,
+		reviewerRuleRepository:  reviewerRuleRepository,
+		userTagRepository:       userTagRepository,
+		reviewPatternRepository: reviewPatternRepository,
+		reviewLoadRepository:    reviewLoadRepository,
+		reviewRepository:        reviewRepository,
+		eventRepository:         eventRepository,
+		broker:                  broker,
+		notificationService:     notificationService,
+		clock:                   clock,
 	}
 }
 
+// Create stores the pull request and records an assignment for each reviewer it is created with.
 func (p *PullRequestServiceImpl) Create(pr *models.PullRequest) error {
-	return p.pullRequestRepository.Add(pr)
+	if err := p.pullRequestRepository.Add(pr); err != nil {
+		return err
+	}
+	return p.record(pr, p.reviewerEvents(pr, pr.Reviewers, nil)...)
 }
 
 func (p *PullRequestServiceImpl) GetByID(id int) (*models.PullRequest, error) {
@@ -28,41 +65,247 @@ func (p *PullRequestServiceImpl) Update(pr *models.PullRequest) error {
 	return p.pullRequestRepository.Update(pr)
 }
 
+// AddReviewers assigns the reviewers next to those the pull request already has.
+func (p *PullRequestServiceImpl) AddReviewers(pr *models.PullRequest, reviewers ...*models.User) error {
+	for _, reviewer := range reviewers {
+		if err := pr.AddReviewer(reviewer); err != nil {
+			return err
+		}
+	}
+	if err := p.pullRequestRepository.Update(pr); err != nil {
+		return err
+	}
+	return p.record(pr, p.reviewerEvents(pr, reviewers, nil)...)
+}
+
+func (p *PullRequestServiceImpl) RemoveReviewer(pr *models.PullRequest, reviewerID int) error {
+	if err := pr.RemoveReviewer(reviewerID); err != nil {
+		return err
+	}
+	if err := p.pullRequestRepository.Update(pr); err != nil {
+		return err
+	}
+	return p.record(pr, models.NewPullRequestEvent(pr.ID, models.EventReviewerUnassigned, reviewerID, p.clock.Now()))
+}
+
+// Edit renames the pull request, replaces its reviewers and changes its status in one write. The
+// reviewers are replaced while it is open: after reopening it, or before closing it, so closing
+// keeps the reviewers it is given.
+func (p *PullRequestServiceImpl) Edit(pr *models.PullRequest, name string, status models.PRStatus, reviewers []*models.User) error {
+	pr.Name = name
+	if status == models.StatusOpen {
+		if err := pr.ChangeStatus(status); err != nil {
+			return err
+		}
+	}
+	added, removed, err := pr.SetReviewers(reviewers)
+	if err != nil {
+		return err
+	}
+	if err := pr.ChangeStatus(status); err != nil {
+		return err
+	}
+	if err := p.pullRequestRepository.Update(pr); err != nil {
+		return err
+	}
+	return p.record(pr, p.reviewerEvents(pr, added, removed)...)
+}
+
+func (p *PullRequestServiceImpl) AssignReviewers(pr *models.PullRequest) error {
+	possibleReviewers, err := p.pullRequestRepository.FindPossibleReviewers(pr.Author)
+	if err != nil {
+		return err
+	}
+	selected, err := p.selectReviewers(pr, pr.Reviewers, possibleReviewers, models.MaxReviewers-len(pr.Reviewers))
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return models.ErrReviewerNotFound
+	}
+	var assigned []*models.PullRequestEvent
+	for _, reviewer := range selected {
+		if err := pr.AddReviewer(reviewer); err != nil {
+			if errors.Is(err, models.ErrReviewerAlreadyAssigned) {
+				continue
+			}
+			return err
+		}
+		assigned = append(assigned, models.NewPullRequestEvent(pr.ID, models.EventReviewerAssigned, reviewer.ID, p.clock.Now()))
+	}
+	if err := p.pullRequestRepository.Update(pr); err != nil {
+		return err
+	}
+	return p.record(pr, assigned...)
+}
+
 func (p *PullRequestServiceImpl) ReassignReviewers(pr *models.PullRequest, oldReviewer *models.User) error {
 	pullRequest, err := p.pullRequestRepository.GetByID(pr.ID)
 	if err != nil {
 		return err
 	}
-	possibleReviewers, err := p.pullRequestRepository.FindPossibleReviewers(pr.Author)
+	possibleReviewers, err := p.pullRequestRepository.FindPossibleReviewers(pullRequest.Author)
 	if err != nil {
 		return err
 	}
-	newReviewerID := defaultId
-	newReviewer := &models.User{}
+	remaining := make([]*models.User, 0, len(pullRequest.Reviewers))
+	for _, reviewer := range pullRequest.Reviewers {
+		if reviewer.ID != oldReviewer.ID {
+			remaining = append(remaining, reviewer)
+		}
+	}
+	candidates := make([]*models.User, 0, len(possibleReviewers))
 	for _, reviewer := range possibleReviewers {
 		if reviewer.ID != oldReviewer.ID {
-			newReviewerID = reviewer.ID
-			newReviewer = reviewer
-			break
+			candidates = append(candidates, reviewer)
 		}
 	}
-	if newReviewerID == defaultId {
+	selected, err := p.selectReviewers(pullRequest, remaining, candidates, 1)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
 		return models.ErrReviewerNotFound
 	}
-	return pullRequest.ReplaceReviewer(oldReviewer.ID, newReviewer)
+	if err := pullRequest.ReplaceReviewer(oldReviewer.ID, selected[0]); err != nil {
+		return err
+	}
+	if err := p.pullRequestRepository.Update(pullRequest); err != nil {
+		return err
+	}
+
+	now := p.clock.Now()
+	assigned := models.NewPullRequestEvent(pullRequest.ID, models.EventReviewerAssigned, selected[0].ID, now)
+	assigned.PreviousUserID = oldReviewer.ID
+	return p.record(pullRequest, models.NewPullRequestEvent(pullRequest.ID, models.EventReviewerUnassigned, oldReviewer.ID, now), assigned)
+}
+
+// selectReviewers ranks the candidates by the tags the team's review patterns ask for and by
+// decayed review load, puts those within their working hours first if a team rule asks for it,
+// then applies the team's reviewer rules. Tags are only loaded when patterns match or the team
+// has rules.
+func (p *PullRequestServiceImpl) selectReviewers(pr *models.PullRequest, assigned, candidates []*models.User, slots int) ([]*models.User, error) {
+	if slots <= 0 {
+		return nil, nil
+	}
+	author := pr.Author
+	rules, err := p.reviewerRuleRepository.GetByTeamName(author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	var wantedTags []string
+	if len(pr.Paths) > 0 || len(pr.Labels) > 0 {
+		patterns, err := p.reviewPatternRepository.GetByTeamName(author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		wantedTags = patterns.MatchingTags(pr.Paths, pr.Labels)
+	}
+	now := p.clock.Now()
+	loads, err := p.reviewLoadRepository.GetByTeamName(author.TeamName, now)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[int][]string)
+	if len(rules) > 0 || len(wantedTags) > 0 {
+		ids := []int{author.ID}
+		for _, user := range assigned {
+			ids = append(ids, user.ID)
+		}
+		for _, user := range candidates {
+			ids = append(ids, user.ID)
+		}
+		if tags, err = p.userTagRepository.GetByUserIDs(ids); err != nil {
+			return nil, err
+		}
+	}
+	ranked := models.RankCandidates(candidates, loads, tags, wantedTags)
+	if rules.PrefersWorkingHours() {
+		ranked = models.PreferWorking(ranked, now)
+	}
+	return rules.SelectReviewers(author, assigned, ranked, tags, slots)
 }
 
+// MergeRequest merges an open pull request once it has the approvals its team requires; merging
+// a merged one again changes nothing.
 func (p *PullRequestServiceImpl) MergeRequest(pr *models.PullRequest) error {
 	pullRequest, err := p.pullRequestRepository.GetByID(pr.ID)
 	if err != nil {
 		return err
 	}
-	pullRequest.Status = models.StatusMerged
-	pullRequest.SetMergedAt(time.Now())
-	return p.pullRequestRepository.Update(pullRequest)
+	if pullRequest.Status == models.StatusMerged {
+		*pr = *pullRequest
+		return nil
+	}
+	if pullRequest.Status == models.StatusClosed {
+		return models.ErrPRClosed
+	}
+	approvals, err := p.reviewRepository.GetApprovals(pullRequest.ID)
+	if err != nil {
+		return err
+	}
+	now := p.clock.Now()
+	if err := pullRequest.Merge(*approvals, now); err != nil {
+		return err
+	}
+	if err := p.pullRequestRepository.Update(pullRequest); err != nil {
+		return err
+	}
+	*pr = *pullRequest
+	return p.record(pullRequest, p.mergedEvents(pullRequest, now)...)
+}
+
+// MarkMerged records a pull request already merged on the code host. Its team's required approvals
+// are not checked, as the merge has happened; marking a merged one again changes nothing.
+func (p *PullRequestServiceImpl) MarkMerged(pr *models.PullRequest) error {
+	if pr.Status == models.StatusMerged {
+		return nil
+	}
+	now := p.clock.Now()
+	pr.SetStatusMerged()
+	pr.SetMergedAt(now)
+	if err := p.pullRequestRepository.Update(pr); err != nil {
+		return err
+	}
+	return p.record(pr, p.mergedEvents(pr, now)...)
 }
 
-var defaultId = -10
+func (p *PullRequestServiceImpl) mergedEvents(pr *models.PullRequest, now time.Time) []*models.PullRequestEvent {
+	events := make([]*models.PullRequestEvent, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		events = append(events, models.NewPullRequestEvent(pr.ID, models.EventMerged, reviewer.ID, now))
+	}
+	return events
+}
+
+func (p *PullRequestServiceImpl) reviewerEvents(pr *models.PullRequest, added, removed []*models.User) []*models.PullRequestEvent {
+	now := p.clock.Now()
+	events := make([]*models.PullRequestEvent, 0, len(added)+len(removed))
+	for _, reviewer := range removed {
+		events = append(events, models.NewPullRequestEvent(pr.ID, models.EventReviewerUnassigned, reviewer.ID, now))
+	}
+	for _, reviewer := range added {
+		events = append(events, models.NewPullRequestEvent(pr.ID, models.EventReviewerAssigned, reviewer.ID, now))
+	}
+	return events
+}
+
+// record stores the events and queues their notifications before publishing them, so a
+// subscriber never sees an event it could not resume from.
+func (p *PullRequestServiceImpl) record(pr *models.PullRequest, events ...*models.PullRequestEvent) error {
+	for _, event := range events {
+		if err := p.eventRepository.Add(event); err != nil {
+			return err
+		}
+	}
+	if p.notificationService != nil && len(events) > 0 {
+		if err := p.notificationService.Enqueue(pr, events...); err != nil {
+			return err
+		}
+	}
+	p.broker.Publish(events...)
+	return nil
+}
 
 func (p *PullRequestServiceImpl) GetByAuthorID(authorID int) ([]*models.PullRequest, error) {
 	return p.pullRequestRepository.GetByAuthorID(authorID)
@@ -70,3 +313,7 @@ func (p *PullRequestServiceImpl) GetByAuthorID(authorID int) ([]*models.PullRequ
 func (p *PullRequestServiceImpl) GetByReviewerID(reviewerID int) ([]*models.PullRequest, error) {
 	return p.pullRequestRepository.GetByReviewerID(reviewerID)
 }
+
+func (p *PullRequestServiceImpl) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	return p.pullRequestRepository.GetByReviewerIDs(reviewerIDs)
+}
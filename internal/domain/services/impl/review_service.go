@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+)
+
+type ReviewServiceImpl struct {
+	reviewRepository   repositories.ReviewRepository
+	eventRepository    repositories.PullRequestEventRepository
+	pullRequestService services.PullRequestService
+	clock              clock.Clock
+}
+
+func NewReviewService(
+	reviewRepository repositories.ReviewRepository,
+	eventRepository repositories.PullRequestEventRepository,
+	pullRequestService services.PullRequestService,
+	clock clock.Clock,
+) *ReviewServiceImpl {
+	return &ReviewServiceImpl{
+		reviewRepository:   reviewRepository,
+		eventRepository:    eventRepository,
+		pullRequestService: pullRequestService,
+		clock:              clock,
+	}
+}
+
+func (s *ReviewServiceImpl) GetReviews(prID int) (*models.PullRequestReviews, error) {
+	if _, err := s.pullRequestService.GetByID(prID); err != nil {
+		return nil, err
+	}
+	return s.reviews(prID)
+}
+
+// SubmitReview records the reviewer's verdict on an open PR; a later verdict replaces the earlier one.
+func (s *ReviewServiceImpl) SubmitReview(prID, reviewerID int, state models.ReviewState) (*models.PullRequestReviews, error) {
+	pr, err := s.pullRequestService.GetByID(prID)
+	if err != nil {
+		return nil, err
+	}
+	if err := pr.CheckReviewable(); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if err := s.reviewRepository.SetState(prID, reviewerID, state, now); err != nil {
+		return nil, err
+	}
+	if err := s.eventRepository.Add(models.NewPullRequestEvent(prID, state.EventKind(), reviewerID, now)); err != nil {
+		return nil, err
+	}
+	return s.reviews(prID)
+}
+
+func (s *ReviewServiceImpl) GetPolicy(teamID int) (*models.ApprovalPolicy, error) {
+	return s.reviewRepository.GetPolicy(teamID)
+}
+
+func (s *ReviewServiceImpl) SetPolicy(policy *models.ApprovalPolicy) error {
+	return s.reviewRepository.SavePolicy(policy)
+}
+
+func (s *ReviewServiceImpl) reviews(prID int) (*models.PullRequestReviews, error) {
+	assignments, err := s.reviewRepository.GetByPullRequestID(prID)
+	if err != nil {
+		return nil, err
+	}
+	approvals, err := s.reviewRepository.GetApprovals(prID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PullRequestReviews{PullRequestID: prID, Assignments: assignments, Approvals: *approvals}, nil
+}
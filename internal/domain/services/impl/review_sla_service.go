@@ -0,0 +1,127 @@
+package impl
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+)
+
+type ReviewSLAServiceImpl struct {
+	slaRepository      repositories.ReviewSLARepository
+	eventRepository    repositories.PullRequestEventRepository
+	pullRequestService services.PullRequestService
+	clock              clock.Clock
+}
+
+func NewReviewSLAService(
+	slaRepository repositories.ReviewSLARepository,
+	eventRepository repositories.PullRequestEventRepository,
+	pullRequestService services.PullRequestService,
+	clock clock.Clock,
+) *ReviewSLAServiceImpl {
+	return &ReviewSLAServiceImpl{
+		slaRepository:      slaRepository,
+		eventRepository:    eventRepository,
+		pullRequestService: pullRequestService,
+		clock:              clock,
+	}
+}
+
+func (s *ReviewSLAServiceImpl) GetSLA(teamID int) (*models.ReviewSLA, error) {
+	return s.slaRepository.GetByTeamID(teamID)
+}
+
+func (s *ReviewSLAServiceImpl) SetSLA(sla *models.ReviewSLA) error {
+	return s.slaRepository.Save(sla)
+}
+
+// Acknowledge marks the reviewer's first review of the PR, which stops its escalation.
+func (s *ReviewSLAServiceImpl) Acknowledge(prID, reviewerID int) ([]*models.ReviewAssignment, error) {
+	if _, err := s.pullRequestService.GetByID(prID); err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	if err := s.slaRepository.MarkReviewed(prID, reviewerID, now); err != nil {
+		return nil, err
+	}
+	if err := s.eventRepository.Add(models.NewPullRequestEvent(prID, models.EventReviewed, reviewerID, now)); err != nil {
+		return nil, err
+	}
+	return s.slaRepository.GetAssignments(prID)
+}
+
+func (s *ReviewSLAServiceImpl) GetEvents(prID int) ([]*models.PullRequestEvent, error) {
+	if _, err := s.pullRequestService.GetByID(prID); err != nil {
+		return nil, err
+	}
+	return s.eventRepository.GetByPullRequestID(prID)
+}
+
+// Escalate acts on every PR past its team's SLA and returns the recorded events. PRs for which
+// the team has nobody left to assign are skipped until someone becomes available.
+func (s *ReviewSLAServiceImpl) Escalate(now time.Time) ([]*models.PullRequestEvent, error) {
+	pending, err := s.slaRepository.FindPending(now)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.PullRequestEvent, 0)
+	for _, review := range pending {
+		if !review.Overdue(now) {
+			continue
+		}
+		escalated, err := s.escalate(review, now)
+		if err != nil {
+			if errors.Is(err, models.ErrReviewerNotFound) || errors.Is(err, models.ErrRequiredReviewerUnavailable) {
+				continue
+			}
+			return events, err
+		}
+		events = append(events, escalated...)
+	}
+	return events, nil
+}
+
+func (s *ReviewSLAServiceImpl) escalate(review *models.PendingReview, now time.Time) ([]*models.PullRequestEvent, error) {
+	pr, err := s.pullRequestService.GetByID(review.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[int]bool, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		before[reviewer.ID] = true
+	}
+
+	kind, previousID := models.EventSLAReviewerAdded, 0
+	stale := review.Stale()
+	if stale != nil && (review.SLA.Action == models.SLAActionReassign || len(pr.Reviewers) >= models.MaxReviewers) {
+		kind, previousID = models.EventSLAReviewerReplaced, stale.ReviewerID
+		err = s.pullRequestService.ReassignReviewers(pr, &models.User{ID: stale.ReviewerID})
+	} else {
+		err = s.pullRequestService.AssignReviewers(pr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.pullRequestService.GetByID(pr.ID)
+	if err != nil {
+		return nil, err
+	}
+	var events []*models.PullRequestEvent
+	for _, reviewer := range updated.Reviewers {
+		if before[reviewer.ID] {
+			continue
+		}
+		event := models.NewPullRequestEvent(pr.ID, kind, reviewer.ID, now)
+		event.PreviousUserID = previousID
+		if err := s.eventRepository.Add(event); err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
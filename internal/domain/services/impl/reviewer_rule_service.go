@@ -0,0 +1,190 @@
+package impl
+
+import (
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"sort"
+	"time"
+)
+
+type ReviewerRuleServiceImpl struct {
+	ruleRepository    repositories.ReviewerRuleRepository
+	teamRepository    repositories.TeamRepository
+	userRepository    repositories.UserRepository
+	tagRepository     repositories.UserTagRepository
+	patternRepository repositories.ReviewPatternRepository
+	loadRepository    repositories.ReviewLoadRepository
+	clock             clock.Clock
+}
+
+func NewReviewerRuleService(
+	ruleRepository repositories.ReviewerRuleRepository,
+	teamRepository repositories.TeamRepository,
+	userRepository repositories.UserRepository,
+	tagRepository repositories.UserTagRepository,
+	patternRepository repositories.ReviewPatternRepository,
+	loadRepository repositories.ReviewLoadRepository,
+	clock clock.Clock,
+) *ReviewerRuleServiceImpl {
+	return &ReviewerRuleServiceImpl{
+		ruleRepository:    ruleRepository,
+		teamRepository:    teamRepository,
+		userRepository:    userRepository,
+		tagRepository:     tagRepository,
+		patternRepository: patternRepository,
+		loadRepository:    loadRepository,
+		clock:             clock,
+	}
+}
+
+func (s *ReviewerRuleServiceImpl) Create(rule *models.ReviewerRule) error {
+	team, err := s.teamRepository.GetByID(rule.TeamID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range []int{rule.ReviewerID, rule.AuthorID} {
+		if userID == 0 {
+			continue
+		}
+		user, err := s.userRepository.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if user.TeamName != team.Name {
+			return models.ErrRuleUserNotInTeam
+		}
+	}
+	return s.ruleRepository.Add(rule)
+}
+
+func (s *ReviewerRuleServiceImpl) GetByTeamID(teamID int) (models.ReviewerRules, error) {
+	if _, err := s.teamRepository.GetByID(teamID); err != nil {
+		return nil, err
+	}
+	return s.ruleRepository.GetByTeamID(teamID)
+}
+
+func (s *ReviewerRuleServiceImpl) Delete(teamID, ruleID int) error {
+	rule, err := s.ruleRepository.GetByID(ruleID)
+	if err != nil {
+		return err
+	}
+	if rule.TeamID != teamID {
+		return repositories.ErrReviewerRuleNotFound
+	}
+	return s.ruleRepository.Delete(ruleID)
+}
+
+func (s *ReviewerRuleServiceImpl) Check(teamID, authorID, candidateID int) (*models.CandidateCheck, error) {
+	team, err := s.teamRepository.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	author, err := s.userRepository.GetByID(authorID)
+	if err != nil {
+		return nil, err
+	}
+	if author.TeamName != team.Name {
+		return nil, models.ErrAuthorNotInTeam
+	}
+	candidate, err := s.userRepository.GetByID(candidateID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.ruleRepository.GetByTeamID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagRepository.GetByUserIDs([]int{authorID, candidateID})
+	if err != nil {
+		return nil, err
+	}
+	return rules.Check(author, candidate, tags), nil
+}
+
+func (s *ReviewerRuleServiceImpl) GetUserTags(userID int) ([]string, error) {
+	if _, err := s.userRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	return s.tagRepository.GetByUserID(userID)
+}
+
+func (s *ReviewerRuleServiceImpl) SetUserTags(userID int, tags []string) ([]string, error) {
+	if _, err := s.userRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !containsTag(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+	if err := s.tagRepository.Replace(userID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func (s *ReviewerRuleServiceImpl) CreatePattern(pattern *models.ReviewPattern) error {
+	if _, err := s.teamRepository.GetByID(pattern.TeamID); err != nil {
+		return err
+	}
+	return s.patternRepository.Add(pattern)
+}
+
+func (s *ReviewerRuleServiceImpl) GetPatternsByTeamID(teamID int) (models.ReviewPatterns, error) {
+	if _, err := s.teamRepository.GetByID(teamID); err != nil {
+		return nil, err
+	}
+	return s.patternRepository.GetByTeamID(teamID)
+}
+
+func (s *ReviewerRuleServiceImpl) DeletePattern(teamID, patternID int) error {
+	pattern, err := s.patternRepository.GetByID(patternID)
+	if err != nil {
+		return err
+	}
+	if pattern.TeamID != teamID {
+		return repositories.ErrReviewPatternNotFound
+	}
+	return s.patternRepository.Delete(patternID)
+}
+
+func (s *ReviewerRuleServiceImpl) GetReviewLoad(teamID int) (*models.TeamReviewLoad, error) {
+	team, err := s.teamRepository.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	halfLife, err := s.loadRepository.GetHalfLife(teamID)
+	if err != nil {
+		return nil, err
+	}
+	loads, err := s.loadRepository.GetByTeamName(team.Name, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	for memberID := range team.Members {
+		if _, ok := loads[memberID]; !ok {
+			loads[memberID] = 0
+		}
+	}
+	return &models.TeamReviewLoad{TeamID: teamID, HalfLife: halfLife, Loads: loads}, nil
+}
+
+func (s *ReviewerRuleServiceImpl) SetReviewHalfLife(teamID int, halfLife time.Duration) (*models.TeamReviewLoad, error) {
+	if err := s.loadRepository.SetHalfLife(teamID, halfLife); err != nil {
+		return nil, err
+	}
+	return s.GetReviewLoad(teamID)
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package impl
+
+import (
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+)
+
+type UserEventServiceImpl struct {
+	userRepository  repositories.UserRepository
+	eventRepository repositories.PullRequestEventRepository
+	broker          *events.Broker
+}
+
+func NewUserEventService(
+	userRepository repositories.UserRepository,
+	eventRepository repositories.PullRequestEventRepository,
+	broker *events.Broker,
+) *UserEventServiceImpl {
+	return &UserEventServiceImpl{
+		userRepository:  userRepository,
+		eventRepository: eventRepository,
+		broker:          broker,
+	}
+}
+
+// Subscribe subscribes before reading the event log, so that nothing recorded in between is lost.
+func (s *UserEventServiceImpl) Subscribe(userID, lastEventID int) (*events.Subscription, []*models.PullRequestEvent, error) {
+	if _, err := s.userRepository.GetByID(userID); err != nil {
+		return nil, nil, err
+	}
+
+	sub := s.broker.Subscribe(userID)
+	if lastEventID <= 0 {
+		return sub, nil, nil
+	}
+	recorded, err := s.eventRepository.GetByUserID(userID, lastEventID)
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+	missed := make([]*models.PullRequestEvent, 0, len(recorded))
+	for _, event := range recorded {
+		if event.Kind.Streamed() {
+			missed = append(missed, event)
+		}
+	}
+	return sub, missed, nil
+}
@@ -6,12 +6,17 @@ import (
 )
 
 type UserServiceImpl struct {
-	userRepository repositories.UserRepository
+	userRepository     repositories.UserRepository
+	identityRepository repositories.UserIdentityRepository
 }
 
-func NewUserService(userRepository repositories.UserRepository) *UserServiceImpl {
+func NewUserService(
+	userRepository repositories.UserRepository,
+	identityRepository repositories.UserIdentityRepository,
+) *UserServiceImpl {
 	return &UserServiceImpl{
-		userRepository: userRepository,
+		userRepository:     userRepository,
+		identityRepository: identityRepository,
 	}
 }
 
@@ -23,6 +28,10 @@ func (u *UserServiceImpl) GetByID(id int) (*models.User, error) {
 	return u.userRepository.GetByID(id)
 }
 
+func (u *UserServiceImpl) GetByIDs(ids []int) ([]*models.User, error) {
+	return u.userRepository.GetByIDs(ids)
+}
+
 func (u *UserServiceImpl) GetByEmail(email string) (*models.User, error) {
 	return u.userRepository.GetByEmail(email)
 }
@@ -35,6 +44,10 @@ func (u *UserServiceImpl) Update(user *models.User) error {
 	return u.userRepository.Update(user)
 }
 
+func (u *UserServiceImpl) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	return u.userRepository.SetWorkingHours(userID, hours)
+}
+
 func (u *UserServiceImpl) SetActive(userID int, isActive bool) error {
 	user, err := u.userRepository.GetByID(userID)
 	if err != nil {
@@ -46,3 +59,41 @@ func (u *UserServiceImpl) SetActive(userID int, isActive bool) error {
 func (u *UserServiceImpl) Deactivate(userID int) error {
 	return u.userRepository.Deactivate(userID)
 }
+
+func (u *UserServiceImpl) AddIdentity(identity *models.UserIdentity) error {
+	if _, err := u.userRepository.GetByID(identity.UserID); err != nil {
+		return err
+	}
+	return u.identityRepository.Add(identity)
+}
+
+func (u *UserServiceImpl) GetIdentityByID(id int) (*models.UserIdentity, error) {
+	return u.identityRepository.GetByID(id)
+}
+
+func (u *UserServiceImpl) GetIdentities(userID int) ([]*models.UserIdentity, error) {
+	if _, err := u.userRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	return u.identityRepository.GetByUserID(userID)
+}
+
+func (u *UserServiceImpl) GetIdentitiesByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	return u.identityRepository.GetByUserIDs(userIDs)
+}
+
+func (u *UserServiceImpl) UpdateIdentity(identity *models.UserIdentity) error {
+	return u.identityRepository.Update(identity)
+}
+
+func (u *UserServiceImpl) DeleteIdentity(id int) error {
+	return u.identityRepository.Delete(id)
+}
+
+func (u *UserServiceImpl) GetByExternalLogin(provider models.VCSProvider, login string) (*models.User, error) {
+	identity, err := u.identityRepository.GetByLogin(provider, login)
+	if err != nil {
+		return nil, err
+	}
+	return u.userRepository.GetByID(identity.UserID)
+}
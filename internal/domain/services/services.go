@@ -1,7 +1,9 @@
 package services
 
 import (
+	"reviewer-assignment-service/internal/domain/events"
 	"reviewer-assignment-service/internal/domain/models"
+	"time"
 )
 
 type PullRequestService interface {
@@ -9,19 +11,34 @@ type PullRequestService interface {
 	GetByID(id int) (*models.PullRequest, error)
 	GetByAuthorID(authorID int) ([]*models.PullRequest, error)
 	GetByReviewerID(reviewerID int) ([]*models.PullRequest, error)
+	GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error)
 	Update(pr *models.PullRequest) error
+	AddReviewers(pr *models.PullRequest, reviewers ...*models.User) error
+	RemoveReviewer(pr *models.PullRequest, reviewerID int) error
+	Edit(pr *models.PullRequest, name string, status models.PRStatus, reviewers []*models.User) error
+	AssignReviewers(pr *models.PullRequest) error
 	ReassignReviewers(pr *models.PullRequest, oldReviewer *models.User) error
 	MergeRequest(pr *models.PullRequest) error
+	MarkMerged(pr *models.PullRequest) error
 }
 
 type UserService interface {
 	Create(user *models.User) error
 	GetByID(id int) (*models.User, error)
+	GetByIDs(ids []int) ([]*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetAll() ([]*models.User, error)
 	Update(user *models.User) error
 	SetActive(userID int, isActive bool) error
+	SetWorkingHours(userID int, hours models.WorkingHours) error
 	Deactivate(userID int) error
+	AddIdentity(identity *models.UserIdentity) error
+	GetIdentityByID(id int) (*models.UserIdentity, error)
+	GetIdentities(userID int) ([]*models.UserIdentity, error)
+	GetIdentitiesByUserIDs(userIDs []int) ([]*models.UserIdentity, error)
+	UpdateIdentity(identity *models.UserIdentity) error
+	DeleteIdentity(id int) error
+	GetByExternalLogin(provider models.VCSProvider, login string) (*models.User, error)
 }
 
 type TeamService interface {
@@ -31,3 +48,89 @@ type TeamService interface {
 	GetAll() ([]*models.Team, error)
 	Update(team *models.Team) error
 }
+
+type IntegrationService interface {
+	HandleEvent(event *models.VCSEvent) (*models.VCSEventResult, error)
+}
+
+type ImportService interface {
+	Import(doc *models.OrgDocument, dryRun bool) (*models.ImportReport, error)
+	Export() (*models.OrgDocument, error)
+}
+
+type OrgSyncService interface {
+	Plan(doc *models.OrgDocument) (*models.SyncPlan, error)
+	Apply(doc *models.OrgDocument) (*models.SyncResult, error)
+}
+
+type MembershipService interface {
+	MoveUser(userID int, teamName string, reassignReviews bool) (*models.TeamMove, error)
+}
+
+type ReviewerRuleService interface {
+	Create(rule *models.ReviewerRule) error
+	GetByTeamID(teamID int) (models.ReviewerRules, error)
+	Delete(teamID, ruleID int) error
+	Check(teamID, authorID, candidateID int) (*models.CandidateCheck, error)
+	GetUserTags(userID int) ([]string, error)
+	SetUserTags(userID int, tags []string) ([]string, error)
+	CreatePattern(pattern *models.ReviewPattern) error
+	GetPatternsByTeamID(teamID int) (models.ReviewPatterns, error)
+	DeletePattern(teamID, patternID int) error
+	GetReviewLoad(teamID int) (*models.TeamReviewLoad, error)
+	SetReviewHalfLife(teamID int, halfLife time.Duration) (*models.TeamReviewLoad, error)
+}
+
+type ReviewSLAService interface {
+	GetSLA(teamID int) (*models.ReviewSLA, error)
+	SetSLA(sla *models.ReviewSLA) error
+	Acknowledge(prID, reviewerID int) ([]*models.ReviewAssignment, error)
+	GetEvents(prID int) ([]*models.PullRequestEvent, error)
+	Escalate(now time.Time) ([]*models.PullRequestEvent, error)
+}
+
+type ReviewService interface {
+	GetReviews(prID int) (*models.PullRequestReviews, error)
+	SubmitReview(prID, reviewerID int, state models.ReviewState) (*models.PullRequestReviews, error)
+	GetPolicy(teamID int) (*models.ApprovalPolicy, error)
+	SetPolicy(policy *models.ApprovalPolicy) error
+}
+
+type IdempotencyService interface {
+	// Begin reserves the key for a new request and returns nil, or returns the key with the
+	// response stored by the request that used it first.
+	Begin(key, requestHash string) (*models.IdempotencyKey, error)
+	Complete(key *models.IdempotencyKey) error
+	Release(key string) error
+}
+
+type UserEventService interface {
+	// Subscribe starts delivering the user's streamed events and also returns those recorded after
+	// lastEventID, oldest first; with lastEventID 0 only new events are delivered. Events can be
+	// both returned and delivered, so callers skip ids they have already sent.
+	Subscribe(userID, lastEventID int) (*events.Subscription, []*models.PullRequestEvent, error)
+}
+
+type NotificationService interface {
+	GetPreferences(userID int) ([]*models.NotificationPreference, error)
+	SetPreferences(userID int, preferences []*models.NotificationPreference) ([]*models.NotificationPreference, error)
+	// Enqueue queues a rendered notification for every channel on which the user an event
+	// concerns wants that kind of event. Sending happens later in Deliver.
+	Enqueue(pr *models.PullRequest, events ...*models.PullRequestEvent) error
+	// EnqueueDigest queues the digest on every channel the user gets digests on.
+	EnqueueDigest(digest *models.Digest) error
+	// Deliver sends the queued notifications that are due and returns how many were sent.
+	Deliver(now time.Time) (int, error)
+}
+
+type DigestService interface {
+	// Build assembles the user's digest as of now without sending it.
+	Build(userID int) (*models.Digest, error)
+	// Send queues a digest for every active user who has something in theirs and returns how
+	// many were queued. Each user gets at most one a day on each channel.
+	Send(now time.Time) (int, error)
+}
+
+type CacheService interface {
+	Stats() []models.CacheStats
+}
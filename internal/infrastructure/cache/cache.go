@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"reviewer-assignment-service/internal/domain/clock"
+	"reviewer-assignment-service/internal/domain/models"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache holds teams and the candidate reviewers of each team. Membership and activation change
+// rarely, so any write that could touch them drops everything instead of working out which
+// entries it affected.
+type Cache struct {
+	teams      Store[*models.Team]
+	candidates Store[[]*models.User]
+
+	mu sync.Mutex
+	// generation grows with every invalidation, so a value read from the database before one
+	// is not stored after it.
+	generation uint64
+
+	teamCounters      counters
+	candidateCounters counters
+}
+
+type counters struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func New(teams Store[*models.Team], candidates Store[[]*models.User]) *Cache {
+	return &Cache{teams: teams, candidates: candidates}
+}
+
+// NewInProcess keeps up to capacity teams and as many candidate lists in memory, each for ttl.
+func NewInProcess(capacity int, ttl time.Duration, clock clock.Clock) *Cache {
+	return New(NewLRU[*models.Team](capacity, ttl, clock), NewLRU[[]*models.User](capacity, ttl, clock))
+}
+
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.teams.Clear()
+	c.candidates.Clear()
+}
+
+func (c *Cache) Stats() []models.CacheStats {
+	return []models.CacheStats{
+		c.teamCounters.stats("teams", c.teams),
+		c.candidateCounters.stats("candidates", c.candidates),
+	}
+}
+
+func (c *counters) stats(name string, store interface{ Len() int }) models.CacheStats {
+	return models.CacheStats{Name: name, Hits: c.hits.Load(), Misses: c.misses.Load(), Entries: store.Len()}
+}
+
+func (c *Cache) team(key string, load func() (*models.Team, error)) (*models.Team, error) {
+	return readThrough(c, c.teams, &c.teamCounters, key, load)
+}
+
+func (c *Cache) teamCandidates(teamName string, load func() ([]*models.User, error)) ([]*models.User, error) {
+	return readThrough(c, c.candidates, &c.candidateCounters, teamName, load)
+}
+
+// readThrough returns the stored value or loads and stores it. Errors are not stored.
+func readThrough[V any](c *Cache, store Store[V], counters *counters, key string, load func() (V, error)) (V, error) {
+	if value, ok := store.Get(key); ok {
+		counters.hits.Add(1)
+		return value, nil
+	}
+	counters.misses.Add(1)
+
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	if generation == c.generation {
+		store.Set(key, value)
+	}
+	c.mu.Unlock()
+	return value, nil
+}
+
+func teamIDKey(id int) string {
+	return "id:" + strconv.Itoa(id)
+}
+
+func teamNameKey(name string) string {
+	return "name:" + name
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+)
+
+// TeamRepository caches GetByID and GetByName and invalidates the cache after every write.
+// GetAll always goes to next.
+type TeamRepository struct {
+	repositories.TeamRepository
+	cache *Cache
+}
+
+func NewTeamRepository(next repositories.TeamRepository, cache *Cache) *TeamRepository {
+	return &TeamRepository{TeamRepository: next, cache: cache}
+}
+
+func (r *TeamRepository) GetByID(id int) (*models.Team, error) {
+	team, err := r.cache.team(teamIDKey(id), func() (*models.Team, error) { return r.TeamRepository.GetByID(id) })
+	if err != nil {
+		return nil, err
+	}
+	return cloneTeam(team), nil
+}
+
+func (r *TeamRepository) GetByName(name string) (*models.Team, error) {
+	team, err := r.cache.team(teamNameKey(name), func() (*models.Team, error) { return r.TeamRepository.GetByName(name) })
+	if err != nil {
+		return nil, err
+	}
+	return cloneTeam(team), nil
+}
+
+func (r *TeamRepository) Add(team *models.Team) error {
+	defer r.cache.Invalidate()
+	return r.TeamRepository.Add(team)
+}
+
+func (r *TeamRepository) Update(team *models.Team) error {
+	defer r.cache.Invalidate()
+	return r.TeamRepository.Update(team)
+}
+
+func (r *TeamRepository) AddUserToTeam(teamID, userID int) error {
+	defer r.cache.Invalidate()
+	return r.TeamRepository.AddUserToTeam(teamID, userID)
+}
+
+func (r *TeamRepository) RemoveUserFromTeam(teamID, userID int) error {
+	defer r.cache.Invalidate()
+	return r.TeamRepository.RemoveUserFromTeam(teamID, userID)
+}
+
+// CandidateRepository caches the active members of each team and leaves out the author on the
+// way back, so every author in a team shares one entry.
+type CandidateRepository struct {
+	next  repositories.ReviewerCandidateRepository
+	cache *Cache
+}
+
+func NewCandidateRepository(next repositories.ReviewerCandidateRepository, cache *Cache) *CandidateRepository {
+	return &CandidateRepository{next: next, cache: cache}
+}
+
+func (r *CandidateRepository) FindPossibleReviewers(author *models.User) ([]*models.User, error) {
+	members, err := r.cache.teamCandidates(author.TeamName, func() ([]*models.User, error) {
+		// No user has id 0, so nobody is left out and the list fits every author in the team.
+		return r.next.FindPossibleReviewers(&models.User{TeamName: author.TeamName})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewers []*models.User
+	for _, member := range members {
+		if member.ID != author.ID {
+			reviewer := *member
+			reviewers = append(reviewers, &reviewer)
+		}
+	}
+	return reviewers, nil
+}
+
+// PullRequestRepository looks up candidate reviewers through the cache and passes everything
+// else to next.
+type PullRequestRepository struct {
+	repositories.PullRequestRepository
+	candidates *CandidateRepository
+}
+
+func NewPullRequestRepository(next repositories.PullRequestRepository, cache *Cache) *PullRequestRepository {
+	return &PullRequestRepository{PullRequestRepository: next, candidates: NewCandidateRepository(next, cache)}
+}
+
+func (r *PullRequestRepository) FindPossibleReviewers(author *models.User) ([]*models.User, error) {
+	return r.candidates.FindPossibleReviewers(author)
+}
+
+// UserRepository invalidates the cache after the writes that can change a team's members or
+// who of them is active and available.
+type UserRepository struct {
+	repositories.UserRepository
+	cache *Cache
+}
+
+func NewUserRepository(next repositories.UserRepository, cache *Cache) *UserRepository {
+	return &UserRepository{UserRepository: next, cache: cache}
+}
+
+func (r *UserRepository) Add(user *models.User) error {
+	defer r.cache.Invalidate()
+	return r.UserRepository.Add(user)
+}
+
+func (r *UserRepository) Update(user *models.User) error {
+	defer r.cache.Invalidate()
+	return r.UserRepository.Update(user)
+}
+
+func (r *UserRepository) Deactivate(userID int) error {
+	defer r.cache.Invalidate()
+	return r.UserRepository.Deactivate(userID)
+}
+
+func (r *UserRepository) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	defer r.cache.Invalidate()
+	return r.UserRepository.SetWorkingHours(userID, hours)
+}
+
+// TransactionManager invalidates the cache once a transaction is over, since the repositories
+// inside it write to the database directly.
+type TransactionManager struct {
+	next  repositories.TransactionManager
+	cache *Cache
+}
+
+func NewTransactionManager(next repositories.TransactionManager, cache *Cache) *TransactionManager {
+	return &TransactionManager{next: next, cache: cache}
+}
+
+func (m *TransactionManager) WithinTransaction(fn func(repos repositories.Repositories) error) error {
+	defer m.cache.Invalidate()
+	return m.next.WithinTransaction(fn)
+}
+
+func cloneTeam(team *models.Team) *models.Team {
+	clone := *team
+	clone.Members = make(map[int]*models.TeamMember, len(team.Members))
+	for id, member := range team.Members {
+		copied := *member
+		clone.Members[id] = &copied
+	}
+	return &clone
+}
@@ -0,0 +1,104 @@
+// Package cache puts a read-through cache in front of the repositories that every reviewer
+// assignment reads: teams by id or name and the candidate reviewers of a team.
+package cache
+
+import (
+	"container/list"
+	"reviewer-assignment-service/internal/domain/clock"
+	"sync"
+	"time"
+)
+
+// Store holds cached values by key. LRU keeps them in process; a store shared between
+// instances only has to implement the same four methods.
+type Store[V any] interface {
+	Get(key string) (V, bool)
+	Set(key string, value V)
+	Clear()
+	Len() int
+}
+
+// LRU keeps up to capacity values, each for ttl after it was set, and evicts the least recently
+// used one when it is full.
+type LRU[V any] struct {
+	capacity int
+	ttl      time.Duration
+	clock    clock.Clock
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+func NewLRU[V any](capacity int, ttl time.Duration, clock clock.Clock) *LRU[V] {
+	return &LRU[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		clock:    clock,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (l *LRU[V]) Get(key string) (V, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var zero V
+	element, ok := l.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := element.Value.(*entry[V])
+	if !l.clock.Now().Before(e.expiresAt) {
+		l.remove(element)
+		return zero, false
+	}
+	l.order.MoveToFront(element)
+	return e.value, true
+}
+
+func (l *LRU[V]) Set(key string, value V) {
+	if l.capacity <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expiresAt := l.clock.Now().Add(l.ttl)
+	if element, ok := l.entries[key]; ok {
+		e := element.Value.(*entry[V])
+		e.value, e.expiresAt = value, expiresAt
+		l.order.MoveToFront(element)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+	for l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *LRU[V]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.order.Init()
+	clear(l.entries)
+}
+
+// Len counts the stored values, including expired ones not yet evicted.
+func (l *LRU[V]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU[V]) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.entries, element.Value.(*entry[V]).key)
+}
@@ -0,0 +1,2 @@
+drop index if exists idx_reviewer_rules_team_id;
+drop table if exists reviewer_rules cascade;
@@ -0,0 +1,11 @@
+create table if not exists reviewer_rules (
+    id serial primary key,
+    team_id int not null references teams(id) on delete cascade,
+    kind varchar(32) not null check (kind in ('never_assign', 'require_tag', 'prefer')),
+    reviewer_id int references users(id) on delete cascade,
+    author_id int references users(id) on delete cascade,
+    tag varchar(50),
+    author_tag varchar(50)
+);
+
+create index if not exists idx_reviewer_rules_team_id on reviewer_rules(team_id);
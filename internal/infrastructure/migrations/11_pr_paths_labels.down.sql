@@ -0,0 +1,2 @@
+alter table prs drop column if exists labels;
+alter table prs drop column if exists paths;
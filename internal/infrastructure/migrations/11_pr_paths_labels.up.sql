@@ -0,0 +1,2 @@
+alter table prs add column if not exists paths text[] default '{}' not null;
+alter table prs add column if not exists labels text[] default '{}' not null;
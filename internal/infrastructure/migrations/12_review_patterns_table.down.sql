@@ -0,0 +1,2 @@
+drop index if exists idx_review_patterns_team_id;
+drop table if exists review_patterns cascade;
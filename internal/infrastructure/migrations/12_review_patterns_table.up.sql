@@ -0,0 +1,10 @@
+create table if not exists review_patterns (
+    id serial primary key,
+    team_id int not null references teams(id) on delete cascade,
+    pattern varchar(255),
+    label varchar(100),
+    tag varchar(50) not null,
+    check ((pattern is null) <> (label is null))
+);
+
+create index if not exists idx_review_patterns_team_id on review_patterns(team_id);
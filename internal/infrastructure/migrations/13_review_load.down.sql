@@ -0,0 +1,2 @@
+alter table teams drop column if exists review_half_life_hours;
+alter table prs drop column if exists size;
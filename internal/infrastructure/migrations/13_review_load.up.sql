@@ -0,0 +1,2 @@
+alter table prs add column if not exists size int check (size >= 0);
+alter table teams add column if not exists review_half_life_hours int default 168 not null check (review_half_life_hours between 1 and 8760);
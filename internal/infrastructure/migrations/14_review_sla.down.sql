@@ -0,0 +1,6 @@
+drop table if exists pr_events;
+alter table teams drop column if exists sla_action;
+alter table teams drop column if exists review_sla_hours;
+alter table assigned_reviewers drop column if exists reviewed_at;
+alter table assigned_reviewers drop column if exists assigned_at;
+drop index if exists idx_assigned_reviewers_unique;
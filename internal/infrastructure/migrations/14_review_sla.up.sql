@@ -0,0 +1,15 @@
+delete from assigned_reviewers a using assigned_reviewers b where a.ctid < b.ctid and a.pr_id = b.pr_id and a.user_id = b.user_id;
+create unique index if not exists idx_assigned_reviewers_unique on assigned_reviewers(pr_id, user_id);
+alter table assigned_reviewers add column if not exists assigned_at timestamp default current_timestamp not null;
+alter table assigned_reviewers add column if not exists reviewed_at timestamp;
+alter table teams add column if not exists review_sla_hours int check (review_sla_hours between 1 and 720);
+alter table teams add column if not exists sla_action varchar(16) default 'add_reviewer' not null check (sla_action in ('add_reviewer', 'reassign'));
+create table if not exists pr_events (
+    id serial primary key,
+    pr_id int not null references prs(id) on delete cascade,
+    kind varchar(32) not null,
+    user_id int references users(id) on delete set null,
+    previous_user_id int references users(id) on delete set null,
+    created_at timestamp default current_timestamp not null
+);
+create index if not exists idx_pr_events_pr_id on pr_events(pr_id);
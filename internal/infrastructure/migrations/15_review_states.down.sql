@@ -0,0 +1,3 @@
+alter table teams drop column if exists required_approvals;
+alter table assigned_reviewers drop column if exists state_changed_at;
+alter table assigned_reviewers drop column if exists review_state;
@@ -0,0 +1,3 @@
+alter table assigned_reviewers add column if not exists review_state varchar(32) default 'pending' not null check (review_state in ('pending', 'approved', 'changes_requested', 'dismissed'));
+alter table assigned_reviewers add column if not exists state_changed_at timestamp;
+alter table teams add column if not exists required_approvals int default 0 not null check (required_approvals between 0 and 2);
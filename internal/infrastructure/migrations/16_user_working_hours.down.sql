@@ -0,0 +1,8 @@
+delete from reviewer_rules where kind = 'prefer_working_hours';
+alter table reviewer_rules drop constraint if exists reviewer_rules_kind_check;
+alter table reviewer_rules add constraint reviewer_rules_kind_check check (kind in ('never_assign', 'require_tag', 'prefer'));
+
+alter table users drop constraint if exists users_working_hours_check;
+alter table users drop column if exists work_end_hour;
+alter table users drop column if exists work_start_hour;
+alter table users drop column if exists timezone;
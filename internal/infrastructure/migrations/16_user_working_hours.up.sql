@@ -0,0 +1,8 @@
+alter table users add column if not exists timezone varchar(64) default 'UTC' not null;
+alter table users add column if not exists work_start_hour smallint default 9 not null;
+alter table users add column if not exists work_end_hour smallint default 18 not null;
+alter table users drop constraint if exists users_working_hours_check;
+alter table users add constraint users_working_hours_check check (work_start_hour >= 0 and work_start_hour < work_end_hour and work_end_hour <= 24);
+
+alter table reviewer_rules drop constraint if exists reviewer_rules_kind_check;
+alter table reviewer_rules add constraint reviewer_rules_kind_check check (kind in ('never_assign', 'require_tag', 'prefer', 'prefer_working_hours'));
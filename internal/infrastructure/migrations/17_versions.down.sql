@@ -0,0 +1,2 @@
+alter table teams drop column if exists version;
+alter table prs drop column if exists version;
@@ -0,0 +1,2 @@
+alter table prs add column if not exists version int default 1 not null;
+alter table teams add column if not exists version int default 1 not null;
@@ -0,0 +1,9 @@
+create table if not exists idempotency_keys (
+    key varchar(255) primary key,
+    request_hash char(64) not null,
+    status_code int,
+    response_headers jsonb,
+    response_body bytea,
+    created_at timestamp default current_timestamp not null
+);
+create index if not exists idx_idempotency_keys_created_at on idempotency_keys(created_at);
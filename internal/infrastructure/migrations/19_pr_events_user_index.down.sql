@@ -0,0 +1 @@
+drop index if exists idx_pr_events_user_id;
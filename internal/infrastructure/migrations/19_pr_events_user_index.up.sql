@@ -0,0 +1 @@
+create index if not exists idx_pr_events_user_id on pr_events(user_id, id);
@@ -0,0 +1,25 @@
+create table if not exists notification_preferences (
+    user_id int not null references users(id) on delete cascade,
+    channel varchar(16) not null check (channel in ('email', 'slack', 'webhook')),
+    target varchar(2048) default '' not null,
+    kinds varchar(32)[] default '{}' not null,
+    primary key (user_id, channel)
+);
+create table if not exists notifications (
+    id serial primary key,
+    event_id int not null references pr_events(id) on delete cascade,
+    kind varchar(32) not null,
+    pr_id int not null references prs(id) on delete cascade,
+    user_id int not null references users(id) on delete cascade,
+    channel varchar(16) not null,
+    target varchar(2048) not null,
+    subject text not null,
+    body text not null,
+    attempts int default 0 not null,
+    next_attempt_at timestamp,
+    sent_at timestamp,
+    last_error text default '' not null,
+    created_at timestamp default current_timestamp not null,
+    unique (event_id, channel)
+);
+create index if not exists idx_notifications_due on notifications(next_attempt_at) where next_attempt_at is not null;
@@ -0,0 +1,6 @@
+drop index if exists idx_notifications_digest;
+delete from notifications where event_id is null or pr_id is null;
+alter table notifications drop column if exists html;
+alter table notifications alter column pr_id set not null;
+alter table notifications alter column event_id set not null;
+alter table notification_preferences drop column if exists digest;
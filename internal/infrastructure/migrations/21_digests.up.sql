@@ -0,0 +1,5 @@
+alter table notification_preferences add column if not exists digest boolean default false not null;
+alter table notifications alter column event_id drop not null;
+alter table notifications alter column pr_id drop not null;
+alter table notifications add column if not exists html text default '' not null;
+create unique index if not exists idx_notifications_digest on notifications(user_id, channel, (created_at::date)) where kind = 'digest';
@@ -0,0 +1,2 @@
+drop index if exists idx_external_prs_pr_id;
+drop table if exists external_prs cascade;
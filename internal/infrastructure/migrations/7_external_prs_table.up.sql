@@ -0,0 +1,9 @@
+create table if not exists external_prs (
+    provider varchar(32) not null,
+    external_id varchar(255) not null,
+    pr_id int references prs(id) on delete cascade,
+    created_at timestamp default current_timestamp not null,
+    primary key (provider, external_id)
+);
+
+create index if not exists idx_external_prs_pr_id on external_prs(pr_id);
@@ -0,0 +1,3 @@
+drop index if exists idx_user_identities_user_provider;
+drop index if exists idx_user_identities_provider_login;
+drop table if exists user_identities cascade;
@@ -0,0 +1,9 @@
+create table if not exists user_identities (
+    id serial primary key,
+    provider varchar(32) not null,
+    login varchar(255) not null,
+    user_id int not null references users(id) on delete cascade
+);
+
+create unique index if not exists idx_user_identities_provider_login on user_identities(provider, lower(login));
+create unique index if not exists idx_user_identities_user_provider on user_identities(user_id, provider);
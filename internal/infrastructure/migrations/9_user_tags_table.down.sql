@@ -0,0 +1,2 @@
+drop index if exists idx_user_tags_tag;
+drop table if exists user_tags cascade;
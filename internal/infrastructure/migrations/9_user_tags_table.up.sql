@@ -0,0 +1,7 @@
+create table if not exists user_tags (
+    user_id int not null references users(id) on delete cascade,
+    tag varchar(50) not null,
+    primary key (user_id, tag)
+);
+
+create index if not exists idx_user_tags_tag on user_tags(tag);
@@ -0,0 +1,71 @@
+package notifiers
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"reviewer-assignment-service/internal/domain/models"
+	"strings"
+)
+
+// EmailNotifier sends mail through an SMTP relay: plain text, or multipart/alternative when the
+// notification has an HTML body.
+type EmailNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewEmailNotifier authenticates with PLAIN only when username is set.
+func NewEmailNotifier(addr, from, username, password string) *EmailNotifier {
+	notifier := &EmailNotifier{addr: addr, from: from}
+	if username != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		notifier.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return notifier
+}
+
+func (n *EmailNotifier) Send(notification *models.Notification) error {
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", n.from)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.Target)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+
+	text := crlf(notification.Body)
+	if notification.HTML == "" {
+		message.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+		message.WriteString(text)
+	} else {
+		parts := multipart.NewWriter(&message)
+		fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+		for _, part := range []struct{ contentType, body string }{
+			{"text/plain; charset=utf-8", text},
+			{"text/html; charset=utf-8", crlf(notification.HTML)},
+		} {
+			w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write([]byte(part.body)); err != nil {
+				return err
+			}
+		}
+		if err := parts.Close(); err != nil {
+			return err
+		}
+	}
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{notification.Target}, []byte(message.String()))
+}
+
+func crlf(body string) string {
+	return strings.ReplaceAll(body, "\n", "\r\n")
+}
@@ -0,0 +1,82 @@
+package notifiers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reviewer-assignment-service/internal/domain/models"
+	"time"
+)
+
+const requestTimeout = 10 * time.Second
+
+// SlackNotifier posts to a Slack incoming webhook.
+type SlackNotifier struct {
+	client *http.Client
+}
+
+func NewSlackNotifier() *SlackNotifier {
+	return &SlackNotifier{client: &http.Client{Timeout: requestTimeout}}
+}
+
+func (n *SlackNotifier) Send(notification *models.Notification) error {
+	return postJSON(n.client, notification.Target, map[string]string{
+		"text": "*" + notification.Subject + "*\n" + notification.Body,
+	})
+}
+
+// WebhookNotifier posts the notification as JSON to a URL of the user's choosing. Digests have no
+// event_id or pull_request_id.
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+func NewWebhookNotifier() *WebhookNotifier {
+	return &WebhookNotifier{client: &http.Client{Timeout: requestTimeout}}
+}
+
+type webhookPayload struct {
+	ID            int       `json:"id"`
+	EventID       int       `json:"event_id,omitempty"`
+	Kind          string    `json:"kind"`
+	PullRequestID int       `json:"pull_request_id,omitempty"`
+	UserID        int       `json:"user_id"`
+	Subject       string    `json:"subject"`
+	Body          string    `json:"body"`
+	HTML          string    `json:"html,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (n *WebhookNotifier) Send(notification *models.Notification) error {
+	return postJSON(n.client, notification.Target, webhookPayload{
+		ID:            notification.ID,
+		EventID:       notification.EventID,
+		Kind:          string(notification.Kind),
+		PullRequestID: notification.PullRequestID,
+		UserID:        notification.UserID,
+		Subject:       notification.Subject,
+		Body:          notification.Body,
+		HTML:          notification.HTML,
+		CreatedAt:     notification.CreatedAt,
+	})
+}
+
+func postJSON(client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s responded %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import "database/sql"
+
+// sqlConn is the part of *sql.DB the repositories use. Running them on a txConn makes
+// their own Begin/Commit join the outer transaction instead of opening a new one.
+type sqlConn interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Begin() (sqlTx, error)
+}
+
+type sqlTx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Commit() error
+	Rollback() error
+}
+
+type dbConn struct {
+	*sql.DB
+}
+
+func (c dbConn) Begin() (sqlTx, error) {
+	return c.DB.Begin()
+}
+
+type txConn struct {
+	*sql.Tx
+}
+
+func (c txConn) Begin() (sqlTx, error) {
+	return nestedTx{c.Tx}, nil
+}
+
+type nestedTx struct {
+	*sql.Tx
+}
+
+func (nestedTx) Commit() error {
+	return nil
+}
+
+func (nestedTx) Rollback() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ExternalPullRequestDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewExternalPullRequestDataBase(db *sql.DB) *ExternalPullRequestDataBase {
+	return &ExternalPullRequestDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (e *ExternalPullRequestDataBase) Add(link *models.ExternalPullRequest) error {
+	query, args, err := e.sb.
+		Insert("external_prs").
+		Columns("provider", "external_id", "pr_id").
+		Values(string(link.Provider), link.ExternalID, link.PullRequestID).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = e.db.Exec(query, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return repositories.ErrExternalPullRequestAlreadyExists
+		}
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return repositories.ErrPullRequestNotFoundInPersistence
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (e *ExternalPullRequestDataBase) GetByExternalID(provider models.VCSProvider, externalID string) (*models.ExternalPullRequest, error) {
+	query, args, err := e.sb.
+		Select("provider", "external_id", "pr_id").
+		From("external_prs").
+		Where(squirrel.Eq{"provider": string(provider), "external_id": externalID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	link := &models.ExternalPullRequest{}
+	var providerStr string
+	err = e.db.QueryRow(query, args...).Scan(&providerStr, &link.ExternalID, &link.PullRequestID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrExternalPullRequestNotFound
+		}
+		return nil, err
+	}
+	link.Provider = models.VCSProvider(providerStr)
+
+	return link, nil
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type IdempotencyKeyDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewIdempotencyKeyDataBase(db *sql.DB) *IdempotencyKeyDataBase {
+	return &IdempotencyKeyDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *IdempotencyKeyDataBase) Reserve(key *models.IdempotencyKey) (bool, error) {
+	query, args, err := r.sb.
+		Insert("idempotency_keys").
+		Columns("key", "request_hash", "created_at").
+		Values(key.Key, key.RequestHash, key.CreatedAt).
+		Suffix("ON CONFLICT (key) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *IdempotencyKeyDataBase) Get(key string) (*models.IdempotencyKey, error) {
+	query, args, err := r.sb.
+		Select("key", "request_hash", "status_code", "response_headers", "response_body", "created_at").
+		From("idempotency_keys").
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.IdempotencyKey{}
+	var statusCode sql.NullInt64
+	var headers []byte
+	err = r.db.QueryRow(query, args...).
+		Scan(&stored.Key, &stored.RequestHash, &statusCode, &headers, &stored.Body, &stored.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrIdempotencyKeyNotFound
+		}
+		return nil, err
+	}
+
+	stored.StatusCode = int(statusCode.Int64)
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &stored.Headers); err != nil {
+			return nil, err
+		}
+	}
+
+	return stored, nil
+}
+
+func (r *IdempotencyKeyDataBase) Complete(key *models.IdempotencyKey) error {
+	headers, err := json.Marshal(key.Headers)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := r.sb.
+		Update("idempotency_keys").
+		Set("status_code", key.StatusCode).
+		Set("response_headers", string(headers)).
+		Set("response_body", key.Body).
+		Where(squirrel.Eq{"key": key.Key}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repositories.ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *IdempotencyKeyDataBase) Delete(key string) error {
+	query, args, err := r.sb.
+		Delete("idempotency_keys").
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(query, args...)
+	return err
+}
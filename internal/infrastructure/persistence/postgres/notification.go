@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type NotificationDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewNotificationDataBase(db *sql.DB) *NotificationDataBase {
+	return &NotificationDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+var notificationColumns = []string{
+	"id", "event_id", "kind", "pr_id", "user_id", "channel", "target", "subject", "body", "html",
+	"attempts", "next_attempt_at", "sent_at", "last_error", "created_at",
+}
+
+func (r *NotificationDataBase) Add(notification *models.Notification) error {
+	query, args, err := r.sb.
+		Insert("notifications").
+		Columns("event_id", "kind", "pr_id", "user_id", "channel", "target", "subject", "body", "html", "next_attempt_at", "created_at").
+		Values(nullInt(notification.EventID), string(notification.Kind), nullInt(notification.PullRequestID), notification.UserID,
+			string(notification.Channel), notification.Target, notification.Subject, notification.Body, notification.HTML,
+			notification.NextAttemptAt, notification.CreatedAt).
+		Suffix("ON CONFLICT DO NOTHING RETURNING id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.QueryRow(query, args...).Scan(&notification.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+func (r *NotificationDataBase) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.Notification, error) {
+	query, args, err := r.sb.
+		Update("notifications").
+		Set("next_attempt_at", leaseUntil).
+		Where("id IN (SELECT id FROM notifications WHERE next_attempt_at <= ? "+
+			"ORDER BY next_attempt_at, id LIMIT ? FOR UPDATE SKIP LOCKED)", now, limit).
+		Suffix("RETURNING " + strings.Join(notificationColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]*models.Notification, 0)
+	for rows.Next() {
+		notification := &models.Notification{}
+		var kind, channel string
+		var eventID, prID sql.NullInt64
+		var nextAttemptAt, sentAt sql.NullTime
+		if err := rows.Scan(
+			&notification.ID, &eventID, &kind, &prID, &notification.UserID,
+			&channel, &notification.Target, &notification.Subject, &notification.Body, &notification.HTML,
+			&notification.Attempts, &nextAttemptAt, &sentAt, &notification.LastError, &notification.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		notification.EventID = int(eventID.Int64)
+		notification.PullRequestID = int(prID.Int64)
+		notification.Kind = models.PullRequestEventKind(kind)
+		notification.Channel = models.NotificationChannel(channel)
+		if nextAttemptAt.Valid {
+			notification.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if sentAt.Valid {
+			notification.SentAt = &sentAt.Time
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (r *NotificationDataBase) Save(notification *models.Notification) error {
+	query, args, err := r.sb.
+		Update("notifications").
+		Set("attempts", notification.Attempts).
+		Set("next_attempt_at", notification.NextAttemptAt).
+		Set("sent_at", notification.SentAt).
+		Set("last_error", notification.LastError).
+		Where(squirrel.Eq{"id": notification.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(query, args...)
+	return err
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"database/sql"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+type NotificationPreferenceDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewNotificationPreferenceDataBase(db *sql.DB) *NotificationPreferenceDataBase {
+	return &NotificationPreferenceDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *NotificationPreferenceDataBase) GetByUserIDs(userIDs []int) (map[int][]*models.NotificationPreference, error) {
+	preferences := make(map[int][]*models.NotificationPreference, len(userIDs))
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	query, args, err := r.sb.
+		Select("user_id", "channel", "target", "kinds", "digest").
+		From("notification_preferences").
+		Where(squirrel.Eq{"user_id": userIDs}).
+		OrderBy("user_id", "channel").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		preference := &models.NotificationPreference{}
+		var channel string
+		var kinds []string
+		if err := rows.Scan(&preference.UserID, &channel, &preference.Target, pq.Array(&kinds), &preference.Digest); err != nil {
+			return nil, err
+		}
+		preference.Channel = models.NotificationChannel(channel)
+		preference.Kinds = make([]models.PullRequestEventKind, 0, len(kinds))
+		for _, kind := range kinds {
+			preference.Kinds = append(preference.Kinds, models.PullRequestEventKind(kind))
+		}
+		preferences[preference.UserID] = append(preferences[preference.UserID], preference)
+	}
+
+	return preferences, rows.Err()
+}
+
+// Replace stores the user's preferences in place of the saved ones. An empty list is kept as
+// "saved nothing", which brings back the defaults.
+func (r *NotificationPreferenceDataBase) Replace(userID int, preferences []*models.NotificationPreference) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := r.sb.
+		Delete("notification_preferences").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	if len(preferences) > 0 {
+		insert := r.sb.Insert("notification_preferences").Columns("user_id", "channel", "target", "kinds", "digest")
+		for _, preference := range preferences {
+			kinds := make([]string, 0, len(preference.Kinds))
+			for _, kind := range preference.Kinds {
+				kinds = append(kinds, string(kind))
+			}
+			insert = insert.Values(userID, string(preference.Channel), preference.Target, pq.Array(kinds), preference.Digest)
+		}
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(query, args...); err != nil {
+			if strings.Contains(err.Error(), "violates foreign key constraint") {
+				return repositories.ErrUserNotFoundInPersistence
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -2,22 +2,24 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"reviewer-assignment-service/internal/domain/models"
 	"reviewer-assignment-service/internal/domain/repositories"
 	"strings"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 )
 
 type PullRequestDataBase struct {
-	db *sql.DB
+	db sqlConn
 	sb squirrel.StatementBuilderType
 }
 
 func NewPullRequestDataBase(db *sql.DB) *PullRequestDataBase {
 	return &PullRequestDataBase{
-		db: db,
+		db: dbConn{db},
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
@@ -57,16 +59,16 @@ func (p *PullRequestDataBase) Add(pr *models.PullRequest) error {
 
 	prQuery, prArgs, err := p.sb.
 		Insert("prs").
-		Columns("title", "author_id", "team_id", "status", "created_at", "merged_at").
-		Values(pr.Name, pr.Author.ID, teamID, string(pr.Status), pr.CreatedAt, mergedAt).
-		Suffix("RETURNING id").
+		Columns("title", "author_id", "team_id", "status", "created_at", "merged_at", "paths", "labels", "size").
+		Values(pr.Name, pr.Author.ID, teamID, string(pr.Status), pr.CreatedAt, mergedAt, pq.Array(nonNil(pr.Paths)), pq.Array(nonNil(pr.Labels)), pr.Size).
+		Suffix("RETURNING id, version").
 		ToSql()
 
 	if err != nil {
 		return err
 	}
 
-	err = tx.QueryRow(prQuery, prArgs...).Scan(&pr.ID)
+	err = tx.QueryRow(prQuery, prArgs...).Scan(&pr.ID, &pr.Version)
 	if err != nil {
 		if strings.Contains(err.Error(), "violates foreign key constraint") {
 			if strings.Contains(err.Error(), "author_id") {
@@ -110,485 +112,155 @@ func (p *PullRequestDataBase) Add(pr *models.PullRequest) error {
 	return tx.Commit()
 }
 
-func (p *PullRequestDataBase) GetByID(id int) (*models.PullRequest, error) {
-	prQuery, prArgs, err := p.sb.
-		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at",
-			"u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("prs p").
-		Join("users u ON p.author_id = u.id").
-		Where(squirrel.Eq{"p.id": id}).
-		ToSql()
+// prReviewers aggregates the reviewers of the selected pull request into a JSON array, so that
+// one query returns whole pull requests. Reviewers come in the order they were assigned.
+const prReviewers = `COALESCE((SELECT json_agg(json_build_object(` +
+	`'id', r.id, 'name', r.name, 'email', r.email, 'team_name', r.team_name, 'is_active', r.is_active, ` +
+	`'working_hours', json_build_object('timezone', r.timezone, 'start_hour', r.work_start_hour, 'end_hour', r.work_end_hour)` +
+	`) ORDER BY a.assigned_at, r.id) FROM assigned_reviewers a JOIN users r ON a.user_id = r.id WHERE a.pr_id = p.id), '[]')`
 
-	if err != nil {
-		return nil, err
-	}
+// prFilter narrows the pull requests selectPullRequests reads; filters combine with AND.
+type prFilter func(squirrel.SelectBuilder) squirrel.SelectBuilder
 
-	pr := &models.PullRequest{}
-	var status string
-	var mergedAt sql.NullTime
-	author := &models.User{}
-
-	row := p.db.QueryRow(prQuery, prArgs...)
-	err = row.Scan(
-		&pr.ID, &pr.Name, &status, &pr.CreatedAt, &mergedAt,
-		&author.ID, &author.Name, &author.Email, &author.TeamName, &author.IsActive,
-	)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, repositories.ErrPullRequestNotFoundInPersistence
-		}
-		return nil, err
+func prWithID(id int) prFilter {
+	return func(b squirrel.SelectBuilder) squirrel.SelectBuilder {
+		return b.Where(squirrel.Eq{"p.id": id})
 	}
+}
 
-	pr.Author = author
-	pr.Status = models.PRStatus(status)
-	if mergedAt.Valid {
-		pr.MergedAt = mergedAt.Time
-	}
-
-	reviewersQuery, reviewersArgs, err := p.sb.
-		Select("u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("assigned_reviewers ar").
-		Join("users u ON ar.user_id = u.id").
-		Where(squirrel.Eq{"ar.pr_id": id}).
-		ToSql()
-
-	if err != nil {
-		return nil, err
+func prWithStatus(status models.PRStatus) prFilter {
+	return func(b squirrel.SelectBuilder) squirrel.SelectBuilder {
+		return b.Where(squirrel.Eq{"p.status": string(status)})
 	}
+}
 
-	reviewersRows, err := p.db.Query(reviewersQuery, reviewersArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer reviewersRows.Close()
-
-	pr.Reviewers = make([]*models.User, 0)
-	for reviewersRows.Next() {
-		reviewer := &models.User{}
-		err := reviewersRows.Scan(
-			&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-		pr.Reviewers = append(pr.Reviewers, reviewer)
+func prByAuthor(authorID int) prFilter {
+	return func(b squirrel.SelectBuilder) squirrel.SelectBuilder {
+		return b.Where(squirrel.Eq{"p.author_id": authorID})
 	}
+}
 
-	if err = reviewersRows.Err(); err != nil {
-		return nil, err
+// prReviewedBy keeps pull requests that any of the users reviews.
+func prReviewedBy(reviewerIDs ...int) prFilter {
+	return func(b squirrel.SelectBuilder) squirrel.SelectBuilder {
+		return b.Where(squirrel.Expr("EXISTS (?)", squirrel.
+			Select("1").
+			From("assigned_reviewers f").
+			Where("f.pr_id = p.id").
+			Where(squirrel.Eq{"f.user_id": reviewerIDs})))
 	}
-
-	return pr, nil
 }
 
-func (p *PullRequestDataBase) GetAll() ([]*models.PullRequest, error) {
-	prsQuery, prsArgs, err := p.sb.
-		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at",
-			"u.id", "u.name", "u.email", "u.team_name", "u.is_active").
+func (p *PullRequestDataBase) selectPullRequests(filters ...prFilter) ([]*models.PullRequest, error) {
+	builder := p.sb.
+		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "p.paths", "p.labels", "p.size", "p.version",
+			"u.id", "u.name", "u.email", "u.team_name", "u.is_active", "u.timezone", "u.work_start_hour", "u.work_end_hour", prReviewers).
 		From("prs p").
 		Join("users u ON p.author_id = u.id").
-		OrderBy("p.created_at DESC").
-		ToSql()
+		OrderBy("p.created_at DESC", "p.id DESC")
+	for _, filter := range filters {
+		builder = filter(builder)
+	}
 
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	prsRows, err := p.db.Query(prsQuery, prsArgs...)
+	rows, err := p.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer prsRows.Close()
-
-	var prs []*models.PullRequest
-	prsByID := make(map[int]*models.PullRequest)
-
-	for prsRows.Next() {
-		pr := &models.PullRequest{}
-		var status string
-		var mergedAt sql.NullTime
-		author := &models.User{}
+	defer rows.Close()
 
-		err := prsRows.Scan(
-			&pr.ID, &pr.Name, &status, &pr.CreatedAt, &mergedAt,
-			&author.ID, &author.Name, &author.Email, &author.TeamName, &author.IsActive,
-		)
+	prs := make([]*models.PullRequest, 0)
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		pr.Author = author
-		pr.Status = models.PRStatus(status)
-		if mergedAt.Valid {
-			pr.MergedAt = mergedAt.Time
-		}
-		pr.Reviewers = make([]*models.User, 0)
-
 		prs = append(prs, pr)
-		prsByID[pr.ID] = pr
-	}
-
-	if err = prsRows.Err(); err != nil {
-		return nil, err
-	}
-
-	if len(prs) == 0 {
-		return prs, nil
 	}
 
-	prIDs := make([]int, 0, len(prs))
-	for _, pr := range prs {
-		prIDs = append(prIDs, pr.ID)
-	}
+	return prs, rows.Err()
+}
 
-	reviewersQuery, reviewersArgs, err := p.sb.
-		Select("ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("assigned_reviewers ar").
-		Join("users u ON ar.user_id = u.id").
-		Where(squirrel.Eq{"ar.pr_id": prIDs}).
-		ToSql()
+func scanPullRequest(row rowScanner) (*models.PullRequest, error) {
+	pr := &models.PullRequest{Author: &models.User{}}
+	var status string
+	var mergedAt sql.NullTime
+	var reviewers []byte
 
+	err := row.Scan(
+		&pr.ID, &pr.Name, &status, &pr.CreatedAt, &mergedAt, pq.Array(&pr.Paths), pq.Array(&pr.Labels), &pr.Size, &pr.Version,
+		&pr.Author.ID, &pr.Author.Name, &pr.Author.Email, &pr.Author.TeamName, &pr.Author.IsActive,
+		&pr.Author.WorkingHours.Timezone, &pr.Author.WorkingHours.StartHour, &pr.Author.WorkingHours.EndHour, &reviewers,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	reviewersRows, err := p.db.Query(reviewersQuery, reviewersArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer reviewersRows.Close()
-
-	for reviewersRows.Next() {
-		var prID int
-		reviewer := &models.User{}
-
-		err := reviewersRows.Scan(
-			&prID,
-			&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if pr, exists := prsByID[prID]; exists {
-			pr.Reviewers = append(pr.Reviewers, reviewer)
-		}
+	pr.Status = models.PRStatus(status)
+	if mergedAt.Valid {
+		pr.MergedAt = mergedAt.Time
 	}
-
-	if err = reviewersRows.Err(); err != nil {
+	pr.Reviewers = make([]*models.User, 0)
+	if err := json.Unmarshal(reviewers, &pr.Reviewers); err != nil {
 		return nil, err
 	}
-
-	return prs, nil
+	return pr, nil
 }
 
-func (p *PullRequestDataBase) GetByStatus(status models.PRStatus) ([]*models.PullRequest, error) {
-	prsQuery, prsArgs, err := p.sb.
-		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at",
-			"u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("prs p").
-		Join("users u ON p.author_id = u.id").
-		Where(squirrel.Eq{"p.status": string(status)}).
-		OrderBy("p.created_at DESC").
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	prsRows, err := p.db.Query(prsQuery, prsArgs...)
+func (p *PullRequestDataBase) GetByID(id int) (*models.PullRequest, error) {
+	prs, err := p.selectPullRequests(prWithID(id))
 	if err != nil {
 		return nil, err
 	}
-	defer prsRows.Close()
-
-	var prs []*models.PullRequest
-	prsByID := make(map[int]*models.PullRequest)
-
-	for prsRows.Next() {
-		pr := &models.PullRequest{}
-		var statusStr string
-		var mergedAt sql.NullTime
-		author := &models.User{}
-
-		err := prsRows.Scan(
-			&pr.ID, &pr.Name, &statusStr, &pr.CreatedAt, &mergedAt,
-			&author.ID, &author.Name, &author.Email, &author.TeamName, &author.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		pr.Author = author
-		pr.Status = models.PRStatus(statusStr)
-		if mergedAt.Valid {
-			pr.MergedAt = mergedAt.Time
-		}
-		pr.Reviewers = make([]*models.User, 0)
-
-		prs = append(prs, pr)
-		prsByID[pr.ID] = pr
-	}
-
-	if err = prsRows.Err(); err != nil {
-		return nil, err
-	}
-
 	if len(prs) == 0 {
-		return prs, nil
-	}
-
-	prIDs := make([]int, 0, len(prs))
-	for _, pr := range prs {
-		prIDs = append(prIDs, pr.ID)
-	}
-
-	reviewersQuery, reviewersArgs, err := p.sb.
-		Select("ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("assigned_reviewers ar").
-		Join("users u ON ar.user_id = u.id").
-		Where(squirrel.Eq{"ar.pr_id": prIDs}).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	reviewersRows, err := p.db.Query(reviewersQuery, reviewersArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer reviewersRows.Close()
-
-	for reviewersRows.Next() {
-		var prID int
-		reviewer := &models.User{}
-
-		err := reviewersRows.Scan(
-			&prID,
-			&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if pr, exists := prsByID[prID]; exists {
-			pr.Reviewers = append(pr.Reviewers, reviewer)
-		}
+		return nil, repositories.ErrPullRequestNotFoundInPersistence
 	}
+	return prs[0], nil
+}
 
-	if err = reviewersRows.Err(); err != nil {
-		return nil, err
-	}
+func (p *PullRequestDataBase) GetAll() ([]*models.PullRequest, error) {
+	return p.selectPullRequests()
+}
 
-	return prs, nil
+func (p *PullRequestDataBase) GetByStatus(status models.PRStatus) ([]*models.PullRequest, error) {
+	return p.selectPullRequests(prWithStatus(status))
 }
 
 func (p *PullRequestDataBase) GetByAuthorID(authorID int) ([]*models.PullRequest, error) {
-	prsQuery, prsArgs, err := p.sb.
-		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at",
-			"u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("prs p").
-		Join("users u ON p.author_id = u.id").
-		Where(squirrel.Eq{"p.author_id": authorID}).
-		OrderBy("p.created_at DESC").
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	prsRows, err := p.db.Query(prsQuery, prsArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer prsRows.Close()
-
-	var prs []*models.PullRequest
-	prsByID := make(map[int]*models.PullRequest)
-
-	for prsRows.Next() {
-		pr := &models.PullRequest{}
-		var status string
-		var mergedAt sql.NullTime
-		author := &models.User{}
-
-		err := prsRows.Scan(
-			&pr.ID, &pr.Name, &status, &pr.CreatedAt, &mergedAt,
-			&author.ID, &author.Name, &author.Email, &author.TeamName, &author.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		pr.Author = author
-		pr.Status = models.PRStatus(status)
-		if mergedAt.Valid {
-			pr.MergedAt = mergedAt.Time
-		}
-		pr.Reviewers = make([]*models.User, 0)
-
-		prs = append(prs, pr)
-		prsByID[pr.ID] = pr
-	}
-
-	if err = prsRows.Err(); err != nil {
-		return nil, err
-	}
-
-	if len(prs) == 0 {
-		return prs, nil
-	}
-
-	prIDs := make([]int, 0, len(prs))
-	for _, pr := range prs {
-		prIDs = append(prIDs, pr.ID)
-	}
-
-	reviewersQuery, reviewersArgs, err := p.sb.
-		Select("ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("assigned_reviewers ar").
-		Join("users u ON ar.user_id = u.id").
-		Where(squirrel.Eq{"ar.pr_id": prIDs}).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	reviewersRows, err := p.db.Query(reviewersQuery, reviewersArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer reviewersRows.Close()
-
-	for reviewersRows.Next() {
-		var prID int
-		reviewer := &models.User{}
-
-		err := reviewersRows.Scan(
-			&prID,
-			&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if pr, exists := prsByID[prID]; exists {
-			pr.Reviewers = append(pr.Reviewers, reviewer)
-		}
-	}
-
-	if err = reviewersRows.Err(); err != nil {
-		return nil, err
-	}
-
-	return prs, nil
+	return p.selectPullRequests(prByAuthor(authorID))
 }
 
 func (p *PullRequestDataBase) GetByReviewerID(reviewerID int) ([]*models.PullRequest, error) {
-	prsQuery, prsArgs, err := p.sb.
-		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at",
-			"u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("prs p").
-		Join("users u ON p.author_id = u.id").
-		Join("assigned_reviewers ar ON p.id = ar.pr_id").
-		Where(squirrel.Eq{"ar.user_id": reviewerID}).
-		OrderBy("p.created_at DESC").
-		ToSql()
+	return p.selectPullRequests(prReviewedBy(reviewerID))
+}
 
-	if err != nil {
-		return nil, err
+func (p *PullRequestDataBase) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	prsByReviewer := make(map[int][]*models.PullRequest, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return prsByReviewer, nil
 	}
 
-	prsRows, err := p.db.Query(prsQuery, prsArgs...)
+	prs, err := p.selectPullRequests(prReviewedBy(reviewerIDs...))
 	if err != nil {
 		return nil, err
 	}
-	defer prsRows.Close()
-
-	var prs []*models.PullRequest
-	prsByID := make(map[int]*models.PullRequest)
 
-	for prsRows.Next() {
-		pr := &models.PullRequest{}
-		var status string
-		var mergedAt sql.NullTime
-		author := &models.User{}
-
-		err := prsRows.Scan(
-			&pr.ID, &pr.Name, &status, &pr.CreatedAt, &mergedAt,
-			&author.ID, &author.Name, &author.Email, &author.TeamName, &author.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		pr.Author = author
-		pr.Status = models.PRStatus(status)
-		if mergedAt.Valid {
-			pr.MergedAt = mergedAt.Time
-		}
-		pr.Reviewers = make([]*models.User, 0)
-
-		prs = append(prs, pr)
-		prsByID[pr.ID] = pr
-	}
-
-	if err = prsRows.Err(); err != nil {
-		return nil, err
-	}
-
-	if len(prs) == 0 {
-		return prs, nil
+	wanted := make(map[int]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		wanted[id] = true
 	}
-
-	prIDs := make([]int, 0, len(prs))
 	for _, pr := range prs {
-		prIDs = append(prIDs, pr.ID)
-	}
-
-	reviewersQuery, reviewersArgs, err := p.sb.
-		Select("ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active").
-		From("assigned_reviewers ar").
-		Join("users u ON ar.user_id = u.id").
-		Where(squirrel.Eq{"ar.pr_id": prIDs}).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	reviewersRows, err := p.db.Query(reviewersQuery, reviewersArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer reviewersRows.Close()
-
-	for reviewersRows.Next() {
-		var prID int
-		reviewer := &models.User{}
-
-		err := reviewersRows.Scan(
-			&prID,
-			&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if pr, exists := prsByID[prID]; exists {
-			pr.Reviewers = append(pr.Reviewers, reviewer)
+		for _, reviewer := range pr.Reviewers {
+			if wanted[reviewer.ID] {
+				prsByReviewer[reviewer.ID] = append(prsByReviewer[reviewer.ID], pr)
+			}
 		}
 	}
-
-	if err = reviewersRows.Err(); err != nil {
-		return nil, err
-	}
-
-	return prs, nil
+	return prsByReviewer, nil
 }
 
 func (p *PullRequestDataBase) Update(pr *models.PullRequest) error {
@@ -605,36 +277,43 @@ func (p *PullRequestDataBase) Update(pr *models.PullRequest) error {
 		mergedAt = nil
 	}
 
-	updateQuery, updateArgs, err := p.sb.
+	update := p.sb.
 		Update("prs").
 		Set("title", pr.Name).
 		Set("status", string(pr.Status)).
 		Set("merged_at", mergedAt).
-		Where(squirrel.Eq{"id": pr.ID}).
-		ToSql()
+		Set("paths", pq.Array(nonNil(pr.Paths))).
+		Set("labels", pq.Array(nonNil(pr.Labels))).
+		Set("size", pr.Size).
+		Set("version", squirrel.Expr("version + 1")).
+		// A pull request is only written over the version it was read at.
+		Where(squirrel.Eq{"id": pr.ID, "version": pr.Version})
+	updateQuery, updateArgs, err := update.Suffix("RETURNING version").ToSql()
 
 	if err != nil {
 		return err
 	}
 
-	result, err := tx.Exec(updateQuery, updateArgs...)
-	if err != nil {
-		return err
+	var version int
+	err = tx.QueryRow(updateQuery, updateArgs...).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p.missingOrConflict(tx, pr)
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return repositories.ErrPullRequestNotFoundInPersistence
-	}
-
+	// Rows of reviewers that stay are kept so that their assigned_at and reviewed_at survive.
 	if pr.Reviewers != nil {
+		reviewerIDs := make([]int, 0, len(pr.Reviewers))
+		for _, reviewer := range pr.Reviewers {
+			reviewerIDs = append(reviewerIDs, reviewer.ID)
+		}
+
 		deleteQuery, deleteArgs, err := p.sb.
 			Delete("assigned_reviewers").
 			Where(squirrel.Eq{"pr_id": pr.ID}).
+			Where(squirrel.NotEq{"user_id": reviewerIDs}).
 			ToSql()
 
 		if err != nil {
@@ -652,6 +331,7 @@ func (p *PullRequestDataBase) Update(pr *models.PullRequest) error {
 					Insert("assigned_reviewers").
 					Columns("pr_id", "user_id").
 					Values(pr.ID, reviewer.ID).
+					Suffix("ON CONFLICT (pr_id, user_id) DO NOTHING").
 					ToSql()
 
 				if err != nil {
@@ -660,9 +340,6 @@ func (p *PullRequestDataBase) Update(pr *models.PullRequest) error {
 
 				_, err = tx.Exec(reviewerQuery, reviewerArgs...)
 				if err != nil {
-					if err.Error() == "pq: duplicate key value violates unique constraint" {
-						return models.ErrReviewerAlreadyAssigned
-					}
 					if strings.Contains(err.Error(), "violates foreign key constraint") {
 						return repositories.ErrUserNotFoundInPersistence
 					}
@@ -672,12 +349,40 @@ func (p *PullRequestDataBase) Update(pr *models.PullRequest) error {
 		}
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	pr.Version = version
+	return nil
+}
+
+// missingOrConflict tells a pull request that is gone from one that was updated concurrently.
+func (p *PullRequestDataBase) missingOrConflict(tx sqlTx, pr *models.PullRequest) error {
+	existsQuery, existsArgs, err := p.sb.
+		Select("1").
+		From("prs").
+		Where(squirrel.Eq{"id": pr.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	var exists int
+	err = tx.QueryRow(existsQuery, existsArgs...).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repositories.ErrPullRequestNotFoundInPersistence
+	}
+	if err != nil {
+		return err
+	}
+	return models.ErrConflictingUpdate
 }
 
+// FindPossibleReviewers lists the active members of the author's team other than the author, by
+// id; models.RankCandidates orders them for assignment.
 func (p *PullRequestDataBase) FindPossibleReviewers(author *models.User) ([]*models.User, error) {
 	reviewersQuery, reviewersArgs, err := p.sb.
-		Select("u.id", "u.name", "u.email", "u.team_name", "u.is_active").
+		Select("u.id", "u.name", "u.email", "u.team_name", "u.is_active", "u.timezone", "u.work_start_hour", "u.work_end_hour").
 		From("team_members m").
 		Join("users u ON m.user_id = u.id").
 		Where(squirrel.And{
@@ -685,8 +390,8 @@ func (p *PullRequestDataBase) FindPossibleReviewers(author *models.User) ([]*mod
 			squirrel.Eq{"u.is_active": true},
 			squirrel.NotEq{"u.id": author.ID},
 		}).
+		OrderBy("u.id").
 		ToSql()
-
 	if err != nil {
 		return nil, err
 	}
@@ -701,7 +406,8 @@ func (p *PullRequestDataBase) FindPossibleReviewers(author *models.User) ([]*mod
 
 	for reviewersRows.Next() {
 		reviewer := &models.User{}
-		err := reviewersRows.Scan(&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive)
+		err := reviewersRows.Scan(&reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive,
+			&reviewer.WorkingHours.Timezone, &reviewer.WorkingHours.StartHour, &reviewer.WorkingHours.EndHour)
 		if err != nil {
 			return nil, err
 		}
@@ -714,3 +420,10 @@ func (p *PullRequestDataBase) FindPossibleReviewers(author *models.User) ([]*mod
 
 	return reviewers, nil
 }
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"database/sql"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type PullRequestEventDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewPullRequestEventDataBase(db *sql.DB) *PullRequestEventDataBase {
+	return &PullRequestEventDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *PullRequestEventDataBase) Add(event *models.PullRequestEvent) error {
+	query, args, err := r.sb.
+		Insert("pr_events").
+		Columns("pr_id", "kind", "user_id", "previous_user_id", "created_at").
+		Values(event.PullRequestID, string(event.Kind), nullInt(event.UserID), nullInt(event.PreviousUserID), event.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.QueryRow(query, args...).Scan(&event.ID); err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			if strings.Contains(err.Error(), "pr_id") {
+				return repositories.ErrPullRequestNotFoundInPersistence
+			}
+			return repositories.ErrUserNotFoundInPersistence
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PullRequestEventDataBase) GetByPullRequestID(prID int) ([]*models.PullRequestEvent, error) {
+	query, args, err := r.sb.
+		Select("id", "pr_id", "kind", "user_id", "previous_user_id", "created_at").
+		From("pr_events").
+		Where(squirrel.Eq{"pr_id": prID}).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.query(query, args...)
+}
+
+func (r *PullRequestEventDataBase) GetByUserID(userID, afterID int) ([]*models.PullRequestEvent, error) {
+	query, args, err := r.sb.
+		Select("id", "pr_id", "kind", "user_id", "previous_user_id", "created_at").
+		From("pr_events").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Gt{"id": afterID}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.query(query, args...)
+}
+
+func (r *PullRequestEventDataBase) query(query string, args ...interface{}) ([]*models.PullRequestEvent, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*models.PullRequestEvent, 0)
+	for rows.Next() {
+		event := &models.PullRequestEvent{}
+		var kind string
+		var userID, previousUserID sql.NullInt64
+		if err := rows.Scan(&event.ID, &event.PullRequestID, &kind, &userID, &previousUserID, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		event.Kind = models.PullRequestEventKind(kind)
+		event.UserID = int(userID.Int64)
+		event.PreviousUserID = int(previousUserID.Int64)
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
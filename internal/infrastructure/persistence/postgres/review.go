@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ReviewDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewReviewDataBase(db *sql.DB) *ReviewDataBase {
+	return &ReviewDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ReviewDataBase) GetByPullRequestID(prID int) ([]*models.ReviewAssignment, error) {
+	return queryAssignments(r.db, r.sb, squirrel.Eq{"pr_id": prID})
+}
+
+// SetState also fills reviewed_at on the first approval or change request, which stops SLA escalation.
+func (r *ReviewDataBase) SetState(prID, reviewerID int, state models.ReviewState, at time.Time) error {
+	update := r.sb.
+		Update("assigned_reviewers").
+		Set("review_state", string(state)).
+		Set("state_changed_at", at)
+	if state.CountsAsReview() {
+		update = update.Set("reviewed_at", squirrel.Expr("COALESCE(reviewed_at, ?)", at))
+	}
+	query, args, err := update.
+		Where(squirrel.Eq{"pr_id": prID, "user_id": reviewerID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return models.ErrReviewerNotAssigned
+	}
+
+	return nil
+}
+
+func (r *ReviewDataBase) GetApprovals(prID int) (*models.ApprovalStatus, error) {
+	query, args, err := r.sb.
+		Select("COALESCE(tm.required_approvals, 0)", "COUNT(ar.user_id) FILTER (WHERE ar.review_state = 'approved')").
+		From("prs p").
+		LeftJoin("teams tm ON tm.id = p.team_id").
+		LeftJoin("assigned_reviewers ar ON ar.pr_id = p.id").
+		Where(squirrel.Eq{"p.id": prID}).
+		GroupBy("tm.required_approvals").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.ApprovalStatus{}
+	if err := r.db.QueryRow(query, args...).Scan(&status.Required, &status.Approved); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrPullRequestNotFoundInPersistence
+		}
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (r *ReviewDataBase) GetPolicy(teamID int) (*models.ApprovalPolicy, error) {
+	query, args, err := r.sb.
+		Select("required_approvals").
+		From("teams").
+		Where(squirrel.Eq{"id": teamID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &models.ApprovalPolicy{TeamID: teamID}
+	if err := r.db.QueryRow(query, args...).Scan(&policy.RequiredApprovals); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrTeamNotFoundInPersistence
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (r *ReviewDataBase) SavePolicy(policy *models.ApprovalPolicy) error {
+	query, args, err := r.sb.
+		Update("teams").
+		Set("required_approvals", policy.RequiredApprovals).
+		Where(squirrel.Eq{"id": policy.TeamID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repositories.ErrTeamNotFoundInPersistence
+	}
+
+	return nil
+}
+
+func queryAssignments(db sqlConn, sb squirrel.StatementBuilderType, where squirrel.Eq) ([]*models.ReviewAssignment, error) {
+	query, args, err := sb.
+		Select("pr_id", "user_id", "review_state", "assigned_at", "reviewed_at", "state_changed_at").
+		From("assigned_reviewers").
+		Where(where).
+		OrderBy("pr_id", "assigned_at", "user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make([]*models.ReviewAssignment, 0)
+	for rows.Next() {
+		assignment := &models.ReviewAssignment{}
+		var state string
+		var reviewedAt, stateChangedAt sql.NullTime
+		if err := rows.Scan(&assignment.PullRequestID, &assignment.ReviewerID, &state, &assignment.AssignedAt,
+			&reviewedAt, &stateChangedAt); err != nil {
+			return nil, err
+		}
+		assignment.State = models.ReviewState(state)
+		if reviewedAt.Valid {
+			assignment.ReviewedAt = &reviewedAt.Time
+		}
+		if stateChangedAt.Valid {
+			assignment.StateChangedAt = &stateChangedAt.Time
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, rows.Err()
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ReviewLoadDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewReviewLoadDataBase(db *sql.DB) *ReviewLoadDataBase {
+	return &ReviewLoadDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// GetByTeamName sums the reviews assigned to the team's members the same way models.ReviewWeight
+// does, using the half-life of the reviewer's team.
+func (r *ReviewLoadDataBase) GetByTeamName(teamName string, now time.Time) (models.ReviewLoads, error) {
+	query, args, err := r.sb.
+		Select("ar.user_id").
+		Column("SUM(GREATEST(COALESCE(p.size, 1), 1) * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (CAST(? AS timestamp) - p.created_at)), 0) / (tm.review_half_life_hours * 3600.0)))", now).
+		From("assigned_reviewers ar").
+		Join("prs p ON p.id = ar.pr_id").
+		Join("users u ON u.id = ar.user_id").
+		Join("teams tm ON tm.name = u.team_name").
+		Where(squirrel.Eq{"u.team_name": teamName}).
+		GroupBy("ar.user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loads := make(models.ReviewLoads)
+	for rows.Next() {
+		var userID int
+		var load float64
+		if err := rows.Scan(&userID, &load); err != nil {
+			return nil, err
+		}
+		loads[userID] = load
+	}
+
+	return loads, rows.Err()
+}
+
+func (r *ReviewLoadDataBase) GetHalfLife(teamID int) (time.Duration, error) {
+	query, args, err := r.sb.
+		Select("review_half_life_hours").
+		From("teams").
+		Where(squirrel.Eq{"id": teamID}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var hours int
+	if err := r.db.QueryRow(query, args...).Scan(&hours); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repositories.ErrTeamNotFoundInPersistence
+		}
+		return 0, err
+	}
+
+	return time.Duration(hours) * time.Hour, nil
+}
+
+func (r *ReviewLoadDataBase) SetHalfLife(teamID int, halfLife time.Duration) error {
+	query, args, err := r.sb.
+		Update("teams").
+		Set("review_half_life_hours", int(halfLife/time.Hour)).
+		Where(squirrel.Eq{"id": teamID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repositories.ErrTeamNotFoundInPersistence
+	}
+
+	return nil
+}
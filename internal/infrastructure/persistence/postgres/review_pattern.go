@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ReviewPatternDataBase struct {
+	db *sql.DB
+	sb squirrel.StatementBuilderType
+}
+
+func NewReviewPatternDataBase(db *sql.DB) *ReviewPatternDataBase {
+	return &ReviewPatternDataBase{
+		db: db,
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ReviewPatternDataBase) Add(pattern *models.ReviewPattern) error {
+	query, args, err := r.sb.
+		Insert("review_patterns").
+		Columns("team_id", "pattern", "label", "tag").
+		Values(pattern.TeamID, nullString(pattern.Pattern), nullString(pattern.Label), pattern.Tag).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(query, args...).Scan(&pattern.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return repositories.ErrTeamNotFoundInPersistence
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewPatternDataBase) GetByID(id int) (*models.ReviewPattern, error) {
+	query, args, err := r.selectPatterns().
+		Where(squirrel.Eq{"p.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	pattern, err := scanPattern(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrReviewPatternNotFound
+		}
+		return nil, err
+	}
+	return pattern, nil
+}
+
+func (r *ReviewPatternDataBase) GetByTeamID(teamID int) (models.ReviewPatterns, error) {
+	return r.query(r.selectPatterns().Where(squirrel.Eq{"p.team_id": teamID}))
+}
+
+func (r *ReviewPatternDataBase) GetByTeamName(teamName string) (models.ReviewPatterns, error) {
+	return r.query(r.selectPatterns().
+		Join("teams t ON t.id = p.team_id").
+		Where(squirrel.Eq{"t.name": teamName}))
+}
+
+func (r *ReviewPatternDataBase) Delete(id int) error {
+	query, args, err := r.sb.
+		Delete("review_patterns").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repositories.ErrReviewPatternNotFound
+	}
+	return nil
+}
+
+func (r *ReviewPatternDataBase) selectPatterns() squirrel.SelectBuilder {
+	return r.sb.
+		Select("p.id", "p.team_id", "p.pattern", "p.label", "p.tag").
+		From("review_patterns p").
+		OrderBy("p.id")
+}
+
+func (r *ReviewPatternDataBase) query(builder squirrel.SelectBuilder) (models.ReviewPatterns, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	patterns := make(models.ReviewPatterns, 0)
+	for rows.Next() {
+		pattern, err := scanPattern(rows)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, rows.Err()
+}
+
+func scanPattern(row rowScanner) (*models.ReviewPattern, error) {
+	pattern := &models.ReviewPattern{}
+	var path, label sql.NullString
+	if err := row.Scan(&pattern.ID, &pattern.TeamID, &path, &label, &pattern.Tag); err != nil {
+		return nil, err
+	}
+	pattern.Pattern = path.String
+	pattern.Label = label.String
+	return pattern, nil
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ReviewSLADataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewReviewSLADataBase(db *sql.DB) *ReviewSLADataBase {
+	return &ReviewSLADataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ReviewSLADataBase) GetByTeamID(teamID int) (*models.ReviewSLA, error) {
+	query, args, err := r.sb.
+		Select("review_sla_hours", "sla_action").
+		From("teams").
+		Where(squirrel.Eq{"id": teamID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var hours sql.NullInt64
+	var action string
+	if err := r.db.QueryRow(query, args...).Scan(&hours, &action); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrTeamNotFoundInPersistence
+		}
+		return nil, err
+	}
+
+	return &models.ReviewSLA{TeamID: teamID, Hours: int(hours.Int64), Action: models.SLAAction(action)}, nil
+}
+
+func (r *ReviewSLADataBase) Save(sla *models.ReviewSLA) error {
+	query, args, err := r.sb.
+		Update("teams").
+		Set("review_sla_hours", nullInt(sla.Hours)).
+		Set("sla_action", string(sla.Action)).
+		Where(squirrel.Eq{"id": sla.TeamID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repositories.ErrTeamNotFoundInPersistence
+	}
+
+	return nil
+}
+
+func (r *ReviewSLADataBase) FindPending(now time.Time) ([]*models.PendingReview, error) {
+	query, args, err := r.sb.
+		Select("p.id", "p.created_at", "tm.id", "tm.review_sla_hours", "tm.sla_action",
+			"u.timezone", "u.work_start_hour", "u.work_end_hour").
+		From("prs p").
+		Join("teams tm ON tm.id = p.team_id").
+		Join("users u ON u.id = p.author_id").
+		Where(squirrel.Eq{"p.status": string(models.StatusOpen)}).
+		Where("tm.review_sla_hours IS NOT NULL").
+		Where("p.created_at <= CAST(? AS timestamp) - tm.review_sla_hours * interval '1 hour'", now).
+		Where("NOT EXISTS (SELECT 1 FROM assigned_reviewers ar WHERE ar.pr_id = p.id AND "+
+			"(ar.reviewed_at IS NOT NULL OR ar.assigned_at > CAST(? AS timestamp) - tm.review_sla_hours * interval '1 hour'))", now).
+		OrderBy("p.id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []*models.PendingReview
+	byID := make(map[int]*models.PendingReview)
+	for rows.Next() {
+		review := &models.PendingReview{ReviewerHours: make(map[int]models.WorkingHours)}
+		var action string
+		if err := rows.Scan(&review.PullRequestID, &review.CreatedAt, &review.SLA.TeamID, &review.SLA.Hours, &action,
+			&review.AuthorHours.Timezone, &review.AuthorHours.StartHour, &review.AuthorHours.EndHour); err != nil {
+			return nil, err
+		}
+		review.SLA.Action = models.SLAAction(action)
+		pending = append(pending, review)
+		byID[review.PullRequestID] = review
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return pending, nil
+	}
+
+	ids := make([]int, 0, len(pending))
+	for _, review := range pending {
+		ids = append(ids, review.PullRequestID)
+	}
+	assignments, err := queryAssignments(r.db, r.sb, squirrel.Eq{"pr_id": ids})
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return pending, nil
+	}
+
+	reviewerIDs := make([]int, 0, len(assignments))
+	for _, assignment := range assignments {
+		review := byID[assignment.PullRequestID]
+		review.Assignments = append(review.Assignments, assignment)
+		reviewerIDs = append(reviewerIDs, assignment.ReviewerID)
+	}
+	hours, err := r.workingHours(reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		byID[assignment.PullRequestID].ReviewerHours[assignment.ReviewerID] = hours[assignment.ReviewerID]
+	}
+
+	return pending, nil
+}
+
+func (r *ReviewSLADataBase) workingHours(userIDs []int) (map[int]models.WorkingHours, error) {
+	query, args, err := r.sb.
+		Select("id", "timezone", "work_start_hour", "work_end_hour").
+		From("users").
+		Where(squirrel.Eq{"id": userIDs}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := make(map[int]models.WorkingHours, len(userIDs))
+	for rows.Next() {
+		var id int
+		var h models.WorkingHours
+		if err := rows.Scan(&id, &h.Timezone, &h.StartHour, &h.EndHour); err != nil {
+			return nil, err
+		}
+		hours[id] = h
+	}
+	return hours, rows.Err()
+}
+
+func (r *ReviewSLADataBase) GetAssignments(prID int) ([]*models.ReviewAssignment, error) {
+	return queryAssignments(r.db, r.sb, squirrel.Eq{"pr_id": prID})
+}
+
+// MarkReviewed keeps the first review time when a reviewer acknowledges the PR again.
+func (r *ReviewSLADataBase) MarkReviewed(prID, reviewerID int, at time.Time) error {
+	query, args, err := r.sb.
+		Update("assigned_reviewers").
+		Set("reviewed_at", squirrel.Expr("COALESCE(reviewed_at, ?)", at)).
+		Where(squirrel.Eq{"pr_id": prID, "user_id": reviewerID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return models.ErrReviewerNotAssigned
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ReviewerRuleDataBase struct {
+	db *sql.DB
+	sb squirrel.StatementBuilderType
+}
+
+func NewReviewerRuleDataBase(db *sql.DB) *ReviewerRuleDataBase {
+	return &ReviewerRuleDataBase{
+		db: db,
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ReviewerRuleDataBase) Add(rule *models.ReviewerRule) error {
+	query, args, err := r.sb.
+		Insert("reviewer_rules").
+		Columns("team_id", "kind", "reviewer_id", "author_id", "tag", "author_tag").
+		Values(rule.TeamID, string(rule.Kind), nullInt(rule.ReviewerID), nullInt(rule.AuthorID),
+			nullString(rule.Tag), nullString(rule.AuthorTag)).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(query, args...).Scan(&rule.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			if strings.Contains(err.Error(), "team_id") {
+				return repositories.ErrTeamNotFoundInPersistence
+			}
+			return repositories.ErrUserNotFoundInPersistence
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewerRuleDataBase) GetByID(id int) (*models.ReviewerRule, error) {
+	query, args, err := r.selectRules().
+		Where(squirrel.Eq{"r.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := scanRule(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrReviewerRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *ReviewerRuleDataBase) GetByTeamID(teamID int) (models.ReviewerRules, error) {
+	return r.query(r.selectRules().Where(squirrel.Eq{"r.team_id": teamID}))
+}
+
+func (r *ReviewerRuleDataBase) GetByTeamName(teamName string) (models.ReviewerRules, error) {
+	return r.query(r.selectRules().
+		Join("teams t ON t.id = r.team_id").
+		Where(squirrel.Eq{"t.name": teamName}))
+}
+
+func (r *ReviewerRuleDataBase) Delete(id int) error {
+	query, args, err := r.sb.
+		Delete("reviewer_rules").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repositories.ErrReviewerRuleNotFound
+	}
+	return nil
+}
+
+func (r *ReviewerRuleDataBase) selectRules() squirrel.SelectBuilder {
+	return r.sb.
+		Select("r.id", "r.team_id", "r.kind", "r.reviewer_id", "r.author_id", "r.tag", "r.author_tag").
+		From("reviewer_rules r").
+		OrderBy("r.id")
+}
+
+func (r *ReviewerRuleDataBase) query(builder squirrel.SelectBuilder) (models.ReviewerRules, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make(models.ReviewerRules, 0)
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func scanRule(row rowScanner) (*models.ReviewerRule, error) {
+	rule := &models.ReviewerRule{}
+	var kind string
+	var reviewerID, authorID sql.NullInt64
+	var tag, authorTag sql.NullString
+	if err := row.Scan(&rule.ID, &rule.TeamID, &kind, &reviewerID, &authorID, &tag, &authorTag); err != nil {
+		return nil, err
+	}
+	rule.Kind = models.RuleKind(kind)
+	rule.ReviewerID = int(reviewerID.Int64)
+	rule.AuthorID = int(authorID.Int64)
+	rule.Tag = tag.String
+	rule.AuthorTag = authorTag.String
+	return rule, nil
+}
+
+func nullInt(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
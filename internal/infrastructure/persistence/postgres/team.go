@@ -11,13 +11,13 @@ import (
 )
 
 type TeamDataBase struct {
-	db *sql.DB
+	db sqlConn
 	sb squirrel.StatementBuilderType
 }
 
 func NewTeamDataBase(db *sql.DB) *TeamDataBase {
 	return &TeamDataBase{
-		db: db,
+		db: dbConn{db},
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
@@ -27,7 +27,7 @@ func (t *TeamDataBase) Add(team *models.Team) error {
 	if err != nil {
 		return err
 	}
-	defer func(tx *sql.Tx) {
+	defer func(tx sqlTx) {
 		err := tx.Rollback()
 		if err != nil {
 			panic(err)
@@ -38,13 +38,13 @@ func (t *TeamDataBase) Add(team *models.Team) error {
 		Insert("teams").
 		Columns("name").
 		Values(team.Name).
-		Suffix("RETURNING id").
+		Suffix("RETURNING id, version").
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	err = tx.QueryRow(query, args...).Scan(&team.ID)
+	err = tx.QueryRow(query, args...).Scan(&team.ID, &team.Version)
 	if err != nil {
 		if err.Error() == "pq: duplicate key value violates unique constraint" {
 			return repositories.ErrTeamAlreadyExists
@@ -100,7 +100,7 @@ func (t *TeamDataBase) Add(team *models.Team) error {
 
 func (t *TeamDataBase) GetByID(id int) (*models.Team, error) {
 	query, args, err := t.sb.
-		Select("id", "name").
+		Select("id", "name", "version").
 		From("teams").
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
@@ -109,7 +109,7 @@ func (t *TeamDataBase) GetByID(id int) (*models.Team, error) {
 		return nil, err
 	}
 	team := &models.Team{Members: make(map[int]*models.TeamMember)}
-	err = t.db.QueryRow(query, args...).Scan(&team.ID, &team.Name)
+	err = t.db.QueryRow(query, args...).Scan(&team.ID, &team.Name, &team.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repositories.ErrTeamNotFoundInPersistence
@@ -155,7 +155,7 @@ func (t *TeamDataBase) GetByID(id int) (*models.Team, error) {
 
 func (t *TeamDataBase) GetByName(name string) (*models.Team, error) {
 	query, args, err := t.sb.
-		Select("id", "name").
+		Select("id", "name", "version").
 		From("teams").
 		Where(squirrel.Eq{"name": name}).
 		ToSql()
@@ -164,7 +164,7 @@ func (t *TeamDataBase) GetByName(name string) (*models.Team, error) {
 		return nil, err
 	}
 	team := &models.Team{Members: make(map[int]*models.TeamMember)}
-	err = t.db.QueryRow(query, args...).Scan(&team.ID, &team.Name)
+	err = t.db.QueryRow(query, args...).Scan(&team.ID, &team.Name, &team.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repositories.ErrTeamNotFoundInPersistence
@@ -210,7 +210,7 @@ func (t *TeamDataBase) GetByName(name string) (*models.Team, error) {
 
 func (t *TeamDataBase) GetAll() ([]*models.Team, error) {
 	teamsQuery, teamsArgs, err := t.sb.
-		Select("id", "name").
+		Select("id", "name", "version").
 		From("teams").
 		ToSql()
 
@@ -231,7 +231,7 @@ func (t *TeamDataBase) GetAll() ([]*models.Team, error) {
 		team := &models.Team{
 			Members: make(map[int]*models.TeamMember),
 		}
-		err := teamsRows.Scan(&team.ID, &team.Name)
+		err := teamsRows.Scan(&team.ID, &team.Name, &team.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -299,17 +299,23 @@ func (t *TeamDataBase) Update(team *models.Team) error {
 	}
 	defer tx.Rollback()
 
-	query, args, err := t.sb.
+	update := t.sb.
 		Update("teams").
 		Set("name", team.Name).
-		Where(squirrel.Eq{"id": team.ID}).
-		ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		// A team is only written over the version it was read at.
+		Where(squirrel.Eq{"id": team.ID, "version": team.Version})
+	query, args, err := update.Suffix("RETURNING version").ToSql()
 
 	if err != nil {
 		return err
 	}
 
-	result, err := tx.Exec(query, args...)
+	var version int
+	err = tx.QueryRow(query, args...).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return t.missingOrConflict(tx, team)
+	}
 	if err != nil {
 		if err.Error() == "pq: duplicate key value violates unique constraint" {
 			return repositories.ErrTeamAlreadyExists
@@ -317,15 +323,6 @@ func (t *TeamDataBase) Update(team *models.Team) error {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rowsAffected == 0 {
-		return repositories.ErrTeamNotFoundInPersistence
-	}
-
 	if team.Members != nil {
 		deleteQuery, deleteArgs, err := t.sb.
 			Delete("team_members").
@@ -366,7 +363,33 @@ func (t *TeamDataBase) Update(team *models.Team) error {
 		}
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	team.Version = version
+	return nil
+}
+
+// missingOrConflict tells a team that is gone from one that was updated concurrently.
+func (t *TeamDataBase) missingOrConflict(tx sqlTx, team *models.Team) error {
+	existsQuery, existsArgs, err := t.sb.
+		Select("1").
+		From("teams").
+		Where(squirrel.Eq{"id": team.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	var exists int
+	err = tx.QueryRow(existsQuery, existsArgs...).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repositories.ErrTeamNotFoundInPersistence
+	}
+	if err != nil {
+		return err
+	}
+	return models.ErrConflictingUpdate
 }
 
 func (t *TeamDataBase) AddUserToTeam(teamID, userID int) error {
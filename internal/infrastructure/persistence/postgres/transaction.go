@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"database/sql"
+	"reviewer-assignment-service/internal/domain/repositories"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type TransactionManager struct {
+	db *sql.DB
+}
+
+func NewTransactionManager(db *sql.DB) *TransactionManager {
+	return &TransactionManager{db: db}
+}
+
+func (m *TransactionManager) WithinTransaction(fn func(repos repositories.Repositories) error) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	sb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	repos := repositories.Repositories{
+		Users: &UserDataBase{db: txConn{tx}, sb: sb},
+		Teams: &TeamDataBase{db: txConn{tx}, sb: sb},
+
+		PullRequests:         &PullRequestDataBase{db: txConn{tx}, sb: sb},
+		ExternalPullRequests: &ExternalPullRequestDataBase{db: txConn{tx}, sb: sb},
+	}
+
+	if err := fn(repos); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
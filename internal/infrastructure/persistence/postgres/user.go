@@ -10,13 +10,13 @@ import (
 )
 
 type UserDataBase struct {
-	db *sql.DB
+	db sqlConn
 	sb squirrel.StatementBuilderType
 }
 
 func NewUserDataBase(db *sql.DB) *UserDataBase {
 	return &UserDataBase{
-		db: db,
+		db: dbConn{db},
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
@@ -32,10 +32,15 @@ func (u *UserDataBase) Add(user *models.User) error {
 		}
 	}()
 
+	if user.WorkingHours.EndHour == 0 {
+		user.WorkingHours = models.DefaultWorkingHours
+	}
+
 	query, args, err := u.sb.
 		Insert("users").
-		Columns("name", "email", "team_name", "is_active").
-		Values(user.Name, user.Email, user.TeamName, user.IsActive).
+		Columns("name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
+		Values(user.Name, user.Email, user.TeamName, user.IsActive,
+			user.WorkingHours.Timezone, user.WorkingHours.StartHour, user.WorkingHours.EndHour).
 		Suffix("RETURNING id").
 		ToSql()
 
@@ -56,7 +61,7 @@ func (u *UserDataBase) Add(user *models.User) error {
 
 func (u *UserDataBase) GetByID(id int) (*models.User, error) {
 	query, args, err := u.sb.
-		Select("id", "name", "email", "team_name", "is_active").
+		Select("id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
 		From("users").
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
@@ -68,6 +73,7 @@ func (u *UserDataBase) GetByID(id int) (*models.User, error) {
 	user := &models.User{}
 	err = u.db.QueryRow(query, args...).Scan(
 		&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive,
+		&user.WorkingHours.Timezone, &user.WorkingHours.StartHour, &user.WorkingHours.EndHour,
 	)
 
 	if err != nil {
@@ -80,9 +86,47 @@ func (u *UserDataBase) GetByID(id int) (*models.User, error) {
 	return user, nil
 }
 
+func (u *UserDataBase) GetByIDs(ids []int) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return []*models.User{}, nil
+	}
+
+	query, args, err := u.sb.
+		Select("id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
+		From("users").
+		Where(squirrel.Eq{"id": ids}).
+		OrderBy("id").
+		ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0, len(ids))
+	for rows.Next() {
+		user := &models.User{}
+		err := rows.Scan(
+			&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive,
+			&user.WorkingHours.Timezone, &user.WorkingHours.StartHour, &user.WorkingHours.EndHour,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
 func (u *UserDataBase) GetByEmail(email string) (*models.User, error) {
 	query, args, err := u.sb.
-		Select("id", "name", "email", "team_name", "is_active").
+		Select("id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
 		From("users").
 		Where(squirrel.Eq{"email": email}).
 		ToSql()
@@ -94,6 +138,7 @@ func (u *UserDataBase) GetByEmail(email string) (*models.User, error) {
 	user := &models.User{}
 	err = u.db.QueryRow(query, args...).Scan(
 		&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive,
+		&user.WorkingHours.Timezone, &user.WorkingHours.StartHour, &user.WorkingHours.EndHour,
 	)
 
 	if err != nil {
@@ -107,7 +152,7 @@ func (u *UserDataBase) GetByEmail(email string) (*models.User, error) {
 
 func (u *UserDataBase) GetAll() ([]*models.User, error) {
 	query, args, err := u.sb.
-		Select("id", "name", "email", "team_name", "is_active").
+		Select("id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
 		From("users").
 		ToSql()
 
@@ -131,6 +176,7 @@ func (u *UserDataBase) GetAll() ([]*models.User, error) {
 		user := &models.User{}
 		err := rows.Scan(
 			&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive,
+			&user.WorkingHours.Timezone, &user.WorkingHours.StartHour, &user.WorkingHours.EndHour,
 		)
 		if err != nil {
 			return nil, err
@@ -143,7 +189,7 @@ func (u *UserDataBase) GetAll() ([]*models.User, error) {
 
 func (u *UserDataBase) GetWithFilters(teamName string, isActive bool) ([]*models.User, error) {
 	builder := u.sb.
-		Select("id", "name", "email", "team_name", "is_active").
+		Select("id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
 		From("users")
 
 	if teamName != "" {
@@ -171,7 +217,8 @@ func (u *UserDataBase) GetWithFilters(teamName string, isActive bool) ([]*models
 	var users []*models.User
 	for rows.Next() {
 		user := &models.User{}
-		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive)
+		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive,
+			&user.WorkingHours.Timezone, &user.WorkingHours.StartHour, &user.WorkingHours.EndHour)
 		if err != nil {
 			return nil, err
 		}
@@ -187,7 +234,7 @@ func (u *UserDataBase) GetWithFilters(teamName string, isActive bool) ([]*models
 
 func (u *UserDataBase) GetActiveUsers() ([]*models.User, error) {
 	query, args, err := u.sb.
-		Select("id", "name", "email", "team_name", "is_active").
+		Select("id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour").
 		From("users").
 		Where(squirrel.Eq{"is_active": true}).
 		ToSql()
@@ -212,6 +259,7 @@ func (u *UserDataBase) GetActiveUsers() ([]*models.User, error) {
 		user := &models.User{}
 		err := rows.Scan(
 			&user.ID, &user.Name, &user.Email, &user.TeamName, &user.IsActive,
+			&user.WorkingHours.Timezone, &user.WorkingHours.StartHour, &user.WorkingHours.EndHour,
 		)
 		if err != nil {
 			return nil, err
@@ -304,3 +352,32 @@ func (u *UserDataBase) Deactivate(userID int) error {
 
 	return tx.Commit()
 }
+
+// SetWorkingHours is separate from Update because callers such as org sync update users they
+// have not loaded.
+func (u *UserDataBase) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	query, args, err := u.sb.
+		Update("users").
+		Set("timezone", hours.Timezone).
+		Set("work_start_hour", hours.StartHour).
+		Set("work_end_hour", hours.EndHour).
+		Where(squirrel.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := u.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repositories.ErrUserNotFoundInPersistence
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type UserIdentityDataBase struct {
+	db *sql.DB
+	sb squirrel.StatementBuilderType
+}
+
+func NewUserIdentityDataBase(db *sql.DB) *UserIdentityDataBase {
+	return &UserIdentityDataBase{
+		db: db,
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (u *UserIdentityDataBase) Add(identity *models.UserIdentity) error {
+	query, args, err := u.sb.
+		Insert("user_identities").
+		Columns("provider", "login", "user_id").
+		Values(string(identity.Provider), identity.Login, identity.UserID).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = u.db.QueryRow(query, args...).Scan(&identity.ID)
+	if err != nil {
+		return identityWriteError(err)
+	}
+
+	return nil
+}
+
+func (u *UserIdentityDataBase) GetByID(id int) (*models.UserIdentity, error) {
+	query, args, err := u.selectIdentities().
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.scanOne(query, args)
+}
+
+func (u *UserIdentityDataBase) GetByUserID(userID int) ([]*models.UserIdentity, error) {
+	return u.GetByUserIDs([]int{userID})
+}
+
+func (u *UserIdentityDataBase) GetByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	if len(userIDs) == 0 {
+		return []*models.UserIdentity{}, nil
+	}
+
+	query, args, err := u.selectIdentities().
+		Where(squirrel.Eq{"user_id": userIDs}).
+		OrderBy("user_id", "provider").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := make([]*models.UserIdentity, 0)
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+func (u *UserIdentityDataBase) GetByLogin(provider models.VCSProvider, login string) (*models.UserIdentity, error) {
+	query, args, err := u.selectIdentities().
+		Where(squirrel.Eq{"provider": string(provider)}).
+		Where("lower(login) = lower(?)", login).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.scanOne(query, args)
+}
+
+func (u *UserIdentityDataBase) Update(identity *models.UserIdentity) error {
+	query, args, err := u.sb.
+		Update("user_identities").
+		Set("provider", string(identity.Provider)).
+		Set("login", identity.Login).
+		Where(squirrel.Eq{"id": identity.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := u.db.Exec(query, args...)
+	if err != nil {
+		return identityWriteError(err)
+	}
+
+	return identityAffected(result)
+}
+
+func (u *UserIdentityDataBase) Delete(id int) error {
+	query, args, err := u.sb.
+		Delete("user_identities").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := u.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return identityAffected(result)
+}
+
+func (u *UserIdentityDataBase) selectIdentities() squirrel.SelectBuilder {
+	return u.sb.
+		Select("id", "provider", "login", "user_id").
+		From("user_identities")
+}
+
+func (u *UserIdentityDataBase) scanOne(query string, args []interface{}) (*models.UserIdentity, error) {
+	identity, err := scanIdentity(u.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositories.ErrUserIdentityNotFound
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanIdentity(row rowScanner) (*models.UserIdentity, error) {
+	identity := &models.UserIdentity{}
+	var provider string
+	if err := row.Scan(&identity.ID, &provider, &identity.Login, &identity.UserID); err != nil {
+		return nil, err
+	}
+	identity.Provider = models.VCSProvider(provider)
+	return identity, nil
+}
+
+func identityAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repositories.ErrUserIdentityNotFound
+	}
+	return nil
+}
+
+func identityWriteError(err error) error {
+	if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+		return repositories.ErrUserIdentityAlreadyExists
+	}
+	if strings.Contains(err.Error(), "violates foreign key constraint") {
+		return repositories.ErrUserNotFoundInPersistence
+	}
+	return err
+}
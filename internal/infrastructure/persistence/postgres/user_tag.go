@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"database/sql"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type UserTagDataBase struct {
+	db sqlConn
+	sb squirrel.StatementBuilderType
+}
+
+func NewUserTagDataBase(db *sql.DB) *UserTagDataBase {
+	return &UserTagDataBase{
+		db: dbConn{db},
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (u *UserTagDataBase) GetByUserID(userID int) ([]string, error) {
+	tags, err := u.GetByUserIDs([]int{userID})
+	if err != nil {
+		return nil, err
+	}
+	if tags[userID] == nil {
+		return []string{}, nil
+	}
+	return tags[userID], nil
+}
+
+func (u *UserTagDataBase) GetByUserIDs(userIDs []int) (map[int][]string, error) {
+	tags := make(map[int][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return tags, nil
+	}
+
+	query, args, err := u.sb.
+		Select("user_id", "tag").
+		From("user_tags").
+		Where(squirrel.Eq{"user_id": userIDs}).
+		OrderBy("user_id", "tag").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var tag string
+		if err := rows.Scan(&userID, &tag); err != nil {
+			return nil, err
+		}
+		tags[userID] = append(tags[userID], tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (u *UserTagDataBase) Replace(userID int, tags []string) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := u.sb.
+		Delete("user_tags").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	if len(tags) > 0 {
+		insert := u.sb.Insert("user_tags").Columns("user_id", "tag")
+		for _, tag := range tags {
+			insert = insert.Values(userID, tag)
+		}
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(query, args...); err != nil {
+			if strings.Contains(err.Error(), "violates foreign key constraint") {
+				return repositories.ErrUserNotFoundInPersistence
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
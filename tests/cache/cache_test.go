@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/cache"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTeams struct {
+	repositories.TeamRepository
+	teams map[string]*models.Team
+	reads int
+	// onRead runs inside every read, after it has counted and before it returns.
+	onRead func()
+}
+
+func (r *countingTeams) GetByName(name string) (*models.Team, error) {
+	r.reads++
+	if r.onRead != nil {
+		r.onRead()
+	}
+	team, ok := r.teams[name]
+	if !ok {
+		return nil, repositories.ErrTeamNotFoundInPersistence
+	}
+	return team, nil
+}
+
+func (r *countingTeams) GetByID(id int) (*models.Team, error) {
+	for _, team := range r.teams {
+		if team.ID == id {
+			return r.GetByName(team.Name)
+		}
+	}
+	r.reads++
+	return nil, repositories.ErrTeamNotFoundInPersistence
+}
+
+func (r *countingTeams) Update(*models.Team) error              { return nil }
+func (r *countingTeams) AddUserToTeam(teamID, userID int) error { return nil }
+func (r *countingTeams) RemoveUserFromTeam(teamID, userID int) error {
+	return repositories.ErrUserNotInTeam
+}
+
+type countingCandidates struct {
+	repositories.PullRequestRepository
+	users []*models.User
+	reads int
+}
+
+func (r *countingCandidates) FindPossibleReviewers(author *models.User) ([]*models.User, error) {
+	r.reads++
+	var reviewers []*models.User
+	for _, user := range r.users {
+		if user.TeamName == author.TeamName && user.IsActive && user.ID != author.ID {
+			reviewers = append(reviewers, user)
+		}
+	}
+	return reviewers, nil
+}
+
+type noopUsers struct {
+	repositories.UserRepository
+}
+
+func (noopUsers) Update(*models.User) error { return nil }
+func (noopUsers) Deactivate(int) error      { return nil }
+
+type failingTransactions struct{}
+
+func (failingTransactions) WithinTransaction(func(repositories.Repositories) error) error {
+	return errors.New("rolled back")
+}
+
+func backendTeam() *models.Team {
+	team := models.NewTeam("backend")
+	team.ID = 1
+	team.Members[1] = models.NewTeamMember(1, "alice", true)
+	team.Members[2] = models.NewTeamMember(2, "bob", true)
+	return team
+}
+
+func stats(c *cache.Cache, name string) models.CacheStats {
+	for _, s := range c.Stats() {
+		if s.Name == name {
+			return s
+		}
+	}
+	return models.CacheStats{}
+}
+
+func TestLRU_EvictsLeastRecentlyUsedAndExpired(t *testing.T) {
+	clk := fakeclock.New(fakeclock.Monday)
+	lru := cache.NewLRU[int](2, time.Minute, clk)
+
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+	_, _ = lru.Get("a")
+	lru.Set("c", 3)
+
+	_, ok := lru.Get("b")
+	assert.False(t, ok, "b was used least recently")
+	value, ok := lru.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	clk.Advance(time.Minute)
+	_, ok = lru.Get("c")
+	assert.False(t, ok, "entries expire after the ttl")
+	assert.Equal(t, 1, lru.Len())
+
+	disabled := cache.NewLRU[int](0, time.Minute, clk)
+	disabled.Set("a", 1)
+	assert.Equal(t, 0, disabled.Len())
+}
+
+func TestTeamRepository_ReadsThroughAndHandsOutCopies(t *testing.T) {
+	c := cache.NewInProcess(10, time.Minute, fakeclock.New(fakeclock.Monday))
+	next := &countingTeams{teams: map[string]*models.Team{"backend": backendTeam()}}
+	teams := cache.NewTeamRepository(next, c)
+
+	first, err := teams.GetByName("backend")
+	require.NoError(t, err)
+	first.Members[1].UpdateIsActive(false)
+	delete(first.Members, 2)
+
+	second, err := teams.GetByName("backend")
+	require.NoError(t, err)
+	assert.Equal(t, backendTeam(), second, "changes to a returned team do not reach the cache")
+	assert.Equal(t, 1, next.reads)
+
+	_, err = teams.GetByID(1)
+	require.NoError(t, err)
+	_, err = teams.GetByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.reads, "teams are cached by id and by name separately")
+
+	assert.Equal(t, models.CacheStats{Name: "teams", Hits: 2, Misses: 2, Entries: 2}, stats(c, "teams"))
+}
+
+func TestTeamRepository_DoesNotCacheErrors(t *testing.T) {
+	c := cache.NewInProcess(10, time.Minute, fakeclock.New(fakeclock.Monday))
+	next := &countingTeams{teams: map[string]*models.Team{}}
+	teams := cache.NewTeamRepository(next, c)
+
+	_, err := teams.GetByName("backend")
+	assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+
+	next.teams["backend"] = backendTeam()
+	team, err := teams.GetByName("backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.Name)
+	assert.Equal(t, 2, next.reads)
+}
+
+func TestCache_InvalidatedByWrites(t *testing.T) {
+	writes := map[string]func(c *cache.Cache, teams repositories.TeamRepository) error{
+		"team update":           func(_ *cache.Cache, teams repositories.TeamRepository) error { return teams.Update(backendTeam()) },
+		"member added":          func(_ *cache.Cache, teams repositories.TeamRepository) error { return teams.AddUserToTeam(1, 3) },
+		"failed member removal": func(_ *cache.Cache, teams repositories.TeamRepository) error { return teams.RemoveUserFromTeam(1, 3) },
+		"user deactivated": func(c *cache.Cache, _ repositories.TeamRepository) error {
+			return cache.NewUserRepository(noopUsers{}, c).Deactivate(2)
+		},
+		"user updated": func(c *cache.Cache, _ repositories.TeamRepository) error {
+			return cache.NewUserRepository(noopUsers{}, c).Update(&models.User{ID: 2})
+		},
+		"transaction": func(c *cache.Cache, _ repositories.TeamRepository) error {
+			return cache.NewTransactionManager(failingTransactions{}, c).WithinTransaction(nil)
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			c := cache.NewInProcess(10, time.Minute, fakeclock.New(fakeclock.Monday))
+			next := &countingTeams{teams: map[string]*models.Team{"backend": backendTeam()}}
+			teams := cache.NewTeamRepository(next, c)
+			candidates := cache.NewCandidateRepository(&countingCandidates{}, c)
+
+			_, err := teams.GetByName("backend")
+			require.NoError(t, err)
+			_, err = candidates.FindPossibleReviewers(&models.User{ID: 1, TeamName: "backend"})
+			require.NoError(t, err)
+
+			_ = write(c, teams)
+
+			for _, s := range c.Stats() {
+				assert.Zero(t, s.Entries, s.Name)
+			}
+			_, err = teams.GetByName("backend")
+			require.NoError(t, err)
+			assert.Equal(t, 2, next.reads)
+		})
+	}
+}
+
+func TestCache_DoesNotStoreWhatWasReadBeforeAnInvalidation(t *testing.T) {
+	c := cache.NewInProcess(10, time.Minute, fakeclock.New(fakeclock.Monday))
+	next := &countingTeams{teams: map[string]*models.Team{"backend": backendTeam()}}
+	next.onRead = func() {
+		// A write commits while this read is on its way back from the database.
+		next.onRead = nil
+		c.Invalidate()
+	}
+	teams := cache.NewTeamRepository(next, c)
+
+	_, err := teams.GetByName("backend")
+	require.NoError(t, err)
+	_, err = teams.GetByName("backend")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, next.reads)
+	assert.Equal(t, 1, stats(c, "teams").Entries)
+}
+
+func TestCandidateRepository_SharesOneEntryPerTeam(t *testing.T) {
+	clk := fakeclock.New(fakeclock.Monday)
+	c := cache.NewInProcess(10, time.Minute, clk)
+	alice := &models.User{ID: 1, Name: "alice", TeamName: "backend", IsActive: true}
+	bob := &models.User{ID: 2, Name: "bob", TeamName: "backend", IsActive: true}
+	carol := &models.User{ID: 3, Name: "carol", TeamName: "backend", IsActive: true}
+	next := &countingCandidates{users: []*models.User{alice, bob, carol}}
+	prs := cache.NewPullRequestRepository(next, c)
+
+	reviewers, err := prs.FindPossibleReviewers(alice)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{bob, carol}, reviewers)
+
+	reviewers, err = prs.FindPossibleReviewers(bob)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{alice, carol}, reviewers)
+	reviewers[0].UpdateIsActive(false)
+	assert.True(t, alice.IsActive, "returned reviewers are copies")
+
+	assert.Equal(t, 1, next.reads)
+	assert.Equal(t, models.CacheStats{Name: "candidates", Hits: 1, Misses: 1, Entries: 1}, stats(c, "candidates"))
+
+	clk.Advance(time.Minute)
+	_, err = prs.FindPossibleReviewers(carol)
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.reads, "entries are reloaded after the ttl")
+}
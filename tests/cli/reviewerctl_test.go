@@ -0,0 +1,362 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reviewer-assignment-service/internal/cli"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResponse struct {
+	status int
+	body   string
+}
+
+type recordedRequest struct {
+	method  string
+	path    string
+	body    string
+	ifMatch string
+}
+
+type stubServer struct {
+	*httptest.Server
+	routes map[string]stubResponse
+	// etags are sent as the ETag of the answers to the same routes.
+	etags    map[string]string
+	requests []recordedRequest
+}
+
+func newStubServer(t *testing.T, routes map[string]stubResponse) *stubServer {
+	t.Helper()
+
+	s := &stubServer{routes: routes}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.requests = append(s.requests, recordedRequest{
+			method: r.Method, path: r.URL.RequestURI(), body: string(body), ifMatch: r.Header.Get("If-Match"),
+		})
+
+		route := r.Method + " " + r.URL.RequestURI()
+		resp, ok := s.routes[route]
+		if !ok {
+			resp = stubResponse{http.StatusNotFound, `{"error":{"code":"NOT_FOUND","message":"no stub"}}`}
+		}
+		if etag, ok := s.etags[route]; ok {
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.status)
+		io.WriteString(w, resp.body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+func run(t *testing.T, env map[string]string, args ...string) result {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(args, &stdout, &stderr, func(key string) string { return env[key] })
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func urlEnv(s *stubServer) map[string]string {
+	return map[string]string{"REVIEWERCTL_URL": s.URL}
+}
+
+const (
+	usersBody = `{"users":[` +
+		`{"user_id":1,"username":"alice","team_name":"backend","is_active":true},` +
+		`{"user_id":2,"username":"bob","team_name":"platform","is_active":false}]}`
+	teamBody = `{"id":3,"name":"backend","members":[{"user_id":1,"username":"alice","is_active":true}]}`
+	prBody   = `{"id":9,"name":"Feature","status":"OPEN","author":{"user_id":1,"username":"alice","team_name":"backend","is_active":true},` +
+		`"reviewers":[{"user_id":2,"username":"bob","team_name":"backend","is_active":true}],"created_at":"2025-11-01T12:00:00Z"}`
+)
+
+func TestUsersList(t *testing.T) {
+	server := newStubServer(t, map[string]stubResponse{
+		"GET /users": {http.StatusOK, usersBody},
+	})
+
+	t.Run("table filtered by team", func(t *testing.T) {
+		res := run(t, urlEnv(server), "users", "list", "--team", "backend")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		lines := strings.Split(strings.TrimSpace(res.stdout), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"ID", "USERNAME", "TEAM", "ACTIVE"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"1", "alice", "backend", "true"}, strings.Fields(lines[1]))
+	})
+
+	t.Run("json from env", func(t *testing.T) {
+		env := urlEnv(server)
+		env["REVIEWERCTL_OUTPUT"] = "json"
+
+		res := run(t, env, "users", "list")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		var decoded struct {
+			Users []map[string]interface{} `json:"users"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(res.stdout), &decoded))
+		assert.Len(t, decoded.Users, 2)
+	})
+
+	t.Run("flag overrides env", func(t *testing.T) {
+		env := map[string]string{"REVIEWERCTL_URL": "http://127.0.0.1:1", "REVIEWERCTL_OUTPUT": "json"}
+
+		res := run(t, env, "--url", server.URL, "users", "list", "-o", "table")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.True(t, strings.HasPrefix(res.stdout, "ID"))
+	})
+}
+
+func TestUsersCreateAndDeactivate(t *testing.T) {
+	server := newStubServer(t, map[string]stubResponse{
+		"POST /users":            {http.StatusCreated, `{"user":{"user_id":5,"username":"carol","team_name":"backend","is_active":false}}`},
+		"POST /users/deactivate": {http.StatusNotFound, `{"error":{"code":"USER_NOT_FOUND","message":"User not found"}}`},
+	})
+
+	res := run(t, urlEnv(server), "users", "create", "--name", "carol", "--email", "carol@example.com", "--team", "backend", "--inactive")
+	require.Equal(t, cli.ExitOK, res.code, res.stderr)
+	assert.JSONEq(t, `{"username":"carol","email":"carol@example.com","team_name":"backend","is_active":false}`, server.requests[0].body)
+	assert.Contains(t, res.stdout, "carol")
+
+	res = run(t, urlEnv(server), "users", "deactivate", "42")
+	assert.Equal(t, 10, res.code)
+	assert.Contains(t, res.stderr, "USER_NOT_FOUND")
+	assert.JSONEq(t, `{"user_id":42}`, server.requests[1].body)
+}
+
+func TestUsersMove(t *testing.T) {
+	server := newStubServer(t, map[string]stubResponse{
+		"POST /users/2/move": {http.StatusOK, `{"user":{"user_id":2,"username":"bob","team_name":"frontend","is_active":true},` +
+			`"from_team":"backend","to_team":"frontend","reviews":[{"pull_request_id":9,"from_user_id":2,"to_user_id":3,"outcome":"reassigned"}]}`},
+	})
+
+	res := run(t, urlEnv(server), "users", "move", "2", "--team", "frontend", "--reassign-reviews")
+
+	require.Equal(t, cli.ExitOK, res.code, res.stderr)
+	assert.JSONEq(t, `{"team_name":"frontend","reassign_reviews":true}`, server.requests[0].body)
+	assert.Contains(t, res.stdout, "reassigned")
+
+	res = run(t, urlEnv(server), "users", "move", "2")
+	assert.Equal(t, cli.ExitUsage, res.code)
+}
+
+func TestTeams(t *testing.T) {
+	server := newStubServer(t, map[string]stubResponse{
+		"GET /teams/by-name/backend": {http.StatusOK, teamBody},
+		"GET /teams/3":               {http.StatusOK, teamBody},
+		"GET /users/2":               {http.StatusOK, `{"user":{"user_id":2,"username":"bob","team_name":"backend","is_active":true}}`},
+		"PUT /teams/3": {http.StatusOK, `{"id":3,"name":"backend","members":[` +
+			`{"user_id":1,"username":"alice","is_active":true},{"user_id":2,"username":"bob","is_active":true}]}`},
+	})
+	server.etags = map[string]string{"GET /teams/3": `"4"`}
+
+	t.Run("show by name", func(t *testing.T) {
+		res := run(t, urlEnv(server), "teams", "show", "backend")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.Contains(t, res.stdout, "alice")
+	})
+
+	t.Run("add member", func(t *testing.T) {
+		server.requests = nil
+
+		res := run(t, urlEnv(server), "teams", "add-member", "3", "--user", "2")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		put := server.requests[len(server.requests)-1]
+		assert.Equal(t, http.MethodPut, put.method)
+		assert.Equal(t, `"4"`, put.ifMatch, "the update is based on the team just read")
+		assert.JSONEq(t, `{"name":"backend","members":[`+
+			`{"user_id":1,"username":"alice","is_active":true},{"user_id":2,"username":"bob","is_active":true}]}`, put.body)
+	})
+
+	t.Run("add existing member", func(t *testing.T) {
+		res := run(t, urlEnv(server), "teams", "add-member", "backend", "--user", "1")
+
+		assert.Equal(t, 26, res.code)
+		assert.Contains(t, res.stderr, "MEMBER_ALREADY_IN_TEAM")
+	})
+}
+
+func TestPullRequests(t *testing.T) {
+	server := newStubServer(t, map[string]stubResponse{
+		"POST /pull-requests":            {http.StatusCreated, prBody},
+		"POST /pull-requests/9/merge":    {http.StatusOK, strings.Replace(prBody, `"OPEN"`, `"MERGED"`, 1)},
+		"POST /pull-requests/9/reassign": {http.StatusConflict, `{"error":{"code":"PR_ALREADY_MERGED","message":"Cannot reassign on merged PR"}}`},
+		"GET /pull-requests/reviewer/2":  {http.StatusOK, `{"pull_requests":[` + prBody + `],"total":1}`},
+		"POST /pull-requests/9/ack": {http.StatusOK, `{"assignments":[` +
+			`{"reviewer_id":2,"state":"pending","assigned_at":"2026-03-02T09:00:00Z","reviewed_at":"2026-03-02T15:30:00Z"},` +
+			`{"reviewer_id":3,"state":"pending","assigned_at":"2026-03-02T09:00:00Z"}]}`},
+		"POST /pull-requests/8/ack": {http.StatusNotFound, `{"error":{"code":"REVIEWER_NOT_ASSIGNED","message":"User is not a reviewer of the pull request"}}`},
+		"POST /pull-requests/9/reviews": {http.StatusOK, `{"pull_request_id":9,"reviews":[` +
+			`{"reviewer_id":2,"state":"approved","assigned_at":"2026-03-02T09:00:00Z","reviewed_at":"2026-03-02T15:30:00Z"}],` +
+			`"approvals":{"required":1,"approved":1}}`},
+		"POST /pull-requests/8/merge": {http.StatusConflict, `{"error":{"code":"NOT_ENOUGH_APPROVALS","message":"not enough approvals: 0 of 1"}}`},
+	})
+
+	t.Run("create with repeated reviewers", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "create", "--name", "Feature", "--author", "1", "--reviewer", "2", "--reviewer", "3")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.JSONEq(t, `{"name":"Feature","author_id":1,"reviewers":[2,3]}`, server.requests[len(server.requests)-1].body)
+		assert.Equal(t, []string{"9", "Feature", "OPEN", "alice", "bob"}, strings.Fields(strings.Split(res.stdout, "\n")[1]))
+	})
+
+	t.Run("create with size", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "create", "--name", "Feature", "--author", "1", "--size", "240")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.JSONEq(t, `{"name":"Feature","author_id":1,"size":240}`, server.requests[len(server.requests)-1].body)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "merge", "9", "-o", "json")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.Contains(t, res.stdout, `"status": "MERGED"`)
+		assert.Equal(t, "*", server.requests[len(server.requests)-1].ifMatch)
+	})
+
+	t.Run("reassign on merged pr", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "reassign", "9", "--old-reviewer", "2")
+
+		assert.Equal(t, 23, res.code)
+		assert.JSONEq(t, `{"old_reviewer_id":2}`, server.requests[len(server.requests)-1].body)
+		assert.Equal(t, "*", server.requests[len(server.requests)-1].ifMatch)
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "ack", "9", "--reviewer", "2")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.JSONEq(t, `{"reviewer_id":2}`, server.requests[len(server.requests)-1].body)
+		assert.Equal(t, []string{"2", "pending", "2026-03-02T09:00:00Z", "2026-03-02T15:30:00Z"}, strings.Fields(strings.Split(res.stdout, "\n")[1]))
+
+		res = run(t, urlEnv(server), "prs", "ack", "8", "--reviewer", "5")
+		assert.Equal(t, 18, res.code)
+	})
+
+	t.Run("review", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "review", "9", "--reviewer", "2", "--state", "approved")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.JSONEq(t, `{"reviewer_id":2,"state":"approved"}`, server.requests[len(server.requests)-1].body)
+		assert.Contains(t, res.stdout, "1/1")
+	})
+
+	t.Run("merge without approvals", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "merge", "8")
+
+		assert.Equal(t, 29, res.code)
+		assert.Contains(t, res.stderr, "not enough approvals")
+	})
+
+	t.Run("list by reviewer", func(t *testing.T) {
+		res := run(t, urlEnv(server), "prs", "list", "--reviewer", "2")
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.Contains(t, res.stdout, "Feature")
+	})
+}
+
+func TestOrgSync(t *testing.T) {
+	const document = "teams:\n  - name: backend\n    members:\n      - username: alice\n        email: alice@example.com\n"
+	file := filepath.Join(t.TempDir(), "org.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(document), 0o600))
+
+	server := newStubServer(t, map[string]stubResponse{
+		"POST /sync": {http.StatusOK, `{"applied":false,"changes":[` +
+			`{"action":"deactivate_user","user_id":2,"email":"bob@example.com","username":"bob","is_active":false,"from_team":"backend"}],"reassignments":[]}`},
+		"POST /sync?apply=true": {http.StatusOK, `{"applied":true,"changes":[` +
+			`{"action":"deactivate_user","user_id":2,"email":"bob@example.com","username":"bob","is_active":false,"from_team":"backend"}],` +
+			`"reassignments":[{"pull_request_id":9,"from_user_id":2,"to_user_id":3,"outcome":"reassigned"}]}`},
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		res := run(t, urlEnv(server), "org", "sync", "-f", file)
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.Equal(t, document, server.requests[len(server.requests)-1].body)
+		lines := strings.Split(strings.TrimSpace(res.stdout), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"deactivate_user", "bob@example.com", "bob", "backend"}, strings.Fields(lines[1]))
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		res := run(t, urlEnv(server), "org", "sync", "--apply", "-f", file)
+
+		require.Equal(t, cli.ExitOK, res.code, res.stderr)
+		assert.Equal(t, "/sync?apply=true", server.requests[len(server.requests)-1].path)
+		assert.Contains(t, res.stdout, "reassigned")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		res := run(t, urlEnv(server), "org", "sync")
+		assert.Equal(t, cli.ExitUsage, res.code)
+	})
+}
+
+func TestUsageAndTransportErrors(t *testing.T) {
+	server := newStubServer(t, nil)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"no command", []string{"users"}, cli.ExitUsage},
+		{"unknown group", []string{"widgets", "list"}, cli.ExitUsage},
+		{"unknown command", []string{"prs", "close", "1"}, cli.ExitUsage},
+		{"list without filter", []string{"prs", "list"}, cli.ExitUsage},
+		{"bad pr id", []string{"prs", "merge", "abc"}, cli.ExitUsage},
+		{"bad output", []string{"users", "list", "-o", "yaml"}, cli.ExitUsage},
+		{"help", []string{"-h"}, cli.ExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := run(t, urlEnv(server), tt.args...)
+			assert.Equal(t, tt.code, res.code, res.stderr)
+			assert.Contains(t, res.stderr, "Usage: reviewerctl")
+		})
+	}
+
+	assert.Empty(t, server.requests)
+
+	t.Run("server unreachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		res := run(t, map[string]string{"REVIEWERCTL_URL": closed.URL}, "users", "list")
+
+		assert.Equal(t, cli.ExitTransport, res.code)
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, cli.ExitOK, cli.ExitCode(nil))
+	assert.Equal(t, 12, cli.ExitCode(&cli.APIError{Code: "PR_NOT_FOUND"}))
+	assert.Equal(t, 30, cli.ExitCode(&cli.APIError{Code: "VALIDATION_ERROR"}))
+	assert.Equal(t, cli.ExitFailure, cli.ExitCode(&cli.APIError{Code: "INTERNAL_ERROR"}))
+}
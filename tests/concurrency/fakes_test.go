@@ -0,0 +1,165 @@
+package concurrency
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"sync"
+)
+
+// versionedPullRequestRepository keeps copies of pull requests and, like the Postgres repository,
+// only writes over the version the caller read.
+type versionedPullRequestRepository struct {
+	mu  sync.Mutex
+	prs map[int]*models.PullRequest
+}
+
+func newVersionedPullRequestRepository(prs ...*models.PullRequest) *versionedPullRequestRepository {
+	r := &versionedPullRequestRepository{prs: make(map[int]*models.PullRequest)}
+	for _, pr := range prs {
+		r.prs[pr.ID] = copyPullRequest(pr)
+	}
+	return r
+}
+
+func copyPullRequest(pr *models.PullRequest) *models.PullRequest {
+	clone := *pr
+	clone.Reviewers = append([]*models.User(nil), pr.Reviewers...)
+	return &clone
+}
+
+func (r *versionedPullRequestRepository) Add(pr *models.PullRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pr.SetId(len(r.prs) + 1)
+	pr.Version = 1
+	r.prs[pr.ID] = copyPullRequest(pr)
+	return nil
+}
+
+func (r *versionedPullRequestRepository) GetByID(id int) (*models.PullRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pr, ok := r.prs[id]
+	if !ok {
+		return nil, repositories.ErrPullRequestNotFoundInPersistence
+	}
+	return copyPullRequest(pr), nil
+}
+
+func (r *versionedPullRequestRepository) GetAll() ([]*models.PullRequest, error) {
+	return nil, nil
+}
+
+func (r *versionedPullRequestRepository) GetByStatus(models.PRStatus) ([]*models.PullRequest, error) {
+	return nil, nil
+}
+
+func (r *versionedPullRequestRepository) GetByAuthorID(int) ([]*models.PullRequest, error) {
+	return nil, nil
+}
+
+func (r *versionedPullRequestRepository) GetByReviewerID(int) ([]*models.PullRequest, error) {
+	return nil, nil
+}
+
+func (r *versionedPullRequestRepository) GetByReviewerIDs([]int) (map[int][]*models.PullRequest, error) {
+	return map[int][]*models.PullRequest{}, nil
+}
+
+func (r *versionedPullRequestRepository) Update(pr *models.PullRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.prs[pr.ID]
+	if !ok {
+		return repositories.ErrPullRequestNotFoundInPersistence
+	}
+	if pr.Version != stored.Version {
+		return models.ErrConflictingUpdate
+	}
+	pr.Version = stored.Version + 1
+	r.prs[pr.ID] = copyPullRequest(pr)
+	return nil
+}
+
+func (r *versionedPullRequestRepository) FindPossibleReviewers(*models.User) ([]*models.User, error) {
+	return nil, nil
+}
+
+type versionedTeamRepository struct {
+	mu    sync.Mutex
+	teams map[int]*models.Team
+}
+
+func newVersionedTeamRepository(teams ...*models.Team) *versionedTeamRepository {
+	r := &versionedTeamRepository{teams: make(map[int]*models.Team)}
+	for _, team := range teams {
+		r.teams[team.ID] = copyTeam(team)
+	}
+	return r
+}
+
+func copyTeam(team *models.Team) *models.Team {
+	clone := *team
+	clone.Members = make(map[int]*models.TeamMember, len(team.Members))
+	for id, member := range team.Members {
+		clone.Members[id] = member
+	}
+	return &clone
+}
+
+func (r *versionedTeamRepository) Add(team *models.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team.SetId(len(r.teams) + 1)
+	team.Version = 1
+	r.teams[team.ID] = copyTeam(team)
+	return nil
+}
+
+func (r *versionedTeamRepository) GetByID(id int) (*models.Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	team, ok := r.teams[id]
+	if !ok {
+		return nil, repositories.ErrTeamNotFoundInPersistence
+	}
+	return copyTeam(team), nil
+}
+
+func (r *versionedTeamRepository) GetByName(name string) (*models.Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, team := range r.teams {
+		if team.Name == name {
+			return copyTeam(team), nil
+		}
+	}
+	return nil, repositories.ErrTeamNotFoundInPersistence
+}
+
+func (r *versionedTeamRepository) GetAll() ([]*models.Team, error) {
+	return nil, nil
+}
+
+func (r *versionedTeamRepository) Update(team *models.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.teams[team.ID]
+	if !ok {
+		return repositories.ErrTeamNotFoundInPersistence
+	}
+	if team.Version != stored.Version {
+		return models.ErrConflictingUpdate
+	}
+	team.Version = stored.Version + 1
+	r.teams[team.ID] = copyTeam(team)
+	return nil
+}
+
+func (r *versionedTeamRepository) AddUserToTeam(int, int) error {
+	return nil
+}
+
+func (r *versionedTeamRepository) RemoveUserFromTeam(int, int) error {
+	return nil
+}
@@ -0,0 +1,199 @@
+package concurrency
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-assignment-service/internal/app/config"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/internal/app/routes"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const writers = 8
+
+func newRouter(prs *versionedPullRequestRepository, teams *versionedTeamRepository) http.Handler {
+	clk := fakeclock.New(fakeclock.Monday)
+	prService := impl.NewPullRequestService(prs, nil, nil, nil, nil, nil, nil, nil, nil, clk)
+	return routes.SetupRouter(impl.NewUserService(nil, nil), prService, impl.NewTeamService(teams),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, config.IntegrationsConfig{}, config.RateLimitConfig{}, clk)
+}
+
+func seededPullRequest() *models.PullRequest {
+	return &models.PullRequest{
+		ID:        1,
+		Name:      "Add login",
+		Status:    models.StatusOpen,
+		Author:    &models.User{ID: 1, Name: "alice", TeamName: "backend", IsActive: true},
+		Reviewers: []*models.User{},
+		CreatedAt: fakeclock.Monday,
+		Version:   1,
+	}
+}
+
+func serve(router http.Handler, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// race sends one request per writer at the same moment and returns the recorders in writer order.
+func race(router http.Handler, request func(i int) (method, path, body string, header http.Header)) []*httptest.ResponseRecorder {
+	recs := make([]*httptest.ResponseRecorder, writers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			method, path, body, header := request(i)
+			<-start
+			recs[i] = serve(router, method, path, body, header)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return recs
+}
+
+func assertOneWinner(t *testing.T, recs []*httptest.ResponseRecorder) int {
+	t.Helper()
+	winner := -1
+	for i, rec := range recs {
+		switch rec.Code {
+		case http.StatusOK:
+			assert.Equal(t, -1, winner, "more than one concurrent update succeeded")
+			winner = i
+		case http.StatusPreconditionFailed:
+			var body response_errors.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, "CONFLICTING_UPDATE", body.Error.Code)
+		default:
+			t.Fatalf("writer %d got %d: %s", i, rec.Code, rec.Body.String())
+		}
+	}
+	require.NotEqual(t, -1, winner, "no concurrent update succeeded")
+	return winner
+}
+
+func TestPullRequestUpdate_ConcurrentWritersWithIfMatch(t *testing.T) {
+	prs := newVersionedPullRequestRepository(seededPullRequest())
+	router := newRouter(prs, newVersionedTeamRepository())
+
+	read := serve(router, http.MethodGet, "/pull-requests/1", "", nil)
+	require.Equal(t, http.StatusOK, read.Code)
+	etag := read.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	recs := race(router, func(i int) (string, string, string, http.Header) {
+		body := fmt.Sprintf(`{"name": "Title from writer %d", "status": "OPEN"}`, i)
+		return http.MethodPut, "/pull-requests/1", body, http.Header{"If-Match": {etag}}
+	})
+	winner := assertOneWinner(t, recs)
+	assert.Equal(t, `"2"`, recs[winner].Header().Get("ETag"))
+
+	stored, err := prs.GetByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+	assert.Equal(t, fmt.Sprintf("Title from writer %d", winner), stored.Name)
+}
+
+func TestPullRequestService_ConcurrentUpdatesWithoutIfMatch(t *testing.T) {
+	prs := newVersionedPullRequestRepository(seededPullRequest())
+	prService := impl.NewPullRequestService(prs, nil, nil, nil, nil, nil, nil, nil, nil, fakeclock.New(fakeclock.Monday))
+
+	errs := make([]error, writers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		pr, err := prService.GetByID(1)
+		require.NoError(t, err)
+		wg.Add(1)
+		go func(i int, pr *models.PullRequest) {
+			defer wg.Done()
+			pr.Name = fmt.Sprintf("Title from writer %d", i)
+			<-start
+			errs[i] = prService.Update(pr)
+		}(i, pr)
+	}
+	close(start)
+	wg.Wait()
+
+	saved := 0
+	for _, err := range errs {
+		if err == nil {
+			saved++
+			continue
+		}
+		assert.ErrorIs(t, err, models.ErrConflictingUpdate)
+	}
+	assert.Equal(t, 1, saved)
+
+	stored, err := prs.GetByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestTeamUpdate_ConcurrentWritersWithIfMatch(t *testing.T) {
+	teams := newVersionedTeamRepository(&models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{}, Version: 3})
+	router := newRouter(newVersionedPullRequestRepository(), teams)
+
+	read := serve(router, http.MethodGet, "/teams/1", "", nil)
+	require.Equal(t, http.StatusOK, read.Code)
+	etag := read.Header().Get("ETag")
+	assert.Equal(t, `"3"`, etag)
+
+	recs := race(router, func(i int) (string, string, string, http.Header) {
+		body := fmt.Sprintf(`{"name": "backend-%d"}`, i)
+		return http.MethodPut, "/teams/1", body, http.Header{"If-Match": {etag}}
+	})
+	winner := assertOneWinner(t, recs)
+	assert.Equal(t, `"4"`, recs[winner].Header().Get("ETag"))
+
+	stored, err := teams.GetByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stored.Version)
+	assert.Equal(t, fmt.Sprintf("backend-%d", winner), stored.Name)
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch []string
+		status  int
+	}{
+		{name: "no header", status: http.StatusPreconditionRequired},
+		{name: "current version", ifMatch: []string{`"1"`}, status: http.StatusOK},
+		{name: "any version", ifMatch: []string{"*"}, status: http.StatusOK},
+		{name: "one of several tags", ifMatch: []string{`"7", "1"`}, status: http.StatusOK},
+		{name: "stale version", ifMatch: []string{`"0"`}, status: http.StatusPreconditionFailed},
+		{name: "weak tag", ifMatch: []string{`W/"1"`}, status: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newRouter(newVersionedPullRequestRepository(seededPullRequest()), newVersionedTeamRepository())
+			header := http.Header{}
+			for _, value := range tt.ifMatch {
+				header.Add("If-Match", value)
+			}
+
+			rec := serve(router, http.MethodPut, "/pull-requests/1", `{"name": "Add login", "status": "CLOSED"}`, header)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
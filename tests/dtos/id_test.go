@@ -0,0 +1,65 @@
+package dtos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"reviewer-assignment-service/internal/app/transport/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestID_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(dtos.UserResponse{UserID: dtos.NewID(42), Username: "Bob"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user_id":42,"username":"Bob","team_name":"","is_active":false}`, string(data))
+}
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	t.Run("number", func(t *testing.T) {
+		var req dtos.DeactivateUserRequest
+		require.NoError(t, json.Unmarshal([]byte(`{"user_id":7}`), &req))
+		assert.Equal(t, 7, req.UserID.Int())
+		assert.False(t, req.UserID.IsLegacy())
+	})
+
+	t.Run("numeric string", func(t *testing.T) {
+		var req dtos.DeactivateUserRequest
+		require.NoError(t, json.Unmarshal([]byte(`{"user_id":"7"}`), &req))
+		assert.Equal(t, 7, req.UserID.Int())
+		assert.True(t, req.UserID.IsLegacy())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var req dtos.DeactivateUserRequest
+		require.NoError(t, json.Unmarshal([]byte(`{}`), &req))
+		assert.Equal(t, 0, req.UserID.Int())
+	})
+
+	t.Run("non numeric string", func(t *testing.T) {
+		var req dtos.DeactivateUserRequest
+		err := json.Unmarshal([]byte(`{"user_id":"abc"}`), &req)
+		assert.ErrorIs(t, err, dtos.ErrInvalidID)
+	})
+
+	t.Run("fraction", func(t *testing.T) {
+		var req dtos.DeactivateUserRequest
+		err := json.Unmarshal([]byte(`{"user_id":1.5}`), &req)
+		assert.ErrorIs(t, err, dtos.ErrInvalidID)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var id dtos.LegacyID
+		require.NoError(t, json.Unmarshal([]byte(`"15"`), &id))
+		data, err := json.Marshal(id)
+		require.NoError(t, err)
+		assert.Equal(t, "15", string(data))
+	})
+
+	t.Run("string outside the user endpoints", func(t *testing.T) {
+		var req dtos.CreatePullRequestRequest
+		err := json.Unmarshal([]byte(`{"author_id":"7"}`), &req)
+		assert.ErrorIs(t, err, dtos.ErrInvalidID)
+	})
+}
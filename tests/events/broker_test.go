@@ -0,0 +1,65 @@
+package events
+
+import (
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assigned(id, userID int) *models.PullRequestEvent {
+	return &models.PullRequestEvent{ID: id, PullRequestID: 1, Kind: models.EventReviewerAssigned, UserID: userID}
+}
+
+func TestBroker_DeliversToTheUsersSubscribers(t *testing.T) {
+	broker := events.NewBroker()
+	first, second, other := broker.Subscribe(2), broker.Subscribe(2), broker.Subscribe(3)
+
+	broker.Publish(assigned(1, 2), assigned(2, 3))
+
+	assert.Equal(t, 1, (<-first.Events()).ID)
+	assert.Equal(t, 1, (<-second.Events()).ID)
+	assert.Equal(t, 2, (<-other.Events()).ID)
+
+	second.Close()
+	second.Close()
+	_, open := <-second.Events()
+	assert.False(t, open)
+
+	broker.Publish(assigned(3, 2))
+	assert.Equal(t, 3, (<-first.Events()).ID)
+}
+
+func TestBroker_DropsSubscribersThatFallBehind(t *testing.T) {
+	broker := events.NewBroker()
+	slow := broker.Subscribe(2)
+
+	for id := 1; id <= events.SubscriptionBuffer+1; id++ {
+		broker.Publish(assigned(id, 2))
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	assert.Equal(t, events.SubscriptionBuffer, received)
+	slow.Close()
+}
+
+func TestBroker_Close(t *testing.T) {
+	broker := events.NewBroker()
+	sub := broker.Subscribe(2)
+
+	broker.Close()
+	_, open := <-sub.Events()
+	assert.False(t, open)
+
+	late := broker.Subscribe(2)
+	_, open = <-late.Events()
+	assert.False(t, open, "subscriptions after shutdown are closed right away")
+	late.Close()
+
+	var nilBroker *events.Broker
+	assert.NotPanics(t, func() { nilBroker.Publish(assigned(1, 2)) })
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-assignment-service/internal/app/handlers"
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logService replays from an in-memory event log the way UserEventServiceImpl does.
+type logService struct {
+	broker *events.Broker
+
+	mu  sync.Mutex
+	log []*models.PullRequestEvent
+}
+
+func (s *logService) record(event *models.PullRequestEvent) {
+	s.mu.Lock()
+	s.log = append(s.log, event)
+	s.mu.Unlock()
+	s.broker.Publish(event)
+}
+
+func (s *logService) Subscribe(userID, lastEventID int) (*events.Subscription, []*models.PullRequestEvent, error) {
+	sub := s.broker.Subscribe(userID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var missed []*models.PullRequestEvent
+	for _, event := range s.log {
+		if lastEventID > 0 && event.UserID == userID && event.ID > lastEventID {
+			missed = append(missed, event)
+		}
+	}
+	return sub, missed, nil
+}
+
+func openStream(t *testing.T, url, lastEventID string) <-chan string {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+// nextMessage returns the next event's id line, skipping heartbeats; "" means the stream ended.
+func nextMessage(t *testing.T, lines <-chan string, heartbeats *int) string {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return ""
+			}
+			if line == ": heartbeat" {
+				*heartbeats++
+			}
+			if strings.HasPrefix(line, "id: ") {
+				return line
+			}
+		case <-timeout:
+			t.Fatal("no event received")
+		}
+	}
+}
+
+func TestUserEventStream(t *testing.T) {
+	service := &logService{broker: events.NewBroker()}
+	service.record(assigned(1, 2))
+	service.record(assigned(2, 2))
+	service.record(assigned(3, 3))
+
+	router := chi.NewRouter()
+	router.Get("/users/{id}/events", handlers.NewUserEventsHandler(service, 20*time.Millisecond).StreamEvents)
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	lines := openStream(t, server.URL+"/users/2/events", "1")
+	heartbeats := 0
+	assert.Equal(t, "id: 2", nextMessage(t, lines, &heartbeats), "missed events are replayed first")
+
+	service.broker.Publish(assigned(2, 2))
+	service.record(assigned(4, 2))
+	assert.Equal(t, "id: 4", nextMessage(t, lines, &heartbeats), "replayed events are not sent twice")
+
+	time.Sleep(3 * server.Config.WriteTimeout)
+	service.record(assigned(5, 2))
+	assert.Equal(t, "id: 5", nextMessage(t, lines, &heartbeats), "the stream outlives the server's WriteTimeout")
+	assert.Greater(t, heartbeats, 0)
+
+	service.broker.Close()
+	assert.Equal(t, "", nextMessage(t, lines, &heartbeats), "shutdown ends the stream")
+}
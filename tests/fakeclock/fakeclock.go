@@ -0,0 +1,41 @@
+// Package fakeclock is a clock.Clock for tests that only moves when the test moves it.
+package fakeclock
+
+import (
+	"reviewer-assignment-service/internal/domain/clock"
+	"sync"
+	"time"
+)
+
+// Monday is the default start: 09:00 UTC on a Monday, so that working hours and business-hour
+// SLAs behave the same on every run.
+var Monday = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+var _ clock.Clock = (*Clock)(nil)
+
+func New(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
@@ -0,0 +1,226 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-assignment-service/internal/app/graphqlapi"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/tests/servicemocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlError struct {
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+type services struct {
+	users *servicemocks.UserService
+	teams *servicemocks.TeamService
+	prs   *servicemocks.PullRequestService
+}
+
+func newServices() services {
+	return services{users: new(servicemocks.UserService), teams: new(servicemocks.TeamService), prs: new(servicemocks.PullRequestService)}
+}
+
+func (s services) handler(limits graphqlapi.Limits) http.Handler {
+	return graphqlapi.NewHandler(s.users, s.teams, s.prs, limits)
+}
+
+func (s services) assertExpectations(t *testing.T) {
+	s.users.AssertExpectations(t)
+	s.teams.AssertExpectations(t)
+	s.prs.AssertExpectations(t)
+}
+
+func query(t *testing.T, handler http.Handler, body string) graphqlResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response graphqlResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	return response
+}
+
+func team(id int, name string, memberIDs ...int) *models.Team {
+	team := models.NewTeam(name)
+	team.ID = id
+	for _, memberID := range memberIDs {
+		team.Members[memberID] = models.NewTeamMember(memberID, "", true)
+	}
+	return team
+}
+
+func user(id int, name string) *models.User {
+	return &models.User{ID: id, Name: name, Email: strings.ToLower(name) + "@example.com", TeamName: "backend", IsActive: true}
+}
+
+func TestHandler_DashboardQueryLoadsEachLevelOnce(t *testing.T) {
+	s := newServices()
+	alice, bob, carol := user(1, "Alice"), user(2, "Bob"), user(3, "Carol")
+	createdAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	openPR := &models.PullRequest{ID: 10, Name: "Feature", Status: models.StatusOpen, Author: alice, Reviewers: []*models.User{bob, carol}, CreatedAt: createdAt, Version: 1}
+	mergedPR := &models.PullRequest{ID: 11, Name: "Fix", Status: models.StatusMerged, Author: alice, Reviewers: []*models.User{bob}, CreatedAt: createdAt, MergedAt: createdAt.Add(time.Hour), Version: 2}
+
+	s.teams.On("GetAll").Return([]*models.Team{team(1, "backend", 2, 1), team(2, "frontend", 3, 2)}, nil)
+	s.users.On("GetByIDs", []int{1, 2, 3}).Return([]*models.User{alice, bob, carol}, nil).Once()
+	s.prs.On("GetByReviewerIDs", []int{1, 2, 3}).Return(map[int][]*models.PullRequest{
+		2: {openPR, mergedPR},
+		3: {openPR},
+	}, nil).Once()
+
+	response := query(t, s.handler(graphqlapi.DefaultLimits),
+		`{"query":"{ teams { name members { id name reviewing(status: OPEN) { id status author { name } reviewers { name } } } } }"}`)
+
+	require.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"teams": [
+		{"name": "backend", "members": [
+			{"id": 1, "name": "Alice", "reviewing": []},
+			{"id": 2, "name": "Bob", "reviewing": [{"id": 10, "status": "OPEN", "author": {"name": "Alice"}, "reviewers": [{"name": "Bob"}, {"name": "Carol"}]}]}
+		]},
+		{"name": "frontend", "members": [
+			{"id": 2, "name": "Bob", "reviewing": [{"id": 10, "status": "OPEN", "author": {"name": "Alice"}, "reviewers": [{"name": "Bob"}, {"name": "Carol"}]}]},
+			{"id": 3, "name": "Carol", "reviewing": [{"id": 10, "status": "OPEN", "author": {"name": "Alice"}, "reviewers": [{"name": "Bob"}, {"name": "Carol"}]}]}
+		]}
+	]}`, string(response.Data))
+	s.assertExpectations(t)
+}
+
+func TestHandler_PullRequestFields(t *testing.T) {
+	s := newServices()
+	size := 42
+	mergedAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	s.prs.On("GetByID", 11).Return(&models.PullRequest{
+		ID: 11, Name: "Fix", Status: models.StatusMerged, Author: user(1, "Alice"), Reviewers: []*models.User{},
+		Labels: []string{"bug"}, Size: &size, CreatedAt: mergedAt.Add(-time.Hour), MergedAt: mergedAt, Version: 2,
+	}, nil)
+
+	response := query(t, s.handler(graphqlapi.DefaultLimits),
+		`{"query":"query($id: Int!) { pullRequest(id: $id) { name status paths labels size createdAt mergedAt version } }","variables":{"id":11}}`)
+
+	require.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"pullRequest": {
+		"name": "Fix", "status": "MERGED", "paths": [], "labels": ["bug"], "size": 42,
+		"createdAt": "2026-03-02T09:00:00Z", "mergedAt": "2026-03-02T10:00:00Z", "version": 2
+	}}`, string(response.Data))
+}
+
+func TestHandler_ServiceErrorsCarryTheirCode(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		s := newServices()
+		s.teams.On("GetByName", "ghosts").Return(nil, repositories.ErrTeamNotFoundInPersistence)
+
+		response := query(t, s.handler(graphqlapi.DefaultLimits), `{"query":"{ team(name: \"ghosts\") { id } }"}`)
+
+		assert.JSONEq(t, `{"team": null}`, string(response.Data))
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "Team not found", response.Errors[0].Message)
+		assert.Equal(t, "TEAM_NOT_FOUND", response.Errors[0].Extensions["code"])
+	})
+
+	t.Run("team needs exactly one of id and name", func(t *testing.T) {
+		s := newServices()
+
+		response := query(t, s.handler(graphqlapi.DefaultLimits), `{"query":"{ team(id: 1, name: \"backend\") { id } }"}`)
+
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "VALIDATION_ERROR", response.Errors[0].Extensions["code"])
+		s.assertExpectations(t)
+	})
+
+	t.Run("failed batch", func(t *testing.T) {
+		s := newServices()
+		s.teams.On("GetByID", 1).Return(team(1, "backend", 1), nil)
+		s.users.On("GetByIDs", []int{1}).Return(nil, errors.New("connection refused"))
+
+		response := query(t, s.handler(graphqlapi.DefaultLimits), `{"query":"{ team(id: 1) { name members { name } } }"}`)
+
+		assert.JSONEq(t, `null`, string(response.Data))
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "Internal server error", response.Errors[0].Message)
+	})
+}
+
+func TestHandler_Limits(t *testing.T) {
+	limits := graphqlapi.Limits{MaxDepth: 3, MaxComplexity: 20}
+
+	t.Run("too deep", func(t *testing.T) {
+		s := newServices()
+
+		response := query(t, s.handler(limits), `{"query":"{ teams { members { reviewing { name } } } }"}`)
+
+		assert.JSONEq(t, `null`, string(response.Data))
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "QUERY_TOO_DEEP", response.Errors[0].Extensions["code"])
+		s.assertExpectations(t)
+	})
+
+	t.Run("too complex through fragments", func(t *testing.T) {
+		s := newServices()
+
+		// teams costs 1 + 5 * (name + members(1 + 5 * (id + name))) = 61.
+		response := query(t, s.handler(limits),
+			`{"query":"{ teams { ...team } } fragment team on Team { name members { ...member } } fragment member on User { id name }"}`)
+
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "QUERY_TOO_COMPLEX", response.Errors[0].Extensions["code"])
+		assert.Equal(t, "Query complexity 61 exceeds the limit of 20", response.Errors[0].Message)
+		s.assertExpectations(t)
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+		s := newServices()
+		s.users.On("GetByID", 1).Return(user(1, "Alice"), nil)
+
+		response := query(t, s.handler(limits), `{"query":"{ user(id: 1) { name } }"}`)
+
+		require.Empty(t, response.Errors)
+		assert.JSONEq(t, `{"user": {"name": "Alice"}}`, string(response.Data))
+	})
+
+	t.Run("introspection is not counted", func(t *testing.T) {
+		s := newServices()
+
+		response := query(t, s.handler(limits),
+			`{"query":"{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }"}`)
+
+		require.Empty(t, response.Errors)
+		s.assertExpectations(t)
+	})
+}
+
+func TestHandler_RejectsBadRequests(t *testing.T) {
+	s := newServices()
+	handler := s.handler(graphqlapi.DefaultLimits)
+
+	for _, body := range []string{`{"query":`, `{"query":"  "}`} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	response := query(t, handler, `{"query":"{ teams { unknown } }"}`)
+	require.Len(t, response.Errors, 1)
+	assert.Contains(t, response.Errors[0].Message, `Cannot query field "unknown" on type "Team"`)
+
+	response = query(t, handler, `{"query":"{ teams {"}`)
+	require.Len(t, response.Errors, 1)
+	assert.Contains(t, response.Errors[0].Message, "Syntax Error")
+	s.assertExpectations(t)
+}
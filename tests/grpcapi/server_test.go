@@ -0,0 +1,345 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reviewer-assignment-service/internal/app/grpcapi"
+	"reviewer-assignment-service/internal/app/grpcapi/reviewerv1"
+	"reviewer-assignment-service/internal/app/validators"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/tests/fakeclock"
+	"reviewer-assignment-service/tests/servicemocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const token = "s3cret"
+
+type clients struct {
+	users        reviewerv1.UserServiceClient
+	teams        reviewerv1.TeamServiceClient
+	pullRequests reviewerv1.PullRequestServiceClient
+}
+
+// serve starts the server on an in-memory listener and returns clients connected to it.
+func serve(t *testing.T, userService *servicemocks.UserService, teamService *servicemocks.TeamService, prService *servicemocks.PullRequestService) clients {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpcapi.NewServer(userService, teamService, prService, token, fakeclock.New(fakeclock.Monday))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return clients{
+		users:        reviewerv1.NewUserServiceClient(conn),
+		teams:        reviewerv1.NewTeamServiceClient(conn),
+		pullRequests: reviewerv1.NewPullRequestServiceClient(conn),
+	}
+}
+
+func authorized() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// requireStatus checks the gRPC code and the REST error code carried in the ErrorInfo detail.
+func requireStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status error: %v", err)
+	assert.Equal(t, code, st.Code(), st.Message())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reason, info.GetReason())
+	assert.Equal(t, grpcapi.ErrorDomain, info.GetDomain())
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	userService := new(servicemocks.UserService)
+	c := serve(t, userService, new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+	for name, ctx := range map[string]context.Context{
+		"no token":    context.Background(),
+		"wrong token": metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer nope"),
+		"not bearer":  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+token),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.users.ListUsers(ctx, &reviewerv1.ListUsersRequest{})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+	userService.AssertNotCalled(t, "GetAll")
+
+	t.Run("an empty token lets nobody in", func(t *testing.T) {
+		interceptor := grpcapi.AuthInterceptor("")
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "))
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+			return nil, nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	userService := new(servicemocks.UserService)
+	userService.On("GetAll").Run(func(mock.Arguments) { panic("boom") })
+	c := serve(t, userService, new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+	_, err := c.users.ListUsers(authorized(), &reviewerv1.ListUsersRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	userService.On("GetByID", 1).Return(&models.User{ID: 1}, nil)
+	_, err = c.users.GetUser(authorized(), &reviewerv1.GetUserRequest{Id: 1})
+	assert.NoError(t, err, "the server keeps serving after a panic")
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{repositories.ErrUserNotFoundInPersistence, codes.NotFound, "USER_NOT_FOUND"},
+		{repositories.ErrTeamAlreadyExists, codes.AlreadyExists, "TEAM_ALREADY_EXISTS"},
+		{models.ErrAuthorNotInTeam, codes.InvalidArgument, "AUTHOR_NOT_IN_TEAM"},
+		{validators.NewValidationError("name is required"), codes.InvalidArgument, "VALIDATION_ERROR"},
+		{models.ErrPRAlreadyMerged, codes.FailedPrecondition, "PR_ALREADY_MERGED"},
+		{models.ErrRequiredReviewerUnavailable, codes.FailedPrecondition, "REQUIRED_REVIEWER_UNAVAILABLE"},
+		{models.ErrConflictingUpdate, codes.Aborted, "CONFLICTING_UPDATE"},
+		{errors.New("connection refused"), codes.Internal, "INTERNAL_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			requireStatus(t, grpcapi.ToStatus(tt.err), tt.code, tt.reason)
+		})
+	}
+
+	st, _ := status.FromError(grpcapi.ToStatus(errors.New("connection refused")))
+	assert.Equal(t, "Internal server error", st.Message(), "internal errors are not leaked")
+}
+
+func TestUserServer(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		userService.On("GetByID", 2).Return(&models.User{
+			ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: true, TeamName: "backend",
+			WorkingHours: models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19},
+		}, nil)
+		c := serve(t, userService, new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+		user, err := c.users.GetUser(authorized(), &reviewerv1.GetUserRequest{Id: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", user.GetName())
+		assert.Equal(t, "backend", user.GetTeamName())
+		assert.Equal(t, "Europe/Moscow", user.GetWorkingHours().GetTimezone())
+		assert.Equal(t, int32(19), user.GetWorkingHours().GetEndHour())
+	})
+
+	t.Run("domain errors", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		userService.On("GetByID", 9).Return(nil, repositories.ErrUserNotFoundInPersistence)
+		c := serve(t, userService, new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+		_, err := c.users.GetUser(authorized(), &reviewerv1.GetUserRequest{Id: 9})
+		requireStatus(t, err, codes.NotFound, "USER_NOT_FOUND")
+	})
+
+	t.Run("requests are validated like REST ones", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		c := serve(t, userService, new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+		_, err := c.users.CreateUser(authorized(), &reviewerv1.CreateUserRequest{Name: "Bob", Email: "bob", TeamName: "backend"})
+		requireStatus(t, err, codes.InvalidArgument, "VALIDATION_ERROR")
+		_, err = c.users.SetWorkingHours(authorized(), &reviewerv1.SetWorkingHoursRequest{UserId: 2})
+		requireStatus(t, err, codes.InvalidArgument, "VALIDATION_ERROR")
+		userService.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		userService.On("Create", mock.MatchedBy(func(user *models.User) bool {
+			return user.Name == "Bob" && user.WorkingHours == models.DefaultWorkingHours
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.User).ID = 5
+		}).Return(nil)
+		userService.On("GetByID", 5).Return(&models.User{ID: 5, Name: "Bob", Email: "bob@example.com", TeamName: "backend"}, nil)
+		c := serve(t, userService, new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+		user, err := c.users.CreateUser(authorized(), &reviewerv1.CreateUserRequest{Name: "Bob", Email: "bob@example.com", TeamName: "backend"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), user.GetId())
+	})
+}
+
+func TestTeamServer(t *testing.T) {
+	team := func() *models.Team {
+		return &models.Team{ID: 3, Name: "backend", Version: 4, Members: map[int]*models.TeamMember{
+			7: {UserID: 7, Username: "Zoe", IsActive: true},
+			2: {UserID: 2, Username: "Bob", IsActive: false},
+		}}
+	}
+
+	t.Run("members come ordered by user id", func(t *testing.T) {
+		teamService := new(servicemocks.TeamService)
+		teamService.On("GetByName", "backend").Return(team(), nil)
+		c := serve(t, new(servicemocks.UserService), teamService, new(servicemocks.PullRequestService))
+
+		got, err := c.teams.GetTeamByName(authorized(), &reviewerv1.GetTeamByNameRequest{Name: "backend"})
+		require.NoError(t, err)
+		require.Len(t, got.GetMembers(), 2)
+		assert.Equal(t, int64(2), got.GetMembers()[0].GetUserId())
+		assert.False(t, got.GetMembers()[0].GetIsActive())
+		assert.Equal(t, int64(7), got.GetMembers()[1].GetUserId())
+		assert.Equal(t, int64(4), got.GetVersion())
+	})
+
+	t.Run("create conflicts", func(t *testing.T) {
+		teamService := new(servicemocks.TeamService)
+		teamService.On("Create", mock.Anything).Return(repositories.ErrTeamAlreadyExists)
+		c := serve(t, new(servicemocks.UserService), teamService, new(servicemocks.PullRequestService))
+
+		_, err := c.teams.CreateTeam(authorized(), &reviewerv1.CreateTeamRequest{Name: "backend"})
+		requireStatus(t, err, codes.AlreadyExists, "TEAM_ALREADY_EXISTS")
+	})
+
+	t.Run("update checks the version", func(t *testing.T) {
+		teamService := new(servicemocks.TeamService)
+		teamService.On("GetByID", 3).Return(team(), nil)
+		teamService.On("Update", mock.Anything).Return(nil)
+		c := serve(t, new(servicemocks.UserService), teamService, new(servicemocks.PullRequestService))
+
+		members := []*reviewerv1.TeamMember{{UserId: 2, Username: "Bob", IsActive: true}}
+		_, err := c.teams.UpdateTeam(authorized(), &reviewerv1.UpdateTeamRequest{Id: 3, Name: "platform", Members: members, Version: 3})
+		requireStatus(t, err, codes.Aborted, "CONFLICTING_UPDATE")
+		teamService.AssertNotCalled(t, "Update", mock.Anything)
+
+		updated, err := c.teams.UpdateTeam(authorized(), &reviewerv1.UpdateTeamRequest{Id: 3, Name: "platform", Members: members, Version: 4})
+		require.NoError(t, err)
+		assert.Equal(t, "platform", updated.GetName())
+		require.Len(t, updated.GetMembers(), 1)
+		assert.True(t, updated.GetMembers()[0].GetIsActive())
+	})
+}
+
+func TestPullRequestServer(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Alice", TeamName: "backend", IsActive: true}
+	reviewer := &models.User{ID: 2, Name: "Bob", TeamName: "backend", IsActive: true}
+
+	t.Run("create assigns reviewers when none are given", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		prService := new(servicemocks.PullRequestService)
+		userService.On("GetByID", 1).Return(author, nil)
+		prService.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.PullRequest).ID = 10
+		}).Return(nil)
+		prService.On("AssignReviewers", mock.Anything).Run(func(args mock.Arguments) {
+			pr := args.Get(0).(*models.PullRequest)
+			pr.Reviewers = append(pr.Reviewers, reviewer)
+		}).Return(nil)
+		c := serve(t, userService, new(servicemocks.TeamService), prService)
+
+		size := int32(120)
+		pr, err := c.pullRequests.CreatePullRequest(authorized(), &reviewerv1.CreatePullRequestRequest{
+			Name: "Add login", AuthorId: 1, Labels: []string{"auth"}, Size: &size,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), pr.GetId())
+		assert.Equal(t, reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, pr.GetStatus())
+		assert.Equal(t, "Alice", pr.GetAuthor().GetName())
+		require.Len(t, pr.GetReviewers(), 1)
+		assert.Equal(t, int64(2), pr.GetReviewers()[0].GetId())
+		assert.Equal(t, int32(120), pr.GetSize())
+		assert.True(t, pr.GetCreatedAt().AsTime().Equal(fakeclock.Monday))
+		assert.Nil(t, pr.GetMergedAt())
+	})
+
+	t.Run("create with nobody to assign", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		prService := new(servicemocks.PullRequestService)
+		userService.On("GetByID", 1).Return(author, nil)
+		prService.On("Create", mock.Anything).Return(nil)
+		prService.On("AssignReviewers", mock.Anything).Return(models.ErrReviewerNotFound)
+		c := serve(t, userService, new(servicemocks.TeamService), prService)
+
+		pr, err := c.pullRequests.CreatePullRequest(authorized(), &reviewerv1.CreatePullRequestRequest{Name: "Add login", AuthorId: 1})
+		require.NoError(t, err)
+		assert.Empty(t, pr.GetReviewers())
+	})
+
+	t.Run("create with explicit reviewers", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		prService := new(servicemocks.PullRequestService)
+		userService.On("GetByID", 1).Return(author, nil)
+		userService.On("GetByID", 2).Return(reviewer, nil)
+		prService.On("Create", mock.MatchedBy(func(pr *models.PullRequest) bool {
+			return len(pr.Reviewers) == 1 && pr.Reviewers[0].ID == 2
+		})).Return(nil)
+		c := serve(t, userService, new(servicemocks.TeamService), prService)
+
+		_, err := c.pullRequests.CreatePullRequest(authorized(), &reviewerv1.CreatePullRequestRequest{
+			Name: "Add login", AuthorId: 1, ReviewerIds: []int64{2},
+		})
+		require.NoError(t, err)
+		prService.AssertNotCalled(t, "AssignReviewers", mock.Anything)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		prService := new(servicemocks.PullRequestService)
+		prService.On("GetByID", 10).Return(&models.PullRequest{ID: 10, Name: "Add login", Status: models.StatusOpen, Author: author, Version: 2}, nil)
+		prService.On("MergeRequest", mock.Anything).Run(func(args mock.Arguments) {
+			pr := args.Get(0).(*models.PullRequest)
+			pr.SetStatusMerged()
+			pr.SetMergedAt(fakeclock.Monday)
+			pr.Version++
+		}).Return(nil)
+		c := serve(t, new(servicemocks.UserService), new(servicemocks.TeamService), prService)
+
+		_, err := c.pullRequests.MergePullRequest(authorized(), &reviewerv1.MergePullRequestRequest{Id: 10, Version: 1})
+		requireStatus(t, err, codes.Aborted, "CONFLICTING_UPDATE")
+
+		pr, err := c.pullRequests.MergePullRequest(authorized(), &reviewerv1.MergePullRequestRequest{Id: 10})
+		require.NoError(t, err)
+		assert.Equal(t, reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, pr.GetStatus())
+		assert.True(t, pr.GetMergedAt().AsTime().Equal(fakeclock.Monday))
+		assert.Equal(t, int64(3), pr.GetVersion())
+	})
+
+	t.Run("reassign on a merged pull request", func(t *testing.T) {
+		userService := new(servicemocks.UserService)
+		prService := new(servicemocks.PullRequestService)
+		userService.On("GetByID", 2).Return(reviewer, nil)
+		prService.On("GetByID", 10).Return(&models.PullRequest{ID: 10, Status: models.StatusMerged, Author: author}, nil)
+		prService.On("ReassignReviewers", mock.Anything, reviewer).Return(models.ErrPRAlreadyMerged)
+		c := serve(t, userService, new(servicemocks.TeamService), prService)
+
+		_, err := c.pullRequests.ReassignReviewer(authorized(), &reviewerv1.ReassignReviewerRequest{Id: 10, OldReviewerId: 2})
+		requireStatus(t, err, codes.FailedPrecondition, "PR_ALREADY_MERGED")
+	})
+
+	t.Run("update needs a status", func(t *testing.T) {
+		c := serve(t, new(servicemocks.UserService), new(servicemocks.TeamService), new(servicemocks.PullRequestService))
+
+		_, err := c.pullRequests.UpdatePullRequest(authorized(), &reviewerv1.UpdatePullRequestRequest{Id: 10, Name: "Add login"})
+		requireStatus(t, err, codes.InvalidArgument, "VALIDATION_ERROR")
+	})
+}
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
 	appHandlers "reviewer-assignment-service/internal/app/handlers"
 	"reviewer-assignment-service/internal/app/transport/dtos"
 	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
 	"reviewer-assignment-service/internal/domain/services"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -53,11 +55,39 @@ func (m *MockPullRequestService) GetByReviewerID(reviewerID int) ([]*models.Pull
 	return args.Get(0).([]*models.PullRequest), args.Error(1)
 }
 
+func (m *MockPullRequestService) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	args := m.Called(reviewerIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]*models.PullRequest), args.Error(1)
+}
+
 func (m *MockPullRequestService) Update(pr *models.PullRequest) error {
 	args := m.Called(pr)
 	return args.Error(0)
 }
 
+func (m *MockPullRequestService) AddReviewers(pr *models.PullRequest, reviewers ...*models.User) error {
+	args := m.Called(pr, reviewers)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) RemoveReviewer(pr *models.PullRequest, reviewerID int) error {
+	args := m.Called(pr, reviewerID)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) Edit(pr *models.PullRequest, name string, status models.PRStatus, reviewers []*models.User) error {
+	args := m.Called(pr, name, status, reviewers)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) AssignReviewers(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
 func (m *MockPullRequestService) ReassignReviewers(pr *models.PullRequest, oldReviewer *models.User) error {
 	args := m.Called(pr, oldReviewer)
 	return args.Error(0)
@@ -68,6 +98,11 @@ func (m *MockPullRequestService) MergeRequest(pr *models.PullRequest) error {
 	return args.Error(0)
 }
 
+func (m *MockPullRequestService) MarkMerged(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
 type MockUserService struct {
 	mock.Mock
 }
@@ -85,6 +120,14 @@ func (m *MockUserService) GetByID(id int) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserService) GetByIDs(ids []int) ([]*models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
 func (m *MockUserService) GetByEmail(email string) (*models.User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
@@ -116,13 +159,65 @@ func (m *MockUserService) Deactivate(userID int) error {
 	return args.Error(0)
 }
 
+func (m *MockUserService) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	args := m.Called(userID, hours)
+	return args.Error(0)
+}
+
+func (m *MockUserService) AddIdentity(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetIdentityByID(id int) (*models.UserIdentity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserService) GetIdentities(userID int) ([]*models.UserIdentity, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserService) GetIdentitiesByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserService) UpdateIdentity(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *MockUserService) DeleteIdentity(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetByExternalLogin(provider models.VCSProvider, login string) (*models.User, error) {
+	args := m.Called(provider, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 var _ services.PullRequestService = (*MockPullRequestService)(nil)
 var _ services.UserService = (*MockUserService)(nil)
 
 func TestPullRequestHandler_CreatePullRequest_Success(t *testing.T) {
 	mockPRService := new(MockPullRequestService)
 	mockUserService := new(MockUserService)
-	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService)
+	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService, fakeclock.New(fakeclock.Monday))
 
 	author := &models.User{ID: 1, Name: "Author", Email: "author@example.com", TeamName: "backend", IsActive: true}
 	reviewer1 := &models.User{ID: 2, Name: "Reviewer1", Email: "rev1@example.com", TeamName: "backend", IsActive: true}
@@ -138,13 +233,17 @@ func TestPullRequestHandler_CreatePullRequest_Success(t *testing.T) {
 			pr.Author == author &&
 			len(pr.Reviewers) == 2 &&
 			pr.Reviewers[0] == reviewer1 &&
-			pr.Reviewers[1] == reviewer2
+			pr.Reviewers[1] == reviewer2 &&
+			pr.CreatedAt.Equal(fakeclock.Monday)
 	})).Return(nil)
+	mockUserService.On("GetIdentitiesByUserIDs", []int{2, 3}).Return([]*models.UserIdentity{
+		{ID: 1, Provider: models.ProviderGitHub, Login: "rev-one", UserID: 2},
+	}, nil)
 
 	reqBody := dtos.CreatePullRequestRequest{
 		Name:      "New PR",
-		AuthorID:  1,
-		Reviewers: []int{2, 3},
+		AuthorID:  dtos.NewID(1),
+		Reviewers: dtos.NewIDs([]int{2, 3}),
 	}
 	bodyBytes, err := json.Marshal(reqBody)
 	require.NoError(t, err)
@@ -163,27 +262,63 @@ func TestPullRequestHandler_CreatePullRequest_Success(t *testing.T) {
 	assert.Equal(t, "New PR", resp.Name)
 	assert.Equal(t, string(models.StatusOpen), resp.Status)
 	if assert.NotNil(t, resp.Author) {
-		assert.Equal(t, "1", resp.Author.UserID)
+		assert.Equal(t, dtos.NewID(1), resp.Author.UserID)
 	}
 	if assert.Len(t, resp.Reviewers, 2) {
-		assert.Equal(t, "2", resp.Reviewers[0].UserID)
-		assert.Equal(t, "3", resp.Reviewers[1].UserID)
+		assert.Equal(t, dtos.NewID(2), resp.Reviewers[0].UserID)
+		assert.Equal(t, dtos.NewID(3), resp.Reviewers[1].UserID)
+		assert.Equal(t, map[string]string{"github": "rev-one"}, resp.Reviewers[0].ExternalLogins)
+		assert.Nil(t, resp.Reviewers[1].ExternalLogins)
 	}
-	assert.False(t, resp.CreatedAt.IsZero())
+	assert.Equal(t, fakeclock.Monday, resp.CreatedAt)
 	assert.Nil(t, resp.MergedAt)
 
 	mockUserService.AssertExpectations(t)
 	mockPRService.AssertExpectations(t)
 }
 
+func TestPullRequestHandler_CreatePullRequest_ReportsFailedAssignment(t *testing.T) {
+	mockPRService := new(MockPullRequestService)
+	mockUserService := new(MockUserService)
+	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService, fakeclock.New(fakeclock.Monday))
+
+	author := &models.User{ID: 1, Name: "Author", Email: "author@example.com", TeamName: "backend", IsActive: true}
+	stored := &models.PullRequest{ID: 7, Name: "New PR", Status: models.StatusOpen, Author: author, Reviewers: []*models.User{}, CreatedAt: fakeclock.Monday, Version: 1}
+
+	mockUserService.On("GetByID", 1).Return(author, nil)
+	mockPRService.On("Create", mock.AnythingOfType("*models.PullRequest")).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.PullRequest).ID = 7
+	}).Return(nil)
+	mockPRService.On("AssignReviewers", mock.AnythingOfType("*models.PullRequest")).Return(models.ErrRequiredReviewerUnavailable)
+	mockPRService.On("GetByID", 7).Return(stored, nil)
+	mockUserService.On("GetIdentitiesByUserIDs", []int{}).Return([]*models.UserIdentity{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pull-requests", bytes.NewReader([]byte(`{"name":"New PR","author_id":1}`)))
+	rec := httptest.NewRecorder()
+
+	handler.CreatePullRequest(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code, "the pull request is stored, so a retry must not create it again")
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+
+	var resp dtos.PullRequestResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, dtos.NewID(7), resp.ID)
+	assert.Empty(t, resp.Reviewers)
+	require.NotNil(t, resp.Warning)
+	assert.Equal(t, "REQUIRED_REVIEWER_UNAVAILABLE", resp.Warning.Code)
+
+	mockPRService.AssertExpectations(t)
+}
+
 func TestPullRequestHandler_GetPullRequestByID_Success(t *testing.T) {
 	mockPRService := new(MockPullRequestService)
 	mockUserService := new(MockUserService)
-	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService)
+	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService, fakeclock.New(fakeclock.Monday))
 
 	author := &models.User{ID: 1, Name: "Author", Email: "author@example.com", TeamName: "backend", IsActive: true}
 	reviewer := &models.User{ID: 2, Name: "Reviewer", Email: "rev@example.com", TeamName: "backend", IsActive: true}
-	createdAt := time.Now()
+	createdAt := fakeclock.Monday
 
 	pr := &models.PullRequest{
 		ID:        1,
@@ -211,15 +346,16 @@ func TestPullRequestHandler_GetPullRequestByID_Success(t *testing.T) {
 	err := json.Unmarshal(rec.Body.Bytes(), &resp)
 	require.NoError(t, err)
 
-	assert.Equal(t, 1, resp.ID)
+	assert.Equal(t, dtos.NewID(1), resp.ID)
 	assert.Equal(t, "Existing PR", resp.Name)
 	assert.Equal(t, string(models.StatusOpen), resp.Status)
 	if assert.NotNil(t, resp.Author) {
-		assert.Equal(t, "1", resp.Author.UserID)
+		assert.Equal(t, dtos.NewID(1), resp.Author.UserID)
 	}
 	if assert.Len(t, resp.Reviewers, 1) {
-		assert.Equal(t, "2", resp.Reviewers[0].UserID)
+		assert.Equal(t, dtos.NewID(2), resp.Reviewers[0].UserID)
 	}
+	assert.Equal(t, createdAt, resp.CreatedAt)
 
 	mockPRService.AssertExpectations(t)
 }
@@ -227,7 +363,7 @@ func TestPullRequestHandler_GetPullRequestByID_Success(t *testing.T) {
 func TestPullRequestHandler_UpdatePullRequest_Success(t *testing.T) {
 	mockPRService := new(MockPullRequestService)
 	mockUserService := new(MockUserService)
-	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService)
+	handler := appHandlers.NewPullRequestHandler(mockPRService, mockUserService, fakeclock.New(fakeclock.Monday))
 
 	author := &models.User{ID: 1, Name: "Author", Email: "author@example.com", TeamName: "backend", IsActive: true}
 	reviewer := &models.User{ID: 2, Name: "Reviewer", Email: "rev@example.com", TeamName: "backend", IsActive: true}
@@ -238,28 +374,27 @@ func TestPullRequestHandler_UpdatePullRequest_Success(t *testing.T) {
 		Status:    models.StatusOpen,
 		Author:    author,
 		Reviewers: []*models.User{},
-		CreatedAt: time.Now(),
+		CreatedAt: fakeclock.Monday,
 	}
 
 	mockPRService.On("GetByID", 1).Return(existingPR, nil)
 	mockUserService.On("GetByID", 2).Return(reviewer, nil)
-	mockPRService.On("Update", mock.MatchedBy(func(pr *models.PullRequest) bool {
-		return pr.ID == 1 &&
-			pr.Name == "Updated PR" &&
-			pr.Status == models.StatusOpen &&
-			len(pr.Reviewers) == 1 &&
-			pr.Reviewers[0] == reviewer
-	})).Return(nil)
+	mockPRService.On("Edit", existingPR, "Updated PR", models.StatusOpen, []*models.User{reviewer}).Run(func(args mock.Arguments) {
+		pr := args.Get(0).(*models.PullRequest)
+		pr.Name = "Updated PR"
+		pr.Reviewers = []*models.User{reviewer}
+	}).Return(nil)
 
 	reqBody := dtos.UpdatePullRequestRequest{
 		Name:      "Updated PR",
 		Status:    "OPEN",
-		Reviewers: []int{2},
+		Reviewers: dtos.NewIDs([]int{2}),
 	}
 	bodyBytes, err := json.Marshal(reqBody)
 	require.NoError(t, err)
 
 	req := httptest.NewRequest(http.MethodPut, "/pull-requests/1", bytes.NewReader(bodyBytes))
+	req.Header.Set("If-Match", "*")
 	rec := httptest.NewRecorder()
 
 	rctx := chi.NewRouteContext()
@@ -274,13 +409,104 @@ func TestPullRequestHandler_UpdatePullRequest_Success(t *testing.T) {
 	err = json.Unmarshal(rec.Body.Bytes(), &resp)
 	require.NoError(t, err)
 
-	assert.Equal(t, 1, resp.ID)
+	assert.Equal(t, dtos.NewID(1), resp.ID)
 	assert.Equal(t, "Updated PR", resp.Name)
 	assert.Equal(t, "OPEN", resp.Status)
 	if assert.Len(t, resp.Reviewers, 1) {
-		assert.Equal(t, "2", resp.Reviewers[0].UserID)
+		assert.Equal(t, dtos.NewID(2), resp.Reviewers[0].UserID)
 	}
 
 	mockPRService.AssertExpectations(t)
 	mockUserService.AssertExpectations(t)
 }
+
+// storedPullRequests keeps pull requests in memory for the tests that run the real service.
+type storedPullRequests struct {
+	repositories.PullRequestRepository
+	prs map[int]*models.PullRequest
+}
+
+func (r *storedPullRequests) GetByID(id int) (*models.PullRequest, error) {
+	pr, ok := r.prs[id]
+	if !ok {
+		return nil, repositories.ErrPullRequestNotFoundInPersistence
+	}
+	clone := *pr
+	clone.Reviewers = append([]*models.User(nil), pr.Reviewers...)
+	return &clone, nil
+}
+
+func (r *storedPullRequests) Update(pr *models.PullRequest) error {
+	pr.Version++
+	clone := *pr
+	clone.Reviewers = append([]*models.User(nil), pr.Reviewers...)
+	r.prs[pr.ID] = &clone
+	return nil
+}
+
+type recordedEvents struct {
+	repositories.PullRequestEventRepository
+	events []*models.PullRequestEvent
+}
+
+func (r *recordedEvents) Add(event *models.PullRequestEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestPullRequestHandler_UpdatePullRequest_ClosesWithReviewers(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Author", TeamName: "backend", IsActive: true}
+	reviewer1 := &models.User{ID: 2, Name: "Reviewer1", TeamName: "backend", IsActive: true}
+	reviewer2 := &models.User{ID: 3, Name: "Reviewer2", TeamName: "backend", IsActive: true}
+
+	prs := &storedPullRequests{prs: map[int]*models.PullRequest{1: {
+		ID: 1, Name: "PR", Status: models.StatusOpen, Author: author, Reviewers: []*models.User{reviewer1, reviewer2}, Version: 3,
+	}}}
+	events := &recordedEvents{}
+	prService := impl.NewPullRequestService(prs, nil, nil, nil, nil, nil, events, nil, nil, fakeclock.New(fakeclock.Monday))
+	mockUserService := new(MockUserService)
+	handler := appHandlers.NewPullRequestHandler(prService, mockUserService, fakeclock.New(fakeclock.Monday))
+
+	mockUserService.On("GetByID", 2).Return(reviewer1, nil)
+	mockUserService.On("GetByID", 3).Return(reviewer2, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/pull-requests/1", bytes.NewReader([]byte(`{"name":"PR","status":"CLOSED","reviewers":[2,3]}`)))
+	req.Header.Set("If-Match", `"3"`)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+
+	handler.UpdatePullRequest(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp dtos.PullRequestResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "CLOSED", resp.Status)
+	assert.Len(t, resp.Reviewers, 2, "closing keeps the reviewers and their review state")
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	assert.Equal(t, models.StatusClosed, prs.prs[1].Status)
+	assert.Len(t, prs.prs[1].Reviewers, 2)
+	assert.Empty(t, events.events, "nobody was assigned or unassigned")
+}
+
+func TestPullRequestHandler_UpdatePullRequest_CannotMerge(t *testing.T) {
+	mockPRService := new(MockPullRequestService)
+	handler := appHandlers.NewPullRequestHandler(mockPRService, new(MockUserService), fakeclock.New(fakeclock.Monday))
+
+	mockPRService.On("GetByID", 1).Return(&models.PullRequest{ID: 1, Name: "PR", Status: models.StatusOpen, Reviewers: []*models.User{}}, nil)
+	mockPRService.On("Edit", mock.Anything, "PR", models.StatusMerged, []*models.User{}).Return(models.ErrInvalidStatusChange)
+
+	req := httptest.NewRequest(http.MethodPut, "/pull-requests/1", bytes.NewReader([]byte(`{"name":"PR","status":"MERGED"}`)))
+	req.Header.Set("If-Match", "*")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+
+	handler.UpdatePullRequest(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_STATUS_CHANGE")
+	mockPRService.AssertNotCalled(t, "Update", mock.Anything)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appHandlers "reviewer-assignment-service/internal/app/handlers"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserHandler_SetUserActive_IDForms(t *testing.T) {
+	user := &models.User{ID: 1, Name: "User", Email: "user@example.com", TeamName: "backend", IsActive: false}
+
+	t.Run("numeric id", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		handler := appHandlers.NewUserHandler(mockUserService, new(MockPullRequestService))
+
+		mockUserService.On("GetByID", 1).Return(user, nil)
+		mockUserService.On("SetActive", 1, false).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", strings.NewReader(`{"user_id":1,"is_active":false}`))
+		rec := httptest.NewRecorder()
+
+		handler.SetUserActive(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Deprecation"))
+
+		var resp struct {
+			User dtos.UserResponse `json:"user"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, dtos.NewID(1), resp.User.UserID)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("legacy string id", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		handler := appHandlers.NewUserHandler(mockUserService, new(MockPullRequestService))
+
+		mockUserService.On("GetByID", 1).Return(user, nil)
+		mockUserService.On("SetActive", 1, false).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", strings.NewReader(`{"user_id":"1","is_active":false}`))
+		rec := httptest.NewRecorder()
+
+		handler.SetUserActive(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Deprecation"))
+		assert.JSONEq(t, `{"user":{"user_id":1,"username":"User","team_name":"backend","is_active":false}}`, rec.Body.String())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("malformed id", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		handler := appHandlers.NewUserHandler(mockUserService, new(MockPullRequestService))
+
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", strings.NewReader(`{"user_id":"abc","is_active":false}`))
+		rec := httptest.NewRecorder()
+
+		handler.SetUserActive(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUserService.AssertExpectations(t)
+	})
+}
+
+func TestUserHandler_DeactivateUser_LegacyID(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := appHandlers.NewUserHandler(mockUserService, new(MockPullRequestService))
+
+	user := &models.User{ID: 3, Name: "User", Email: "user@example.com", TeamName: "backend", IsActive: false}
+	mockUserService.On("GetByID", 3).Return(user, nil)
+	mockUserService.On("Deactivate", 3).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/deactivate", strings.NewReader(`{"user_id":"3"}`))
+	rec := httptest.NewRecorder()
+
+	handler.DeactivateUser(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Deprecation"))
+	mockUserService.AssertExpectations(t)
+}
@@ -0,0 +1,203 @@
+package idempotency
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-assignment-service/internal/app/idempotency"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryIdempotencyKeyRepository struct {
+	mu   sync.Mutex
+	keys map[string]models.IdempotencyKey
+}
+
+func (r *memoryIdempotencyKeyRepository) Reserve(key *models.IdempotencyKey) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, taken := r.keys[key.Key]; taken {
+		return false, nil
+	}
+	r.keys[key.Key] = *key
+	return true, nil
+}
+
+func (r *memoryIdempotencyKeyRepository) Get(key string) (*models.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.keys[key]
+	if !ok {
+		return nil, repositories.ErrIdempotencyKeyNotFound
+	}
+	return &stored, nil
+}
+
+func (r *memoryIdempotencyKeyRepository) Complete(key *models.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.keys[key.Key]
+	if !ok {
+		return repositories.ErrIdempotencyKeyNotFound
+	}
+	stored.StatusCode, stored.Headers, stored.Body = key.StatusCode, key.Headers, key.Body
+	r.keys[key.Key] = stored
+	return nil
+}
+
+func (r *memoryIdempotencyKeyRepository) Delete(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, key)
+	return nil
+}
+
+type env struct {
+	clock   *fakeclock.Clock
+	calls   atomic.Int32
+	status  int
+	release chan struct{}
+	handler http.Handler
+}
+
+func newEnv() *env {
+	e := &env{clock: fakeclock.New(fakeclock.Monday), status: http.StatusCreated}
+	repo := &memoryIdempotencyKeyRepository{keys: make(map[string]models.IdempotencyKey)}
+	service := impl.NewIdempotencyService(repo, time.Hour, e.clock)
+	e.handler = idempotency.Middleware(service)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := e.calls.Add(1)
+		if e.release != nil {
+			<-e.release
+		}
+		w.Header().Set("ETag", `"1"`)
+		w.WriteHeader(e.status)
+		fmt.Fprintf(w, `{"call":%d}`, n)
+	}))
+	return e
+}
+
+func (e *env) post(path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(idempotency.KeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware_ReplaysRetries(t *testing.T) {
+	e := newEnv()
+
+	first := e.post("/pull-requests", "retry-1", `{"name":"Feature","author_id":1}`)
+	retry := e.post("/pull-requests", "retry-1", `{ "author_id": 1, "name": "Feature" }`)
+
+	assert.Equal(t, int32(1), e.calls.Load())
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+	assert.Equal(t, "true", retry.Header().Get(idempotency.ReplayedHeader))
+	assert.Empty(t, first.Header().Get(idempotency.ReplayedHeader))
+}
+
+func TestMiddleware_RejectsKeyReusedForAnotherRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{name: "different body", path: "/pull-requests", body: `{"name":"Other","author_id":1}`},
+		{name: "different endpoint", path: "/pull-requests/1/merge", body: `{"name":"Feature","author_id":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			e.post("/pull-requests", "retry-1", `{"name":"Feature","author_id":1}`)
+
+			rec := e.post(tt.path, "retry-1", tt.body)
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			assert.Contains(t, rec.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+			assert.Equal(t, int32(1), e.calls.Load())
+		})
+	}
+}
+
+func TestMiddleware_RunsAgain(t *testing.T) {
+	t.Run("without a key", func(t *testing.T) {
+		e := newEnv()
+		e.post("/pull-requests", "", `{}`)
+		e.post("/pull-requests", "", `{}`)
+		assert.Equal(t, int32(2), e.calls.Load())
+	})
+
+	t.Run("after a server error", func(t *testing.T) {
+		e := newEnv()
+		e.status = http.StatusInternalServerError
+		e.post("/pull-requests", "retry-1", `{}`)
+		e.status = http.StatusCreated
+		rec := e.post("/pull-requests", "retry-1", `{}`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, int32(2), e.calls.Load())
+	})
+
+	for _, status := range []int{http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired, http.StatusTooManyRequests} {
+		t.Run(fmt.Sprintf("after a %d", status), func(t *testing.T) {
+			e := newEnv()
+			e.status = status
+			e.post("/pull-requests/1/merge", "retry-1", `{}`)
+			e.status = http.StatusOK
+			rec := e.post("/pull-requests/1/merge", "retry-1", `{}`)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, rec.Header().Get(idempotency.ReplayedHeader))
+			assert.Equal(t, int32(2), e.calls.Load())
+		})
+	}
+
+	t.Run("after the key expired", func(t *testing.T) {
+		e := newEnv()
+		e.post("/pull-requests", "retry-1", `{}`)
+		e.clock.Advance(time.Hour)
+		rec := e.post("/pull-requests", "retry-1", `{"name":"Other"}`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, int32(2), e.calls.Load())
+	})
+}
+
+func TestMiddleware_KeepsClientErrors(t *testing.T) {
+	e := newEnv()
+	e.status = http.StatusNotFound
+	e.post("/pull-requests/1/reassign", "retry-1", `{"old_reviewer_id":2}`)
+	rec := e.post("/pull-requests/1/reassign", "retry-1", `{"old_reviewer_id":2}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, int32(1), e.calls.Load())
+}
+
+func TestMiddleware_ConcurrentRetryWhileInProgress(t *testing.T) {
+	e := newEnv()
+	e.release = make(chan struct{})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- e.post("/pull-requests", "retry-1", `{}`) }()
+	require.Eventually(t, func() bool { return e.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	rec := e.post("/pull-requests", "retry-1", `{}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "IDEMPOTENCY_KEY_IN_PROGRESS")
+
+	close(e.release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, http.StatusCreated, e.post("/pull-requests", "retry-1", `{}`).Code)
+	assert.Equal(t, int32(1), e.calls.Load())
+}
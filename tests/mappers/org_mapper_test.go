@@ -0,0 +1,84 @@
+package mappers
+
+import (
+	"bytes"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOrgCSV(t *testing.T) {
+	t.Run("groups rows by team", func(t *testing.T) {
+		input := "team,username,email,is_active\n" +
+			"backend,Alice,alice@example.com,true\n" +
+			"frontend,Bob,bob@example.com,false\n" +
+			"backend,Carol,carol@example.com,\n" +
+			"platform,,,\n"
+
+		doc, err := mappers.ReadOrgCSV(strings.NewReader(input))
+		require.NoError(t, err)
+
+		require.Len(t, doc.Teams, 3)
+		assert.Equal(t, "backend", doc.Teams[0].Name)
+		require.Len(t, doc.Teams[0].Members, 2)
+		assert.True(t, doc.Teams[0].Members[1].IsActive)
+		assert.False(t, doc.Teams[1].Members[0].IsActive)
+		assert.Equal(t, "platform", doc.Teams[2].Name)
+		assert.Empty(t, doc.Teams[2].Members)
+	})
+
+	t.Run("rejects wrong header", func(t *testing.T) {
+		_, err := mappers.ReadOrgCSV(strings.NewReader("name,user,mail,active\n"))
+		assert.ErrorIs(t, err, mappers.ErrInvalidCSV)
+	})
+
+	t.Run("rejects invalid is_active", func(t *testing.T) {
+		_, err := mappers.ReadOrgCSV(strings.NewReader("team,username,email,is_active\nbackend,Alice,alice@example.com,maybe\n"))
+		assert.ErrorIs(t, err, mappers.ErrInvalidCSV)
+	})
+
+	t.Run("round trips through WriteOrgCSV", func(t *testing.T) {
+		input := "team,username,email,is_active\n" +
+			"backend,Alice,alice@example.com,true\n" +
+			"platform,,,\n"
+
+		doc, err := mappers.ReadOrgCSV(strings.NewReader(input))
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		require.NoError(t, mappers.WriteOrgCSV(&out, doc))
+		assert.Equal(t, input, out.String())
+	})
+}
+
+func TestReadOrgYAML(t *testing.T) {
+	t.Run("members are active by default", func(t *testing.T) {
+		input := `
+teams:
+  - name: backend
+    members:
+      - username: Alice
+        email: alice@example.com
+      - username: Bob
+        email: bob@example.com
+        is_active: false
+  - name: platform
+`
+		doc, err := mappers.ReadOrgYAML(strings.NewReader(input))
+		require.NoError(t, err)
+
+		require.Len(t, doc.Teams, 2)
+		require.Len(t, doc.Teams[0].Members, 2)
+		assert.True(t, doc.Teams[0].Members[0].IsActive)
+		assert.False(t, doc.Teams[0].Members[1].IsActive)
+		assert.Empty(t, doc.Teams[1].Members)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := mappers.ReadOrgYAML(strings.NewReader("teams:\n  - name: backend\n    lead: alice\n"))
+		assert.ErrorIs(t, err, mappers.ErrInvalidYAML)
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"reviewer-assignment-service/internal/app/transport/dtos"
 	"reviewer-assignment-service/internal/app/transport/mappers"
 	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/tests/fakeclock"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,7 +17,7 @@ func TestToPullRequestResponse(t *testing.T) {
 	reviewer1 := &models.User{ID: 2, Name: "Reviewer1", Email: "rev1@example.com", TeamName: "backend", IsActive: true}
 	reviewer2 := &models.User{ID: 3, Name: "Reviewer2", Email: "rev2@example.com", TeamName: "backend", IsActive: true}
 
-	createdAt := time.Now()
+	createdAt := fakeclock.Monday
 	mergedAt := createdAt.Add(time.Hour)
 
 	pr := &models.PullRequest{
@@ -30,20 +32,20 @@ func TestToPullRequestResponse(t *testing.T) {
 
 	resp := mappers.ToPullRequestResponse(pr)
 
-	assert.Equal(t, 10, resp.ID)
+	assert.Equal(t, dtos.NewID(10), resp.ID)
 	assert.Equal(t, "Test PR", resp.Name)
 	assert.Equal(t, string(models.StatusOpen), resp.Status)
 
 	if assert.NotNil(t, resp.Author) {
-		assert.Equal(t, "1", resp.Author.UserID)
+		assert.Equal(t, dtos.NewID(1), resp.Author.UserID)
 		assert.Equal(t, "Author", resp.Author.Username)
 		assert.Equal(t, "backend", resp.Author.TeamName)
 		assert.True(t, resp.Author.IsActive)
 	}
 
 	if assert.Len(t, resp.Reviewers, 2) {
-		assert.Equal(t, "2", resp.Reviewers[0].UserID)
-		assert.Equal(t, "3", resp.Reviewers[1].UserID)
+		assert.Equal(t, dtos.NewID(2), resp.Reviewers[0].UserID)
+		assert.Equal(t, dtos.NewID(3), resp.Reviewers[1].UserID)
 	}
 
 	assert.Equal(t, createdAt, resp.CreatedAt)
@@ -2,6 +2,7 @@ package models
 
 import (
 	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/tests/fakeclock"
 	"testing"
 	"time"
 
@@ -31,7 +32,7 @@ func setupPRTest(t *testing.T) (*models.User, *models.User, *models.User, *model
 	team.AddMember(models.NewTeamMember(reviewer2.ID, reviewer2.Name, reviewer2.IsActive))
 	team.AddMember(models.NewTeamMember(reviewer3.ID, reviewer3.Name, reviewer3.IsActive))
 
-	pr, err := models.NewPullRequest("Test PR", author, team)
+	pr, err := models.NewPullRequest("Test PR", author, team, fakeclock.Monday)
 	require.NoError(t, err)
 
 	return author, reviewer1, reviewer2, team, pr
@@ -40,7 +41,7 @@ func setupPRTest(t *testing.T) (*models.User, *models.User, *models.User, *model
 func TestNewPullRequest_Success(t *testing.T) {
 	author, _, _, team, _ := setupPRTest(t)
 
-	pr, err := models.NewPullRequest("New Feature", author, team)
+	pr, err := models.NewPullRequest("New Feature", author, team, fakeclock.Monday)
 	require.NoError(t, err)
 
 	assert.Equal(t, "New Feature", pr.Name)
@@ -48,7 +49,7 @@ func TestNewPullRequest_Success(t *testing.T) {
 	assert.Equal(t, author, pr.Author)
 	assert.Empty(t, pr.Reviewers)
 	assert.True(t, pr.CanModifyReviewers())
-	assert.WithinDuration(t, time.Now(), pr.CreatedAt, time.Second)
+	assert.Equal(t, fakeclock.Monday, pr.CreatedAt)
 }
 
 func TestNewPullRequest_AuthorNotInTeam(t *testing.T) {
@@ -57,7 +58,7 @@ func TestNewPullRequest_AuthorNotInTeam(t *testing.T) {
 
 	team := models.NewTeam("Developers") // Empty team
 
-	_, err := models.NewPullRequest("Test PR", author, team)
+	_, err := models.NewPullRequest("Test PR", author, team, fakeclock.Monday)
 	assert.Equal(t, models.ErrAuthorNotInTeam, err)
 }
 
@@ -85,6 +86,40 @@ func TestPullRequest_AddReviewer_AlreadyAssigned(t *testing.T) {
 	assert.Len(t, pr.Reviewers, 1)
 }
 
+func TestPullRequest_HasReviewer(t *testing.T) {
+	author, reviewer1, reviewer2, _, pr := setupPRTest(t)
+
+	require.NoError(t, pr.AddReviewer(reviewer1))
+
+	assert.True(t, pr.HasReviewer(reviewer1.ID))
+	assert.False(t, pr.HasReviewer(reviewer2.ID))
+	assert.False(t, pr.HasReviewer(author.ID))
+}
+
+func TestPullRequest_SetReviewers(t *testing.T) {
+	_, reviewer1, reviewer2, _, pr := setupPRTest(t)
+	require.NoError(t, pr.AddReviewer(reviewer1))
+
+	added, removed, err := pr.SetReviewers([]*models.User{reviewer2})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{reviewer2}, added)
+	assert.Equal(t, []*models.User{reviewer1}, removed)
+	assert.Equal(t, []*models.User{reviewer2}, pr.Reviewers)
+
+	_, _, err = pr.SetReviewers([]*models.User{reviewer1, reviewer1})
+	assert.Equal(t, models.ErrReviewerAlreadyAssigned, err)
+
+	require.NoError(t, pr.Close())
+	added, removed, err = pr.SetReviewers([]*models.User{reviewer2})
+	require.NoError(t, err, "a closed pull request can keep its reviewers")
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	_, _, err = pr.SetReviewers(nil)
+	assert.Equal(t, models.ErrPRClosed, err)
+	assert.Equal(t, []*models.User{reviewer2}, pr.Reviewers)
+}
+
 func TestPullRequest_AddReviewer_TooManyReviewers(t *testing.T) {
 	_, reviewer1, reviewer2, _, pr := setupPRTest(t)
 
@@ -184,7 +219,7 @@ func TestPullRequest_Setters(t *testing.T) {
 	assert.Equal(t, models.StatusMerged, pr.Status)
 	assert.False(t, pr.CanModifyReviewers())
 
-	mergedTime := time.Now()
+	mergedTime := fakeclock.Monday.Add(time.Hour)
 	pr.SetMergedAt(mergedTime)
 	assert.Equal(t, mergedTime, pr.MergedAt)
 }
@@ -192,7 +227,60 @@ func TestPullRequest_Setters(t *testing.T) {
 func TestPullRequest_EdgeCases(t *testing.T) {
 	author, _, _, team, _ := setupPRTest(t)
 
-	pr, err := models.NewPullRequest("", author, team)
+	pr, err := models.NewPullRequest("", author, team, fakeclock.Monday)
 	require.NoError(t, err)
 	assert.Equal(t, "", pr.Name)
 }
+
+func TestPullRequest_CloseAndReopen(t *testing.T) {
+	_, reviewer1, _, _, pr := setupPRTest(t)
+
+	pr.SetStatusClosed()
+	assert.Equal(t, models.StatusClosed, pr.Status)
+	assert.False(t, pr.CanModifyReviewers())
+	assert.Equal(t, models.ErrPRClosed, pr.AddReviewer(reviewer1))
+
+	require.NoError(t, pr.Reopen())
+	assert.Equal(t, models.StatusOpen, pr.Status)
+	assert.NoError(t, pr.AddReviewer(reviewer1))
+}
+
+func TestPullRequest_Reopen_Merged(t *testing.T) {
+	_, _, _, _, pr := setupPRTest(t)
+
+	pr.SetStatusMerged()
+	assert.Equal(t, models.ErrPRAlreadyMerged, pr.Reopen())
+	assert.Equal(t, models.StatusMerged, pr.Status)
+}
+
+func TestPullRequest_Merge(t *testing.T) {
+	_, _, _, _, pr := setupPRTest(t)
+	mergedAt := fakeclock.Monday.Add(time.Hour)
+
+	assert.ErrorIs(t, pr.Merge(models.ApprovalStatus{Required: 1}, mergedAt), models.ErrNotEnoughApprovals)
+	assert.Equal(t, models.StatusOpen, pr.Status)
+
+	require.NoError(t, pr.Merge(models.ApprovalStatus{Required: 1, Approved: 1}, mergedAt))
+	assert.Equal(t, models.StatusMerged, pr.Status)
+	assert.Equal(t, mergedAt, pr.MergedAt)
+
+	assert.Equal(t, models.ErrPRAlreadyMerged, pr.Merge(models.ApprovalStatus{}, mergedAt.Add(time.Hour)))
+	assert.Equal(t, mergedAt, pr.MergedAt)
+}
+
+func TestPullRequest_ChangeStatus(t *testing.T) {
+	_, _, _, _, pr := setupPRTest(t)
+
+	assert.Equal(t, models.ErrInvalidStatusChange, pr.ChangeStatus(models.StatusMerged))
+	assert.Equal(t, models.StatusOpen, pr.Status)
+
+	require.NoError(t, pr.ChangeStatus(models.StatusClosed))
+	assert.Equal(t, models.StatusClosed, pr.Status)
+	assert.Equal(t, models.ErrInvalidStatusChange, pr.ChangeStatus(models.StatusMerged))
+	require.NoError(t, pr.ChangeStatus(models.StatusOpen))
+
+	require.NoError(t, pr.Merge(models.ApprovalStatus{}, fakeclock.Monday))
+	assert.NoError(t, pr.ChangeStatus(models.StatusMerged), "a merged PR can still be updated")
+	assert.Equal(t, models.ErrPRAlreadyMerged, pr.ChangeStatus(models.StatusClosed))
+	assert.Equal(t, models.ErrPRAlreadyMerged, pr.ChangeStatus(models.StatusOpen))
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewWeight(t *testing.T) {
+	size := 200
+	halfLife := 24 * time.Hour
+
+	assert.Equal(t, 200.0, models.ReviewWeight(&size, 0, halfLife))
+	assert.Equal(t, 100.0, models.ReviewWeight(&size, halfLife, halfLife))
+	assert.Equal(t, 1.0, models.ReviewWeight(nil, 0, halfLife))
+	assert.Equal(t, 200.0, models.ReviewWeight(&size, -time.Hour, halfLife))
+
+	// Ages stay under 256 half-lives so that weights do not reach subnormal floats.
+	halves := func(lines uint16, ageHours uint16) bool {
+		size := int(lines)
+		age := time.Duration(ageHours%(256*24)) * time.Hour
+		now := models.ReviewWeight(&size, age, halfLife)
+		later := models.ReviewWeight(&size, age+halfLife, halfLife)
+		return math.Abs(now/2-later) <= 1e-9*now
+	}
+	require.NoError(t, quick.Check(halves, nil))
+}
+
+func TestRankCandidates(t *testing.T) {
+	t.Run("tags first, then least loaded, ties keep order", func(t *testing.T) {
+		a, b, c, d := &models.User{ID: 1}, &models.User{ID: 2}, &models.User{ID: 3}, &models.User{ID: 4}
+		loads := models.ReviewLoads{1: 50, 2: 10, 3: 500}
+		tags := map[int][]string{3: {"billing"}}
+
+		ranked := models.RankCandidates([]*models.User{a, b, c, d}, loads, tags, []string{"billing"})
+		assert.Equal(t, []*models.User{c, d, b, a}, ranked)
+	})
+
+	t.Run("ranking is a permutation sorted by load", func(t *testing.T) {
+		property := func(raw []uint16) bool {
+			candidates := make([]*models.User, len(raw))
+			loads := make(models.ReviewLoads)
+			for i, load := range raw {
+				candidates[i] = &models.User{ID: i + 1}
+				loads[i+1] = float64(load)
+			}
+			ranked := models.RankCandidates(candidates, loads, nil, nil)
+			if len(ranked) != len(candidates) {
+				return false
+			}
+			seen := make(map[int]bool)
+			for i, user := range ranked {
+				seen[user.ID] = true
+				if i > 0 && loads[ranked[i-1].ID] > loads[user.ID] {
+					return false
+				}
+			}
+			return len(seen) == len(candidates)
+		}
+		require.NoError(t, quick.Check(property, nil))
+	})
+}
+
+// simulateLoad replays prs PRs of heavy-tailed size in a team of six, two reviewers each, and
+// returns the lines each member reviewed in total, the same under round-robin, and the widest
+// gap between decayed loads seen relative to the largest PR.
+func simulateLoad(seed int64, prs int) (fair, roundRobin map[int]float64, gap float64) {
+	rng := rand.New(rand.NewSource(seed))
+	halfLife := models.DefaultReviewHalfLife
+	team := make([]*models.User, 6)
+	for i := range team {
+		team[i] = &models.User{ID: i + 1}
+	}
+
+	loads := make(models.ReviewLoads)
+	fair, roundRobin = make(map[int]float64), make(map[int]float64)
+	next, largest := 0, 0
+	for n := 0; n < prs; n++ {
+		elapsed := time.Duration(rng.ExpFloat64() * float64(3*time.Hour))
+		for id := range loads {
+			loads[id] *= math.Pow(0.5, elapsed.Hours()/halfLife.Hours())
+		}
+		size := int(math.Exp(rng.NormFloat64()*1.2 + 4))
+		largest = max(largest, size)
+		author := team[rng.Intn(len(team))]
+
+		low, high := math.Inf(1), 0.0
+		for _, user := range team {
+			low, high = math.Min(low, loads[user.ID]), math.Max(high, loads[user.ID])
+		}
+		gap = math.Max(gap, (high-low)/float64(largest))
+
+		candidates := make([]*models.User, 0, len(team)-1)
+		for _, user := range team {
+			if user.ID != author.ID {
+				candidates = append(candidates, user)
+			}
+		}
+		for _, reviewer := range models.RankCandidates(candidates, loads, nil, nil)[:models.MaxReviewers] {
+			loads.Add(reviewer.ID, &size, 0, halfLife)
+			fair[reviewer.ID] += float64(size)
+		}
+
+		for picked := 0; picked < models.MaxReviewers; next++ {
+			if reviewer := team[next%len(team)]; reviewer.ID != author.ID {
+				roundRobin[reviewer.ID] += float64(size)
+				picked++
+			}
+		}
+	}
+	return fair, roundRobin, gap
+}
+
+// spread is the difference between the busiest and the idlest member relative to the mean.
+func spread(totals map[int]float64) float64 {
+	low, high, sum := math.Inf(1), 0.0, 0.0
+	for _, total := range totals {
+		low, high, sum = math.Min(low, total), math.Max(high, total), sum+total
+	}
+	return (high - low) / (sum / float64(len(totals)))
+}
+
+func TestRankCandidates_LongRunSpread(t *testing.T) {
+	var fairSum, roundRobinSum float64
+	for seed := int64(1); seed <= 20; seed++ {
+		fair, roundRobin, gap := simulateLoad(seed, 2000)
+
+		assert.Less(t, spread(fair), 0.15, "seed %d", seed)
+		assert.LessOrEqual(t, gap, 2.0, "seed %d", seed)
+		fairSum += spread(fair)
+		roundRobinSum += spread(roundRobin)
+	}
+	assert.Less(t, fairSum, roundRobinSum/3)
+}
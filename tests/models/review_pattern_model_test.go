@@ -0,0 +1,51 @@
+package models
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewPattern_Matches(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "internal/app/router.go", true},
+		{"*.go", "docs/go.md", false},
+		{"/docs/", "docs/index.md", true},
+		{"/docs/", "api/docs/index.md", false},
+		{"docs/", "api/docs/index.md", true},
+		{"docs", "api/docs/index.md", true},
+		{"internal/billing", "/internal/billing/invoice.go", true},
+		{"internal/billing", "internal/billing_v2/invoice.go", false},
+		{"/internal/*/handlers", "internal/app/handlers/user.go", true},
+		{"/internal/*/handlers", "internal/app/v2/handlers/user.go", false},
+		{"/internal/**/handlers", "internal/app/v2/handlers/user.go", true},
+		{"**/migrations/*.sql", "db/migrations/1_init.sql", true},
+		{"/cmd/**", "cmd/app/main/main.go", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+	}
+
+	for _, tc := range cases {
+		pattern := models.NewReviewPattern(1, tc.pattern, "", "tag")
+		assert.Equal(t, tc.matches, pattern.Matches([]string{tc.path}, nil), "%s ~ %s", tc.pattern, tc.path)
+	}
+}
+
+func TestReviewPatterns_MatchingTags(t *testing.T) {
+	patterns := models.ReviewPatterns{
+		models.NewReviewPattern(1, "*.sql", "", "db"),
+		models.NewReviewPattern(1, "/internal/billing/", "", "billing"),
+		models.NewReviewPattern(1, "", "security", "security"),
+		models.NewReviewPattern(1, "/migrations/", "", "db"),
+	}
+
+	assert.Equal(t, []string{"db", "security"},
+		patterns.MatchingTags([]string{"migrations/1_init.sql", "README.md"}, []string{"Security"}))
+	assert.Empty(t, patterns.MatchingTags(nil, nil))
+}
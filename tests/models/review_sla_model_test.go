@@ -0,0 +1,57 @@
+package models
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingReview_Overdue(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	sla := models.ReviewSLA{TeamID: 1, Hours: 24, Action: models.SLAActionAddReviewer}
+
+	t.Run("measured from the newest assignment", func(t *testing.T) {
+		review := &models.PendingReview{CreatedAt: monday, SLA: sla, Assignments: []*models.ReviewAssignment{
+			{ReviewerID: 2, AssignedAt: monday},
+			{ReviewerID: 3, AssignedAt: monday.Add(12 * time.Hour)},
+		}, ReviewerHours: map[int]models.WorkingHours{2: models.DefaultWorkingHours, 3: models.DefaultWorkingHours}}
+
+		// Assigned Monday 21:00: 9 hours on Tuesday, 9 on Wednesday and 6 on Thursday.
+		assert.False(t, review.Overdue(monday.Add(77*time.Hour)))
+		assert.True(t, review.Overdue(monday.Add(78*time.Hour)))
+		assert.Equal(t, 2, review.Stale().ReviewerID)
+	})
+
+	t.Run("in the working hours of the newest reviewer", func(t *testing.T) {
+		tokyo := models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 10, EndHour: 19}
+		review := &models.PendingReview{CreatedAt: monday, SLA: models.ReviewSLA{TeamID: 1, Hours: 8},
+			Assignments:   []*models.ReviewAssignment{{ReviewerID: 2, AssignedAt: monday}},
+			AuthorHours:   models.DefaultWorkingHours,
+			ReviewerHours: map[int]models.WorkingHours{2: tokyo}}
+
+		// Assigned at 18:00 in Tokyo: one hour on Monday and seven on Tuesday, until 08:00 UTC.
+		assert.False(t, review.Overdue(monday.Add(22*time.Hour)))
+		assert.True(t, review.Overdue(monday.Add(23*time.Hour)))
+	})
+
+	t.Run("without reviewers from the creation of the PR in the author's hours", func(t *testing.T) {
+		review := &models.PendingReview{CreatedAt: monday, SLA: sla, AuthorHours: models.DefaultWorkingHours}
+
+		assert.False(t, review.Overdue(monday.Add(53*time.Hour)))
+		assert.True(t, review.Overdue(monday.Add(54*time.Hour)))
+		assert.Nil(t, review.Stale())
+	})
+
+	t.Run("reviewed or disabled is never overdue", func(t *testing.T) {
+		reviewedAt := monday.Add(time.Hour)
+		reviewed := &models.PendingReview{CreatedAt: monday, SLA: sla, Assignments: []*models.ReviewAssignment{
+			{ReviewerID: 2, AssignedAt: monday, ReviewedAt: &reviewedAt},
+		}}
+		disabled := &models.PendingReview{CreatedAt: monday, SLA: models.ReviewSLA{TeamID: 1}}
+
+		assert.False(t, reviewed.Overdue(monday.Add(72*time.Hour)))
+		assert.False(t, disabled.Overdue(monday.Add(72*time.Hour)))
+	})
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewState(t *testing.T) {
+	assert.False(t, models.ReviewPending.IsVerdict())
+	assert.True(t, models.ReviewDismissed.IsVerdict())
+	assert.False(t, models.ReviewState("lgtm").IsVerdict())
+
+	assert.True(t, models.ReviewChangesRequested.CountsAsReview())
+	assert.False(t, models.ReviewDismissed.CountsAsReview())
+}
+
+func TestApprovalStatus_Check(t *testing.T) {
+	assert.NoError(t, models.ApprovalStatus{}.Check())
+	assert.NoError(t, models.ApprovalStatus{Required: 1, Approved: 2}.Check())
+
+	err := models.ApprovalStatus{Required: 2, Approved: 1}.Check()
+	assert.ErrorIs(t, err, models.ErrNotEnoughApprovals)
+	assert.EqualError(t, err, "not enough approvals: 1 of 2")
+}
+
+func TestPullRequest_CheckReviewable(t *testing.T) {
+	assert.NoError(t, (&models.PullRequest{Status: models.StatusOpen}).CheckReviewable())
+	assert.ErrorIs(t, (&models.PullRequest{Status: models.StatusMerged}).CheckReviewable(), models.ErrPRAlreadyMerged)
+}
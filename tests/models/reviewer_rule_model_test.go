@@ -0,0 +1,97 @@
+package models
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ruleUsers() (*models.User, *models.User, *models.User, *models.User) {
+	author := &models.User{ID: 1, Name: "Author", TeamName: "backend", IsActive: true}
+	peer := &models.User{ID: 2, Name: "Peer", TeamName: "backend", IsActive: true}
+	rival := &models.User{ID: 3, Name: "Rival", TeamName: "backend", IsActive: true}
+	senior := &models.User{ID: 4, Name: "Senior", TeamName: "backend", IsActive: true}
+	return author, peer, rival, senior
+}
+
+func TestReviewerRules_SelectReviewers_WithoutRules(t *testing.T) {
+	author, peer, rival, senior := ruleUsers()
+
+	chosen, err := models.ReviewerRules{}.SelectReviewers(author, nil, []*models.User{peer, rival, senior}, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{peer, rival}, chosen)
+}
+
+func TestReviewerRules_SelectReviewers_NeverAssignIsSymmetric(t *testing.T) {
+	author, peer, rival, _ := ruleUsers()
+	rules := models.ReviewerRules{{ID: 1, Kind: models.RuleNeverAssign, ReviewerID: 1, AuthorID: 3}}
+
+	chosen, err := rules.SelectReviewers(author, nil, []*models.User{rival, peer}, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{peer}, chosen)
+}
+
+func TestReviewerRules_SelectReviewers_PreferredGoFirst(t *testing.T) {
+	author, peer, rival, senior := ruleUsers()
+	rules := models.ReviewerRules{{ID: 1, Kind: models.RulePrefer, ReviewerID: 4, AuthorID: 1}}
+
+	chosen, err := rules.SelectReviewers(author, nil, []*models.User{peer, rival, senior}, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{senior, peer}, chosen)
+}
+
+func TestReviewerRules_SelectReviewers_RequiredTag(t *testing.T) {
+	author, peer, rival, senior := ruleUsers()
+	rules := models.ReviewerRules{{ID: 1, Kind: models.RuleRequireTag, Tag: "senior", AuthorTag: "junior"}}
+	tags := map[int][]string{1: {"junior"}, 4: {"senior"}}
+
+	t.Run("takes a slot for the tag", func(t *testing.T) {
+		chosen, err := rules.SelectReviewers(author, nil, []*models.User{peer, rival, senior}, tags, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.User{senior, peer}, chosen)
+	})
+
+	t.Run("already covered by an assigned reviewer", func(t *testing.T) {
+		chosen, err := rules.SelectReviewers(author, []*models.User{senior}, []*models.User{peer, rival}, tags, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.User{peer}, chosen)
+	})
+
+	t.Run("ignored for authors without the author tag", func(t *testing.T) {
+		chosen, err := rules.SelectReviewers(author, nil, []*models.User{peer, rival}, map[int][]string{}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.User{peer, rival}, chosen)
+	})
+
+	t.Run("no candidate has the tag", func(t *testing.T) {
+		_, err := rules.SelectReviewers(author, nil, []*models.User{peer, rival}, tags, 2)
+		assert.ErrorIs(t, err, models.ErrRequiredReviewerUnavailable)
+	})
+}
+
+func TestReviewerRules_Check(t *testing.T) {
+	author, peer, rival, senior := ruleUsers()
+	rules := models.ReviewerRules{
+		{ID: 1, Kind: models.RuleNeverAssign, ReviewerID: 3, AuthorID: 1},
+		{ID: 2, Kind: models.RulePrefer, ReviewerID: 4, AuthorID: 1},
+	}
+
+	check := rules.Check(author, rival, nil)
+	assert.False(t, check.Eligible)
+	assert.Equal(t, []string{"rule 1: users 3 and 1 never review each other"}, check.Reasons)
+
+	check = rules.Check(author, senior, nil)
+	assert.True(t, check.Eligible)
+	assert.True(t, check.Preferred)
+
+	outsider := &models.User{ID: 5, TeamName: "frontend", IsActive: false}
+	check = rules.Check(author, outsider, nil)
+	assert.False(t, check.Eligible)
+	assert.Len(t, check.Reasons, 2)
+
+	check = rules.Check(author, peer, nil)
+	assert.True(t, check.Eligible)
+	assert.False(t, check.Preferred)
+}
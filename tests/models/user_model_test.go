@@ -3,6 +3,7 @@ package models
 import (
 	"reviewer-assignment-service/internal/domain/models"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -35,3 +36,74 @@ func TestUser_EdgeCases(t *testing.T) {
 	assert.False(t, user.IsActive)
 	assert.Equal(t, "", user.TeamName)
 }
+
+func TestWorkingHours_Contains(t *testing.T) {
+	// Monday 07:30 UTC is 10:30 in Moscow and 16:30 in Tokyo.
+	monday := time.Date(2026, 3, 2, 7, 30, 0, 0, time.UTC)
+
+	assert.False(t, models.DefaultWorkingHours.Contains(monday))
+	assert.True(t, models.DefaultWorkingHours.Contains(monday.Add(2*time.Hour)))
+	assert.False(t, models.DefaultWorkingHours.Contains(monday.Add(10*time.Hour+30*time.Minute)))
+	assert.True(t, models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19}.Contains(monday))
+	assert.False(t, models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 9, EndHour: 16}.Contains(monday))
+	for range 2 {
+		// An unknown zone is counted in UTC, both when it is first loaded and once it is kept.
+		assert.True(t, models.WorkingHours{Timezone: "Mars/Olympus", StartHour: 9, EndHour: 18}.Contains(monday.Add(2*time.Hour)))
+	}
+
+	saturday := monday.AddDate(0, 0, 5).Add(4 * time.Hour)
+	assert.False(t, models.DefaultWorkingHours.Contains(saturday))
+	assert.True(t, models.WorkingHours{}.Contains(saturday))
+}
+
+func TestWorkingHours_Between(t *testing.T) {
+	friday := time.Date(2026, 3, 6, 17, 0, 0, 0, time.UTC)
+
+	t.Run("across days and the weekend", func(t *testing.T) {
+		assert.Equal(t, time.Hour, models.DefaultWorkingHours.Between(friday, friday.Add(time.Hour)))
+		assert.Equal(t, 2*time.Hour, models.DefaultWorkingHours.Between(friday, friday.Add(65*time.Hour)), "Friday 17-18 and Monday 9-10")
+		assert.Zero(t, models.DefaultWorkingHours.Between(friday.Add(3*time.Hour), friday.Add(15*time.Hour)), "Friday night")
+		assert.Equal(t, 45*time.Hour, models.DefaultWorkingHours.Between(friday, friday.AddDate(0, 0, 7)))
+		assert.Zero(t, models.DefaultWorkingHours.Between(friday, friday.Add(-time.Hour)))
+	})
+
+	t.Run("in the user's time zone", func(t *testing.T) {
+		monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+		tokyo := models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 10, EndHour: 19}
+		losAngeles := models.WorkingHours{Timezone: "America/Los_Angeles", StartHour: 9, EndHour: 18}
+
+		// Monday 00:00-12:00 UTC is 09:00-21:00 in Tokyo.
+		assert.Equal(t, 9*time.Hour, tokyo.Between(monday, monday.Add(12*time.Hour)))
+		// Friday 21:00 in Tokyo to Monday 11:00.
+		assert.Equal(t, time.Hour, tokyo.Between(friday.Add(-5*time.Hour), friday.Add(57*time.Hour)))
+		// A working day in Los Angeles, 17:00-02:00 UTC, spans two UTC days.
+		assert.Equal(t, 7*time.Hour, losAngeles.Between(monday, monday.AddDate(0, 0, 1)))
+		// Thursday's shift ends at 02:00 UTC on Friday, and after the switch to summer time on
+		// March 8 Monday's starts at 16:00 UTC instead of 17:00.
+		assert.Equal(t, 19*time.Hour, losAngeles.Between(monday.AddDate(0, 0, 4), monday.AddDate(0, 0, 8)))
+	})
+
+	t.Run("without working hours every hour counts", func(t *testing.T) {
+		saturday := friday.Add(24 * time.Hour)
+		assert.Equal(t, 5*time.Hour, models.WorkingHours{}.Between(saturday, saturday.Add(5*time.Hour)))
+	})
+}
+
+func TestPreferWorking(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 7, 30, 0, 0, time.UTC)
+	candidates := []*models.User{
+		{ID: 1, WorkingHours: models.DefaultWorkingHours},
+		{ID: 2, WorkingHours: models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19}},
+		{ID: 3, WorkingHours: models.DefaultWorkingHours},
+		{ID: 4, WorkingHours: models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 9, EndHour: 18}},
+	}
+
+	ranked := models.PreferWorking(candidates, monday)
+
+	ids := make([]int, 0, len(ranked))
+	for _, user := range ranked {
+		ids = append(ids, user.ID)
+	}
+	assert.Equal(t, []int{2, 4, 1, 3}, ids)
+	assert.Equal(t, 1, candidates[0].ID)
+}
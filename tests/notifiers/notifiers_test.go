@@ -0,0 +1,173 @@
+package notifiers
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/infrastructure/notifiers"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notification(target string) *models.Notification {
+	return &models.Notification{
+		ID:            5,
+		EventID:       11,
+		Kind:          models.EventReviewerAssigned,
+		PullRequestID: 7,
+		UserID:        2,
+		Target:        target,
+		Subject:       "Review requested: Добавить вход",
+		Body:          "Hi Bob,\n\nyou were assigned.\n",
+		CreatedAt:     time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+// smtpServer accepts one message without authentication and sends it to the returned channel.
+func smtpServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "DATA"):
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				messages <- data.String()
+				reply("250 queued")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func TestEmailNotifier_Send(t *testing.T) {
+	addr, messages := smtpServer(t)
+
+	err := notifiers.NewEmailNotifier(addr, "reviewers@example.com", "", "").Send(notification("bob@example.com"))
+	require.NoError(t, err)
+
+	message, err := mail.ReadMessage(strings.NewReader(<-messages))
+	require.NoError(t, err)
+	assert.Equal(t, "reviewers@example.com", message.Header.Get("From"))
+	assert.Equal(t, "bob@example.com", message.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Review requested: Добавить вход", subject)
+	body, err := io.ReadAll(message.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi Bob,\r\n\r\nyou were assigned.\r\n", string(body))
+}
+
+func TestEmailNotifier_SendWithHTML(t *testing.T) {
+	addr, messages := smtpServer(t)
+	digest := notification("bob@example.com")
+	digest.HTML = "<p>Hi Bob</p>\n"
+
+	require.NoError(t, notifiers.NewEmailNotifier(addr, "reviewers@example.com", "", "").Send(digest))
+
+	message, err := mail.ReadMessage(strings.NewReader(<-messages))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	var types, bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		types = append(types, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, types)
+	assert.Equal(t, []string{"Hi Bob,\r\n\r\nyou were assigned.\r\n", "<p>Hi Bob</p>\r\n"}, bodies)
+}
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	require.NoError(t, notifiers.NewSlackNotifier().Send(notification(server.URL)))
+	assert.Equal(t, "*Review requested: Добавить вход*\nHi Bob,\n\nyou were assigned.\n", payload["text"])
+}
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	t.Run("posts the notification", func(t *testing.T) {
+		var payload map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		require.NoError(t, notifiers.NewWebhookNotifier().Send(notification(server.URL)))
+		assert.Equal(t, "reviewer_assigned", payload["kind"])
+		assert.Equal(t, float64(7), payload["pull_request_id"])
+		assert.Equal(t, float64(11), payload["event_id"])
+		assert.Equal(t, "2026-03-02T09:00:00Z", payload["created_at"])
+	})
+
+	t.Run("an error status fails the attempt", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := notifiers.NewWebhookNotifier().Send(notification(server.URL))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+}
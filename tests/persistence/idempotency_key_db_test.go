@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"database/sql"
+	"net/http"
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKeyDataBase_Reserve(t *testing.T) {
+	createdAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`INSERT INTO idempotency_keys (key,request_hash,created_at) VALUES ($1,$2,$3) ON CONFLICT (key) DO NOTHING`)
+
+	tests := []struct {
+		name     string
+		inserted int64
+		want     bool
+	}{
+		{name: "new key", inserted: 1, want: true},
+		{name: "taken key", inserted: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(query).
+				WithArgs("retry-1", "abc", createdAt).
+				WillReturnResult(sqlmock.NewResult(0, tt.inserted))
+
+			reserved, err := postgres.NewIdempotencyKeyDataBase(db).Reserve(models.NewIdempotencyKey("retry-1", "abc", createdAt))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, reserved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyKeyDataBase_Get(t *testing.T) {
+	createdAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SELECT key, request_hash, status_code, response_headers, response_body, created_at FROM idempotency_keys WHERE key = $1`)
+	columns := []string{"key", "request_hash", "status_code", "response_headers", "response_body", "created_at"}
+
+	t.Run("completed", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).
+			WithArgs("retry-1").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("retry-1", "abc", 201, []byte(`{"Etag":["\"1\""]}`), []byte(`{"id":1}`), createdAt))
+
+		stored, err := postgres.NewIdempotencyKeyDataBase(db).Get("retry-1")
+		require.NoError(t, err)
+		assert.Equal(t, &models.IdempotencyKey{
+			Key:         "retry-1",
+			RequestHash: "abc",
+			StatusCode:  http.StatusCreated,
+			Headers:     http.Header{"Etag": {`"1"`}},
+			Body:        []byte(`{"id":1}`),
+			CreatedAt:   createdAt,
+		}, stored)
+		assert.True(t, stored.Completed())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).
+			WithArgs("retry-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("retry-1", "abc", nil, nil, nil, createdAt))
+
+		stored, err := postgres.NewIdempotencyKeyDataBase(db).Get("retry-1")
+		require.NoError(t, err)
+		assert.False(t, stored.Completed())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).WithArgs("retry-1").WillReturnError(sql.ErrNoRows)
+
+		_, err = postgres.NewIdempotencyKeyDataBase(db).Get("retry-1")
+		assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIdempotencyKeyDataBase_Complete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE idempotency_keys SET status_code = $1, response_headers = $2, response_body = $3 WHERE key = $4`)).
+		WithArgs(http.StatusOK, `{"Etag":["\"2\""]}`, []byte(`{}`), "retry-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = postgres.NewIdempotencyKeyDataBase(db).Complete(&models.IdempotencyKey{
+		Key:        "retry-1",
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Etag": {`"2"`}},
+		Body:       []byte(`{}`),
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferenceDataBase_GetByUserIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, channel, target, kinds, digest FROM notification_preferences WHERE user_id IN ($1,$2) ORDER BY user_id, channel`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "channel", "target", "kinds", "digest"}).
+			AddRow(2, "email", "", "{reviewer_assigned,merged}", true).
+			AddRow(2, "slack", "https://hooks.slack.test/x", "{}", false))
+
+	preferences, err := postgres.NewNotificationPreferenceDataBase(db).GetByUserIDs([]int{2, 3})
+	require.NoError(t, err)
+	require.Len(t, preferences[2], 2)
+	assert.Equal(t, []models.PullRequestEventKind{models.EventReviewerAssigned, models.EventMerged}, preferences[2][0].Kinds)
+	assert.True(t, preferences[2][0].Digest)
+	assert.Equal(t, models.ChannelSlack, preferences[2][1].Channel)
+	assert.Empty(t, preferences[2][1].Kinds)
+	_, saved := preferences[3]
+	assert.False(t, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationPreferenceDataBase_Replace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM notification_preferences WHERE user_id = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO notification_preferences (user_id,channel,target,kinds,digest) VALUES ($1,$2,$3,$4,$5)`)).
+		WithArgs(2, "webhook", "https://hooks.example.com", pq.Array([]string{"merged"}), true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = postgres.NewNotificationPreferenceDataBase(db).Replace(2, []*models.NotificationPreference{
+		{UserID: 2, Channel: models.ChannelWebhook, Target: "https://hooks.example.com", Kinds: []models.PullRequestEventKind{models.EventMerged}, Digest: true},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationDataBase_Add(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	notification := &models.Notification{
+		EventID: 11, Kind: models.EventReviewerAssigned, PullRequestID: 1, UserID: 2, Channel: models.ChannelEmail,
+		Target: "bob@example.com", Subject: "Review requested", Body: "Hi", NextAttemptAt: &at, CreatedAt: at,
+	}
+	query := regexp.QuoteMeta(`INSERT INTO notifications (event_id,kind,pr_id,user_id,channel,target,subject,body,html,next_attempt_at,created_at) ` +
+		`VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) ON CONFLICT DO NOTHING RETURNING id`)
+
+	mock.ExpectQuery(query).
+		WithArgs(11, "reviewer_assigned", 1, 2, "email", "bob@example.com", "Review requested", "Hi", "", &at, at).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	notificationDB := postgres.NewNotificationDataBase(db)
+	require.NoError(t, notificationDB.Add(notification))
+	assert.Equal(t, 5, notification.ID)
+
+	duplicate := *notification
+	duplicate.ID = 0
+	require.NoError(t, notificationDB.Add(&duplicate))
+	assert.Zero(t, duplicate.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationDataBase_AddDigest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	digest := &models.Notification{
+		Kind: models.NotificationDigest, UserID: 2, Channel: models.ChannelEmail, Target: "bob@example.com",
+		Subject: "Review digest", Body: "Hi", HTML: "<p>Hi</p>", NextAttemptAt: &at, CreatedAt: at,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO notifications`)).
+		WithArgs(nil, "digest", nil, 2, "email", "bob@example.com", "Review digest", "Hi", "<p>Hi</p>", &at, at).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+
+	require.NoError(t, postgres.NewNotificationDataBase(db).Add(digest))
+	assert.Equal(t, 6, digest.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationDataBase_ClaimDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	lease := now.Add(5 * time.Minute)
+	columns := []string{"id", "event_id", "kind", "pr_id", "user_id", "channel", "target", "subject", "body", "html",
+		"attempts", "next_attempt_at", "sent_at", "last_error", "created_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE notifications SET next_attempt_at = $1 WHERE id IN (SELECT id FROM notifications `+
+		`WHERE next_attempt_at <= $2 ORDER BY next_attempt_at, id LIMIT $3 FOR UPDATE SKIP LOCKED) `+
+		`RETURNING id, event_id, kind, pr_id, user_id, channel, target, subject, body, html, attempts, next_attempt_at, sent_at, last_error, created_at`)).
+		WithArgs(lease, now, 100).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, 11, "merged", 1, 2, "slack", "https://hooks.slack.test/x", "Merged", "Hi", "", 2, lease, nil, "timeout", now).
+			AddRow(6, nil, "digest", nil, 2, "email", "bob@example.com", "Review digest", "Hi", "<p>Hi</p>", 0, lease, nil, "", now))
+
+	due, err := postgres.NewNotificationDataBase(db).ClaimDue(now, lease, 100)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, models.EventMerged, due[0].Kind)
+	assert.Equal(t, models.ChannelSlack, due[0].Channel)
+	assert.Equal(t, 2, due[0].Attempts)
+	assert.Equal(t, lease, *due[0].NextAttemptAt)
+	assert.Nil(t, due[0].SentAt)
+	assert.Equal(t, "timeout", due[0].LastError)
+	assert.Zero(t, due[1].EventID)
+	assert.Zero(t, due[1].PullRequestID)
+	assert.Equal(t, "<p>Hi</p>", due[1].HTML)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationDataBase_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	notification := &models.Notification{ID: 5}
+	notification.MarkSent(at)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE notifications SET attempts = $1, next_attempt_at = $2, sent_at = $3, last_error = $4 WHERE id = $5`)).
+		WithArgs(1, nil, &at, "", 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, postgres.NewNotificationDataBase(db).Save(notification))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+const benchPullRequests = 10000
+
+// BenchmarkPullRequestDataBase_GetAll reads 10k pull requests with two reviewers each, once with
+// the aggregated query and once the way the repository used to: pull requests first, then their
+// reviewers in a second query with every pull request id as a parameter. It needs a migrated
+// Postgres in BENCH_DATABASE_URL and is skipped without one; the rows it seeds are deleted
+// afterwards.
+func BenchmarkPullRequestDataBase_GetAll(b *testing.B) {
+	dsn := os.Getenv("BENCH_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("BENCH_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(b, err)
+	b.Cleanup(func() { db.Close() })
+	seedPullRequests(b, db, fmt.Sprintf("bench-%d", time.Now().UnixNano()))
+
+	b.Run("one query", func(b *testing.B) {
+		prDB := postgres.NewPullRequestDataBase(db)
+		for b.Loop() {
+			prs, err := prDB.GetAll()
+			require.NoError(b, err)
+			require.GreaterOrEqual(b, len(prs), benchPullRequests)
+		}
+	})
+
+	b.Run("pull request and reviewer queries", func(b *testing.B) {
+		for b.Loop() {
+			prs, err := getAllInTwoQueries(db)
+			require.NoError(b, err)
+			require.GreaterOrEqual(b, len(prs), benchPullRequests)
+		}
+	})
+}
+
+// seedPullRequests adds a team of 52 users whose first member authors benchPullRequests pull
+// requests, each reviewed by two of the others, and deletes the team with everything in it once
+// the benchmark is done.
+func seedPullRequests(b *testing.B, db *sql.DB, team string) {
+	b.Helper()
+	var teamID int
+	require.NoError(b, db.QueryRow(`insert into teams (name) values ($1) returning id`, team).Scan(&teamID))
+	b.Cleanup(func() {
+		_, err := db.Exec(`delete from teams where name = $1`, team)
+		require.NoError(b, err)
+	})
+
+	_, err := db.Exec(`insert into users (name, email, team_name)
+		select 'User ' || n, $1 || '-' || n || '@test.com', $1 from generate_series(1, 52) n`, team)
+	require.NoError(b, err)
+	_, err = db.Exec(`insert into prs (title, author_id, team_id, status)
+		select 'PR ' || n, (select min(id) from users where team_name = $1), $2, 'OPEN' from generate_series(1, $3::int) n`,
+		team, teamID, benchPullRequests)
+	require.NoError(b, err)
+	_, err = db.Exec(`insert into assigned_reviewers (pr_id, user_id)
+		select p.id, r.id from prs p
+		join (select id, row_number() over (order by id) - 1 as n from users where team_name = $1) r
+			on r.n in (1 + p.id % 50, 2 + p.id % 50)
+		where p.team_id = $2`, team, teamID)
+	require.NoError(b, err)
+}
+
+// getAllInTwoQueries is the previous PullRequestDataBase.GetAll, kept as the baseline.
+func getAllInTwoQueries(db *sql.DB) ([]*models.PullRequest, error) {
+	sb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	prsQuery, prsArgs, err := sb.
+		Select("p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "p.paths", "p.labels", "p.size", "p.version",
+			"u.id", "u.name", "u.email", "u.team_name", "u.is_active").
+		From("prs p").
+		Join("users u ON p.author_id = u.id").
+		OrderBy("p.created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	prsRows, err := db.Query(prsQuery, prsArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer prsRows.Close()
+
+	var prs []*models.PullRequest
+	prsByID := make(map[int]*models.PullRequest)
+	for prsRows.Next() {
+		pr := &models.PullRequest{Author: &models.User{}, Reviewers: make([]*models.User, 0)}
+		var status string
+		var mergedAt sql.NullTime
+		err := prsRows.Scan(
+			&pr.ID, &pr.Name, &status, &pr.CreatedAt, &mergedAt, pq.Array(&pr.Paths), pq.Array(&pr.Labels), &pr.Size, &pr.Version,
+			&pr.Author.ID, &pr.Author.Name, &pr.Author.Email, &pr.Author.TeamName, &pr.Author.IsActive,
+		)
+		if err != nil {
+			return nil, err
+		}
+		pr.Status = models.PRStatus(status)
+		if mergedAt.Valid {
+			pr.MergedAt = mergedAt.Time
+		}
+		prs = append(prs, pr)
+		prsByID[pr.ID] = pr
+	}
+	if err := prsRows.Err(); err != nil {
+		return nil, err
+	}
+
+	prIDs := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.ID)
+	}
+	reviewersQuery, reviewersArgs, err := sb.
+		Select("ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active").
+		From("assigned_reviewers ar").
+		Join("users u ON ar.user_id = u.id").
+		Where(squirrel.Eq{"ar.pr_id": prIDs}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	reviewersRows, err := db.Query(reviewersQuery, reviewersArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer reviewersRows.Close()
+
+	for reviewersRows.Next() {
+		var prID int
+		reviewer := &models.User{}
+		err := reviewersRows.Scan(&prID, &reviewer.ID, &reviewer.Name, &reviewer.Email, &reviewer.TeamName, &reviewer.IsActive)
+		if err != nil {
+			return nil, err
+		}
+		if pr, exists := prsByID[prID]; exists {
+			pr.Reviewers = append(pr.Reviewers, reviewer)
+		}
+	}
+	return prs, reviewersRows.Err()
+}
@@ -1,8 +1,9 @@
 package persistence
 
 import (
-	"database/sql"
+	"fmt"
 	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
 	"reviewer-assignment-service/internal/domain/repositories"
 	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
 	"testing"
@@ -13,6 +14,18 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const selectPullRequests = `SELECT p.id, p.title, p.status, p.created_at, p.merged_at, p.paths, p.labels, p.size, p.version, ` +
+	`u.id, u.name, u.email, u.team_name, u.is_active, u.timezone, u.work_start_hour, u.work_end_hour, ` +
+	`COALESCE((SELECT json_agg(json_build_object('id', r.id, 'name', r.name, 'email', r.email, 'team_name', r.team_name, 'is_active', r.is_active, ` +
+	`'working_hours', json_build_object('timezone', r.timezone, 'start_hour', r.work_start_hour, 'end_hour', r.work_end_hour)) ORDER BY a.assigned_at, r.id) ` +
+	`FROM assigned_reviewers a JOIN users r ON a.user_id = r.id WHERE a.pr_id = p.id), '[]') ` +
+	`FROM prs p JOIN users u ON p.author_id = u.id`
+
+const orderPullRequests = ` ORDER BY p.created_at DESC, p.id DESC`
+
+var pullRequestColumns = []string{"p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "p.paths", "p.labels", "p.size", "p.version",
+	"u.id", "u.name", "u.email", "u.team_name", "u.is_active", "u.timezone", "u.work_start_hour", "u.work_end_hour", "reviewers"}
+
 func TestPullRequestDataBase_GetByID(t *testing.T) {
 	t.Run("pr not found", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -21,9 +34,9 @@ func TestPullRequestDataBase_GetByID(t *testing.T) {
 
 		prDB := postgres.NewPullRequestDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.title, p.status, p.created_at, p.merged_at, u.id, u.name, u.email, u.team_name, u.is_active FROM prs p JOIN users u ON p.author_id = u.id WHERE p.id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + ` WHERE p.id = $1` + orderPullRequests)).
 			WithArgs(999).
-			WillReturnError(sql.ErrNoRows)
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns))
 
 		pr, err := prDB.GetByID(999)
 		assert.Nil(t, pr)
@@ -39,20 +52,61 @@ func TestPullRequestDataBase_GetByID(t *testing.T) {
 		prDB := postgres.NewPullRequestDataBase(db)
 		createdAt := time.Now()
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.title, p.status, p.created_at, p.merged_at, u.id, u.name, u.email, u.team_name, u.is_active FROM prs p JOIN users u ON p.author_id = u.id WHERE p.id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + ` WHERE p.id = $1` + orderPullRequests)).
 			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}).
-				AddRow(1, "Test PR", "open", createdAt, nil, 1, "User 1", "user1@test.com", "Team A", true))
-
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.name, u.email, u.team_name, u.is_active FROM assigned_reviewers ar JOIN users u ON ar.user_id = u.id WHERE ar.pr_id = $1`)).
-			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"u.id", "u.name", "u.email", "u.team_name", "u.is_active"}))
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "Test PR", "open", createdAt, nil, "{}", "{}", nil, 3, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18, "[]"))
 
 		pr, err := prDB.GetByID(1)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, pr.ID)
+		assert.NotNil(t, pr.Reviewers)
 		assert.Empty(t, pr.Reviewers)
 		assert.True(t, pr.MergedAt.IsZero())
+		assert.Nil(t, pr.Size)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("pr with reviewers and their working hours", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		prDB := postgres.NewPullRequestDataBase(db)
+		createdAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+		mergedAt := createdAt.Add(time.Hour)
+		size := 40
+
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + ` WHERE p.id = $1` + orderPullRequests)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "Test PR", "merged", createdAt, mergedAt, "{api/handlers.go}", "{bug}", 40, 3, 1, "User 1", "user1@test.com", "Team A", true, "Europe/Moscow", 10, 19,
+					`[{"id": 2, "name": "Reviewer 2", "email": "reviewer2@test.com", "team_name": "Team A", "is_active": true, `+
+						`"working_hours": {"timezone": "Asia/Tokyo", "start_hour": 8, "end_hour": 17}}, `+
+						`{"id": 3, "name": "Reviewer 3", "email": "reviewer3@test.com", "team_name": "Team A", "is_active": false, `+
+						`"working_hours": {"timezone": "UTC", "start_hour": 9, "end_hour": 18}}]`))
+
+		pr, err := prDB.GetByID(1)
+		require.NoError(t, err)
+		assert.Equal(t, &models.PullRequest{
+			ID:     1,
+			Name:   "Test PR",
+			Status: models.PRStatus("merged"),
+			Author: &models.User{ID: 1, Name: "User 1", Email: "user1@test.com", TeamName: "Team A", IsActive: true,
+				WorkingHours: models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19}},
+			Reviewers: []*models.User{
+				{ID: 2, Name: "Reviewer 2", Email: "reviewer2@test.com", TeamName: "Team A", IsActive: true,
+					WorkingHours: models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 8, EndHour: 17}},
+				{ID: 3, Name: "Reviewer 3", Email: "reviewer3@test.com", TeamName: "Team A", IsActive: false,
+					WorkingHours: models.DefaultWorkingHours},
+			},
+			Paths:     []string{"api/handlers.go"},
+			Labels:    []string{"bug"},
+			Size:      &size,
+			CreatedAt: createdAt,
+			MergedAt:  mergedAt,
+			Version:   3,
+		}, pr)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -67,16 +121,12 @@ func TestPullRequestDataBase_GetAll(t *testing.T) {
 		createdAt1 := time.Now()
 		createdAt2 := time.Now().Add(-time.Hour)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.title, p.status, p.created_at, p.merged_at, u.id, u.name, u.email, u.team_name, u.is_active FROM prs p JOIN users u ON p.author_id = u.id ORDER BY p.created_at DESC`)).
-			WillReturnRows(sqlmock.NewRows([]string{"p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}).
-				AddRow(1, "PR 1", "open", createdAt1, nil, 1, "User 1", "user1@test.com", "Team A", true).
-				AddRow(2, "PR 2", "merged", createdAt2, createdAt2.Add(time.Hour), 2, "User 2", "user2@test.com", "Team B", true))
-
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ar.pr_id, u.id, u.name, u.email, u.team_name, u.is_active FROM assigned_reviewers ar JOIN users u ON ar.user_id = u.id WHERE ar.pr_id IN ($1,$2)`)).
-			WithArgs(1, 2).
-			WillReturnRows(sqlmock.NewRows([]string{"ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}).
-				AddRow(1, 3, "Reviewer 1", "reviewer1@test.com", "Team A", true).
-				AddRow(2, 4, "Reviewer 2", "reviewer2@test.com", "Team B", true))
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + orderPullRequests)).
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "PR 1", "open", createdAt1, nil, "{}", "{}", nil, 3, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18,
+					`[{"id": 3, "name": "Reviewer 1", "email": "reviewer1@test.com", "team_name": "Team A", "is_active": true}]`).
+				AddRow(2, "PR 2", "merged", createdAt2, createdAt2.Add(time.Hour), "{}", "{}", nil, 3, 2, "User 2", "user2@test.com", "Team B", true, "UTC", 9, 18,
+					`[{"id": 4, "name": "Reviewer 2", "email": "reviewer2@test.com", "team_name": "Team B", "is_active": true}]`))
 
 		prs, err := prDB.GetAll()
 		assert.NoError(t, err)
@@ -85,6 +135,7 @@ func TestPullRequestDataBase_GetAll(t *testing.T) {
 		assert.Equal(t, 2, prs[1].ID)
 		assert.Len(t, prs[0].Reviewers, 1)
 		assert.Len(t, prs[1].Reviewers, 1)
+		assert.Equal(t, 4, prs[1].Reviewers[0].ID)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -95,14 +146,50 @@ func TestPullRequestDataBase_GetAll(t *testing.T) {
 
 		prDB := postgres.NewPullRequestDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.title, p.status, p.created_at, p.merged_at, u.id, u.name, u.email, u.team_name, u.is_active FROM prs p JOIN users u ON p.author_id = u.id ORDER BY p.created_at DESC`)).
-			WillReturnRows(sqlmock.NewRows([]string{"p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}))
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + orderPullRequests)).
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns))
 
 		prs, err := prDB.GetAll()
 		assert.NoError(t, err)
 		assert.Empty(t, prs)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("malformed reviewers", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		prDB := postgres.NewPullRequestDataBase(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + orderPullRequests)).
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "PR 1", "open", time.Now(), nil, "{}", "{}", nil, 3, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18, `[{"id":`))
+
+		prs, err := prDB.GetAll()
+		assert.Nil(t, prs)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPullRequestDataBase_GetByStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	prDB := postgres.NewPullRequestDataBase(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + ` WHERE p.status = $1` + orderPullRequests)).
+		WithArgs("OPEN").
+		WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+			AddRow(1, "Open PR", "OPEN", time.Now(), nil, "{}", "{}", nil, 1, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18, "[]"))
+
+	prs, err := prDB.GetByStatus(models.StatusOpen)
+	assert.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, models.StatusOpen, prs[0].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestPullRequestDataBase_GetByAuthorID(t *testing.T) {
@@ -114,19 +201,17 @@ func TestPullRequestDataBase_GetByAuthorID(t *testing.T) {
 		prDB := postgres.NewPullRequestDataBase(db)
 		createdAt := time.Now()
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.title, p.status, p.created_at, p.merged_at, u.id, u.name, u.email, u.team_name, u.is_active FROM prs p JOIN users u ON p.author_id = u.id WHERE p.author_id = $1 ORDER BY p.created_at DESC`)).
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + ` WHERE p.author_id = $1` + orderPullRequests)).
 			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}).
-				AddRow(1, "Author PR", "open", createdAt, nil, 1, "User 1", "user1@test.com", "Team A", true))
-
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ar.pr_id, u.id, u.name, u.email, u.team_name, u.is_active FROM assigned_reviewers ar JOIN users u ON ar.user_id = u.id WHERE ar.pr_id IN ($1)`)).
-			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}))
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "Author PR", "open", createdAt, nil, "{}", "{}", 150, 3, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18, "[]"))
 
 		prs, err := prDB.GetByAuthorID(1)
 		assert.NoError(t, err)
 		assert.Len(t, prs, 1)
 		assert.Equal(t, 1, prs[0].Author.ID)
+		require.NotNil(t, prs[0].Size)
+		assert.Equal(t, 150, *prs[0].Size)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -140,15 +225,11 @@ func TestPullRequestDataBase_GetByReviewerID(t *testing.T) {
 		prDB := postgres.NewPullRequestDataBase(db)
 		createdAt := time.Now()
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.title, p.status, p.created_at, p.merged_at, u.id, u.name, u.email, u.team_name, u.is_active FROM prs p JOIN users u ON p.author_id = u.id JOIN assigned_reviewers ar ON p.id = ar.pr_id WHERE ar.user_id = $1 ORDER BY p.created_at DESC`)).
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests + ` WHERE EXISTS (SELECT 1 FROM assigned_reviewers f WHERE f.pr_id = p.id AND f.user_id IN ($1))` + orderPullRequests)).
 			WithArgs(2).
-			WillReturnRows(sqlmock.NewRows([]string{"p.id", "p.title", "p.status", "p.created_at", "p.merged_at", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}).
-				AddRow(1, "Reviewed PR", "open", createdAt, nil, 1, "User 1", "user1@test.com", "Team A", true))
-
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ar.pr_id, u.id, u.name, u.email, u.team_name, u.is_active FROM assigned_reviewers ar JOIN users u ON ar.user_id = u.id WHERE ar.pr_id IN ($1)`)).
-			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"ar.pr_id", "u.id", "u.name", "u.email", "u.team_name", "u.is_active"}).
-				AddRow(1, 2, "Reviewer", "reviewer@test.com", "Team A", true))
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "Reviewed PR", "open", createdAt, nil, "{}", "{}", nil, 3, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18,
+					`[{"id": 2, "name": "Reviewer", "email": "reviewer@test.com", "team_name": "Team A", "is_active": true}]`))
 
 		prs, err := prDB.GetByReviewerID(2)
 		assert.NoError(t, err)
@@ -158,3 +239,140 @@ func TestPullRequestDataBase_GetByReviewerID(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestPullRequestDataBase_GetByReviewerIDs(t *testing.T) {
+	t.Run("shares pull requests between reviewers", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		prDB := postgres.NewPullRequestDataBase(db)
+		createdAt := time.Now()
+		reviewer := func(id int) string {
+			return fmt.Sprintf(`{"id": %d, "name": "Reviewer %d", "email": "reviewer%d@test.com", "team_name": "Team A", "is_active": true}`, id, id, id)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(selectPullRequests+` WHERE EXISTS (SELECT 1 FROM assigned_reviewers f WHERE f.pr_id = p.id AND f.user_id IN ($1,$2,$3))`+orderPullRequests)).
+			WithArgs(2, 3, 4).
+			WillReturnRows(sqlmock.NewRows(pullRequestColumns).
+				AddRow(1, "Shared PR", "open", createdAt, nil, "{}", "{}", nil, 1, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18, "["+reviewer(2)+", "+reviewer(3)+"]").
+				AddRow(2, "Other PR", "open", createdAt, nil, "{}", "{}", nil, 1, 1, "User 1", "user1@test.com", "Team A", true, "UTC", 9, 18, "["+reviewer(3)+", "+reviewer(5)+"]"))
+
+		prsByReviewer, err := prDB.GetByReviewerIDs([]int{2, 3, 4})
+		require.NoError(t, err)
+		require.Len(t, prsByReviewer[2], 1)
+		require.Len(t, prsByReviewer[3], 2)
+		assert.Empty(t, prsByReviewer[4])
+		assert.NotContains(t, prsByReviewer, 5)
+		assert.Same(t, prsByReviewer[2][0], prsByReviewer[3][0])
+		assert.Len(t, prsByReviewer[2][0].Reviewers, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no reviewers", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		prsByReviewer, err := postgres.NewPullRequestDataBase(db).GetByReviewerIDs(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, prsByReviewer)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPullRequestDataBase_FindPossibleReviewers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	prDB := postgres.NewPullRequestDataBase(db)
+	author := &models.User{ID: 1, TeamName: "backend"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.name, u.email, u.team_name, u.is_active, u.timezone, u.work_start_hour, u.work_end_hour FROM team_members m JOIN users u ON m.user_id = u.id WHERE (u.team_name = $1 AND u.is_active = $2 AND u.id <> $3) ORDER BY u.id`)).
+		WithArgs("backend", true, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"u.id", "u.name", "u.email", "u.team_name", "u.is_active", "u.timezone", "u.work_start_hour", "u.work_end_hour"}).
+			AddRow(2, "Peer", "peer@test.com", "backend", true, "UTC", 9, 18).
+			AddRow(3, "Billing", "billing@test.com", "backend", true, "Asia/Tokyo", 10, 19))
+
+	reviewers, err := prDB.FindPossibleReviewers(author)
+	require.NoError(t, err)
+	require.Len(t, reviewers, 2)
+	assert.Equal(t, 2, reviewers[0].ID)
+	assert.Equal(t, models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 10, EndHour: 19}, reviewers[1].WorkingHours)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPullRequestDataBase_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	prDB := postgres.NewPullRequestDataBase(db)
+	pr := &models.PullRequest{
+		ID:        1,
+		Name:      "Feature",
+		Status:    models.StatusOpen,
+		Reviewers: []*models.User{{ID: 3}, {ID: 4}},
+		Version:   2,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE prs SET title = $1, status = $2, merged_at = $3, paths = $4, labels = $5, size = $6, version = version + 1 WHERE id = $7 AND version = $8 RETURNING version`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM assigned_reviewers WHERE pr_id = $1 AND user_id NOT IN ($2,$3)`)).
+		WithArgs(1, 3, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assigned_reviewers (pr_id,user_id) VALUES ($1,$2) ON CONFLICT (pr_id, user_id) DO NOTHING`)).
+		WithArgs(1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO assigned_reviewers (pr_id,user_id) VALUES ($1,$2) ON CONFLICT (pr_id, user_id) DO NOTHING`)).
+		WithArgs(1, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, prDB.Update(pr))
+	assert.Equal(t, 3, pr.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPullRequestDataBase_UpdateStaleVersion(t *testing.T) {
+	updateQuery := regexp.QuoteMeta(`UPDATE prs SET title = $1, status = $2, merged_at = $3, paths = $4, labels = $5, size = $6, version = version + 1 WHERE id = $7 AND version = $8 RETURNING version`)
+
+	t.Run("pull request was changed concurrently", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		pr := &models.PullRequest{ID: 1, Name: "Feature", Status: models.StatusOpen, Version: 2}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT 1 FROM prs WHERE id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, postgres.NewPullRequestDataBase(db).Update(pr), models.ErrConflictingUpdate)
+		assert.Equal(t, 2, pr.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("pull request is gone", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		pr := &models.PullRequest{ID: 1, Name: "Feature", Status: models.StatusOpen, Version: 2}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT 1 FROM prs WHERE id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, postgres.NewPullRequestDataBase(db).Update(pr), repositories.ErrPullRequestNotFoundInPersistence)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,36 @@
+package persistence
+
+import (
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestEventDataBase_GetByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	eventDB := postgres.NewPullRequestEventDataBase(db)
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, pr_id, kind, user_id, previous_user_id, created_at FROM pr_events WHERE user_id = $1 AND id > $2 ORDER BY id`)).
+		WithArgs(2, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pr_id", "kind", "user_id", "previous_user_id", "created_at"}).
+			AddRow(11, 1, "reviewer_assigned", 2, 3, at).
+			AddRow(12, 1, "merged", 2, nil, at))
+
+	events, err := eventDB.GetByUserID(2, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.PullRequestEvent{ID: 11, PullRequestID: 1, Kind: models.EventReviewerAssigned, UserID: 2, PreviousUserID: 3, CreatedAt: at}, *events[0])
+	assert.Equal(t, models.EventMerged, events[1].Kind)
+	assert.Zero(t, events[1].PreviousUserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
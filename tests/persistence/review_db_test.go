@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewDataBase_SetState(t *testing.T) {
+	at := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	t.Run("approval marks the reviewer as reviewed", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE assigned_reviewers SET review_state = $1, state_changed_at = $2, reviewed_at = COALESCE(reviewed_at, $3) WHERE pr_id = $4 AND user_id = $5`)).
+			WithArgs("approved", at, at, 10, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = postgres.NewReviewDataBase(db).SetState(10, 5, models.ReviewApproved, at)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("dismissal of an unassigned reviewer", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE assigned_reviewers SET review_state = $1, state_changed_at = $2 WHERE pr_id = $3 AND user_id = $4`)).
+			WithArgs("dismissed", at, 10, 5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = postgres.NewReviewDataBase(db).SetState(10, 5, models.ReviewDismissed, at)
+		assert.ErrorIs(t, err, models.ErrReviewerNotAssigned)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReviewDataBase_GetApprovals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	reviewDB := postgres.NewReviewDataBase(db)
+	query := regexp.QuoteMeta(`SELECT COALESCE(tm.required_approvals, 0), COUNT(ar.user_id) FILTER (WHERE ar.review_state = 'approved') FROM prs p LEFT JOIN teams tm ON tm.id = p.team_id LEFT JOIN assigned_reviewers ar ON ar.pr_id = p.id WHERE p.id = $1 GROUP BY tm.required_approvals`)
+
+	mock.ExpectQuery(query).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"required_approvals", "approved"}).AddRow(2, 1))
+	mock.ExpectQuery(query).
+		WithArgs(11).
+		WillReturnRows(sqlmock.NewRows([]string{"required_approvals", "approved"}))
+
+	status, err := reviewDB.GetApprovals(10)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ApprovalStatus{Required: 2, Approved: 1}, status)
+
+	_, err = reviewDB.GetApprovals(11)
+	assert.ErrorIs(t, err, repositories.ErrPullRequestNotFoundInPersistence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewDataBase_SavePolicy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET required_approvals = $1 WHERE id = $2`)).
+		WithArgs(1, 9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = postgres.NewReviewDataBase(db).SavePolicy(&models.ApprovalPolicy{TeamID: 9, RequiredApprovals: 1})
+	assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
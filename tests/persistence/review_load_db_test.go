@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"database/sql"
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewLoadDataBase_GetByTeamName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	loadDB := postgres.NewReviewLoadDataBase(db)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT ar.user_id, SUM(GREATEST(COALESCE(p.size, 1), 1) * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (CAST($1 AS timestamp) - p.created_at)), 0) / (tm.review_half_life_hours * 3600.0))) FROM assigned_reviewers ar JOIN prs p ON p.id = ar.pr_id JOIN users u ON u.id = ar.user_id JOIN teams tm ON tm.name = u.team_name WHERE u.team_name = $2 GROUP BY ar.user_id`)).
+		WithArgs(now, "backend").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "sum"}).
+			AddRow(2, 150.5).
+			AddRow(3, 12.25))
+
+	loads, err := loadDB.GetByTeamName("backend", now)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReviewLoads{2: 150.5, 3: 12.25}, loads)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewLoadDataBase_HalfLife(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		loadDB := postgres.NewReviewLoadDataBase(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT review_half_life_hours FROM teams WHERE id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"review_half_life_hours"}).AddRow(72))
+
+		halfLife, err := loadDB.GetHalfLife(1)
+		require.NoError(t, err)
+		assert.Equal(t, 72*time.Hour, halfLife)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get unknown team", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		loadDB := postgres.NewReviewLoadDataBase(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT review_half_life_hours FROM teams WHERE id = $1`)).
+			WithArgs(9).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err = loadDB.GetHalfLife(9)
+		assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("set unknown team", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		loadDB := postgres.NewReviewLoadDataBase(db)
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET review_half_life_hours = $1 WHERE id = $2`)).
+			WithArgs(48, 9).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = loadDB.SetHalfLife(9, 48*time.Hour)
+		assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
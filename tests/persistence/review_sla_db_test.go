@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewSLADataBase_FindPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	slaDB := postgres.NewReviewSLADataBase(db)
+	now := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	created := now.Add(-48 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.created_at, tm.id, tm.review_sla_hours, tm.sla_action, u.timezone, u.work_start_hour, u.work_end_hour FROM prs p JOIN teams tm ON tm.id = p.team_id JOIN users u ON u.id = p.author_id WHERE p.status = $1 AND tm.review_sla_hours IS NOT NULL AND p.created_at <= CAST($2 AS timestamp) - tm.review_sla_hours * interval '1 hour' AND NOT EXISTS (SELECT 1 FROM assigned_reviewers ar WHERE ar.pr_id = p.id AND (ar.reviewed_at IS NOT NULL OR ar.assigned_at > CAST($3 AS timestamp) - tm.review_sla_hours * interval '1 hour')) ORDER BY p.id`)).
+		WithArgs("OPEN", now, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "id", "review_sla_hours", "sla_action", "timezone", "work_start_hour", "work_end_hour"}).
+			AddRow(10, created, 1, 24, "reassign", "UTC", 9, 18).
+			AddRow(11, created, 1, 24, "reassign", "Asia/Tokyo", 10, 19))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pr_id, user_id, review_state, assigned_at, reviewed_at, state_changed_at FROM assigned_reviewers WHERE pr_id IN ($1,$2) ORDER BY pr_id, assigned_at, user_id`)).
+		WithArgs(10, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"pr_id", "user_id", "review_state", "assigned_at", "reviewed_at", "state_changed_at"}).
+			AddRow(10, 2, "pending", created, nil, nil).
+			AddRow(10, 3, "dismissed", created.Add(time.Hour), nil, created.Add(2*time.Hour)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, timezone, work_start_hour, work_end_hour FROM users WHERE id IN ($1,$2)`)).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "timezone", "work_start_hour", "work_end_hour"}).
+			AddRow(2, "Europe/Berlin", 8, 17).
+			AddRow(3, "UTC", 9, 18))
+
+	pending, err := slaDB.FindPending(now)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, models.ReviewSLA{TeamID: 1, Hours: 24, Action: models.SLAActionReassign}, pending[0].SLA)
+	require.Len(t, pending[0].Assignments, 2)
+	assert.Equal(t, models.ReviewDismissed, pending[0].Assignments[1].State)
+	assert.Equal(t, map[int]models.WorkingHours{
+		2: {Timezone: "Europe/Berlin", StartHour: 8, EndHour: 17},
+		3: {Timezone: "UTC", StartHour: 9, EndHour: 18},
+	}, pending[0].ReviewerHours)
+	assert.Empty(t, pending[1].Assignments)
+	assert.Equal(t, models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 10, EndHour: 19}, pending[1].AuthorHours)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewSLADataBase_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	slaDB := postgres.NewReviewSLADataBase(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET review_sla_hours = $1, sla_action = $2 WHERE id = $3`)).
+		WithArgs(nil, "add_reviewer", 9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = slaDB.Save(&models.ReviewSLA{TeamID: 9, Action: models.SLAActionAddReviewer})
+	assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewSLADataBase_MarkReviewed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	slaDB := postgres.NewReviewSLADataBase(db)
+	at := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE assigned_reviewers SET reviewed_at = COALESCE(reviewed_at, $1) WHERE pr_id = $2 AND user_id = $3`)).
+		WithArgs(at, 10, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = slaDB.MarkReviewed(10, 5, at)
+	assert.ErrorIs(t, err, models.ErrReviewerNotAssigned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"errors"
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerRuleDataBase_Add(t *testing.T) {
+	t.Run("successful add", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		ruleDB := postgres.NewReviewerRuleDataBase(db)
+		rule := &models.ReviewerRule{TeamID: 1, Kind: models.RuleRequireTag, Tag: "senior"}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO reviewer_rules (team_id,kind,reviewer_id,author_id,tag,author_tag) VALUES ($1,$2,$3,$4,$5,$6) RETURNING id`)).
+			WithArgs(1, "require_tag", nil, nil, "senior", nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+		err = ruleDB.Add(rule)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, rule.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		ruleDB := postgres.NewReviewerRuleDataBase(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO reviewer_rules`)).
+			WillReturnError(errors.New(`pq: insert or update on table "reviewer_rules" violates foreign key constraint "reviewer_rules_team_id_fkey"`))
+
+		err = ruleDB.Add(&models.ReviewerRule{TeamID: 9, Kind: models.RuleRequireTag, Tag: "senior"})
+		assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReviewerRuleDataBase_GetByTeamName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ruleDB := postgres.NewReviewerRuleDataBase(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.team_id, r.kind, r.reviewer_id, r.author_id, r.tag, r.author_tag FROM reviewer_rules r JOIN teams t ON t.id = r.team_id WHERE t.name = $1 ORDER BY r.id`)).
+		WithArgs("backend").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "kind", "reviewer_id", "author_id", "tag", "author_tag"}).
+			AddRow(1, 1, "never_assign", 2, 3, nil, nil).
+			AddRow(2, 1, "require_tag", nil, nil, "senior", "junior"))
+
+	rules, err := ruleDB.GetByTeamName("backend")
+	require.NoError(t, err)
+	assert.Equal(t, models.ReviewerRules{
+		{ID: 1, TeamID: 1, Kind: models.RuleNeverAssign, ReviewerID: 2, AuthorID: 3},
+		{ID: 2, TeamID: 1, Kind: models.RuleRequireTag, Tag: "senior", AuthorTag: "junior"},
+	}, rules)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewerRuleDataBase_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ruleDB := postgres.NewReviewerRuleDataBase(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM reviewer_rules WHERE id = $1`)).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = ruleDB.Delete(4)
+	assert.ErrorIs(t, err, repositories.ErrReviewerRuleNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTagDataBase_Replace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	tagDB := postgres.NewUserTagDataBase(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_tags WHERE user_id = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_tags (user_id,tag) VALUES ($1,$2),($3,$4)`)).
+		WithArgs(2, "go", 2, "senior").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err = tagDB.Replace(2, []string{"go", "senior"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -31,9 +31,9 @@ func TestTeamDataBase_GetByID(t *testing.T) {
 			},
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM teams WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, version FROM teams WHERE id = $1`)).
 			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "backend"))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "backend", 1))
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.name, u.is_active FROM users u JOIN teams tm ON u.team_name = tm.name WHERE tm.id = $1`)).
 			WithArgs(1).
@@ -56,7 +56,7 @@ func TestTeamDataBase_GetByID(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM teams WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, version FROM teams WHERE id = $1`)).
 			WithArgs(999).
 			WillReturnError(sql.ErrNoRows)
 
@@ -73,9 +73,9 @@ func TestTeamDataBase_GetByID(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM teams WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, version FROM teams WHERE id = $1`)).
 			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "backend"))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "backend", 1))
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.name, u.is_active FROM users u JOIN teams tm ON u.team_name = tm.name WHERE tm.id = $1`)).
 			WithArgs(1).
@@ -98,9 +98,9 @@ func TestTeamDataBase_GetByName(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM teams WHERE name = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, version FROM teams WHERE name = $1`)).
 			WithArgs("backend").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "backend"))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "backend", 1))
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.name, u.is_active FROM users u JOIN teams tm ON u.team_name = tm.name WHERE tm.name = $1`)).
 			WithArgs("backend").
@@ -122,7 +122,7 @@ func TestTeamDataBase_GetByName(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM teams WHERE name = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, version FROM teams WHERE name = $1`)).
 			WithArgs("nonexistent").
 			WillReturnError(sql.ErrNoRows)
 
@@ -141,8 +141,8 @@ func TestTeamDataBase_GetAll(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM teams`)).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, version FROM teams`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}))
 
 		teams, err := teamDB.GetAll()
 		assert.NoError(t, err)
@@ -160,18 +160,61 @@ func TestTeamDataBase_Update(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 		team := &models.Team{
-			ID:   1,
-			Name: "backend-updated",
+			ID:      1,
+			Name:    "backend-updated",
+			Version: 1,
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET name = $1 WHERE id = $2`)).
-			WithArgs("backend-updated", 1).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE teams SET name = $1, version = version + 1 WHERE id = $2 AND version = $3 RETURNING version`)).
+			WithArgs("backend-updated", 1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
 		mock.ExpectCommit()
 
 		err = teamDB.Update(team)
 		assert.NoError(t, err)
+		assert.Equal(t, 2, team.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update over the version that was read", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		teamDB := postgres.NewTeamDataBase(db)
+		team := &models.Team{ID: 1, Name: "backend", Version: 4}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE teams SET name = $1, version = version + 1 WHERE id = $2 AND version = $3 RETURNING version`)).
+			WithArgs("backend", 1, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(5))
+		mock.ExpectCommit()
+
+		require.NoError(t, teamDB.Update(team))
+		assert.Equal(t, 5, team.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		teamDB := postgres.NewTeamDataBase(db)
+		team := &models.Team{ID: 1, Name: "backend", Version: 4}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE teams SET name = $1, version = version + 1 WHERE id = $2 AND version = $3 RETURNING version`)).
+			WithArgs("backend", 1, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT 1 FROM teams WHERE id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, teamDB.Update(team), models.ErrConflictingUpdate)
+		assert.Equal(t, 4, team.Version)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -182,14 +225,18 @@ func TestTeamDataBase_Update(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 		team := &models.Team{
-			ID:   999,
-			Name: "nonexistent",
+			ID:      999,
+			Name:    "nonexistent",
+			Version: 1,
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET name = $1 WHERE id = $2`)).
-			WithArgs("nonexistent", 999).
-			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE teams SET name = $1, version = version + 1 WHERE id = $2 AND version = $3 RETURNING version`)).
+			WithArgs("nonexistent", 999, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT 1 FROM teams WHERE id = $1`)).
+			WithArgs(999).
+			WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
 		mock.ExpectRollback()
 
 		err = teamDB.Update(team)
@@ -204,13 +251,14 @@ func TestTeamDataBase_Update(t *testing.T) {
 
 		teamDB := postgres.NewTeamDataBase(db)
 		team := &models.Team{
-			ID:   1,
-			Name: "existing-name",
+			ID:      1,
+			Name:    "existing-name",
+			Version: 1,
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE teams SET name = $1 WHERE id = $2`)).
-			WithArgs("existing-name", 1).
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE teams SET name = $1, version = version + 1 WHERE id = $2 AND version = $3 RETURNING version`)).
+			WithArgs("existing-name", 1, 1).
 			WillReturnError(errors.New("pq: duplicate key value violates unique constraint"))
 		mock.ExpectRollback()
 
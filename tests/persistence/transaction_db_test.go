@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"errors"
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionManager_WithinTransaction(t *testing.T) {
+	t.Run("repositories share one transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO users (name,email,team_name,is_active,timezone,work_start_hour,work_end_hour) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id`)).
+			WithArgs("John", "john@example.com", "backend", true, "UTC", 9, 18).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET is_active = $1 WHERE id = $2`)).
+			WithArgs(false, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		manager := postgres.NewTransactionManager(db)
+		err = manager.WithinTransaction(func(repos repositories.Repositories) error {
+			user := models.NewUser("John", "john@example.com", true, "backend")
+			if err := repos.Users.Add(user); err != nil {
+				return err
+			}
+			return repos.Users.Deactivate(user.ID)
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error rolls back everything", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		failure := errors.New("boom")
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO users (name,email,team_name,is_active,timezone,work_start_hour,work_end_hour) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id`)).
+			WithArgs("John", "john@example.com", "backend", true, "UTC", 9, 18).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectRollback()
+
+		manager := postgres.NewTransactionManager(db)
+		err = manager.WithinTransaction(func(repos repositories.Repositories) error {
+			if err := repos.Users.Add(models.NewUser("John", "john@example.com", true, "backend")); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
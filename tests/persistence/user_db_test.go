@@ -23,17 +23,18 @@ func TestUserDataBase_GetByID(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUser := &models.User{
-			ID:       1,
-			Name:     "John Doe",
-			Email:    "john@example.com",
-			TeamName: "backend",
-			IsActive: true,
+			ID:           1,
+			Name:         "John Doe",
+			Email:        "john@example.com",
+			TeamName:     "backend",
+			IsActive:     true,
+			WorkingHours: models.DefaultWorkingHours,
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE id = $1`)).
 			WithArgs(1).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(1, "John Doe", "john@example.com", "backend", true))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18))
 
 		user, err := userDB.GetByID(1)
 		assert.NoError(t, err)
@@ -48,7 +49,7 @@ func TestUserDataBase_GetByID(t *testing.T) {
 
 		userDB := postgres.NewUserDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE id = $1`)).
 			WithArgs(999).
 			WillReturnError(sql.ErrNoRows)
 
@@ -65,7 +66,7 @@ func TestUserDataBase_GetByID(t *testing.T) {
 
 		userDB := postgres.NewUserDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE id = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE id = $1`)).
 			WithArgs(1).
 			WillReturnError(errors.New("connection failed"))
 
@@ -77,6 +78,40 @@ func TestUserDataBase_GetByID(t *testing.T) {
 	})
 }
 
+func TestUserDataBase_GetByIDs(t *testing.T) {
+	t.Run("successful get by ids", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		userDB := postgres.NewUserDataBase(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE id IN ($1,$2,$3) ORDER BY id`)).
+			WithArgs(1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18).
+				AddRow(3, "Jane Doe", "jane@example.com", "backend", false, "UTC", 9, 18))
+
+		users, err := userDB.GetByIDs([]int{1, 2, 3})
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, 1, users[0].ID)
+		assert.Equal(t, 3, users[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		users, err := postgres.NewUserDataBase(db).GetByIDs(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestUserDataBase_GetByEmail(t *testing.T) {
 	t.Run("successful get by email", func(t *testing.T) {
 		db, mock, err := sqlmock.New()
@@ -86,17 +121,18 @@ func TestUserDataBase_GetByEmail(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUser := &models.User{
-			ID:       1,
-			Name:     "John Doe",
-			Email:    "john@example.com",
-			TeamName: "backend",
-			IsActive: true,
+			ID:           1,
+			Name:         "John Doe",
+			Email:        "john@example.com",
+			TeamName:     "backend",
+			IsActive:     true,
+			WorkingHours: models.DefaultWorkingHours,
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE email = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE email = $1`)).
 			WithArgs("john@example.com").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(1, "John Doe", "john@example.com", "backend", true))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18))
 
 		user, err := userDB.GetByEmail("john@example.com")
 		assert.NoError(t, err)
@@ -111,7 +147,7 @@ func TestUserDataBase_GetByEmail(t *testing.T) {
 
 		userDB := postgres.NewUserDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE email = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE email = $1`)).
 			WithArgs("nonexistent@example.com").
 			WillReturnError(sql.ErrNoRows)
 
@@ -131,16 +167,16 @@ func TestUserDataBase_GetAll(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUsers := []*models.User{
-			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true},
-			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", TeamName: "frontend", IsActive: true},
-			{ID: 3, Name: "Bob Johnson", Email: "bob@example.com", TeamName: "backend", IsActive: false},
+			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
+			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", TeamName: "frontend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
+			{ID: 3, Name: "Bob Johnson", Email: "bob@example.com", TeamName: "backend", IsActive: false, WorkingHours: models.DefaultWorkingHours},
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users`)).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(1, "John Doe", "john@example.com", "backend", true).
-				AddRow(2, "Jane Smith", "jane@example.com", "frontend", true).
-				AddRow(3, "Bob Johnson", "bob@example.com", "backend", false))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18).
+				AddRow(2, "Jane Smith", "jane@example.com", "frontend", true, "UTC", 9, 18).
+				AddRow(3, "Bob Johnson", "bob@example.com", "backend", false, "UTC", 9, 18))
 
 		users, err := userDB.GetAll()
 		assert.NoError(t, err)
@@ -155,8 +191,8 @@ func TestUserDataBase_GetAll(t *testing.T) {
 
 		userDB := postgres.NewUserDataBase(db)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users`)).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}))
 
 		users, err := userDB.GetAll()
 		assert.NoError(t, err)
@@ -174,15 +210,15 @@ func TestUserDataBase_GetActiveUsers(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUsers := []*models.User{
-			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true},
-			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", TeamName: "frontend", IsActive: true},
+			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
+			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", TeamName: "frontend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE is_active = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE is_active = $1`)).
 			WithArgs(true).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(1, "John Doe", "john@example.com", "backend", true).
-				AddRow(2, "Jane Smith", "jane@example.com", "frontend", true))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18).
+				AddRow(2, "Jane Smith", "jane@example.com", "frontend", true, "UTC", 9, 18))
 
 		users, err := userDB.GetActiveUsers()
 		assert.NoError(t, err)
@@ -200,13 +236,13 @@ func TestUserDataBase_GetWithFilters(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUsers := []*models.User{
-			{ID: 3, Name: "Bob Johnson", Email: "bob@example.com", TeamName: "backend", IsActive: false},
+			{ID: 3, Name: "Bob Johnson", Email: "bob@example.com", TeamName: "backend", IsActive: false, WorkingHours: models.DefaultWorkingHours},
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE team_name = $1 AND is_active = $2`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE team_name = $1 AND is_active = $2`)).
 			WithArgs("backend", false).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(3, "Bob Johnson", "bob@example.com", "backend", false))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(3, "Bob Johnson", "bob@example.com", "backend", false, "UTC", 9, 18))
 
 		users, err := userDB.GetWithFilters("backend", false)
 		assert.NoError(t, err)
@@ -222,15 +258,15 @@ func TestUserDataBase_GetWithFilters(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUsers := []*models.User{
-			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true},
-			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", TeamName: "frontend", IsActive: true},
+			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
+			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", TeamName: "frontend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE is_active = $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE is_active = $1`)).
 			WithArgs(true).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(1, "John Doe", "john@example.com", "backend", true).
-				AddRow(2, "Jane Smith", "jane@example.com", "frontend", true))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18).
+				AddRow(2, "Jane Smith", "jane@example.com", "frontend", true, "UTC", 9, 18))
 
 		users, err := userDB.GetWithFilters("", true)
 		assert.NoError(t, err)
@@ -246,13 +282,13 @@ func TestUserDataBase_GetWithFilters(t *testing.T) {
 		userDB := postgres.NewUserDataBase(db)
 
 		expectedUsers := []*models.User{
-			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true},
+			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true, WorkingHours: models.DefaultWorkingHours},
 		}
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active FROM users WHERE team_name = $1 AND is_active = $2`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, email, team_name, is_active, timezone, work_start_hour, work_end_hour FROM users WHERE team_name = $1 AND is_active = $2`)).
 			WithArgs("backend", true).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active"}).
-				AddRow(1, "John Doe", "john@example.com", "backend", true))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "team_name", "is_active", "timezone", "work_start_hour", "work_end_hour"}).
+				AddRow(1, "John Doe", "john@example.com", "backend", true, "UTC", 9, 18))
 
 		users, err := userDB.GetWithFilters("backend", true)
 		assert.NoError(t, err)
@@ -354,3 +390,26 @@ func TestUserDataBase_Deactivate(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestUserDataBase_SetWorkingHours(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	userDB := postgres.NewUserDataBase(db)
+	query := regexp.QuoteMeta(`UPDATE users SET timezone = $1, work_start_hour = $2, work_end_hour = $3 WHERE id = $4`)
+
+	mock.ExpectExec(query).
+		WithArgs("Europe/Moscow", 10, 19, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs("UTC", 9, 18, 999).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = userDB.SetWorkingHours(1, models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19})
+	assert.NoError(t, err)
+
+	err = userDB.SetWorkingHours(999, models.DefaultWorkingHours)
+	assert.ErrorIs(t, err, repositories.ErrUserNotFoundInPersistence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
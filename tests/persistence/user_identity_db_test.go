@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"errors"
+	"regexp"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/infrastructure/persistence/postgres"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIdentityDataBase_Add(t *testing.T) {
+	t.Run("successful add", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		identityDB := postgres.NewUserIdentityDataBase(db)
+		identity := models.NewUserIdentity(models.ProviderGitHub, "octocat", 1)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO user_identities (provider,login,user_id) VALUES ($1,$2,$3) RETURNING id`)).
+			WithArgs("github", "octocat", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+		err = identityDB.Add(identity)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, identity.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("login already mapped", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		identityDB := postgres.NewUserIdentityDataBase(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO user_identities`)).
+			WillReturnError(errors.New(`pq: duplicate key value violates unique constraint "idx_user_identities_provider_login"`))
+
+		err = identityDB.Add(models.NewUserIdentity(models.ProviderGitHub, "octocat", 1))
+		assert.ErrorIs(t, err, repositories.ErrUserIdentityAlreadyExists)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserIdentityDataBase_GetByLogin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	identityDB := postgres.NewUserIdentityDataBase(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, provider, login, user_id FROM user_identities WHERE provider = $1 AND lower(login) = lower($2)`)).
+		WithArgs("gitlab", "OctoCat").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "login", "user_id"}).
+			AddRow(2, "gitlab", "octocat", 7))
+
+	identity, err := identityDB.GetByLogin(models.ProviderGitLab, "OctoCat")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.UserIdentity{ID: 2, Provider: models.ProviderGitLab, Login: "octocat", UserID: 7}, identity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserIdentityDataBase_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	identityDB := postgres.NewUserIdentityDataBase(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_identities WHERE id = $1`)).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = identityDB.Delete(9)
+	assert.ErrorIs(t, err, repositories.ErrUserIdentityNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
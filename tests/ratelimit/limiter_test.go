@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-assignment-service/internal/app/ratelimit"
+	"reviewer-assignment-service/internal/app/response_errors"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_BurstThenRefill(t *testing.T) {
+	clk := fakeclock.New(fakeclock.Monday)
+	limiter := ratelimit.New(3, time.Minute, clk)
+
+	for i := 2; i >= 0; i-- {
+		decision := limiter.Allow("10.0.0.1")
+		require.True(t, decision.Allowed)
+		assert.Equal(t, i, decision.Remaining)
+	}
+
+	denied := limiter.Allow("10.0.0.1")
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 0, denied.Remaining)
+	assert.Equal(t, 20*time.Second, denied.RetryAfter)
+	assert.Equal(t, time.Minute, denied.Reset)
+
+	assert.True(t, limiter.Allow("10.0.0.2").Allowed, "clients have separate buckets")
+
+	clk.Advance(20 * time.Second)
+	assert.True(t, limiter.Allow("10.0.0.1").Allowed)
+	assert.False(t, limiter.Allow("10.0.0.1").Allowed)
+
+	clk.Advance(time.Hour)
+	decision := limiter.Allow("10.0.0.1")
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Remaining, "a bucket never holds more than the limit")
+}
+
+func TestLimiter_DisabledWithoutRequests(t *testing.T) {
+	assert.Nil(t, ratelimit.New(0, time.Minute, fakeclock.New(fakeclock.Monday)))
+
+	handler := ratelimit.Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	for i := 0; i < 100; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(ratelimit.LimitHeader))
+	}
+}
+
+func TestMiddleware_Headers(t *testing.T) {
+	clk := fakeclock.New(fakeclock.Monday)
+	calls := 0
+	handler := ratelimit.Middleware(ratelimit.New(2, 10*time.Second, clk))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/pull-requests/1", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send("192.0.2.1:50000")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get(ratelimit.LimitHeader))
+	assert.Equal(t, "1", first.Header().Get(ratelimit.RemainingHeader))
+	assert.Equal(t, "5", first.Header().Get(ratelimit.ResetHeader))
+	assert.Empty(t, first.Header().Get(ratelimit.RetryAfterHeader))
+
+	assert.Equal(t, http.StatusOK, send("192.0.2.1:50001").Code, "the port is not part of the client key")
+
+	limited := send("192.0.2.1:50002")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "0", limited.Header().Get(ratelimit.RemainingHeader))
+	assert.Equal(t, "5", limited.Header().Get(ratelimit.RetryAfterHeader))
+	assert.Equal(t, "10", limited.Header().Get(ratelimit.ResetHeader))
+
+	var body response_errors.ErrorResponse
+	require.NoError(t, json.NewDecoder(limited.Body).Decode(&body))
+	assert.Equal(t, "RATE_LIMITED", body.Error.Code)
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, http.StatusOK, send("192.0.2.2:50000").Code)
+
+	clk.Advance(5 * time.Second)
+	assert.Equal(t, http.StatusOK, send("192.0.2.1:50003").Code)
+}
@@ -0,0 +1,573 @@
+package routes
+
+import (
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) Create(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetByID(id int) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) GetByIDs(ids []int) ([]*models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserService) GetByEmail(email string) (*models.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) GetAll() ([]*models.User, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserService) Update(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserService) SetActive(userID int, isActive bool) error {
+	args := m.Called(userID, isActive)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Deactivate(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	args := m.Called(userID, hours)
+	return args.Error(0)
+}
+
+func (m *MockUserService) AddIdentity(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetIdentityByID(id int) (*models.UserIdentity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserService) GetIdentities(userID int) ([]*models.UserIdentity, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserService) GetIdentitiesByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserService) UpdateIdentity(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *MockUserService) DeleteIdentity(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetByExternalLogin(provider models.VCSProvider, login string) (*models.User, error) {
+	args := m.Called(provider, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type MockTeamService struct {
+	mock.Mock
+}
+
+func (m *MockTeamService) Create(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockTeamService) GetByID(id int) (*models.Team, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Team), args.Error(1)
+}
+
+func (m *MockTeamService) GetByName(name string) (*models.Team, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Team), args.Error(1)
+}
+
+func (m *MockTeamService) GetAll() ([]*models.Team, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Team), args.Error(1)
+}
+
+func (m *MockTeamService) Update(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+type MockPullRequestService struct {
+	mock.Mock
+}
+
+func (m *MockPullRequestService) Create(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) GetByID(id int) (*models.PullRequest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) GetByAuthorID(authorID int) ([]*models.PullRequest, error) {
+	args := m.Called(authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) GetByReviewerID(reviewerID int) ([]*models.PullRequest, error) {
+	args := m.Called(reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	args := m.Called(reviewerIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) Update(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) AddReviewers(pr *models.PullRequest, reviewers ...*models.User) error {
+	args := m.Called(pr, reviewers)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) RemoveReviewer(pr *models.PullRequest, reviewerID int) error {
+	args := m.Called(pr, reviewerID)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) Edit(pr *models.PullRequest, name string, status models.PRStatus, reviewers []*models.User) error {
+	args := m.Called(pr, name, status, reviewers)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) AssignReviewers(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) ReassignReviewers(pr *models.PullRequest, oldReviewer *models.User) error {
+	args := m.Called(pr, oldReviewer)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) MergeRequest(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestService) MarkMerged(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+type MockIntegrationService struct {
+	mock.Mock
+}
+
+func (m *MockIntegrationService) HandleEvent(event *models.VCSEvent) (*models.VCSEventResult, error) {
+	args := m.Called(event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VCSEventResult), args.Error(1)
+}
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) Import(doc *models.OrgDocument, dryRun bool) (*models.ImportReport, error) {
+	args := m.Called(doc, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportReport), args.Error(1)
+}
+
+func (m *MockImportService) Export() (*models.OrgDocument, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrgDocument), args.Error(1)
+}
+
+type MockOrgSyncService struct {
+	mock.Mock
+}
+
+func (m *MockOrgSyncService) Plan(doc *models.OrgDocument) (*models.SyncPlan, error) {
+	args := m.Called(doc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncPlan), args.Error(1)
+}
+
+func (m *MockOrgSyncService) Apply(doc *models.OrgDocument) (*models.SyncResult, error) {
+	args := m.Called(doc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncResult), args.Error(1)
+}
+
+type MockMembershipService struct {
+	mock.Mock
+}
+
+func (m *MockMembershipService) MoveUser(userID int, teamName string, reassignReviews bool) (*models.TeamMove, error) {
+	args := m.Called(userID, teamName, reassignReviews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TeamMove), args.Error(1)
+}
+
+var _ services.UserService = (*MockUserService)(nil)
+var _ services.TeamService = (*MockTeamService)(nil)
+var _ services.PullRequestService = (*MockPullRequestService)(nil)
+var _ services.IntegrationService = (*MockIntegrationService)(nil)
+var _ services.ImportService = (*MockImportService)(nil)
+var _ services.OrgSyncService = (*MockOrgSyncService)(nil)
+var _ services.MembershipService = (*MockMembershipService)(nil)
+
+type MockReviewerRuleService struct {
+	mock.Mock
+}
+
+func (m *MockReviewerRuleService) Create(rule *models.ReviewerRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockReviewerRuleService) GetByTeamID(teamID int) (models.ReviewerRules, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewerRules), args.Error(1)
+}
+
+func (m *MockReviewerRuleService) Delete(teamID, ruleID int) error {
+	args := m.Called(teamID, ruleID)
+	return args.Error(0)
+}
+
+func (m *MockReviewerRuleService) Check(teamID, authorID, candidateID int) (*models.CandidateCheck, error) {
+	args := m.Called(teamID, authorID, candidateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CandidateCheck), args.Error(1)
+}
+
+func (m *MockReviewerRuleService) GetUserTags(userID int) ([]string, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockReviewerRuleService) SetUserTags(userID int, tags []string) ([]string, error) {
+	args := m.Called(userID, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockReviewerRuleService) CreatePattern(pattern *models.ReviewPattern) error {
+	args := m.Called(pattern)
+	return args.Error(0)
+}
+
+func (m *MockReviewerRuleService) GetPatternsByTeamID(teamID int) (models.ReviewPatterns, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewPatterns), args.Error(1)
+}
+
+func (m *MockReviewerRuleService) DeletePattern(teamID, patternID int) error {
+	args := m.Called(teamID, patternID)
+	return args.Error(0)
+}
+
+func (m *MockReviewerRuleService) GetReviewLoad(teamID int) (*models.TeamReviewLoad, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TeamReviewLoad), args.Error(1)
+}
+
+func (m *MockReviewerRuleService) SetReviewHalfLife(teamID int, halfLife time.Duration) (*models.TeamReviewLoad, error) {
+	args := m.Called(teamID, halfLife)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TeamReviewLoad), args.Error(1)
+}
+
+var _ services.ReviewerRuleService = (*MockReviewerRuleService)(nil)
+
+type MockReviewSLAService struct {
+	mock.Mock
+}
+
+func (m *MockReviewSLAService) GetSLA(teamID int) (*models.ReviewSLA, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReviewSLA), args.Error(1)
+}
+
+func (m *MockReviewSLAService) SetSLA(sla *models.ReviewSLA) error {
+	args := m.Called(sla)
+	return args.Error(0)
+}
+
+func (m *MockReviewSLAService) Acknowledge(prID, reviewerID int) ([]*models.ReviewAssignment, error) {
+	args := m.Called(prID, reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReviewAssignment), args.Error(1)
+}
+
+func (m *MockReviewSLAService) GetEvents(prID int) ([]*models.PullRequestEvent, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequestEvent), args.Error(1)
+}
+
+func (m *MockReviewSLAService) Escalate(now time.Time) ([]*models.PullRequestEvent, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequestEvent), args.Error(1)
+}
+
+var _ services.ReviewSLAService = (*MockReviewSLAService)(nil)
+
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) GetReviews(prID int) (*models.PullRequestReviews, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PullRequestReviews), args.Error(1)
+}
+
+func (m *MockReviewService) SubmitReview(prID, reviewerID int, state models.ReviewState) (*models.PullRequestReviews, error) {
+	args := m.Called(prID, reviewerID, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PullRequestReviews), args.Error(1)
+}
+
+func (m *MockReviewService) GetPolicy(teamID int) (*models.ApprovalPolicy, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalPolicy), args.Error(1)
+}
+
+func (m *MockReviewService) SetPolicy(policy *models.ApprovalPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+var _ services.ReviewService = (*MockReviewService)(nil)
+
+type MockIdempotencyService struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyService) Begin(key, requestHash string) (*models.IdempotencyKey, error) {
+	args := m.Called(key, requestHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IdempotencyKey), args.Error(1)
+}
+
+func (m *MockIdempotencyService) Complete(key *models.IdempotencyKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyService) Release(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+var _ services.IdempotencyService = (*MockIdempotencyService)(nil)
+
+type MockUserEventService struct {
+	mock.Mock
+}
+
+func (m *MockUserEventService) Subscribe(userID, lastEventID int) (*events.Subscription, []*models.PullRequestEvent, error) {
+	args := m.Called(userID, lastEventID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*events.Subscription), args.Get(1).([]*models.PullRequestEvent), args.Error(2)
+}
+
+var _ services.UserEventService = (*MockUserEventService)(nil)
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) GetPreferences(userID int) ([]*models.NotificationPreference, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotificationService) SetPreferences(userID int, preferences []*models.NotificationPreference) ([]*models.NotificationPreference, error) {
+	args := m.Called(userID, preferences)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotificationService) Enqueue(pr *models.PullRequest, events ...*models.PullRequestEvent) error {
+	args := m.Called(pr, events)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) EnqueueDigest(digest *models.Digest) error {
+	args := m.Called(digest)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) Deliver(now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
+
+var _ services.NotificationService = (*MockNotificationService)(nil)
+
+type MockDigestService struct {
+	mock.Mock
+}
+
+func (m *MockDigestService) Build(userID int) (*models.Digest, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Digest), args.Error(1)
+}
+
+func (m *MockDigestService) Send(now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
+
+var _ services.DigestService = (*MockDigestService)(nil)
+
+type MockCacheService struct {
+	mock.Mock
+}
+
+func (m *MockCacheService) Stats() []models.CacheStats {
+	return m.Called().Get(0).([]models.CacheStats)
+}
+
+var _ services.CacheService = (*MockCacheService)(nil)
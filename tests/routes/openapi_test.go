@@ -0,0 +1,1243 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"reviewer-assignment-service/internal/app/config"
+	"reviewer-assignment-service/internal/app/docs"
+	"reviewer-assignment-service/internal/app/routes"
+	"reviewer-assignment-service/internal/app/transport/mappers"
+	"reviewer-assignment-service/internal/app/webhooks"
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/tests/fakeclock"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceMocks struct {
+	users *MockUserService
+	teams *MockTeamService
+	prs   *MockPullRequestService
+	integ *MockIntegrationService
+	imp   *MockImportService
+	sync  *MockOrgSyncService
+	memb  *MockMembershipService
+	rules *MockReviewerRuleService
+	sla   *MockReviewSLAService
+	revs  *MockReviewService
+	idem  *MockIdempotencyService
+	evts  *MockUserEventService
+	notes *MockNotificationService
+	dgst  *MockDigestService
+	cache *MockCacheService
+
+	limits config.RateLimitConfig
+	clock  *fakeclock.Clock
+}
+
+func newServiceMocks() *serviceMocks {
+	return &serviceMocks{
+		users: new(MockUserService),
+		teams: new(MockTeamService),
+		prs:   new(MockPullRequestService),
+		integ: new(MockIntegrationService),
+		imp:   new(MockImportService),
+		sync:  new(MockOrgSyncService),
+		memb:  new(MockMembershipService),
+		rules: new(MockReviewerRuleService),
+		sla:   new(MockReviewSLAService),
+		revs:  new(MockReviewService),
+		idem:  new(MockIdempotencyService),
+		evts:  new(MockUserEventService),
+		notes: new(MockNotificationService),
+		dgst:  new(MockDigestService),
+		cache: new(MockCacheService),
+		clock: fakeclock.New(fakeclock.Monday),
+	}
+}
+
+func (m *serviceMocks) router() http.Handler {
+	return routes.SetupRouter(m.users, m.prs, m.teams, m.integ, m.imp, m.sync, m.memb, m.rules, m.sla, m.revs, m.idem, m.evts, m.notes, m.dgst, m.cache, config.IntegrationsConfig{
+		GitHubWebhookSecret: webhookSecret,
+		GitLabWebhookToken:  webhookSecret,
+	}, m.limits, m.clock)
+}
+
+const webhookSecret = "contract-secret"
+
+type contractCase struct {
+	name         string
+	method       string
+	path         string
+	body         string
+	headers      map[string]string
+	status       int
+	invalidInput bool
+	setup        func(m *serviceMocks)
+	// sentBefore is how many identical requests go through the same router first.
+	sentBefore int
+}
+
+const (
+	githubOpened = `{"action":"opened","number":7,"pull_request":{"title":"Feature","user":{"login":"Author"}},"repository":{"full_name":"acme/api"}}`
+	orgJSON      = `{"teams":[{"name":"backend","members":[{"username":"Author","email":"author@example.com","is_active":true}]}]}`
+	orgYAML      = "teams:\n  - name: backend\n    members:\n      - username: Author\n        email: author@example.com\n"
+	orgCSV       = "team,username,email,is_active\nbackend,Author,author@example.com,true\n"
+	gitlabOpened = `{"object_kind":"merge_request","user":{"username":"ghost"},"object_attributes":{"iid":3,"title":"Feature","action":"open"},"project":{"path_with_namespace":"acme/api"}}`
+)
+
+func contractCases() []contractCase {
+	author := &models.User{ID: 1, Name: "Author", Email: "author@example.com", TeamName: "backend", IsActive: true}
+	reviewer := &models.User{ID: 2, Name: "Reviewer", Email: "rev@example.com", TeamName: "backend", IsActive: true}
+	spare := &models.User{ID: 3, Name: "Spare", Email: "spare@example.com", TeamName: "backend", IsActive: true}
+	createdAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	openPR := func() *models.PullRequest {
+		return &models.PullRequest{
+			ID:        1,
+			Name:      "Feature",
+			Status:    models.StatusOpen,
+			Author:    author,
+			Reviewers: []*models.User{reviewer},
+			CreatedAt: createdAt,
+			Version:   1,
+		}
+	}
+	team := func() *models.Team {
+		return &models.Team{
+			ID:   1,
+			Name: "backend",
+			Members: map[int]*models.TeamMember{
+				1: models.NewTeamMember(1, "Author", true),
+				2: models.NewTeamMember(2, "Reviewer", true),
+			},
+			Version: 1,
+		}
+	}
+
+	identity := func() *models.UserIdentity {
+		return &models.UserIdentity{ID: 1, Provider: models.ProviderGitHub, Login: "reviewer-gh", UserID: 2}
+	}
+
+	return []contractCase{
+		{name: "health", method: http.MethodGet, path: "/health", status: http.StatusOK},
+		{name: "openapi document", method: http.MethodGet, path: "/openapi.json", status: http.StatusOK},
+		{name: "docs page", method: http.MethodGet, path: "/docs", status: http.StatusOK},
+
+		{
+			name: "list users", method: http.MethodGet, path: "/users", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetAll").Return([]*models.User{author, reviewer}, nil)
+			},
+		},
+		{
+			name: "list users empty", method: http.MethodGet, path: "/users", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetAll").Return([]*models.User{}, nil)
+			},
+		},
+		{
+			name: "list users rate limited", method: http.MethodGet, path: "/users", status: http.StatusTooManyRequests,
+			sentBefore: 2,
+			setup: func(m *serviceMocks) {
+				m.limits.Users = config.RateLimit{Requests: 2, Per: time.Minute}
+				m.users.On("GetAll").Return([]*models.User{author}, nil).Twice()
+			},
+		},
+		{
+			name: "create user", method: http.MethodPost, path: "/users", status: http.StatusCreated,
+			body: `{"username":"Author","email":"author@example.com","team_name":"backend","is_active":true}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("Create", mock.AnythingOfType("*models.User")).Return(nil)
+				m.users.On("GetByID", 0).Return(author, nil)
+			},
+		},
+		{
+			name: "create user conflict", method: http.MethodPost, path: "/users", status: http.StatusConflict,
+			body: `{"username":"Author","email":"author@example.com","team_name":"backend","is_active":true}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("Create", mock.AnythingOfType("*models.User")).Return(repositories.ErrUserAlreadyExists)
+			},
+		},
+		{
+			name: "set user active", method: http.MethodPost, path: "/users/setIsActive", status: http.StatusOK,
+			body: `{"user_id":"1","is_active":false}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 1).Return(author, nil)
+				m.users.On("SetActive", 1, false).Return(nil)
+			},
+		},
+		{
+			name: "deactivate user", method: http.MethodPost, path: "/users/deactivate", status: http.StatusOK,
+			body: `{"user_id":"1"}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 1).Return(author, nil)
+				m.users.On("Deactivate", 1).Return(nil)
+			},
+		},
+		{
+			name: "set user active with idempotency key", method: http.MethodPost, path: "/users/setIsActive", status: http.StatusOK,
+			body:    `{"user_id":"1","is_active":false}`,
+			headers: map[string]string{"Idempotency-Key": "deactivate-alice"},
+			setup: func(m *serviceMocks) {
+				m.idem.On("Begin", "deactivate-alice", mock.AnythingOfType("string")).Return(nil, nil)
+				m.users.On("GetByID", 1).Return(author, nil)
+				m.users.On("SetActive", 1, false).Return(nil)
+				m.idem.On("Complete", mock.MatchedBy(func(key *models.IdempotencyKey) bool {
+					return key.Key == "deactivate-alice" && key.StatusCode == http.StatusOK && len(key.Body) > 0
+				})).Return(nil)
+			},
+		},
+		{
+			name: "deactivate user with too long idempotency key", method: http.MethodPost, path: "/users/deactivate", status: http.StatusBadRequest,
+			body:         `{"user_id":"1"}`,
+			headers:      map[string]string{"Idempotency-Key": strings.Repeat("k", 256)},
+			invalidInput: true,
+		},
+		{
+			name: "user review prs", method: http.MethodGet, path: "/users/getReview?user_id=2", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 2).Return(reviewer, nil)
+				m.prs.On("GetByReviewerID", 2).Return([]*models.PullRequest{openPR()}, nil)
+			},
+		},
+		{
+			name: "user by email", method: http.MethodGet, path: "/users/by-email?email=author@example.com", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByEmail", "author@example.com").Return(author, nil)
+			},
+		},
+		{
+			name: "user by id", method: http.MethodGet, path: "/users/1", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 1).Return(author, nil)
+			},
+		},
+		{
+			name: "user by id not found", method: http.MethodGet, path: "/users/999", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 999).Return(nil, repositories.ErrUserNotFoundInPersistence)
+			},
+		},
+		{
+			name: "user event stream resumes after last event id", method: http.MethodGet, path: "/users/2/events", status: http.StatusOK,
+			headers: map[string]string{"Last-Event-ID": "10"},
+			setup: func(m *serviceMocks) {
+				broker := events.NewBroker()
+				sub := broker.Subscribe(2)
+				broker.Close()
+				m.evts.On("Subscribe", 2, 10).Return(sub, []*models.PullRequestEvent{
+					{ID: 11, PullRequestID: 1, Kind: models.EventReviewerAssigned, UserID: 2, CreatedAt: createdAt},
+				}, nil)
+			},
+		},
+		{
+			name: "user event stream for unknown user", method: http.MethodGet, path: "/users/999/events", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.evts.On("Subscribe", 999, 0).Return(nil, nil, repositories.ErrUserNotFoundInPersistence)
+			},
+		},
+		{
+			name: "user event stream with invalid last event id", method: http.MethodGet, path: "/users/2/events", status: http.StatusBadRequest,
+			headers:      map[string]string{"Last-Event-ID": "latest"},
+			invalidInput: true,
+		},
+		{
+			name: "user by login", method: http.MethodGet, path: "/users/by-login?provider=github&login=reviewer-gh", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByExternalLogin", models.ProviderGitHub, "reviewer-gh").Return(reviewer, nil)
+			},
+		},
+		{
+			name: "user by login unknown provider", method: http.MethodGet, path: "/users/by-login?provider=svn&login=x", status: http.StatusBadRequest,
+			invalidInput: true,
+		},
+		{
+			name: "list identities", method: http.MethodGet, path: "/users/2/identities", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetIdentities", 2).Return([]*models.UserIdentity{identity()}, nil)
+			},
+		},
+		{
+			name: "create identity", method: http.MethodPost, path: "/users/2/identities", status: http.StatusCreated,
+			body: `{"provider":"github","login":"reviewer-gh"}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("AddIdentity", mock.AnythingOfType("*models.UserIdentity")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.UserIdentity).SetId(1)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "create identity taken", method: http.MethodPost, path: "/users/2/identities", status: http.StatusConflict,
+			body: `{"provider":"gitlab","login":"reviewer"}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("AddIdentity", mock.AnythingOfType("*models.UserIdentity")).Return(repositories.ErrUserIdentityAlreadyExists)
+			},
+		},
+		{
+			name: "get identity", method: http.MethodGet, path: "/users/2/identities/1", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetIdentityByID", 1).Return(identity(), nil)
+			},
+		},
+		{
+			name: "get identity of another user", method: http.MethodGet, path: "/users/3/identities/1", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetIdentityByID", 1).Return(identity(), nil)
+			},
+		},
+		{
+			name: "update identity", method: http.MethodPut, path: "/users/2/identities/1", status: http.StatusOK,
+			body: `{"login":"reviewer-new"}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetIdentityByID", 1).Return(identity(), nil)
+				m.users.On("UpdateIdentity", mock.AnythingOfType("*models.UserIdentity")).Return(nil)
+			},
+		},
+		{
+			name: "delete identity", method: http.MethodDelete, path: "/users/2/identities/1", status: http.StatusNoContent,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetIdentityByID", 1).Return(identity(), nil)
+				m.users.On("DeleteIdentity", 1).Return(nil)
+			},
+		},
+		{
+			name: "user by malformed id", method: http.MethodGet, path: "/users/abc", status: http.StatusBadRequest,
+			invalidInput: true,
+		},
+
+		{
+			name: "list teams", method: http.MethodGet, path: "/teams", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetAll").Return([]*models.Team{team()}, nil)
+			},
+		},
+		{
+			name: "create team", method: http.MethodPost, path: "/teams", status: http.StatusCreated,
+			body: `{"name":"backend","members":[{"user_id":1,"username":"Author","is_active":true}]}`,
+			setup: func(m *serviceMocks) {
+				m.teams.On("Create", mock.AnythingOfType("*models.Team")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.Team).SetId(1)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "team by name", method: http.MethodGet, path: "/teams/by-name/backend", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByName", "backend").Return(team(), nil)
+			},
+		},
+		{
+			name: "team by id", method: http.MethodGet, path: "/teams/1", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByID", 1).Return(team(), nil)
+			},
+		},
+		{
+			name: "team by id not found", method: http.MethodGet, path: "/teams/2", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByID", 2).Return(nil, repositories.ErrTeamNotFoundInPersistence)
+			},
+		},
+		{
+			name: "update team", method: http.MethodPut, path: "/teams/1", status: http.StatusOK,
+			body:    `{"name":"platform","members":[{"user_id":1,"username":"Author","is_active":true}]}`,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByID", 1).Return(team(), nil)
+				m.teams.On("Update", mock.AnythingOfType("*models.Team")).Return(nil)
+			},
+		},
+		{
+			name: "update team with stale If-Match", method: http.MethodPut, path: "/teams/1", status: http.StatusPreconditionFailed,
+			body:    `{"name":"platform"}`,
+			headers: map[string]string{"If-Match": `"0"`},
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByID", 1).Return(team(), nil)
+			},
+		},
+
+		{
+			name: "create pull request", method: http.MethodPost, path: "/pull-requests", status: http.StatusCreated,
+			body: `{"name":"Feature","author_id":1,"reviewers":[2]}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 1).Return(author, nil)
+				m.users.On("GetByID", 2).Return(reviewer, nil)
+				m.prs.On("Create", mock.AnythingOfType("*models.PullRequest")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.PullRequest).SetId(1)
+				}).Return(nil)
+				m.users.On("GetIdentitiesByUserIDs", []int{2}).Return([]*models.UserIdentity{identity()}, nil)
+			},
+		},
+		{
+			name: "create pull request replayed for a retry", method: http.MethodPost, path: "/pull-requests", status: http.StatusCreated,
+			body:    `{"name":"Feature","author_id":1,"reviewers":[2]}`,
+			headers: map[string]string{"Idempotency-Key": "bot-retry-1"},
+			setup: func(m *serviceMocks) {
+				body, _ := json.Marshal(mappers.ToPullRequestResponse(openPR()))
+				m.idem.On("Begin", "bot-retry-1", mock.AnythingOfType("string")).Return(&models.IdempotencyKey{
+					Key:        "bot-retry-1",
+					StatusCode: http.StatusCreated,
+					Headers:    http.Header{"Content-Type": {"application/json"}, "Etag": {`"1"`}},
+					Body:       body,
+				}, nil)
+			},
+		},
+		{
+			name: "create pull request with paths", method: http.MethodPost, path: "/pull-requests", status: http.StatusCreated,
+			body: `{"name":"Feature","author_id":1,"paths":["api/billing/ledger.go"],"labels":["security"],"size":240}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 1).Return(author, nil)
+				m.prs.On("Create", mock.AnythingOfType("*models.PullRequest")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.PullRequest).SetId(1)
+				}).Return(nil)
+				m.prs.On("AssignReviewers", mock.AnythingOfType("*models.PullRequest")).Run(func(args mock.Arguments) {
+					_ = args.Get(0).(*models.PullRequest).AddReviewer(reviewer)
+				}).Return(nil)
+				m.users.On("GetIdentitiesByUserIDs", []int{2}).Return([]*models.UserIdentity{identity()}, nil)
+			},
+		},
+		{
+			name: "create pull request whose reviewers cannot be assigned", method: http.MethodPost, path: "/pull-requests", status: http.StatusCreated,
+			body: `{"name":"Feature","author_id":1}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("GetByID", 1).Return(author, nil)
+				m.prs.On("Create", mock.AnythingOfType("*models.PullRequest")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.PullRequest).SetId(1)
+				}).Return(nil)
+				m.prs.On("AssignReviewers", mock.AnythingOfType("*models.PullRequest")).Return(models.ErrRequiredReviewerUnavailable)
+				m.prs.On("GetByID", 1).Return(nil, errors.New("connection reset"))
+				m.users.On("GetIdentitiesByUserIDs", []int{}).Return([]*models.UserIdentity{}, nil)
+			},
+		},
+		{
+			name: "create pull request with empty path", method: http.MethodPost, path: "/pull-requests", status: http.StatusBadRequest,
+			body: `{"name":"Feature","author_id":1,"paths":[""]}`, invalidInput: true,
+		},
+		{
+			name: "create pull request invalid", method: http.MethodPost, path: "/pull-requests", status: http.StatusBadRequest,
+			body:         `{"name":"","author_id":1}`,
+			invalidInput: true,
+		},
+		{
+			name: "pull requests by author", method: http.MethodGet, path: "/pull-requests/author/1", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByAuthorID", 1).Return([]*models.PullRequest{openPR()}, nil)
+			},
+		},
+		{
+			name: "pull requests by reviewer", method: http.MethodGet, path: "/pull-requests/reviewer/2", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByReviewerID", 2).Return([]*models.PullRequest{}, nil)
+			},
+		},
+		{
+			name: "pull request by id", method: http.MethodGet, path: "/pull-requests/1", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+			},
+		},
+		{
+			name: "pull request not found", method: http.MethodGet, path: "/pull-requests/5", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 5).Return(nil, repositories.ErrPullRequestNotFoundInPersistence)
+			},
+		},
+		{
+			name: "update pull request", method: http.MethodPut, path: "/pull-requests/1", status: http.StatusOK,
+			body:    `{"name":"Feature v2","status":"OPEN","reviewers":[2]}`,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+				m.users.On("GetByID", 2).Return(reviewer, nil)
+				m.prs.On("Edit", mock.AnythingOfType("*models.PullRequest"), "Feature v2", models.StatusOpen, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "update pull request with current If-Match", method: http.MethodPut, path: "/pull-requests/1", status: http.StatusOK,
+			body:    `{"name":"Feature v2","status":"OPEN"}`,
+			headers: map[string]string{"If-Match": `"1"`},
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+				m.prs.On("Edit", mock.AnythingOfType("*models.PullRequest"), "Feature v2", models.StatusOpen, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "update pull request with stale If-Match", method: http.MethodPut, path: "/pull-requests/1", status: http.StatusPreconditionFailed,
+			body:    `{"name":"Feature v2","status":"OPEN"}`,
+			headers: map[string]string{"If-Match": `"0"`},
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+			},
+		},
+		{
+			name: "update pull request changed concurrently", method: http.MethodPut, path: "/pull-requests/1", status: http.StatusPreconditionFailed,
+			body:    `{"name":"Feature v2","status":"OPEN"}`,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+				m.prs.On("Edit", mock.AnythingOfType("*models.PullRequest"), "Feature v2", models.StatusOpen, mock.Anything).Return(models.ErrConflictingUpdate)
+			},
+		},
+		{
+			name: "update pull request without If-Match", method: http.MethodPut, path: "/pull-requests/1", status: http.StatusPreconditionRequired,
+			body:         `{"name":"Feature v2","status":"OPEN"}`,
+			invalidInput: true,
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+			},
+		},
+		{
+			name: "merge pull request with stale If-Match", method: http.MethodPost, path: "/pull-requests/1/merge", status: http.StatusPreconditionFailed,
+			headers: map[string]string{"If-Match": `"0"`},
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+			},
+		},
+		{
+			name: "merge pull request with reused idempotency key", method: http.MethodPost, path: "/pull-requests/1/merge", status: http.StatusUnprocessableEntity,
+			headers: map[string]string{"If-Match": "*", "Idempotency-Key": "merge-1"},
+			setup: func(m *serviceMocks) {
+				m.idem.On("Begin", "merge-1", mock.AnythingOfType("string")).Return(nil, models.ErrIdempotencyKeyReused)
+			},
+		},
+		{
+			name: "reassign while the same idempotency key is in progress", method: http.MethodPost, path: "/pull-requests/1/reassign", status: http.StatusConflict,
+			body:    `{"old_reviewer_id":2}`,
+			headers: map[string]string{"If-Match": "*", "Idempotency-Key": "reassign-1"},
+			setup: func(m *serviceMocks) {
+				m.idem.On("Begin", "reassign-1", mock.AnythingOfType("string")).Return(nil, models.ErrIdempotencyKeyInProgress)
+			},
+		},
+		{
+			name: "reassign rate limited", method: http.MethodPost, path: "/pull-requests/1/reassign", status: http.StatusTooManyRequests,
+			body:       `{"old_reviewer_id":2}`,
+			headers:    map[string]string{"If-Match": "*"},
+			sentBefore: 1,
+			setup: func(m *serviceMocks) {
+				m.limits.PullRequests = config.RateLimit{Requests: 1, Per: time.Hour}
+				m.prs.On("GetByID", 1).Return(nil, repositories.ErrPullRequestNotFoundInPersistence).Once()
+			},
+		},
+		{
+			name: "merge pull request", method: http.MethodPost, path: "/pull-requests/1/merge", status: http.StatusOK,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				pr := openPR()
+				m.prs.On("GetByID", 1).Return(pr, nil)
+				m.prs.On("MergeRequest", pr).Run(func(args mock.Arguments) {
+					merged := args.Get(0).(*models.PullRequest)
+					merged.SetStatusMerged()
+					merged.SetMergedAt(createdAt.Add(time.Hour))
+				}).Return(nil)
+			},
+		},
+		{
+			name: "merge already merged pull request", method: http.MethodPost, path: "/pull-requests/1/merge", status: http.StatusConflict,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				pr := openPR()
+				m.prs.On("GetByID", 1).Return(pr, nil)
+				m.prs.On("MergeRequest", pr).Return(models.ErrPRAlreadyMerged)
+			},
+		},
+		{
+			name: "reassign reviewer", method: http.MethodPost, path: "/pull-requests/1/reassign", status: http.StatusOK,
+			body:    `{"old_reviewer_id":2}`,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				reassigned := openPR()
+				reassigned.Reviewers = []*models.User{spare}
+				m.prs.On("GetByID", 1).Return(openPR(), nil).Once()
+				m.users.On("GetByID", 2).Return(reviewer, nil)
+				m.prs.On("ReassignReviewers", mock.AnythingOfType("*models.PullRequest"), reviewer).Return(nil)
+				m.prs.On("GetByID", 1).Return(reassigned, nil).Once()
+				m.users.On("GetIdentitiesByUserIDs", []int{3}).Return([]*models.UserIdentity{}, nil)
+			},
+		},
+		{
+			name: "reassign without candidates", method: http.MethodPost, path: "/pull-requests/1/reassign", status: http.StatusNotFound,
+			body:    `{"old_reviewer_id":2}`,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				m.prs.On("GetByID", 1).Return(openPR(), nil)
+				m.users.On("GetByID", 2).Return(reviewer, nil)
+				m.prs.On("ReassignReviewers", mock.AnythingOfType("*models.PullRequest"), reviewer).Return(models.ErrReviewerNotFound)
+			},
+		},
+		{
+			name: "github webhook", method: http.MethodPost, path: "/integrations/github", status: http.StatusOK,
+			body: githubOpened,
+			headers: map[string]string{
+				"X-GitHub-Event":      "pull_request",
+				"X-Hub-Signature-256": webhooks.Sign([]byte(githubOpened), webhookSecret),
+			},
+			setup: func(m *serviceMocks) {
+				m.integ.On("HandleEvent", mock.AnythingOfType("*models.VCSEvent")).Return(&models.VCSEventResult{
+					Outcome:     models.VCSEventApplied,
+					PullRequest: openPR(),
+				}, nil)
+				m.users.On("GetIdentitiesByUserIDs", []int{2}).Return([]*models.UserIdentity{identity()}, nil)
+			},
+		},
+		{
+			name: "github webhook bad signature", method: http.MethodPost, path: "/integrations/github", status: http.StatusUnauthorized,
+			body: githubOpened,
+			headers: map[string]string{
+				"X-GitHub-Event":      "pull_request",
+				"X-Hub-Signature-256": webhooks.Sign([]byte(githubOpened), "wrong"),
+			},
+		},
+		{
+			name: "github webhook ping", method: http.MethodPost, path: "/integrations/github", status: http.StatusOK,
+			body: `{"zen":"Keep it logically awesome."}`,
+			headers: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": webhooks.Sign([]byte(`{"zen":"Keep it logically awesome."}`), webhookSecret),
+			},
+		},
+		{
+			name: "gitlab webhook unknown author", method: http.MethodPost, path: "/integrations/gitlab", status: http.StatusUnprocessableEntity,
+			body: gitlabOpened,
+			headers: map[string]string{
+				"X-Gitlab-Event": "Merge Request Hook",
+				"X-Gitlab-Token": webhookSecret,
+			},
+			setup: func(m *serviceMocks) {
+				m.integ.On("HandleEvent", mock.AnythingOfType("*models.VCSEvent")).Return(nil, models.ErrUnknownExternalUser)
+			},
+		},
+		{
+			name: "import json", method: http.MethodPost, path: "/import", status: http.StatusOK,
+			body: orgJSON,
+			setup: func(m *serviceMocks) {
+				report := models.NewImportReport(false)
+				report.TeamsUpdated = []string{"backend"}
+				m.imp.On("Import", mock.AnythingOfType("*models.OrgDocument"), false).Return(report, nil)
+			},
+		},
+		{
+			name: "import csv dry run", method: http.MethodPost, path: "/import?dry_run=true", status: http.StatusOK,
+			body:    orgCSV,
+			headers: map[string]string{"Content-Type": "text/csv"},
+			setup: func(m *serviceMocks) {
+				report := models.NewImportReport(true)
+				report.UsersCreated = []string{"author@example.com"}
+				m.imp.On("Import", mock.AnythingOfType("*models.OrgDocument"), true).Return(report, nil)
+			},
+		},
+		{
+			name: "import duplicate email", method: http.MethodPost, path: "/import", status: http.StatusBadRequest,
+			body: orgJSON,
+			setup: func(m *serviceMocks) {
+				m.imp.On("Import", mock.AnythingOfType("*models.OrgDocument"), false).Return(nil, models.ErrDuplicateEmailInDocument)
+			},
+		},
+		{
+			name: "export json", method: http.MethodGet, path: "/export", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.imp.On("Export").Return(orgDocument(), nil)
+			},
+		},
+		{
+			name: "export csv", method: http.MethodGet, path: "/export?format=csv", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.imp.On("Export").Return(orgDocument(), nil)
+			},
+		},
+		{
+			name: "sync plan", method: http.MethodPost, path: "/sync", status: http.StatusOK,
+			body:    orgYAML,
+			headers: map[string]string{"Content-Type": "application/yaml"},
+			setup: func(m *serviceMocks) {
+				m.sync.On("Plan", mock.AnythingOfType("*models.OrgDocument")).Return(&models.SyncPlan{Changes: []*models.SyncChange{
+					{Action: models.SyncDeactivateUser, UserID: 2, Email: "rev@example.com", Username: "Reviewer", FromTeam: "backend"},
+				}}, nil)
+			},
+		},
+		{
+			name: "sync apply", method: http.MethodPost, path: "/sync?apply=true", status: http.StatusOK,
+			body:    orgYAML,
+			headers: map[string]string{"Content-Type": "application/yaml"},
+			setup: func(m *serviceMocks) {
+				m.sync.On("Apply", mock.AnythingOfType("*models.OrgDocument")).Return(&models.SyncResult{
+					Plan: &models.SyncPlan{Changes: []*models.SyncChange{
+						{Action: models.SyncMoveUser, UserID: 2, Email: "rev@example.com", Username: "Reviewer", IsActive: true, FromTeam: "backend", ToTeam: "frontend"},
+					}},
+					Applied: true,
+					Reassignments: []*models.Reassignment{
+						{PullRequestID: 1, FromUserID: 2, ToUserID: 3, Outcome: models.ReassignmentDone},
+						{PullRequestID: 4, FromUserID: 2, Outcome: models.ReassignmentNoCandidate},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "sync invalid yaml", method: http.MethodPost, path: "/sync", status: http.StatusBadRequest,
+			body:    "teams: [",
+			headers: map[string]string{"Content-Type": "application/yaml"},
+		},
+		{
+			name: "set working hours", method: http.MethodPut, path: "/users/2/working-hours", status: http.StatusOK,
+			body: `{"timezone":"Europe/Moscow","start_hour":10,"end_hour":19}`,
+			setup: func(m *serviceMocks) {
+				hours := models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19}
+				updated := *reviewer
+				updated.WorkingHours = hours
+				m.users.On("SetWorkingHours", 2, hours).Return(nil)
+				m.users.On("GetByID", 2).Return(&updated, nil)
+			},
+		},
+		{
+			name: "set working hours with unknown timezone", method: http.MethodPut, path: "/users/2/working-hours", status: http.StatusBadRequest,
+			body: `{"timezone":"Mars/Olympus","start_hour":9,"end_hour":18}`,
+		},
+		{
+			name: "set working hours past midnight", method: http.MethodPut, path: "/users/2/working-hours", status: http.StatusBadRequest,
+			body: `{"timezone":"UTC","start_hour":9,"end_hour":25}`, invalidInput: true,
+		},
+		{
+			name: "set working hours of unknown user", method: http.MethodPut, path: "/users/999/working-hours", status: http.StatusNotFound,
+			body: `{"timezone":"UTC","start_hour":9,"end_hour":18}`,
+			setup: func(m *serviceMocks) {
+				m.users.On("SetWorkingHours", 999, models.DefaultWorkingHours).Return(repositories.ErrUserNotFoundInPersistence)
+			},
+		},
+		{
+			name: "move user keeping reviews", method: http.MethodPost, path: "/users/2/move", status: http.StatusOK,
+			body: `{"team_name":"frontend"}`,
+			setup: func(m *serviceMocks) {
+				moved := *reviewer
+				moved.TeamName = "frontend"
+				m.memb.On("MoveUser", 2, "frontend", false).Return(&models.TeamMove{
+					User: &moved, FromTeam: "backend", ToTeam: "frontend",
+					Reviews: []*models.Reassignment{{PullRequestID: 1, FromUserID: 2, ToUserID: 2, Outcome: models.ReassignmentKept}},
+				}, nil)
+			},
+		},
+		{
+			name: "move user reassigning reviews", method: http.MethodPost, path: "/users/2/move", status: http.StatusOK,
+			body: `{"team_name":"frontend","reassign_reviews":true}`,
+			setup: func(m *serviceMocks) {
+				moved := *reviewer
+				moved.TeamName = "frontend"
+				m.memb.On("MoveUser", 2, "frontend", true).Return(&models.TeamMove{
+					User: &moved, FromTeam: "backend", ToTeam: "frontend",
+					Reviews: []*models.Reassignment{{PullRequestID: 1, FromUserID: 2, ToUserID: 3, Outcome: models.ReassignmentDone}},
+				}, nil)
+			},
+		},
+		{
+			name: "move user to same team", method: http.MethodPost, path: "/users/2/move", status: http.StatusConflict,
+			body: `{"team_name":"backend"}`,
+			setup: func(m *serviceMocks) {
+				m.memb.On("MoveUser", 2, "backend", false).Return(nil, models.ErrMemberAlreadyInTeam)
+			},
+		},
+		{
+			name: "move user to unknown team", method: http.MethodPost, path: "/users/2/move", status: http.StatusNotFound,
+			body: `{"team_name":"mobile"}`,
+			setup: func(m *serviceMocks) {
+				m.memb.On("MoveUser", 2, "mobile", false).Return(nil, repositories.ErrTeamNotFoundInPersistence)
+			},
+		},
+		{
+			name: "move user without team", method: http.MethodPost, path: "/users/2/move", status: http.StatusBadRequest,
+			body: `{"reassign_reviews":true}`, invalidInput: true,
+		},
+		{
+			name: "list team rules", method: http.MethodGet, path: "/teams/1/rules", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.rules.On("GetByTeamID", 1).Return(models.ReviewerRules{
+					{ID: 1, TeamID: 1, Kind: models.RuleNeverAssign, ReviewerID: 2, AuthorID: 3},
+					{ID: 2, TeamID: 1, Kind: models.RuleRequireTag, Tag: "senior", AuthorTag: "junior"},
+				}, nil)
+			},
+		},
+		{
+			name: "create team rule", method: http.MethodPost, path: "/teams/1/rules", status: http.StatusCreated,
+			body: `{"kind":"prefer","reviewer_id":2,"author_id":1}`,
+			setup: func(m *serviceMocks) {
+				m.rules.On("Create", mock.AnythingOfType("*models.ReviewerRule")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.ReviewerRule).SetId(3)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "create team rule with foreign user", method: http.MethodPost, path: "/teams/1/rules", status: http.StatusBadRequest,
+			body: `{"kind":"never_assign","reviewer_id":2,"author_id":9}`,
+			setup: func(m *serviceMocks) {
+				m.rules.On("Create", mock.AnythingOfType("*models.ReviewerRule")).Return(models.ErrRuleUserNotInTeam)
+			},
+		},
+		{
+			name: "create team rule without tag", method: http.MethodPost, path: "/teams/1/rules", status: http.StatusBadRequest,
+			body: `{"kind":"require_tag"}`,
+		},
+		{
+			name: "check candidate", method: http.MethodGet, path: "/teams/1/rules/check?author_id=1&candidate_id=2", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.rules.On("Check", 1, 1, 2).Return(&models.CandidateCheck{
+					AuthorID: 1, CandidateID: 2,
+					Reasons:      []string{"rule 1: users 2 and 1 never review each other"},
+					RequiredTags: []string{"senior"}, ProvidedTags: []string{},
+				}, nil)
+			},
+		},
+		{
+			name: "check candidate without author", method: http.MethodGet, path: "/teams/1/rules/check?candidate_id=2", status: http.StatusBadRequest,
+			invalidInput: true,
+		},
+		{
+			name: "delete team rule", method: http.MethodDelete, path: "/teams/1/rules/3", status: http.StatusNoContent,
+			setup: func(m *serviceMocks) {
+				m.rules.On("Delete", 1, 3).Return(nil)
+			},
+		},
+		{
+			name: "delete unknown team rule", method: http.MethodDelete, path: "/teams/1/rules/4", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.rules.On("Delete", 1, 4).Return(repositories.ErrReviewerRuleNotFound)
+			},
+		},
+		{
+			name: "get user tags", method: http.MethodGet, path: "/users/2/tags", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.rules.On("GetUserTags", 2).Return([]string{"senior"}, nil)
+			},
+		},
+		{
+			name: "set user tags", method: http.MethodPut, path: "/users/2/tags", status: http.StatusOK,
+			body: `{"tags":["senior","go"]}`,
+			setup: func(m *serviceMocks) {
+				m.rules.On("SetUserTags", 2, []string{"senior", "go"}).Return([]string{"go", "senior"}, nil)
+			},
+		},
+		{
+			name: "set invalid user tags", method: http.MethodPut, path: "/users/2/tags", status: http.StatusBadRequest,
+			body: `{"tags":["Senior Dev"]}`, invalidInput: true,
+		},
+		{
+			name: "get default notification preferences", method: http.MethodGet, path: "/users/2/notifications", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.notes.On("GetPreferences", 2).Return(models.DefaultNotificationPreferences(2), nil)
+			},
+		},
+		{
+			name: "get notification preferences of unknown user", method: http.MethodGet, path: "/users/9/notifications", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.notes.On("GetPreferences", 9).Return(nil, repositories.ErrUserNotFoundInPersistence)
+			},
+		},
+		{
+			name: "set notification preferences", method: http.MethodPut, path: "/users/2/notifications", status: http.StatusOK,
+			body: `{"preferences":[{"channel":"email","target":"Bob <bob@example.com>","kinds":["reviewer_assigned","merged"],"digest":true},{"channel":"slack","target":"https://hooks.slack.com/services/T/B/X","kinds":["reviewer_assigned"]}]}`,
+			setup: func(m *serviceMocks) {
+				preferences := []*models.NotificationPreference{
+					{UserID: 2, Channel: models.ChannelEmail, Target: "bob@example.com", Kinds: []models.PullRequestEventKind{models.EventReviewerAssigned, models.EventMerged}, Digest: true},
+					{UserID: 2, Channel: models.ChannelSlack, Target: "https://hooks.slack.com/services/T/B/X", Kinds: []models.PullRequestEventKind{models.EventReviewerAssigned}},
+				}
+				m.notes.On("SetPreferences", 2, preferences).Return(preferences, nil)
+			},
+		},
+		{
+			name: "set notification preferences with unknown channel", method: http.MethodPut, path: "/users/2/notifications", status: http.StatusBadRequest,
+			body: `{"preferences":[{"channel":"sms","kinds":["merged"]}]}`, invalidInput: true,
+		},
+		{
+			name: "set notification preferences with a duplicate channel", method: http.MethodPut, path: "/users/2/notifications", status: http.StatusBadRequest,
+			body: `{"preferences":[{"channel":"email","kinds":["merged"]},{"channel":"email","kinds":[]}]}`,
+		},
+		{
+			name: "set webhook preference without a URL", method: http.MethodPut, path: "/users/2/notifications", status: http.StatusBadRequest,
+			body: `{"preferences":[{"channel":"webhook","target":"ftp://example.com/hook","kinds":["merged"]}]}`,
+		},
+		{
+			name: "preview digest", method: http.MethodGet, path: "/users/2/digest", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				alice := &models.User{ID: 1, Name: "Alice"}
+				bob := &models.User{ID: 2, Name: "Bob"}
+				m.dgst.On("Build", 2).Return(&models.Digest{
+					User:        bob,
+					GeneratedAt: fakeclock.Monday,
+					Reviewing: []*models.DigestItem{
+						models.NewDigestItem(&models.PullRequest{ID: 10, Name: "Add login", Author: alice}, models.ReviewPending, fakeclock.Monday.Add(-3*time.Hour), fakeclock.Monday),
+					},
+					Authored: []*models.DigestItem{
+						models.NewDigestItem(&models.PullRequest{ID: 20, Name: "Billing", Author: bob}, "", fakeclock.Monday.Add(-26*time.Hour), fakeclock.Monday),
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "preview digest of unknown user", method: http.MethodGet, path: "/users/9/digest", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.dgst.On("Build", 9).Return(nil, repositories.ErrUserNotFoundInPersistence)
+			},
+		},
+		{
+			name: "list team patterns", method: http.MethodGet, path: "/teams/1/patterns", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.rules.On("GetPatternsByTeamID", 1).Return(models.ReviewPatterns{
+					{ID: 1, TeamID: 1, Pattern: "api/billing/", Tag: "billing"},
+					{ID: 2, TeamID: 1, Label: "security", Tag: "security"},
+				}, nil)
+			},
+		},
+		{
+			name: "create team pattern", method: http.MethodPost, path: "/teams/1/patterns", status: http.StatusCreated,
+			body: `{"pattern":"*.sql","tag":"dba"}`,
+			setup: func(m *serviceMocks) {
+				m.rules.On("CreatePattern", mock.AnythingOfType("*models.ReviewPattern")).Run(func(args mock.Arguments) {
+					args.Get(0).(*models.ReviewPattern).SetId(3)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "create team pattern with pattern and label", method: http.MethodPost, path: "/teams/1/patterns", status: http.StatusBadRequest,
+			body: `{"pattern":"*.sql","label":"db","tag":"dba"}`,
+		},
+		{
+			name: "delete team pattern", method: http.MethodDelete, path: "/teams/1/patterns/3", status: http.StatusNoContent,
+			setup: func(m *serviceMocks) {
+				m.rules.On("DeletePattern", 1, 3).Return(nil)
+			},
+		},
+		{
+			name: "delete unknown team pattern", method: http.MethodDelete, path: "/teams/1/patterns/4", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.rules.On("DeletePattern", 1, 4).Return(repositories.ErrReviewPatternNotFound)
+			},
+		},
+		{
+			name: "team review load", method: http.MethodGet, path: "/teams/1/fairness", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.rules.On("GetReviewLoad", 1).Return(&models.TeamReviewLoad{
+					TeamID: 1, HalfLife: 168 * time.Hour, Loads: models.ReviewLoads{2: 412.5, 3: 0},
+				}, nil)
+			},
+		},
+		{
+			name: "set team review half-life", method: http.MethodPut, path: "/teams/1/fairness", status: http.StatusOK,
+			body: `{"half_life_hours":72}`,
+			setup: func(m *serviceMocks) {
+				m.rules.On("SetReviewHalfLife", 1, 72*time.Hour).Return(&models.TeamReviewLoad{
+					TeamID: 1, HalfLife: 72 * time.Hour, Loads: models.ReviewLoads{2: 120},
+				}, nil)
+			},
+		},
+		{
+			name: "set team review half-life out of range", method: http.MethodPut, path: "/teams/1/fairness", status: http.StatusBadRequest,
+			body: `{"half_life_hours":0}`, invalidInput: true,
+		},
+		{
+			name: "review load of unknown team", method: http.MethodGet, path: "/teams/9/fairness", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.rules.On("GetReviewLoad", 9).Return(nil, repositories.ErrTeamNotFoundInPersistence)
+			},
+		},
+		{
+			name: "reassign without required reviewer", method: http.MethodPost, path: "/pull-requests/1/reassign", status: http.StatusUnprocessableEntity,
+			body:    `{"old_reviewer_id":2}`,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				pr := &models.PullRequest{ID: 1, Name: "Feature", Status: models.StatusOpen, Author: author, Reviewers: []*models.User{reviewer}}
+				m.prs.On("GetByID", 1).Return(pr, nil)
+				m.users.On("GetByID", 2).Return(reviewer, nil)
+				m.prs.On("ReassignReviewers", pr, reviewer).Return(models.ErrRequiredReviewerUnavailable)
+			},
+		},
+		{
+			name: "team sla", method: http.MethodGet, path: "/teams/1/sla", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.sla.On("GetSLA", 1).Return(&models.ReviewSLA{TeamID: 1, Hours: 24, Action: models.SLAActionAddReviewer}, nil)
+			},
+		},
+		{
+			name: "set team sla", method: http.MethodPut, path: "/teams/1/sla", status: http.StatusOK,
+			body: `{"hours":16,"action":"reassign"}`,
+			setup: func(m *serviceMocks) {
+				m.sla.On("SetSLA", &models.ReviewSLA{TeamID: 1, Hours: 16, Action: models.SLAActionReassign}).Return(nil)
+			},
+		},
+		{
+			name: "set team sla with unknown action", method: http.MethodPut, path: "/teams/1/sla", status: http.StatusBadRequest,
+			body: `{"hours":16,"action":"page"}`, invalidInput: true,
+		},
+		{
+			name: "sla of unknown team", method: http.MethodGet, path: "/teams/9/sla", status: http.StatusNotFound,
+			setup: func(m *serviceMocks) {
+				m.sla.On("GetSLA", 9).Return(nil, repositories.ErrTeamNotFoundInPersistence)
+			},
+		},
+		{
+			name: "acknowledge review", method: http.MethodPost, path: "/pull-requests/1/ack", status: http.StatusOK,
+			body: `{"reviewer_id":2}`,
+			setup: func(m *serviceMocks) {
+				reviewedAt := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+				m.sla.On("Acknowledge", 1, 2).Return([]*models.ReviewAssignment{
+					{PullRequestID: 1, ReviewerID: 2, State: models.ReviewPending, AssignedAt: reviewedAt.Add(-time.Hour), ReviewedAt: &reviewedAt},
+					{PullRequestID: 1, ReviewerID: 3, State: models.ReviewPending, AssignedAt: reviewedAt.Add(-time.Hour)},
+				}, nil)
+			},
+		},
+		{
+			name: "acknowledge by someone who is not a reviewer", method: http.MethodPost, path: "/pull-requests/1/ack", status: http.StatusNotFound,
+			body: `{"reviewer_id":5}`,
+			setup: func(m *serviceMocks) {
+				m.sla.On("Acknowledge", 1, 5).Return(nil, models.ErrReviewerNotAssigned)
+			},
+		},
+		{
+			name: "pull request events", method: http.MethodGet, path: "/pull-requests/1/events", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.sla.On("GetEvents", 1).Return([]*models.PullRequestEvent{
+					{ID: 1, PullRequestID: 1, Kind: models.EventSLAReviewerReplaced, UserID: 4, PreviousUserID: 2, CreatedAt: time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)},
+				}, nil)
+			},
+		},
+		{
+			name: "escalate overdue reviews", method: http.MethodPost, path: "/sla/escalate", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.sla.On("Escalate", fakeclock.Monday).Return([]*models.PullRequestEvent{
+					{ID: 2, PullRequestID: 1, Kind: models.EventSLAReviewerAdded, UserID: 3, CreatedAt: time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)},
+				}, nil)
+			},
+		},
+		{
+			name: "graphql team with members", method: http.MethodPost, path: "/graphql", status: http.StatusOK,
+			body: `{"query":"query($id: Int!) { team(id: $id) { name members { name reviewing(status: OPEN) { name reviewers { name } } } } }","variables":{"id":1}}`,
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByID", 1).Return(team(), nil)
+				m.users.On("GetByIDs", []int{1, 2}).Return([]*models.User{author, reviewer}, nil)
+				m.prs.On("GetByReviewerIDs", []int{1, 2}).Return(map[int][]*models.PullRequest{2: {openPR()}}, nil)
+			},
+		},
+		{
+			name: "graphql query error", method: http.MethodPost, path: "/graphql", status: http.StatusOK,
+			body: `{"query":"{ team(id: 9) { name } }"}`,
+			setup: func(m *serviceMocks) {
+				m.teams.On("GetByID", 9).Return(nil, repositories.ErrTeamNotFoundInPersistence)
+			},
+		},
+		{
+			name: "graphql without query", method: http.MethodPost, path: "/graphql", status: http.StatusBadRequest,
+			body: `{"query":""}`, invalidInput: true,
+		},
+		{
+			name: "cache stats", method: http.MethodGet, path: "/cache/stats", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.cache.On("Stats").Return([]models.CacheStats{
+					{Name: "teams", Hits: 40, Misses: 2, Entries: 2},
+					{Name: "candidates", Hits: 12, Misses: 1, Entries: 1},
+				})
+			},
+		},
+		{
+			name: "pull request reviews", method: http.MethodGet, path: "/pull-requests/1/reviews", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.revs.On("GetReviews", 1).Return(&models.PullRequestReviews{
+					PullRequestID: 1,
+					Assignments:   []*models.ReviewAssignment{{PullRequestID: 1, ReviewerID: 2, State: models.ReviewPending, AssignedAt: createdAt}},
+					Approvals:     models.ApprovalStatus{Required: 1},
+				}, nil)
+			},
+		},
+		{
+			name: "approve pull request", method: http.MethodPost, path: "/pull-requests/1/reviews", status: http.StatusOK,
+			body: `{"reviewer_id":2,"state":"approved"}`,
+			setup: func(m *serviceMocks) {
+				approvedAt := createdAt.Add(time.Hour)
+				m.revs.On("SubmitReview", 1, 2, models.ReviewApproved).Return(&models.PullRequestReviews{
+					PullRequestID: 1,
+					Assignments: []*models.ReviewAssignment{{
+						PullRequestID: 1, ReviewerID: 2, State: models.ReviewApproved, AssignedAt: createdAt, ReviewedAt: &approvedAt, StateChangedAt: &approvedAt,
+					}},
+					Approvals: models.ApprovalStatus{Required: 1, Approved: 1},
+				}, nil)
+			},
+		},
+		{
+			name: "submit pending review", method: http.MethodPost, path: "/pull-requests/1/reviews", status: http.StatusBadRequest,
+			body: `{"reviewer_id":2,"state":"pending"}`, invalidInput: true,
+		},
+		{
+			name: "review merged pull request", method: http.MethodPost, path: "/pull-requests/1/reviews", status: http.StatusConflict,
+			body: `{"reviewer_id":2,"state":"changes_requested"}`,
+			setup: func(m *serviceMocks) {
+				m.revs.On("SubmitReview", 1, 2, models.ReviewChangesRequested).Return(nil, models.ErrPRAlreadyMerged)
+			},
+		},
+		{
+			name: "merge without approvals", method: http.MethodPost, path: "/pull-requests/1/merge", status: http.StatusConflict,
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *serviceMocks) {
+				pr := openPR()
+				m.prs.On("GetByID", 1).Return(pr, nil)
+				m.prs.On("MergeRequest", pr).Return(fmt.Errorf("%w: 0 of 1", models.ErrNotEnoughApprovals))
+			},
+		},
+		{
+			name: "team approval policy", method: http.MethodGet, path: "/teams/1/approvals", status: http.StatusOK,
+			setup: func(m *serviceMocks) {
+				m.revs.On("GetPolicy", 1).Return(&models.ApprovalPolicy{TeamID: 1, RequiredApprovals: 1}, nil)
+			},
+		},
+		{
+			name: "set team approval policy", method: http.MethodPut, path: "/teams/1/approvals", status: http.StatusOK,
+			body: `{"required_approvals":2}`,
+			setup: func(m *serviceMocks) {
+				m.revs.On("SetPolicy", &models.ApprovalPolicy{TeamID: 1, RequiredApprovals: 2}).Return(nil)
+			},
+		},
+		{
+			name: "set team approval policy above reviewer limit", method: http.MethodPut, path: "/teams/1/approvals", status: http.StatusBadRequest,
+			body: `{"required_approvals":3}`, invalidInput: true,
+		},
+	}
+}
+
+func orgDocument() *models.OrgDocument {
+	return &models.OrgDocument{Teams: []*models.OrgTeam{{
+		Name:    "backend",
+		Members: []*models.OrgMember{{Username: "Author", Email: "author@example.com", IsActive: true}},
+	}}}
+}
+
+func init() {
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.PlainBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/event-stream", openapi3filter.PlainBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.PlainBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/yaml", openapi3filter.PlainBodyDecoder)
+}
+
+func newSpecRouter(t *testing.T) routers.Router {
+	t.Helper()
+
+	doc, err := docs.Spec()
+	require.NoError(t, err)
+
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	return router
+}
+
+func TestOpenAPI_ResponsesMatchSpec(t *testing.T) {
+	specRouter := newSpecRouter(t)
+
+	for _, tc := range contractCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newServiceMocks()
+			if tc.setup != nil {
+				tc.setup(m)
+			}
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req := httptest.NewRequest(tc.method, tc.path, body)
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			route, pathParams, err := specRouter.FindRoute(req)
+			require.NoError(t, err, "route is not documented")
+
+			reqInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+			}
+			requestErr := openapi3filter.ValidateRequest(context.Background(), reqInput)
+			if tc.invalidInput {
+				assert.Error(t, requestErr, "spec accepts input the handler rejects")
+			} else {
+				require.NoError(t, requestErr)
+			}
+			if tc.body != "" {
+				req.Body = io.NopCloser(strings.NewReader(tc.body))
+			}
+
+			router := m.router()
+			for i := 0; i < tc.sentBefore; i++ {
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code, rec.Body.String())
+
+			respInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: reqInput,
+				Status:                 rec.Code,
+				Header:                 rec.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+			}
+			assert.NoError(t, openapi3filter.ValidateResponse(context.Background(), respInput))
+
+			m.users.AssertExpectations(t)
+			m.teams.AssertExpectations(t)
+			m.prs.AssertExpectations(t)
+			m.integ.AssertExpectations(t)
+			m.imp.AssertExpectations(t)
+			m.sync.AssertExpectations(t)
+			m.memb.AssertExpectations(t)
+			m.idem.AssertExpectations(t)
+			m.evts.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOpenAPI_EveryRouteIsDocumented(t *testing.T) {
+	doc, err := docs.Spec()
+	require.NoError(t, err)
+
+	router, ok := newServiceMocks().router().(chi.Routes)
+	require.True(t, ok)
+
+	documented := make(map[string]bool)
+	err = chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path := route
+		if len(path) > 1 {
+			path = strings.TrimSuffix(path, "/")
+		}
+
+		item := doc.Paths.Value(path)
+		if assert.NotNil(t, item, "path %s is missing from openapi.yaml", path) {
+			assert.NotNil(t, item.GetOperation(method), "%s %s is missing from openapi.yaml", method, path)
+		}
+		documented[method+" "+path] = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			assert.True(t, documented[method+" "+path], "%s %s is documented but not routed", method, path)
+		}
+	}
+}
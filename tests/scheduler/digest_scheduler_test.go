@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"reviewer-assignment-service/internal/app/scheduler"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/tests/fakeclock"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingDigestService struct {
+	mu   sync.Mutex
+	sent []time.Time
+}
+
+func (s *recordingDigestService) Build(int) (*models.Digest, error) { return nil, nil }
+
+func (s *recordingDigestService) Send(now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, now)
+	return 0, nil
+}
+
+func (s *recordingDigestService) runs() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.sent...)
+}
+
+func TestDigestScheduler_Run(t *testing.T) {
+	service := &recordingDigestService{}
+	clk := fakeclock.New(fakeclock.Monday.Add(-2 * time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go scheduler.NewDigestScheduler(service, 9, time.Millisecond, clk).Run(ctx)
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, service.runs(), "before the hour nothing is sent")
+
+	clk.Set(fakeclock.Monday)
+	assert.Eventually(t, func() bool { return len(service.runs()) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, service.runs(), 1, "once a day")
+
+	clk.Set(fakeclock.Monday.Add(24 * time.Hour))
+	assert.Eventually(t, func() bool { return len(service.runs()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, []time.Time{fakeclock.Monday, fakeclock.Monday.Add(24 * time.Hour)}, service.runs())
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"reviewer-assignment-service/internal/app/scheduler"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/tests/fakeclock"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingNotificationService struct {
+	passes atomic.Int32
+}
+
+func (s *countingNotificationService) GetPreferences(int) ([]*models.NotificationPreference, error) {
+	return nil, nil
+}
+
+func (s *countingNotificationService) SetPreferences(int, []*models.NotificationPreference) ([]*models.NotificationPreference, error) {
+	return nil, nil
+}
+
+func (s *countingNotificationService) Enqueue(*models.PullRequest, ...*models.PullRequestEvent) error {
+	return nil
+}
+
+func (s *countingNotificationService) EnqueueDigest(*models.Digest) error {
+	return nil
+}
+
+func (s *countingNotificationService) Deliver(now time.Time) (int, error) {
+	if s.passes.Add(1) == 1 {
+		return 0, errors.New("database is down")
+	}
+	return 1, nil
+}
+
+func TestNotificationDispatcher_Run(t *testing.T) {
+	service := &countingNotificationService{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		scheduler.NewNotificationDispatcher(service, 5*time.Millisecond, fakeclock.New(fakeclock.Monday)).Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return service.passes.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not stop after cancel")
+	}
+}
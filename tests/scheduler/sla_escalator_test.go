@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"reviewer-assignment-service/internal/app/scheduler"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/tests/fakeclock"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingSLAService struct {
+	passes atomic.Int32
+
+	mu  sync.Mutex
+	now []time.Time
+}
+
+func (s *countingSLAService) GetSLA(int) (*models.ReviewSLA, error) { return nil, nil }
+
+func (s *countingSLAService) SetSLA(*models.ReviewSLA) error { return nil }
+
+func (s *countingSLAService) Acknowledge(int, int) ([]*models.ReviewAssignment, error) {
+	return nil, nil
+}
+
+func (s *countingSLAService) GetEvents(int) ([]*models.PullRequestEvent, error) { return nil, nil }
+
+func (s *countingSLAService) Escalate(now time.Time) ([]*models.PullRequestEvent, error) {
+	s.mu.Lock()
+	s.now = append(s.now, now)
+	s.mu.Unlock()
+	if s.passes.Add(1) == 1 {
+		return nil, errors.New("database is down")
+	}
+	return nil, nil
+}
+
+func TestSLAEscalator_Run(t *testing.T) {
+	service := &countingSLAService{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		scheduler.NewSLAEscalator(service, 5*time.Millisecond, fakeclock.New(fakeclock.Monday)).Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return service.passes.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("escalator did not stop after cancel")
+	}
+	stopped := service.passes.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, service.passes.Load())
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	for _, now := range service.now {
+		assert.Equal(t, fakeclock.Monday, now)
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/notifications"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func digestFixture() (*MockUserRepository, *MockPullRequestRepository, *MockReviewRepository) {
+	now := fakeclock.Monday
+	bob := &models.User{ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: true}
+	alice := &models.User{ID: 1, Name: "Alice", Email: "alice@example.com", IsActive: true}
+
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", 2).Return(bob, nil)
+	userRepo.On("GetActiveUsers").Return([]*models.User{alice, bob}, nil)
+
+	prRepo := new(MockPullRequestRepository)
+	prRepo.On("GetByReviewerID", 2).Return([]*models.PullRequest{
+		{ID: 10, Name: "Add login", Status: models.StatusOpen, Author: alice, CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: 11, Name: "Fix <b>typo</b>", Status: models.StatusOpen, Author: alice, CreatedAt: now.Add(-5 * time.Hour)},
+		{ID: 12, Name: "Old work", Status: models.StatusMerged, Author: alice, CreatedAt: now.Add(-200 * time.Hour)},
+	}, nil)
+	prRepo.On("GetByAuthorID", 2).Return([]*models.PullRequest{
+		{ID: 20, Name: "Billing", Status: models.StatusOpen, Author: bob, CreatedAt: now.Add(-26 * time.Hour)},
+		{ID: 21, Name: "Approved", Status: models.StatusOpen, Author: bob, CreatedAt: now.Add(-30 * time.Hour)},
+		{ID: 22, Name: "Closed", Status: models.StatusClosed, Author: bob, CreatedAt: now.Add(-30 * time.Hour)},
+	}, nil)
+	prRepo.On("GetByReviewerID", 1).Return([]*models.PullRequest{}, nil)
+	prRepo.On("GetByAuthorID", 1).Return([]*models.PullRequest{}, nil)
+
+	reviewRepo := new(MockReviewRepository)
+	reviewRepo.On("GetByPullRequestID", 10).Return([]*models.ReviewAssignment{
+		{PullRequestID: 10, ReviewerID: 3, State: models.ReviewApproved, AssignedAt: now.Add(-72 * time.Hour)},
+		{PullRequestID: 10, ReviewerID: 2, State: models.ReviewChangesRequested, AssignedAt: now.Add(-2 * time.Hour)},
+	}, nil)
+	reviewRepo.On("GetByPullRequestID", 11).Return([]*models.ReviewAssignment{
+		{PullRequestID: 11, ReviewerID: 2, State: models.ReviewPending, AssignedAt: now.Add(-5 * time.Hour)},
+	}, nil)
+	reviewRepo.On("GetApprovals", 20).Return(&models.ApprovalStatus{Required: 2, Approved: 1}, nil)
+	reviewRepo.On("GetApprovals", 21).Return(&models.ApprovalStatus{Required: 1, Approved: 1}, nil)
+
+	return userRepo, prRepo, reviewRepo
+}
+
+func TestDigestService_Build(t *testing.T) {
+	userRepo, prRepo, reviewRepo := digestFixture()
+
+	digest, err := impl.NewDigestService(userRepo, prRepo, reviewRepo, nil, fakeclock.New(fakeclock.Monday)).Build(2)
+	require.NoError(t, err)
+	assert.Equal(t, fakeclock.Monday, digest.GeneratedAt)
+
+	require.Len(t, digest.Reviewing, 2)
+	assert.Equal(t, 11, digest.Reviewing[0].PullRequest.ID)
+	assert.Equal(t, 5*time.Hour, digest.Reviewing[0].Waiting)
+	assert.Equal(t, models.ReviewPending, digest.Reviewing[0].State)
+	assert.Equal(t, 10, digest.Reviewing[1].PullRequest.ID)
+	assert.Equal(t, 2*time.Hour, digest.Reviewing[1].Waiting)
+	assert.Equal(t, models.ReviewChangesRequested, digest.Reviewing[1].State)
+
+	require.Len(t, digest.Authored, 1)
+	assert.Equal(t, 20, digest.Authored[0].PullRequest.ID)
+	assert.Equal(t, 26*time.Hour, digest.Authored[0].Waiting)
+
+	subject, text, html, err := notifications.RenderDigest(digest)
+	require.NoError(t, err)
+	assert.Equal(t, "Review digest: 2 to review, 1 waiting on reviewers", subject)
+	assert.Contains(t, text, `- "Fix <b>typo</b>" (#11) by Alice, assigned 5h 0m ago, pending`)
+	assert.Contains(t, text, `- "Billing" (#20), opened 1d 2h ago`)
+	assert.Contains(t, html, "<b>Fix &lt;b&gt;typo&lt;/b&gt;</b> (#11)")
+	assert.NotContains(t, html, "<b>typo</b>")
+}
+
+func TestDigestService_BuildUnknownUser(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", 9).Return(nil, repositories.ErrUserNotFoundInPersistence)
+
+	_, err := impl.NewDigestService(userRepo, nil, nil, nil, fakeclock.New(fakeclock.Monday)).Build(9)
+	assert.ErrorIs(t, err, repositories.ErrUserNotFoundInPersistence)
+}
+
+func TestDigestService_Send(t *testing.T) {
+	userRepo, prRepo, reviewRepo := digestFixture()
+	notificationService := new(MockNotificationService)
+	notificationService.On("EnqueueDigest", mock.Anything).Return(nil)
+	sendAt := fakeclock.Monday.Add(time.Hour)
+
+	queued, err := impl.NewDigestService(userRepo, prRepo, reviewRepo, notificationService, fakeclock.New(fakeclock.Monday)).Send(sendAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	notificationService.AssertNumberOfCalls(t, "EnqueueDigest", 1)
+	notificationService.AssertCalled(t, "EnqueueDigest", mock.MatchedBy(func(digest *models.Digest) bool {
+		return digest.User.ID == 2 && digest.GeneratedAt.Equal(sendAt)
+	}))
+}
+
+func TestNotificationService_EnqueueDigest(t *testing.T) {
+	bob := &models.User{ID: 2, Name: "Bob", Email: "bob@example.com"}
+	digest := &models.Digest{
+		User:        bob,
+		GeneratedAt: fakeclock.Monday,
+		Reviewing: []*models.DigestItem{
+			models.NewDigestItem(&models.PullRequest{ID: 10, Name: "Add login"}, models.ReviewPending, fakeclock.Monday.Add(-time.Hour), fakeclock.Monday),
+		},
+	}
+	notifiers := map[models.NotificationChannel]notifications.Notifier{
+		models.ChannelEmail:   &recordingNotifier{},
+		models.ChannelWebhook: &recordingNotifier{},
+	}
+
+	t.Run("defaults send it by email", func(t *testing.T) {
+		prefRepo := new(MockNotificationPreferenceRepository)
+		notificationRepo := new(MockNotificationRepository)
+		prefRepo.On("GetByUserIDs", []int{2}).Return(map[int][]*models.NotificationPreference{}, nil)
+		var queued []*models.Notification
+		notificationRepo.On("Add", mock.Anything).Run(func(args mock.Arguments) {
+			queued = append(queued, args.Get(0).(*models.Notification))
+		}).Return(nil)
+
+		err := impl.NewNotificationService(nil, prefRepo, notificationRepo, notifiers, fakeclock.New(fakeclock.Monday)).EnqueueDigest(digest)
+		require.NoError(t, err)
+		require.Len(t, queued, 1)
+		assert.Equal(t, models.NotificationDigest, queued[0].Kind)
+		assert.Equal(t, "bob@example.com", queued[0].Target)
+		assert.Zero(t, queued[0].EventID)
+		assert.Contains(t, queued[0].Body, "Add login")
+		assert.Contains(t, queued[0].HTML, "<li><b>Add login</b>")
+		assert.Equal(t, fakeclock.Monday, queued[0].CreatedAt)
+	})
+
+	t.Run("only channels with the digest turned on", func(t *testing.T) {
+		prefRepo := new(MockNotificationPreferenceRepository)
+		notificationRepo := new(MockNotificationRepository)
+		prefRepo.On("GetByUserIDs", []int{2}).Return(map[int][]*models.NotificationPreference{
+			2: {
+				{UserID: 2, Channel: models.ChannelEmail, Kinds: []models.PullRequestEventKind{models.EventReviewerAssigned}},
+				{UserID: 2, Channel: models.ChannelWebhook, Target: "https://hooks.example.com", Digest: true},
+			},
+		}, nil)
+		notificationRepo.On("Add", mock.MatchedBy(func(notification *models.Notification) bool {
+			return notification.Channel == models.ChannelWebhook
+		})).Return(nil)
+
+		err := impl.NewNotificationService(nil, prefRepo, notificationRepo, notifiers, fakeclock.New(fakeclock.Monday)).EnqueueDigest(digest)
+		require.NoError(t, err)
+		notificationRepo.AssertNumberOfCalls(t, "Add", 1)
+	})
+}
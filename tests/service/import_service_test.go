@@ -0,0 +1,236 @@
+package service
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTransactionManager struct {
+	repos      repositories.Repositories
+	calls      int
+	rolledBack bool
+}
+
+func (f *fakeTransactionManager) WithinTransaction(fn func(repos repositories.Repositories) error) error {
+	f.calls++
+	err := fn(f.repos)
+	f.rolledBack = err != nil
+	return err
+}
+
+func newImportFixture() (*impl.ImportServiceImpl, *MockUserRepository, *MockTeamRepository, *fakeTransactionManager) {
+	users := new(MockUserRepository)
+	teams := new(MockTeamRepository)
+	tm := &fakeTransactionManager{repos: repositories.Repositories{Users: users, Teams: teams}}
+	return impl.NewImportService(tm), users, teams, tm
+}
+
+func backendDocument() *models.OrgDocument {
+	return &models.OrgDocument{Teams: []*models.OrgTeam{{
+		Name: "backend",
+		Members: []*models.OrgMember{
+			{Username: "Alice", Email: "alice@example.com", IsActive: true},
+			{Username: "Bob", Email: "BOB@example.com", IsActive: true},
+		},
+	}}}
+}
+
+func TestImportService_Import(t *testing.T) {
+	t.Run("dry run reports changes without writing", func(t *testing.T) {
+		service, users, teams, _ := newImportFixture()
+
+		existing := []*models.User{
+			{ID: 2, Name: "Bobby", Email: "bob@example.com", IsActive: true, TeamName: "backend"},
+			{ID: 3, Name: "Carol", Email: "carol@example.com", IsActive: true, TeamName: "backend"},
+		}
+		team := &models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{
+			2: models.NewTeamMember(2, "Bobby", true),
+			3: models.NewTeamMember(3, "Carol", true),
+		}}
+		users.On("GetAll").Return(existing, nil)
+		teams.On("GetByName", "backend").Return(team, nil)
+
+		report, err := service.Import(backendDocument(), true)
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []string{"backend"}, report.TeamsUpdated)
+		assert.Equal(t, []string{"alice@example.com"}, report.UsersCreated)
+		assert.Equal(t, []string{"bob@example.com"}, report.UsersUpdated)
+		assert.Equal(t, []string{"carol@example.com"}, report.UsersDeactivated)
+		assert.Equal(t, "Bobby", existing[0].Name)
+		users.AssertExpectations(t)
+		teams.AssertExpectations(t)
+		users.AssertNotCalled(t, "Add", mock.Anything)
+		teams.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("creates missing team and users", func(t *testing.T) {
+		service, users, teams, _ := newImportFixture()
+
+		users.On("GetAll").Return([]*models.User{}, nil)
+		teams.On("GetByName", "backend").Return(nil, repositories.ErrTeamNotFoundInPersistence)
+		teams.On("Add", mock.AnythingOfType("*models.Team")).Return(nil)
+		users.On("Add", mock.AnythingOfType("*models.User")).Return(nil).Run(func(args mock.Arguments) {
+			user := args.Get(0).(*models.User)
+			user.SetId(len(user.Name))
+		})
+		teams.On("Update", mock.MatchedBy(func(team *models.Team) bool {
+			return team.Name == "backend" && team.GetMemberCount() == 2
+		})).Return(nil)
+
+		report, err := service.Import(backendDocument(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"backend"}, report.TeamsCreated)
+		assert.Empty(t, report.TeamsUpdated)
+		assert.Equal(t, []string{"alice@example.com", "BOB@example.com"}, report.UsersCreated)
+		users.AssertExpectations(t)
+		teams.AssertExpectations(t)
+	})
+
+	t.Run("deactivates members missing from the document", func(t *testing.T) {
+		service, users, teams, _ := newImportFixture()
+
+		existing := []*models.User{
+			{ID: 1, Name: "Alice", Email: "alice@example.com", IsActive: true, TeamName: "backend"},
+			{ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: true, TeamName: "backend"},
+			{ID: 3, Name: "Carol", Email: "carol@example.com", IsActive: true, TeamName: "backend"},
+		}
+		team := &models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{
+			1: models.NewTeamMember(1, "Alice", true),
+			2: models.NewTeamMember(2, "Bob", true),
+			3: models.NewTeamMember(3, "Carol", true),
+		}}
+		users.On("GetAll").Return(existing, nil)
+		teams.On("GetByName", "backend").Return(team, nil)
+		users.On("Deactivate", 3).Return(nil)
+		teams.On("Update", mock.MatchedBy(func(team *models.Team) bool {
+			return team.GetMemberCount() == 3 && !team.Members[3].IsActive
+		})).Return(nil)
+
+		report, err := service.Import(backendDocument(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"carol@example.com"}, report.UsersDeactivated)
+		assert.Equal(t, []string{"backend"}, report.TeamsUpdated)
+		users.AssertExpectations(t)
+		teams.AssertExpectations(t)
+	})
+
+	t.Run("unchanged document is a no-op", func(t *testing.T) {
+		service, users, teams, _ := newImportFixture()
+
+		users.On("GetAll").Return([]*models.User{
+			{ID: 1, Name: "Alice", Email: "alice@example.com", IsActive: true, TeamName: "backend"},
+			{ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: true, TeamName: "backend"},
+		}, nil)
+		teams.On("GetByName", "backend").Return(&models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{
+			1: models.NewTeamMember(1, "Alice", true),
+			2: models.NewTeamMember(2, "Bob", true),
+		}}, nil)
+
+		report, err := service.Import(backendDocument(), false)
+		require.NoError(t, err)
+
+		assert.Empty(t, report.TeamsUpdated)
+		assert.Empty(t, report.UsersCreated)
+		assert.Empty(t, report.UsersUpdated)
+		teams.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("moving a user removes them from the old team", func(t *testing.T) {
+		service, users, teams, _ := newImportFixture()
+
+		doc := &models.OrgDocument{Teams: []*models.OrgTeam{{
+			Name:    "backend",
+			Members: []*models.OrgMember{{Username: "Dave", Email: "dave@example.com", IsActive: true}},
+		}}}
+		users.On("GetAll").Return([]*models.User{
+			{ID: 4, Name: "Dave", Email: "dave@example.com", IsActive: true, TeamName: "frontend"},
+		}, nil)
+		teams.On("GetByName", "backend").Return(&models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{}}, nil)
+		teams.On("GetByName", "frontend").Return(&models.Team{ID: 2, Name: "frontend", Members: map[int]*models.TeamMember{
+			4: models.NewTeamMember(4, "Dave", true),
+		}}, nil)
+		users.On("Update", mock.MatchedBy(func(user *models.User) bool { return user.TeamName == "backend" })).Return(nil)
+		teams.On("RemoveUserFromTeam", 2, 4).Return(nil)
+		teams.On("Update", mock.AnythingOfType("*models.Team")).Return(nil)
+
+		report, err := service.Import(doc, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"dave@example.com"}, report.UsersUpdated)
+		users.AssertExpectations(t)
+		teams.AssertExpectations(t)
+	})
+
+	t.Run("repository failure rolls back", func(t *testing.T) {
+		service, users, teams, tm := newImportFixture()
+
+		failure := errors.New("connection reset")
+		users.On("GetAll").Return([]*models.User{}, nil)
+		teams.On("GetByName", "backend").Return(nil, repositories.ErrTeamNotFoundInPersistence)
+		teams.On("Add", mock.AnythingOfType("*models.Team")).Return(nil)
+		users.On("Add", mock.AnythingOfType("*models.User")).Return(failure)
+
+		_, err := service.Import(backendDocument(), false)
+		assert.ErrorIs(t, err, failure)
+		assert.True(t, tm.rolledBack)
+	})
+
+	t.Run("duplicate email is rejected before the transaction", func(t *testing.T) {
+		service, _, _, tm := newImportFixture()
+
+		doc := backendDocument()
+		doc.Teams = append(doc.Teams, &models.OrgTeam{
+			Name:    "frontend",
+			Members: []*models.OrgMember{{Username: "Alice", Email: "ALICE@example.com", IsActive: true}},
+		})
+
+		_, err := service.Import(doc, false)
+		assert.ErrorIs(t, err, models.ErrDuplicateEmailInDocument)
+		assert.Zero(t, tm.calls)
+	})
+
+	t.Run("duplicate team is rejected", func(t *testing.T) {
+		service, _, _, _ := newImportFixture()
+
+		doc := backendDocument()
+		doc.Teams = append(doc.Teams, &models.OrgTeam{Name: "backend"})
+
+		_, err := service.Import(doc, false)
+		assert.ErrorIs(t, err, models.ErrDuplicateTeamInDocument)
+	})
+}
+
+func TestImportService_Export(t *testing.T) {
+	service, users, teams, _ := newImportFixture()
+
+	teams.On("GetAll").Return([]*models.Team{
+		{ID: 2, Name: "frontend", Members: map[int]*models.TeamMember{}},
+		{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{}},
+	}, nil)
+	users.On("GetAll").Return([]*models.User{
+		{ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: false, TeamName: "backend"},
+		{ID: 1, Name: "Alice", Email: "alice@example.com", IsActive: true, TeamName: "backend"},
+	}, nil)
+
+	doc, err := service.Export()
+	require.NoError(t, err)
+
+	require.Len(t, doc.Teams, 2)
+	assert.Equal(t, "backend", doc.Teams[0].Name)
+	assert.Equal(t, "frontend", doc.Teams[1].Name)
+	assert.Empty(t, doc.Teams[1].Members)
+	require.Len(t, doc.Teams[0].Members, 2)
+	assert.Equal(t, "alice@example.com", doc.Teams[0].Members[0].Email)
+	assert.False(t, doc.Teams[0].Members[1].IsActive)
+}
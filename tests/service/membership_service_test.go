@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMembershipFixture() (*impl.MembershipServiceImpl, *MockUserRepository, *MockTeamRepository, *MockPullRequestService, *fakeTransactionManager) {
+	users := new(MockUserRepository)
+	teams := new(MockTeamRepository)
+	prs := new(MockPullRequestService)
+	tm := &fakeTransactionManager{repos: repositories.Repositories{Users: users, Teams: teams}}
+	return impl.NewMembershipService(tm, prs), users, teams, prs, tm
+}
+
+func expectMove(users *MockUserRepository, teams *MockTeamRepository) {
+	users.On("GetByID", 2).Return(&models.User{ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: true, TeamName: "backend"}, nil)
+	teams.On("GetByName", "frontend").Return(&models.Team{ID: 2, Name: "frontend"}, nil)
+	teams.On("GetByName", "backend").Return(&models.Team{ID: 1, Name: "backend"}, nil)
+	teams.On("RemoveUserFromTeam", 1, 2).Return(nil)
+	users.On("Update", mock.MatchedBy(func(user *models.User) bool { return user.TeamName == "frontend" })).Return(nil)
+	teams.On("RemoveUserFromTeam", 2, 2).Return(repositories.ErrUserNotInTeam)
+	teams.On("AddUserToTeam", 2, 2).Return(nil)
+}
+
+func TestMembershipService_MoveUser(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Alice", TeamName: "backend", IsActive: true}
+	bob := &models.User{ID: 2, Name: "Bob", TeamName: "backend", IsActive: true}
+	carol := &models.User{ID: 3, Name: "Carol", TeamName: "backend", IsActive: true}
+
+	t.Run("keeps open reviews", func(t *testing.T) {
+		service, users, teams, prs, _ := newMembershipFixture()
+		expectMove(users, teams)
+		prs.On("GetByReviewerID", 2).Return([]*models.PullRequest{
+			{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}},
+			{ID: 11, Status: models.StatusMerged, Author: author, Reviewers: []*models.User{bob}},
+		}, nil)
+
+		move, err := service.MoveUser(2, "frontend", false)
+		require.NoError(t, err)
+
+		assert.Equal(t, "backend", move.FromTeam)
+		assert.Equal(t, "frontend", move.User.TeamName)
+		assert.Equal(t, []*models.Reassignment{
+			{PullRequestID: 10, FromUserID: 2, ToUserID: 2, Outcome: models.ReassignmentKept},
+		}, move.Reviews)
+		users.AssertExpectations(t)
+		teams.AssertExpectations(t)
+		prs.AssertNotCalled(t, "ReassignReviewers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reassigns open reviews", func(t *testing.T) {
+		service, users, teams, prs, _ := newMembershipFixture()
+		expectMove(users, teams)
+		open := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		prs.On("GetByReviewerID", 2).Return([]*models.PullRequest{open}, nil)
+		prs.On("ReassignReviewers", open, mock.MatchedBy(func(user *models.User) bool { return user.ID == 2 })).Return(nil)
+		prs.On("GetByID", 10).Return(&models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{carol}}, nil)
+
+		move, err := service.MoveUser(2, "frontend", true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*models.Reassignment{
+			{PullRequestID: 10, FromUserID: 2, ToUserID: 3, Outcome: models.ReassignmentDone},
+		}, move.Reviews)
+		prs.AssertExpectations(t)
+	})
+
+	t.Run("reports reviews that could not be reassigned after the move", func(t *testing.T) {
+		service, users, teams, prs, tm := newMembershipFixture()
+		expectMove(users, teams)
+		failing := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		open := &models.PullRequest{ID: 11, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		prs.On("GetByReviewerID", 2).Return([]*models.PullRequest{failing, open}, nil)
+		prs.On("ReassignReviewers", failing, mock.Anything).Return(errors.New("connection reset"))
+		prs.On("ReassignReviewers", open, mock.Anything).Return(nil)
+		prs.On("GetByID", 11).Return(&models.PullRequest{ID: 11, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{carol}}, nil)
+
+		move, err := service.MoveUser(2, "frontend", true)
+		require.NoError(t, err)
+
+		assert.False(t, tm.rolledBack)
+		assert.Equal(t, "frontend", move.User.TeamName)
+		assert.Equal(t, []*models.Reassignment{
+			{PullRequestID: 10, FromUserID: 2, Outcome: models.ReassignmentFailed},
+			{PullRequestID: 11, FromUserID: 2, ToUserID: 3, Outcome: models.ReassignmentDone},
+		}, move.Reviews)
+	})
+
+	t.Run("same team is rejected", func(t *testing.T) {
+		service, users, _, _, tm := newMembershipFixture()
+		users.On("GetByID", 2).Return(&models.User{ID: 2, TeamName: "backend"}, nil)
+
+		_, err := service.MoveUser(2, "backend", false)
+		assert.ErrorIs(t, err, models.ErrMemberAlreadyInTeam)
+		assert.True(t, tm.rolledBack)
+	})
+
+	t.Run("unknown team is rejected", func(t *testing.T) {
+		service, users, teams, _, tm := newMembershipFixture()
+		users.On("GetByID", 2).Return(&models.User{ID: 2, TeamName: "backend"}, nil)
+		teams.On("GetByName", "mobile").Return(nil, repositories.ErrTeamNotFoundInPersistence)
+
+		_, err := service.MoveUser(2, "mobile", false)
+		assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+		assert.True(t, tm.rolledBack)
+		users.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("membership failure rolls back", func(t *testing.T) {
+		service, users, teams, prs, tm := newMembershipFixture()
+		failure := errors.New("connection reset")
+		users.On("GetByID", 2).Return(&models.User{ID: 2, TeamName: "backend"}, nil)
+		teams.On("GetByName", "frontend").Return(&models.Team{ID: 2, Name: "frontend"}, nil)
+		teams.On("GetByName", "backend").Return(&models.Team{ID: 1, Name: "backend"}, nil)
+		teams.On("RemoveUserFromTeam", 1, 2).Return(nil)
+		users.On("Update", mock.Anything).Return(nil)
+		teams.On("RemoveUserFromTeam", 2, 2).Return(nil)
+		teams.On("AddUserToTeam", 2, 2).Return(failure)
+
+		_, err := service.MoveUser(2, "frontend", true)
+		assert.ErrorIs(t, err, failure)
+		assert.True(t, tm.rolledBack)
+		prs.AssertNotCalled(t, "GetByReviewerID", mock.Anything)
+	})
+}
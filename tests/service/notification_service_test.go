@@ -0,0 +1,244 @@
+package service
+
+import (
+	"errors"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/notifications"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockNotificationPreferenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPreferenceRepository) GetByUserIDs(userIDs []int) (map[int][]*models.NotificationPreference, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceRepository) Replace(userID int, preferences []*models.NotificationPreference) error {
+	args := m.Called(userID, preferences)
+	return args.Error(0)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Add(notification *models.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*models.Notification, error) {
+	args := m.Called(now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) Save(notification *models.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) GetPreferences(userID int) ([]*models.NotificationPreference, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotificationService) SetPreferences(userID int, preferences []*models.NotificationPreference) ([]*models.NotificationPreference, error) {
+	args := m.Called(userID, preferences)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotificationService) Enqueue(pr *models.PullRequest, events ...*models.PullRequestEvent) error {
+	args := m.Called(pr, events)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) EnqueueDigest(digest *models.Digest) error {
+	args := m.Called(digest)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) Deliver(now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
+
+var _ services.NotificationService = (*MockNotificationService)(nil)
+
+// recordingNotifier fails with err when it is set and remembers what it sent otherwise.
+type recordingNotifier struct {
+	sent []*models.Notification
+	err  error
+}
+
+func (n *recordingNotifier) Send(notification *models.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestNotificationService_Preferences(t *testing.T) {
+	t.Run("unknown user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetByID", 9).Return(nil, repositories.ErrUserNotFoundInPersistence)
+		service := impl.NewNotificationService(userRepo, nil, nil, nil, fakeclock.New(fakeclock.Monday))
+
+		_, err := service.GetPreferences(9)
+		assert.ErrorIs(t, err, repositories.ErrUserNotFoundInPersistence)
+		_, err = service.SetPreferences(9, nil)
+		assert.ErrorIs(t, err, repositories.ErrUserNotFoundInPersistence)
+	})
+
+	t.Run("users who saved nothing get the defaults", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prefRepo := new(MockNotificationPreferenceRepository)
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2}, nil)
+		prefRepo.On("GetByUserIDs", []int{2}).Return(map[int][]*models.NotificationPreference{}, nil)
+
+		preferences, err := impl.NewNotificationService(userRepo, prefRepo, nil, nil, fakeclock.New(fakeclock.Monday)).GetPreferences(2)
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultNotificationPreferences(2), preferences)
+	})
+
+	t.Run("saving an empty list restores the defaults", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prefRepo := new(MockNotificationPreferenceRepository)
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2}, nil)
+		prefRepo.On("Replace", 2, []*models.NotificationPreference{}).Return(nil)
+
+		preferences, err := impl.NewNotificationService(userRepo, prefRepo, nil, nil, fakeclock.New(fakeclock.Monday)).
+			SetPreferences(2, []*models.NotificationPreference{})
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultNotificationPreferences(2), preferences)
+		prefRepo.AssertExpectations(t)
+	})
+}
+
+func TestNotificationService_Enqueue(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Author"}
+	pr := &models.PullRequest{ID: 7, Name: "Add login", Author: author}
+
+	t.Run("queues on the channels the user wants and that are configured", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prefRepo := new(MockNotificationPreferenceRepository)
+		notificationRepo := new(MockNotificationRepository)
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2, Name: "Old", Email: "old@example.com"}, nil)
+		userRepo.On("GetByID", 3).Return(&models.User{ID: 3, Name: "New", Email: "new@example.com"}, nil)
+		prefRepo.On("GetByUserIDs", []int{2, 3}).Return(map[int][]*models.NotificationPreference{
+			3: {
+				{UserID: 3, Channel: models.ChannelEmail, Kinds: []models.PullRequestEventKind{models.EventReviewerAssigned}},
+				{UserID: 3, Channel: models.ChannelSlack, Target: "https://hooks.slack.test/x", Kinds: []models.PullRequestEventKind{models.EventReviewerAssigned}},
+				{UserID: 3, Channel: models.ChannelWebhook, Target: "https://hooks.example.com", Kinds: []models.PullRequestEventKind{models.EventReviewerAssigned}},
+			},
+		}, nil)
+		var queued []*models.Notification
+		notificationRepo.On("Add", mock.Anything).Run(func(args mock.Arguments) {
+			queued = append(queued, args.Get(0).(*models.Notification))
+		}).Return(nil)
+
+		notifiers := map[models.NotificationChannel]notifications.Notifier{
+			models.ChannelEmail: &recordingNotifier{},
+			models.ChannelSlack: &recordingNotifier{},
+		}
+		service := impl.NewNotificationService(userRepo, prefRepo, notificationRepo, notifiers, fakeclock.New(fakeclock.Monday))
+
+		assigned := &models.PullRequestEvent{ID: 11, PullRequestID: 7, Kind: models.EventReviewerAssigned, UserID: 3, PreviousUserID: 2}
+		err := service.Enqueue(pr,
+			&models.PullRequestEvent{ID: 10, PullRequestID: 7, Kind: models.EventReviewerUnassigned, UserID: 2},
+			assigned,
+			&models.PullRequestEvent{ID: 12, PullRequestID: 7, Kind: models.EventReviewApproved, UserID: 3},
+		)
+		require.NoError(t, err)
+
+		require.Len(t, queued, 2)
+		assert.Equal(t, models.ChannelEmail, queued[0].Channel)
+		assert.Equal(t, "new@example.com", queued[0].Target)
+		assert.Equal(t, 11, queued[0].EventID)
+		assert.Equal(t, "Review requested: Add login", queued[0].Subject)
+		assert.Contains(t, queued[0].Body, "by Author")
+		assert.Contains(t, queued[0].Body, "You take over from Old.")
+		assert.Equal(t, fakeclock.Monday, *queued[0].NextAttemptAt)
+		assert.Equal(t, models.ChannelSlack, queued[1].Channel)
+		assert.Equal(t, "https://hooks.slack.test/x", queued[1].Target)
+	})
+
+	t.Run("events nobody is told about are not looked up", func(t *testing.T) {
+		service := impl.NewNotificationService(nil, nil, nil, nil, fakeclock.New(fakeclock.Monday))
+
+		err := service.Enqueue(pr, &models.PullRequestEvent{ID: 1, PullRequestID: 7, Kind: models.EventReviewed, UserID: 2})
+		assert.NoError(t, err)
+	})
+}
+
+func TestNotificationService_Deliver(t *testing.T) {
+	now := fakeclock.Monday
+	due := func() []*models.Notification {
+		return []*models.Notification{
+			{ID: 1, Channel: models.ChannelEmail, Target: "a@example.com"},
+			{ID: 2, Channel: models.ChannelSlack, Target: "https://hooks.slack.test/x", Attempts: 2},
+			{ID: 3, Channel: models.ChannelWebhook, Target: "https://hooks.example.com"},
+		}
+	}
+
+	notificationRepo := new(MockNotificationRepository)
+	notificationRepo.On("ClaimDue", now, now.Add(5*time.Minute), 100).Return(due(), nil)
+	saved := make(map[int]*models.Notification)
+	notificationRepo.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		notification := args.Get(0).(*models.Notification)
+		saved[notification.ID] = notification
+	}).Return(nil)
+
+	email := &recordingNotifier{}
+	slack := &recordingNotifier{err: errors.New("slack is down")}
+	service := impl.NewNotificationService(nil, nil, notificationRepo, map[models.NotificationChannel]notifications.Notifier{
+		models.ChannelEmail: email,
+		models.ChannelSlack: slack,
+	}, fakeclock.New(now))
+
+	sent, err := service.Deliver(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, email.sent, 1)
+
+	require.Len(t, saved, 3)
+	assert.Equal(t, now, *saved[1].SentAt)
+	assert.Nil(t, saved[1].NextAttemptAt)
+
+	assert.Nil(t, saved[2].SentAt)
+	assert.Equal(t, 3, saved[2].Attempts)
+	assert.Equal(t, "slack is down", saved[2].LastError)
+	assert.Equal(t, now.Add(4*time.Minute), *saved[2].NextAttemptAt)
+
+	assert.Equal(t, 1, saved[3].Attempts)
+	assert.Contains(t, saved[3].LastError, "not configured")
+	assert.Equal(t, now.Add(time.Minute), *saved[3].NextAttemptAt)
+}
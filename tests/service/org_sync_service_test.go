@@ -0,0 +1,199 @@
+package service
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPullRequestService struct {
+	mock.Mock
+}
+
+func (m *MockPullRequestService) Create(pr *models.PullRequest) error {
+	return m.Called(pr).Error(0)
+}
+
+func (m *MockPullRequestService) GetByID(id int) (*models.PullRequest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) GetByAuthorID(authorID int) ([]*models.PullRequest, error) {
+	args := m.Called(authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) GetByReviewerID(reviewerID int) ([]*models.PullRequest, error) {
+	args := m.Called(reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	args := m.Called(reviewerIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]*models.PullRequest), args.Error(1)
+}
+
+func (m *MockPullRequestService) Update(pr *models.PullRequest) error {
+	return m.Called(pr).Error(0)
+}
+
+func (m *MockPullRequestService) AddReviewers(pr *models.PullRequest, reviewers ...*models.User) error {
+	return m.Called(pr, reviewers).Error(0)
+}
+
+func (m *MockPullRequestService) RemoveReviewer(pr *models.PullRequest, reviewerID int) error {
+	return m.Called(pr, reviewerID).Error(0)
+}
+
+func (m *MockPullRequestService) Edit(pr *models.PullRequest, name string, status models.PRStatus, reviewers []*models.User) error {
+	return m.Called(pr, name, status, reviewers).Error(0)
+}
+
+func (m *MockPullRequestService) AssignReviewers(pr *models.PullRequest) error {
+	return m.Called(pr).Error(0)
+}
+
+func (m *MockPullRequestService) ReassignReviewers(pr *models.PullRequest, oldReviewer *models.User) error {
+	return m.Called(pr, oldReviewer).Error(0)
+}
+
+func (m *MockPullRequestService) MergeRequest(pr *models.PullRequest) error {
+	return m.Called(pr).Error(0)
+}
+
+func (m *MockPullRequestService) MarkMerged(pr *models.PullRequest) error {
+	return m.Called(pr).Error(0)
+}
+
+func syncDocument() *models.OrgDocument {
+	return &models.OrgDocument{Teams: []*models.OrgTeam{
+		{
+			Name: "backend",
+			Members: []*models.OrgMember{
+				{Username: "Alice", Email: "alice@example.com", IsActive: true},
+				{Username: "Erin", Email: "erin@example.com", IsActive: true},
+			},
+		},
+		{
+			Name: "mobile",
+			Members: []*models.OrgMember{
+				{Username: "Bob", Email: "bob@example.com", IsActive: true},
+			},
+		},
+	}}
+}
+
+func syncState(users *MockUserRepository, teams *MockTeamRepository) {
+	users.On("GetAll").Return([]*models.User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com", IsActive: true, TeamName: "backend"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com", IsActive: true, TeamName: "backend"},
+		{ID: 3, Name: "Carol", Email: "carol@example.com", IsActive: true, TeamName: "backend"},
+		{ID: 4, Name: "Dan", Email: "dan@example.com", IsActive: false, TeamName: "backend"},
+	}, nil)
+	teams.On("GetAll").Return([]*models.Team{{ID: 1, Name: "backend"}}, nil)
+}
+
+func TestOrgSyncService_Plan(t *testing.T) {
+	users := new(MockUserRepository)
+	teams := new(MockTeamRepository)
+	tm := &fakeTransactionManager{repos: repositories.Repositories{Users: users, Teams: teams}}
+	service := impl.NewOrgSyncService(tm, new(MockPullRequestService))
+	syncState(users, teams)
+
+	plan, err := service.Plan(syncDocument())
+	require.NoError(t, err)
+
+	actions := make([]models.SyncAction, len(plan.Changes))
+	for i, change := range plan.Changes {
+		actions[i] = change.Action
+	}
+	assert.Equal(t, []models.SyncAction{
+		models.SyncAddUser,
+		models.SyncCreateTeam,
+		models.SyncMoveUser,
+		models.SyncDeactivateUser,
+	}, actions)
+	assert.Equal(t, "erin@example.com", plan.Changes[0].Email)
+	assert.Equal(t, "backend", plan.Changes[2].FromTeam)
+	assert.Equal(t, "mobile", plan.Changes[2].ToTeam)
+	assert.Equal(t, 3, plan.Changes[3].UserID)
+	users.AssertNotCalled(t, "Add", mock.Anything)
+	teams.AssertNotCalled(t, "Add", mock.Anything)
+}
+
+func TestOrgSyncService_Apply(t *testing.T) {
+	users := new(MockUserRepository)
+	teams := new(MockTeamRepository)
+	prs := new(MockPullRequestService)
+	tm := &fakeTransactionManager{repos: repositories.Repositories{Users: users, Teams: teams}}
+	service := impl.NewOrgSyncService(tm, prs)
+	syncState(users, teams)
+
+	backend := &models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{}}
+	mobile := &models.Team{ID: 2, Name: "mobile", Members: map[int]*models.TeamMember{}}
+	users.On("Add", mock.MatchedBy(func(user *models.User) bool { return user.Email == "erin@example.com" })).
+		Return(nil).Run(func(args mock.Arguments) { args.Get(0).(*models.User).SetId(5) })
+	teams.On("Add", mock.MatchedBy(func(team *models.Team) bool { return team.Name == "mobile" })).Return(nil)
+	users.On("Update", mock.MatchedBy(func(user *models.User) bool { return user.ID == 2 && user.TeamName == "mobile" })).Return(nil)
+	users.On("Deactivate", 3).Return(nil)
+	teams.On("GetByName", "backend").Return(backend, nil)
+	teams.On("GetByName", "mobile").Return(mobile, nil)
+	teams.On("Update", backend).Return(nil)
+	teams.On("Update", mobile).Return(nil)
+
+	alice := &models.User{ID: 1, Name: "Alice", TeamName: "backend", IsActive: true}
+	bob := &models.User{ID: 2, Name: "Bob", TeamName: "mobile", IsActive: true}
+	carol := &models.User{ID: 3, Name: "Carol", TeamName: "backend"}
+	erin := &models.User{ID: 5, Name: "Erin", TeamName: "backend", IsActive: true}
+	open := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: alice, Reviewers: []*models.User{bob}}
+	merged := &models.PullRequest{ID: 11, Status: models.StatusMerged, Author: alice, Reviewers: []*models.User{bob}}
+	stuck := &models.PullRequest{ID: 12, Status: models.StatusOpen, Author: alice, Reviewers: []*models.User{carol}}
+
+	prs.On("GetByReviewerID", 2).Return([]*models.PullRequest{open, merged}, nil)
+	prs.On("ReassignReviewers", open, mock.MatchedBy(func(user *models.User) bool { return user.ID == 2 })).Return(nil)
+	prs.On("GetByID", 10).Return(&models.PullRequest{ID: 10, Status: models.StatusOpen, Author: alice, Reviewers: []*models.User{erin}}, nil)
+	prs.On("GetByReviewerID", 3).Return([]*models.PullRequest{stuck}, nil)
+	prs.On("ReassignReviewers", stuck, mock.AnythingOfType("*models.User")).Return(models.ErrReviewerNotFound)
+
+	result, err := service.Apply(syncDocument())
+	require.NoError(t, err)
+
+	assert.True(t, result.Applied)
+	assert.Equal(t, 5, result.Plan.Changes[0].UserID)
+	require.Len(t, result.Reassignments, 2)
+	assert.Equal(t, &models.Reassignment{PullRequestID: 10, FromUserID: 2, ToUserID: 5, Outcome: models.ReassignmentDone}, result.Reassignments[0])
+	assert.Equal(t, &models.Reassignment{PullRequestID: 12, FromUserID: 3, Outcome: models.ReassignmentNoCandidate}, result.Reassignments[1])
+	users.AssertExpectations(t)
+	teams.AssertExpectations(t)
+	prs.AssertExpectations(t)
+}
+
+func TestOrgSyncService_RejectsDuplicates(t *testing.T) {
+	tm := &fakeTransactionManager{}
+	service := impl.NewOrgSyncService(tm, new(MockPullRequestService))
+
+	doc := syncDocument()
+	doc.Teams[1].Members = append(doc.Teams[1].Members, &models.OrgMember{Username: "Alice", Email: "Alice@example.com"})
+
+	_, err := service.Apply(doc)
+	assert.ErrorIs(t, err, models.ErrDuplicateEmailInDocument)
+	assert.Zero(t, tm.calls)
+}
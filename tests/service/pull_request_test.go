@@ -1,14 +1,17 @@
 package service
 
 import (
+	"reviewer-assignment-service/internal/domain/events"
 	"reviewer-assignment-service/internal/domain/models"
 	"reviewer-assignment-service/internal/domain/repositories"
 	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockPullRequestRepository struct {
@@ -60,6 +63,14 @@ func (m *MockPullRequestRepository) GetByReviewerID(reviewerID int) ([]*models.P
 	return args.Get(0).([]*models.PullRequest), args.Error(1)
 }
 
+func (m *MockPullRequestRepository) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	args := m.Called(reviewerIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]*models.PullRequest), args.Error(1)
+}
+
 func (m *MockPullRequestRepository) Update(pr *models.PullRequest) error {
 	args := m.Called(pr)
 	return args.Error(0)
@@ -73,10 +84,33 @@ func (m *MockPullRequestRepository) FindPossibleReviewers(author *models.User) (
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
+func noLoad() *MockReviewLoadRepository {
+	loadRepo := new(MockReviewLoadRepository)
+	loadRepo.On("GetByTeamName", mock.Anything, mock.Anything).Return(models.ReviewLoads{}, nil)
+	return loadRepo
+}
+
+// eventLog accepts every recorded event and numbers it like the pr_events sequence.
+func eventLog() *MockPullRequestEventRepository {
+	repo := new(MockPullRequestEventRepository)
+	nextID := 0
+	repo.On("Add", mock.Anything).Run(func(args mock.Arguments) {
+		nextID++
+		args.Get(0).(*models.PullRequestEvent).SetId(nextID)
+	}).Return(nil)
+	return repo
+}
+
+func approvals(required, approved int) *MockReviewRepository {
+	reviewRepo := new(MockReviewRepository)
+	reviewRepo.On("GetApprovals", mock.Anything).Return(&models.ApprovalStatus{Required: required, Approved: approved}, nil)
+	return reviewRepo
+}
+
 func TestPullRequestService_Create(t *testing.T) {
 	t.Run("successful PR creation", func(t *testing.T) {
 		mockRepo := new(MockPullRequestRepository)
-		prService := impl.NewPullRequestService(mockRepo)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
 
 		author := &models.User{
 			ID:       1,
@@ -91,7 +125,7 @@ func TestPullRequestService_Create(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		mockRepo.On("Add", pr).Return(nil)
@@ -102,10 +136,90 @@ func TestPullRequestService_Create(t *testing.T) {
 	})
 }
 
+func TestPullRequestService_ReviewerChangesAreRecorded(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Author", TeamName: "backend", IsActive: true}
+	bob := &models.User{ID: 2, Name: "Bob", TeamName: "backend", IsActive: true}
+	carol := &models.User{ID: 3, Name: "Carol", TeamName: "backend", IsActive: true}
+
+	newService := func(repo *MockPullRequestRepository) (*impl.PullRequestServiceImpl, *events.Broker, *MockNotificationService) {
+		broker := events.NewBroker()
+		notifier := new(MockNotificationService)
+		notifier.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+		return impl.NewPullRequestService(repo, nil, nil, nil, nil, nil, eventLog(), broker, notifier, fakeclock.New(fakeclock.Monday)), broker, notifier
+	}
+	kinds := func(t *testing.T, sub *events.Subscription, n int) []models.PullRequestEventKind {
+		t.Helper()
+		var got []models.PullRequestEventKind
+		for range n {
+			got = append(got, (<-sub.Events()).Kind)
+		}
+		assert.Empty(t, sub.Events())
+		return got
+	}
+
+	t.Run("created with reviewers", func(t *testing.T) {
+		repo := new(MockPullRequestRepository)
+		prService, broker, notifier := newService(repo)
+		bobEvents := broker.Subscribe(2)
+		pr := &models.PullRequest{Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		repo.On("Add", pr).Run(func(args mock.Arguments) { args.Get(0).(*models.PullRequest).ID = 7 }).Return(nil)
+
+		require.NoError(t, prService.Create(pr))
+
+		assert.Equal(t, []models.PullRequestEventKind{models.EventReviewerAssigned}, kinds(t, bobEvents, 1))
+		notifier.AssertNumberOfCalls(t, "Enqueue", 1)
+	})
+
+	t.Run("added and removed", func(t *testing.T) {
+		repo := new(MockPullRequestRepository)
+		prService, broker, notifier := newService(repo)
+		bobEvents := broker.Subscribe(2)
+		pr := &models.PullRequest{ID: 7, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{}}
+		repo.On("Update", pr).Return(nil)
+
+		require.NoError(t, prService.AddReviewers(pr, bob))
+		require.NoError(t, prService.RemoveReviewer(pr, bob.ID))
+
+		assert.Empty(t, pr.Reviewers)
+		assert.Equal(t, []models.PullRequestEventKind{models.EventReviewerAssigned, models.EventReviewerUnassigned}, kinds(t, bobEvents, 2))
+		notifier.AssertNumberOfCalls(t, "Enqueue", 2)
+	})
+
+	t.Run("edited", func(t *testing.T) {
+		repo := new(MockPullRequestRepository)
+		prService, broker, _ := newService(repo)
+		bobEvents, carolEvents := broker.Subscribe(2), broker.Subscribe(3)
+		pr := &models.PullRequest{ID: 7, Name: "Old", Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		repo.On("Update", pr).Return(nil)
+
+		require.NoError(t, prService.Edit(pr, "New", models.StatusClosed, []*models.User{carol}))
+
+		assert.Equal(t, "New", pr.Name)
+		assert.Equal(t, models.StatusClosed, pr.Status)
+		assert.Equal(t, []*models.User{carol}, pr.Reviewers)
+		assert.Equal(t, []models.PullRequestEventKind{models.EventReviewerUnassigned}, kinds(t, bobEvents, 1))
+		assert.Equal(t, []models.PullRequestEventKind{models.EventReviewerAssigned}, kinds(t, carolEvents, 1))
+	})
+
+	t.Run("failed change records nothing", func(t *testing.T) {
+		repo := new(MockPullRequestRepository)
+		prService, broker, notifier := newService(repo)
+		bobEvents := broker.Subscribe(2)
+		pr := &models.PullRequest{ID: 7, Status: models.StatusClosed, Author: author, Reviewers: []*models.User{}}
+
+		assert.ErrorIs(t, prService.AddReviewers(pr, bob), models.ErrPRClosed)
+		assert.ErrorIs(t, prService.Edit(pr, "PR", models.StatusMerged, nil), models.ErrInvalidStatusChange)
+
+		assert.Empty(t, bobEvents.Events())
+		repo.AssertNotCalled(t, "Update", mock.Anything)
+		notifier.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+}
+
 func TestPullRequestService_GetByID(t *testing.T) {
 	t.Run("successful get by id", func(t *testing.T) {
 		mockRepo := new(MockPullRequestRepository)
-		prService := impl.NewPullRequestService(mockRepo)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
 
 		author := &models.User{
 			ID:       1,
@@ -121,7 +235,7 @@ func TestPullRequestService_GetByID(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		mockRepo.On("GetByID", 1).Return(expectedPR, nil)
@@ -136,7 +250,7 @@ func TestPullRequestService_GetByID(t *testing.T) {
 func TestPullRequestService_Update(t *testing.T) {
 	t.Run("successful PR update", func(t *testing.T) {
 		mockRepo := new(MockPullRequestRepository)
-		prService := impl.NewPullRequestService(mockRepo)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
 
 		author := &models.User{
 			ID:       1,
@@ -152,7 +266,7 @@ func TestPullRequestService_Update(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		mockRepo.On("Update", pr).Return(nil)
@@ -166,7 +280,9 @@ func TestPullRequestService_Update(t *testing.T) {
 func TestPullRequestService_ReassignReviewers(t *testing.T) {
 	t.Run("no alternative reviewers available", func(t *testing.T) {
 		mockRepo := new(MockPullRequestRepository)
-		prService := impl.NewPullRequestService(mockRepo)
+		ruleRepo := new(MockReviewerRuleRepository)
+		ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{}, nil)
+		prService := impl.NewPullRequestService(mockRepo, ruleRepo, nil, nil, noLoad(), nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
 
 		author := &models.User{
 			ID:       1,
@@ -190,7 +306,7 @@ func TestPullRequestService_ReassignReviewers(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{oldReviewer},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		existingPR := &models.PullRequest{
@@ -199,7 +315,7 @@ func TestPullRequestService_ReassignReviewers(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{oldReviewer},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		possibleReviewers := []*models.User{
@@ -213,12 +329,48 @@ func TestPullRequestService_ReassignReviewers(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrReviewerNotFound)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("replacement skips assigned reviewers and is saved", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		ruleRepo := new(MockReviewerRuleRepository)
+		ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{}, nil)
+		broker := events.NewBroker()
+		oldReviewerEvents, spareEvents := broker.Subscribe(2), broker.Subscribe(4)
+		prService := impl.NewPullRequestService(mockRepo, ruleRepo, nil, nil, noLoad(), nil, eventLog(), broker, nil, fakeclock.New(fakeclock.Monday))
+
+		author := &models.User{ID: 1, Name: "John Doe", TeamName: "backend", IsActive: true}
+		oldReviewer := &models.User{ID: 2, Name: "Old", TeamName: "backend", IsActive: true}
+		other := &models.User{ID: 3, Name: "Other", TeamName: "backend", IsActive: true}
+		spare := &models.User{ID: 4, Name: "Spare", TeamName: "backend", IsActive: true}
+
+		pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{oldReviewer, other}}
+		existingPR := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{oldReviewer, other}}
+
+		mockRepo.On("GetByID", 1).Return(existingPR, nil)
+		mockRepo.On("FindPossibleReviewers", author).Return([]*models.User{oldReviewer, other, spare}, nil)
+		mockRepo.On("Update", mock.MatchedBy(func(saved *models.PullRequest) bool {
+			return len(saved.Reviewers) == 2 && saved.Reviewers[0].ID == 3 && saved.Reviewers[1].ID == 4
+		})).Return(nil)
+
+		err := prService.ReassignReviewers(pr, oldReviewer)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+
+		unassigned := <-oldReviewerEvents.Events()
+		assert.Equal(t, models.EventReviewerUnassigned, unassigned.Kind)
+		assert.Equal(t, 1, unassigned.PullRequestID)
+		assigned := <-spareEvents.Events()
+		assert.Equal(t, models.EventReviewerAssigned, assigned.Kind)
+		assert.Equal(t, 2, assigned.PreviousUserID)
+		assert.Greater(t, assigned.ID, unassigned.ID)
+	})
 }
 
 func TestPullRequestService_MergeRequest(t *testing.T) {
 	t.Run("successful merge request", func(t *testing.T) {
 		mockRepo := new(MockPullRequestRepository)
-		prService := impl.NewPullRequestService(mockRepo)
+		clk := fakeclock.New(fakeclock.Monday)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, approvals(1, 1), eventLog(), nil, nil, clk)
 
 		author := &models.User{
 			ID:       1,
@@ -234,7 +386,7 @@ func TestPullRequestService_MergeRequest(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		existingPR := &models.PullRequest{
@@ -243,22 +395,49 @@ func TestPullRequestService_MergeRequest(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		mockRepo.On("GetByID", 1).Return(existingPR, nil)
 		mockRepo.On("Update", mock.MatchedBy(func(pr *models.PullRequest) bool {
-			return pr.Status == models.StatusMerged && !pr.MergedAt.IsZero()
+			return pr.Status == models.StatusMerged && pr.MergedAt.Equal(fakeclock.Monday.Add(2*time.Hour))
 		})).Return(nil)
 
+		clk.Advance(2 * time.Hour)
 		err := prService.MergeRequest(pr)
 		assert.NoError(t, err)
+		assert.Equal(t, models.StatusMerged, pr.Status)
+		assert.Equal(t, fakeclock.Monday.Add(2*time.Hour), pr.MergedAt)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("reviewers hear about the merge once", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		broker := events.NewBroker()
+		reviewerEvents := broker.Subscribe(2)
+		notifier := new(MockNotificationService)
+		notifier.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, approvals(1, 1), eventLog(), broker, notifier, fakeclock.New(fakeclock.Monday))
+
+		reviewer := &models.User{ID: 2, Name: "Reviewer", TeamName: "backend", IsActive: true}
+		mockRepo.On("GetByID", 1).Return(&models.PullRequest{ID: 1, Status: models.StatusOpen, Reviewers: []*models.User{reviewer}}, nil).Once()
+		mockRepo.On("GetByID", 1).Return(&models.PullRequest{ID: 1, Status: models.StatusMerged, Reviewers: []*models.User{reviewer}}, nil).Once()
+		mockRepo.On("Update", mock.Anything).Return(nil)
+
+		require.NoError(t, prService.MergeRequest(&models.PullRequest{ID: 1}))
+		require.NoError(t, prService.MergeRequest(&models.PullRequest{ID: 1}))
+
+		merged := <-reviewerEvents.Events()
+		assert.Equal(t, models.EventMerged, merged.Kind)
+		assert.Equal(t, 2, merged.UserID)
+		assert.Empty(t, reviewerEvents.Events())
+		notifier.AssertNumberOfCalls(t, "Enqueue", 1)
+		notifier.AssertCalled(t, "Enqueue", mock.MatchedBy(func(pr *models.PullRequest) bool { return pr.ID == 1 }), []*models.PullRequestEvent{merged})
+	})
+
 	t.Run("PR not found for merge", func(t *testing.T) {
 		mockRepo := new(MockPullRequestRepository)
-		prService := impl.NewPullRequestService(mockRepo)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, approvals(0, 0), eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
 
 		author := &models.User{
 			ID:       1,
@@ -274,7 +453,7 @@ func TestPullRequestService_MergeRequest(t *testing.T) {
 			Status:    models.StatusOpen,
 			Author:    author,
 			Reviewers: []*models.User{},
-			CreatedAt: time.Now(),
+			CreatedAt: fakeclock.Monday,
 		}
 
 		mockRepo.On("GetByID", 999).Return(nil, repositories.ErrPullRequestNotFoundInPersistence)
@@ -283,4 +462,228 @@ func TestPullRequestService_MergeRequest(t *testing.T) {
 		assert.ErrorIs(t, err, repositories.ErrPullRequestNotFoundInPersistence)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("not enough approvals", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, approvals(2, 1), eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		pr := &models.PullRequest{ID: 1, Name: "Feature PR", Status: models.StatusOpen}
+		mockRepo.On("GetByID", 1).Return(&models.PullRequest{ID: 1, Name: "Feature PR", Status: models.StatusOpen}, nil)
+
+		err := prService.MergeRequest(pr)
+		assert.ErrorIs(t, err, models.ErrNotEnoughApprovals)
+		assert.EqualError(t, err, "not enough approvals: 1 of 2")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("closed PR is not merged", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		reviewRepo := approvals(0, 0)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, reviewRepo, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		mockRepo.On("GetByID", 1).Return(&models.PullRequest{ID: 1, Status: models.StatusClosed}, nil)
+
+		err := prService.MergeRequest(&models.PullRequest{ID: 1})
+		assert.ErrorIs(t, err, models.ErrPRClosed)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+		reviewRepo.AssertNotCalled(t, "GetApprovals", mock.Anything)
+	})
+
+	t.Run("merged PR keeps its merge time and version", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		reviewRepo := approvals(2, 0)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, reviewRepo, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		mergedAt := fakeclock.Monday.Add(-time.Hour)
+		mockRepo.On("GetByID", 1).Return(&models.PullRequest{ID: 1, Status: models.StatusMerged, MergedAt: mergedAt, Version: 3}, nil)
+
+		pr := &models.PullRequest{ID: 1}
+		require.NoError(t, prService.MergeRequest(pr))
+		assert.Equal(t, mergedAt, pr.MergedAt)
+		assert.Equal(t, 3, pr.Version)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+		reviewRepo.AssertNotCalled(t, "GetApprovals", mock.Anything)
+	})
+}
+
+func TestPullRequestService_MarkMerged(t *testing.T) {
+	t.Run("merged without approvals and recorded", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		broker := events.NewBroker()
+		reviewerEvents := broker.Subscribe(2)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, approvals(2, 0), eventLog(), broker, nil, fakeclock.New(fakeclock.Monday))
+
+		reviewer := &models.User{ID: 2, Name: "Reviewer", TeamName: "backend", IsActive: true}
+		pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Reviewers: []*models.User{reviewer}}
+		mockRepo.On("Update", pr).Return(nil)
+
+		require.NoError(t, prService.MarkMerged(pr))
+		assert.Equal(t, models.StatusMerged, pr.Status)
+		assert.Equal(t, fakeclock.Monday, pr.MergedAt)
+		merged := <-reviewerEvents.Events()
+		assert.Equal(t, models.EventMerged, merged.Kind)
+		assert.Equal(t, 2, merged.UserID)
+	})
+
+	t.Run("merged PR is left alone", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		prService := impl.NewPullRequestService(mockRepo, nil, nil, nil, nil, nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		require.NoError(t, prService.MarkMerged(&models.PullRequest{ID: 1, Status: models.StatusMerged}))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestPullRequestService_AssignReviewersWithRules(t *testing.T) {
+	junior := &models.User{ID: 1, Name: "Junior", TeamName: "backend", IsActive: true}
+	peer := &models.User{ID: 2, Name: "Peer", TeamName: "backend", IsActive: true}
+	rival := &models.User{ID: 3, Name: "Rival", TeamName: "backend", IsActive: true}
+	senior := &models.User{ID: 4, Name: "Senior", TeamName: "backend", IsActive: true}
+
+	t.Run("rules exclude, require and prefer reviewers", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		ruleRepo := new(MockReviewerRuleRepository)
+		tagRepo := new(MockUserTagRepository)
+		prService := impl.NewPullRequestService(mockRepo, ruleRepo, tagRepo, nil, noLoad(), nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: junior, Reviewers: []*models.User{}}
+		mockRepo.On("FindPossibleReviewers", junior).Return([]*models.User{peer, rival, senior}, nil)
+		ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{
+			{ID: 1, Kind: models.RuleNeverAssign, ReviewerID: 3, AuthorID: 1},
+			{ID: 2, Kind: models.RuleRequireTag, Tag: "senior", AuthorTag: "junior"},
+		}, nil)
+		tagRepo.On("GetByUserIDs", []int{1, 2, 3, 4}).Return(map[int][]string{1: {"junior"}, 4: {"senior"}}, nil)
+		mockRepo.On("Update", pr).Return(nil)
+
+		err := prService.AssignReviewers(pr)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.User{senior, peer}, pr.Reviewers)
+	})
+
+	t.Run("missing required reviewer fails", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		ruleRepo := new(MockReviewerRuleRepository)
+		tagRepo := new(MockUserTagRepository)
+		prService := impl.NewPullRequestService(mockRepo, ruleRepo, tagRepo, nil, noLoad(), nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: junior, Reviewers: []*models.User{}}
+		mockRepo.On("FindPossibleReviewers", junior).Return([]*models.User{peer}, nil)
+		ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{
+			{ID: 2, Kind: models.RuleRequireTag, Tag: "senior"},
+		}, nil)
+		tagRepo.On("GetByUserIDs", []int{1, 2}).Return(map[int][]string{}, nil)
+
+		err := prService.AssignReviewers(pr)
+		assert.ErrorIs(t, err, models.ErrRequiredReviewerUnavailable)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("nobody to assign is not saved", func(t *testing.T) {
+		mockRepo := new(MockPullRequestRepository)
+		ruleRepo := new(MockReviewerRuleRepository)
+		prService := impl.NewPullRequestService(mockRepo, ruleRepo, nil, nil, noLoad(), nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+		pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: junior, Reviewers: []*models.User{peer}}
+		mockRepo.On("FindPossibleReviewers", junior).Return([]*models.User{peer}, nil)
+		ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{}, nil)
+
+		err := prService.AssignReviewers(pr)
+		assert.ErrorIs(t, err, models.ErrReviewerNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestPullRequestService_AssignReviewersWithPatterns(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Author", TeamName: "backend", IsActive: true}
+	peer := &models.User{ID: 2, Name: "Peer", TeamName: "backend", IsActive: true}
+	billing := &models.User{ID: 3, Name: "Billing", TeamName: "backend", IsActive: true}
+
+	mockRepo := new(MockPullRequestRepository)
+	ruleRepo := new(MockReviewerRuleRepository)
+	tagRepo := new(MockUserTagRepository)
+	patternRepo := new(MockReviewPatternRepository)
+	prService := impl.NewPullRequestService(mockRepo, ruleRepo, tagRepo, patternRepo, noLoad(), nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+	pr := &models.PullRequest{
+		ID: 1, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{},
+		Paths: []string{"internal/billing/invoice.go"}, Labels: []string{"Security"},
+	}
+	patternRepo.On("GetByTeamName", "backend").Return(models.ReviewPatterns{
+		{ID: 1, Pattern: "/internal/billing/", Tag: "billing"},
+		{ID: 2, Pattern: "*.sql", Tag: "db"},
+		{ID: 3, Label: "security", Tag: "security"},
+	}, nil)
+	mockRepo.On("FindPossibleReviewers", author).Return([]*models.User{peer, billing}, nil)
+	ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{}, nil)
+	tagRepo.On("GetByUserIDs", []int{1, 2, 3}).Return(map[int][]string{3: {"billing", "security"}}, nil)
+	mockRepo.On("Update", pr).Return(nil)
+
+	err := prService.AssignReviewers(pr)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{billing, peer}, pr.Reviewers)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_AssignReviewersByLoad(t *testing.T) {
+	author := &models.User{ID: 1, Name: "Author", TeamName: "backend", IsActive: true}
+	busy := &models.User{ID: 2, Name: "Busy", TeamName: "backend", IsActive: true}
+	idle := &models.User{ID: 3, Name: "Idle", TeamName: "backend", IsActive: true}
+	light := &models.User{ID: 4, Name: "Light", TeamName: "backend", IsActive: true}
+
+	mockRepo := new(MockPullRequestRepository)
+	ruleRepo := new(MockReviewerRuleRepository)
+	loadRepo := new(MockReviewLoadRepository)
+	prService := impl.NewPullRequestService(mockRepo, ruleRepo, nil, nil, loadRepo, nil, eventLog(), nil, nil, fakeclock.New(fakeclock.Monday))
+
+	pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{}}
+	mockRepo.On("FindPossibleReviewers", author).Return([]*models.User{busy, idle, light}, nil)
+	ruleRepo.On("GetByTeamName", "backend").Return(models.ReviewerRules{}, nil)
+	loadRepo.On("GetByTeamName", "backend", fakeclock.Monday).Return(models.ReviewLoads{2: 900, 4: 35.5}, nil)
+	mockRepo.On("Update", pr).Return(nil)
+
+	err := prService.AssignReviewers(pr)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.User{idle, light}, pr.Reviewers)
+	loadRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_AssignReviewersInWorkingHours(t *testing.T) {
+	// Monday 10:00 UTC is 13:00 in Moscow and 19:00 in Tokyo.
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	author := &models.User{ID: 1, Name: "Author", TeamName: "backend", IsActive: true}
+	london := &models.User{ID: 2, Name: "London", TeamName: "backend", IsActive: true, WorkingHours: models.DefaultWorkingHours}
+	tokyo := &models.User{ID: 3, Name: "Tokyo", TeamName: "backend", IsActive: true,
+		WorkingHours: models.WorkingHours{Timezone: "Asia/Tokyo", StartHour: 9, EndHour: 18}}
+	moscow := &models.User{ID: 4, Name: "Moscow", TeamName: "backend", IsActive: true,
+		WorkingHours: models.WorkingHours{Timezone: "Europe/Moscow", StartHour: 10, EndHour: 19}}
+
+	tests := []struct {
+		name     string
+		rules    models.ReviewerRules
+		expected []*models.User
+	}{
+		{"by load without the rule", models.ReviewerRules{}, []*models.User{tokyo, moscow}},
+		{"working hours first with the rule", models.ReviewerRules{models.NewReviewerRule(1, models.RulePreferWorkingHours)}, []*models.User{moscow, london}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPullRequestRepository)
+			ruleRepo := new(MockReviewerRuleRepository)
+			tagRepo := new(MockUserTagRepository)
+			loadRepo := new(MockReviewLoadRepository)
+			prService := impl.NewPullRequestService(mockRepo, ruleRepo, tagRepo, nil, loadRepo, nil, eventLog(), nil, nil, fakeclock.New(now))
+
+			pr := &models.PullRequest{ID: 1, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{}}
+			mockRepo.On("FindPossibleReviewers", author).Return([]*models.User{london, tokyo, moscow}, nil)
+			ruleRepo.On("GetByTeamName", "backend").Return(tt.rules, nil)
+			tagRepo.On("GetByUserIDs", []int{1, 2, 3, 4}).Return(map[int][]string{}, nil)
+			loadRepo.On("GetByTeamName", "backend", now).Return(models.ReviewLoads{2: 900, 4: 35.5}, nil)
+			mockRepo.On("Update", pr).Return(nil)
+
+			err := prService.AssignReviewers(pr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pr.Reviewers)
+		})
+	}
 }
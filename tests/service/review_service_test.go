@@ -0,0 +1,88 @@
+package service
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) GetByPullRequestID(prID int) ([]*models.ReviewAssignment, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReviewAssignment), args.Error(1)
+}
+
+func (m *MockReviewRepository) SetState(prID, reviewerID int, state models.ReviewState, at time.Time) error {
+	args := m.Called(prID, reviewerID, state, at)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) GetApprovals(prID int) (*models.ApprovalStatus, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalStatus), args.Error(1)
+}
+
+func (m *MockReviewRepository) GetPolicy(teamID int) (*models.ApprovalPolicy, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApprovalPolicy), args.Error(1)
+}
+
+func (m *MockReviewRepository) SavePolicy(policy *models.ApprovalPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+var _ repositories.ReviewRepository = (*MockReviewRepository)(nil)
+
+func TestReviewService_SubmitReview(t *testing.T) {
+	t.Run("records the verdict", func(t *testing.T) {
+		reviewRepo := new(MockReviewRepository)
+		events := new(MockPullRequestEventRepository)
+		prs := new(MockPullRequestService)
+		service := impl.NewReviewService(reviewRepo, events, prs, fakeclock.New(fakeclock.Monday))
+
+		prs.On("GetByID", 10).Return(&models.PullRequest{ID: 10, Status: models.StatusOpen}, nil)
+		reviewRepo.On("SetState", 10, 2, models.ReviewApproved, fakeclock.Monday).Return(nil)
+		events.On("Add", models.NewPullRequestEvent(10, models.EventReviewApproved, 2, fakeclock.Monday)).Return(nil)
+		reviewRepo.On("GetByPullRequestID", 10).Return([]*models.ReviewAssignment{
+			{PullRequestID: 10, ReviewerID: 2, State: models.ReviewApproved},
+		}, nil)
+		reviewRepo.On("GetApprovals", 10).Return(&models.ApprovalStatus{Required: 1, Approved: 1}, nil)
+
+		reviews, err := service.SubmitReview(10, 2, models.ReviewApproved)
+		require.NoError(t, err)
+		assert.NoError(t, reviews.Approvals.Check())
+		events.AssertExpectations(t)
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		reviewRepo := new(MockReviewRepository)
+		prs := new(MockPullRequestService)
+		service := impl.NewReviewService(reviewRepo, nil, prs, fakeclock.New(fakeclock.Monday))
+
+		prs.On("GetByID", 10).Return(&models.PullRequest{ID: 10, Status: models.StatusMerged}, nil)
+
+		_, err := service.SubmitReview(10, 2, models.ReviewChangesRequested)
+		assert.ErrorIs(t, err, models.ErrPRAlreadyMerged)
+		reviewRepo.AssertNotCalled(t, "SetState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReviewSLARepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewSLARepository) GetByTeamID(teamID int) (*models.ReviewSLA, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReviewSLA), args.Error(1)
+}
+
+func (m *MockReviewSLARepository) Save(sla *models.ReviewSLA) error {
+	args := m.Called(sla)
+	return args.Error(0)
+}
+
+func (m *MockReviewSLARepository) FindPending(now time.Time) ([]*models.PendingReview, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PendingReview), args.Error(1)
+}
+
+func (m *MockReviewSLARepository) GetAssignments(prID int) ([]*models.ReviewAssignment, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ReviewAssignment), args.Error(1)
+}
+
+func (m *MockReviewSLARepository) MarkReviewed(prID, reviewerID int, at time.Time) error {
+	args := m.Called(prID, reviewerID, at)
+	return args.Error(0)
+}
+
+type MockPullRequestEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockPullRequestEventRepository) Add(event *models.PullRequestEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestEventRepository) GetByPullRequestID(prID int) ([]*models.PullRequestEvent, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequestEvent), args.Error(1)
+}
+
+func (m *MockPullRequestEventRepository) GetByUserID(userID, afterID int) ([]*models.PullRequestEvent, error) {
+	args := m.Called(userID, afterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequestEvent), args.Error(1)
+}
+
+var _ repositories.ReviewSLARepository = (*MockReviewSLARepository)(nil)
+var _ repositories.PullRequestEventRepository = (*MockPullRequestEventRepository)(nil)
+
+func newSLAFixture() (*impl.ReviewSLAServiceImpl, *MockReviewSLARepository, *MockPullRequestEventRepository, *MockPullRequestService) {
+	slas := new(MockReviewSLARepository)
+	events := new(MockPullRequestEventRepository)
+	prs := new(MockPullRequestService)
+	return impl.NewReviewSLAService(slas, events, prs, fakeclock.New(fakeclock.Monday)), slas, events, prs
+}
+
+func TestReviewSLAService_Acknowledge(t *testing.T) {
+	t.Run("marks the review and records it", func(t *testing.T) {
+		service, slas, events, prs := newSLAFixture()
+		prs.On("GetByID", 10).Return(&models.PullRequest{ID: 10}, nil)
+		slas.On("MarkReviewed", 10, 2, fakeclock.Monday).Return(nil)
+		events.On("Add", models.NewPullRequestEvent(10, models.EventReviewed, 2, fakeclock.Monday)).Return(nil)
+		slas.On("GetAssignments", 10).Return([]*models.ReviewAssignment{{PullRequestID: 10, ReviewerID: 2}}, nil)
+
+		assignments, err := service.Acknowledge(10, 2)
+		require.NoError(t, err)
+		assert.Len(t, assignments, 1)
+		events.AssertExpectations(t)
+	})
+
+	t.Run("not a reviewer", func(t *testing.T) {
+		service, slas, events, prs := newSLAFixture()
+		prs.On("GetByID", 10).Return(&models.PullRequest{ID: 10}, nil)
+		slas.On("MarkReviewed", 10, 5, fakeclock.Monday).Return(models.ErrReviewerNotAssigned)
+
+		_, err := service.Acknowledge(10, 5)
+		assert.ErrorIs(t, err, models.ErrReviewerNotAssigned)
+		events.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
+
+func TestReviewSLAService_Escalate(t *testing.T) {
+	// Thursday 2026-03-05 09:00 UTC; an SLA of 24 business hours started on Tuesday has run out.
+	now := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	author := &models.User{ID: 1, TeamName: "backend", IsActive: true}
+	bob := &models.User{ID: 2, TeamName: "backend", IsActive: true}
+	carol := &models.User{ID: 3, TeamName: "backend", IsActive: true}
+	dave := &models.User{ID: 4, TeamName: "backend", IsActive: true}
+
+	pending := func(action models.SLAAction, reviewers ...int) *models.PendingReview {
+		review := &models.PendingReview{PullRequestID: 10, CreatedAt: tuesday, SLA: models.ReviewSLA{TeamID: 1, Hours: 24, Action: action}}
+		for i, id := range reviewers {
+			review.Assignments = append(review.Assignments, &models.ReviewAssignment{
+				PullRequestID: 10, ReviewerID: id, AssignedAt: tuesday.Add(time.Duration(i) * time.Minute),
+			})
+		}
+		return review
+	}
+
+	t.Run("adds a reviewer while a slot is free", func(t *testing.T) {
+		service, slas, events, prs := newSLAFixture()
+		slas.On("FindPending", now).Return([]*models.PendingReview{pending(models.SLAActionAddReviewer, 2)}, nil)
+		before := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		after := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob, carol}}
+		prs.On("GetByID", 10).Return(before, nil).Once()
+		prs.On("AssignReviewers", before).Return(nil)
+		prs.On("GetByID", 10).Return(after, nil).Once()
+		events.On("Add", mock.Anything).Return(nil)
+
+		recorded, err := service.Escalate(now)
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, models.EventSLAReviewerAdded, recorded[0].Kind)
+		assert.Equal(t, 3, recorded[0].UserID)
+		assert.Zero(t, recorded[0].PreviousUserID)
+		prs.AssertNotCalled(t, "ReassignReviewers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("replaces the longest waiting reviewer when full", func(t *testing.T) {
+		service, slas, events, prs := newSLAFixture()
+		slas.On("FindPending", now).Return([]*models.PendingReview{pending(models.SLAActionAddReviewer, 2, 3)}, nil)
+		before := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob, carol}}
+		after := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{dave, carol}}
+		prs.On("GetByID", 10).Return(before, nil).Once()
+		prs.On("ReassignReviewers", before, mock.MatchedBy(func(user *models.User) bool { return user.ID == 2 })).Return(nil)
+		prs.On("GetByID", 10).Return(after, nil).Once()
+		events.On("Add", mock.Anything).Return(nil)
+
+		recorded, err := service.Escalate(now)
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, models.EventSLAReviewerReplaced, recorded[0].Kind)
+		assert.Equal(t, 4, recorded[0].UserID)
+		assert.Equal(t, 2, recorded[0].PreviousUserID)
+	})
+
+	t.Run("reassign mode replaces even with a free slot", func(t *testing.T) {
+		service, slas, events, prs := newSLAFixture()
+		slas.On("FindPending", now).Return([]*models.PendingReview{pending(models.SLAActionReassign, 2)}, nil)
+		before := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		after := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{carol}}
+		prs.On("GetByID", 10).Return(before, nil).Once()
+		prs.On("ReassignReviewers", before, mock.MatchedBy(func(user *models.User) bool { return user.ID == 2 })).Return(nil)
+		prs.On("GetByID", 10).Return(after, nil).Once()
+		events.On("Add", mock.Anything).Return(nil)
+
+		recorded, err := service.Escalate(now)
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, models.EventSLAReviewerReplaced, recorded[0].Kind)
+	})
+
+	t.Run("skips PRs within working hours and without candidates", func(t *testing.T) {
+		service, slas, events, prs := newSLAFixture()
+		// Assigned on Friday evening: the weekend and the nights do not count, so the SLA has not run out.
+		weekend := pending(models.SLAActionReassign, 2)
+		weekend.PullRequestID = 11
+		weekend.Assignments[0].PullRequestID = 11
+		weekend.Assignments[0].AssignedAt = time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC)
+		weekend.ReviewerHours = map[int]models.WorkingHours{2: models.DefaultWorkingHours}
+		slas.On("FindPending", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)).Return([]*models.PendingReview{
+			pending(models.SLAActionReassign, 2), weekend,
+		}, nil)
+		pr := &models.PullRequest{ID: 10, Status: models.StatusOpen, Author: author, Reviewers: []*models.User{bob}}
+		prs.On("GetByID", 10).Return(pr, nil)
+		prs.On("ReassignReviewers", pr, mock.Anything).Return(models.ErrReviewerNotFound)
+
+		recorded, err := service.Escalate(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Empty(t, recorded)
+		prs.AssertNotCalled(t, "GetByID", 11)
+		events.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
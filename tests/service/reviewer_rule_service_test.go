@@ -0,0 +1,240 @@
+package service
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReviewerRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewerRuleRepository) Add(rule *models.ReviewerRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockReviewerRuleRepository) GetByID(id int) (*models.ReviewerRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReviewerRule), args.Error(1)
+}
+
+func (m *MockReviewerRuleRepository) GetByTeamID(teamID int) (models.ReviewerRules, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewerRules), args.Error(1)
+}
+
+func (m *MockReviewerRuleRepository) GetByTeamName(teamName string) (models.ReviewerRules, error) {
+	args := m.Called(teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewerRules), args.Error(1)
+}
+
+func (m *MockReviewerRuleRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockUserTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserTagRepository) GetByUserID(userID int) ([]string, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserTagRepository) GetByUserIDs(userIDs []int) (map[int][]string, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]string), args.Error(1)
+}
+
+func (m *MockUserTagRepository) Replace(userID int, tags []string) error {
+	args := m.Called(userID, tags)
+	return args.Error(0)
+}
+
+type MockReviewPatternRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewPatternRepository) Add(pattern *models.ReviewPattern) error {
+	args := m.Called(pattern)
+	return args.Error(0)
+}
+
+func (m *MockReviewPatternRepository) GetByID(id int) (*models.ReviewPattern, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReviewPattern), args.Error(1)
+}
+
+func (m *MockReviewPatternRepository) GetByTeamID(teamID int) (models.ReviewPatterns, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewPatterns), args.Error(1)
+}
+
+func (m *MockReviewPatternRepository) GetByTeamName(teamName string) (models.ReviewPatterns, error) {
+	args := m.Called(teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewPatterns), args.Error(1)
+}
+
+func (m *MockReviewPatternRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockReviewLoadRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewLoadRepository) GetByTeamName(teamName string, now time.Time) (models.ReviewLoads, error) {
+	args := m.Called(teamName, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.ReviewLoads), args.Error(1)
+}
+
+func (m *MockReviewLoadRepository) GetHalfLife(teamID int) (time.Duration, error) {
+	args := m.Called(teamID)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockReviewLoadRepository) SetHalfLife(teamID int, halfLife time.Duration) error {
+	args := m.Called(teamID, halfLife)
+	return args.Error(0)
+}
+
+func newRuleFixture() (*impl.ReviewerRuleServiceImpl, *MockReviewerRuleRepository, *MockTeamRepository, *MockUserRepository, *MockUserTagRepository) {
+	rules := new(MockReviewerRuleRepository)
+	teams := new(MockTeamRepository)
+	users := new(MockUserRepository)
+	tags := new(MockUserTagRepository)
+	return impl.NewReviewerRuleService(rules, teams, users, tags, nil, nil, fakeclock.New(fakeclock.Monday)), rules, teams, users, tags
+}
+
+func TestReviewerRuleService_Create(t *testing.T) {
+	t.Run("rule for team members is saved", func(t *testing.T) {
+		service, rules, teams, users, _ := newRuleFixture()
+		teams.On("GetByID", 1).Return(&models.Team{ID: 1, Name: "backend"}, nil)
+		users.On("GetByID", 2).Return(&models.User{ID: 2, TeamName: "backend"}, nil)
+		users.On("GetByID", 3).Return(&models.User{ID: 3, TeamName: "backend"}, nil)
+		rule := &models.ReviewerRule{TeamID: 1, Kind: models.RuleNeverAssign, ReviewerID: 2, AuthorID: 3}
+		rules.On("Add", rule).Return(nil)
+
+		require.NoError(t, service.Create(rule))
+		rules.AssertExpectations(t)
+	})
+
+	t.Run("user from another team is rejected", func(t *testing.T) {
+		service, rules, teams, users, _ := newRuleFixture()
+		teams.On("GetByID", 1).Return(&models.Team{ID: 1, Name: "backend"}, nil)
+		users.On("GetByID", 2).Return(&models.User{ID: 2, TeamName: "frontend"}, nil)
+
+		err := service.Create(&models.ReviewerRule{TeamID: 1, Kind: models.RulePrefer, ReviewerID: 2, AuthorID: 3})
+		assert.ErrorIs(t, err, models.ErrRuleUserNotInTeam)
+		rules.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
+
+func TestReviewerRuleService_Delete(t *testing.T) {
+	t.Run("rule of another team is not found", func(t *testing.T) {
+		service, rules, _, _, _ := newRuleFixture()
+		rules.On("GetByID", 5).Return(&models.ReviewerRule{ID: 5, TeamID: 2}, nil)
+
+		err := service.Delete(1, 5)
+		assert.ErrorIs(t, err, repositories.ErrReviewerRuleNotFound)
+		rules.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
+
+func TestReviewerRuleService_Check(t *testing.T) {
+	service, rules, teams, users, tags := newRuleFixture()
+	teams.On("GetByID", 1).Return(&models.Team{ID: 1, Name: "backend"}, nil)
+	users.On("GetByID", 1).Return(&models.User{ID: 1, TeamName: "backend", IsActive: true}, nil)
+	users.On("GetByID", 2).Return(&models.User{ID: 2, TeamName: "backend", IsActive: true}, nil)
+	rules.On("GetByTeamID", 1).Return(models.ReviewerRules{
+		{ID: 7, TeamID: 1, Kind: models.RuleNeverAssign, ReviewerID: 2, AuthorID: 1},
+		{ID: 8, TeamID: 1, Kind: models.RuleRequireTag, Tag: "senior", AuthorTag: "junior"},
+	}, nil)
+	tags.On("GetByUserIDs", []int{1, 2}).Return(map[int][]string{1: {"junior"}, 2: {"senior"}}, nil)
+
+	check, err := service.Check(1, 1, 2)
+	require.NoError(t, err)
+
+	assert.False(t, check.Eligible)
+	assert.Equal(t, []string{"rule 7: users 2 and 1 never review each other"}, check.Reasons)
+	assert.Equal(t, []string{"senior"}, check.RequiredTags)
+	assert.Equal(t, []string{"senior"}, check.ProvidedTags)
+}
+
+func TestReviewerRuleService_SetUserTags(t *testing.T) {
+	service, _, _, users, tags := newRuleFixture()
+	users.On("GetByID", 2).Return(&models.User{ID: 2}, nil)
+	tags.On("Replace", 2, []string{"go", "senior"}).Return(nil)
+
+	saved, err := service.SetUserTags(2, []string{"senior", "go", "senior"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "senior"}, saved)
+	tags.AssertExpectations(t)
+}
+
+func TestReviewerRuleService_ReviewLoad(t *testing.T) {
+	t.Run("members without reviews have zero load", func(t *testing.T) {
+		teams := new(MockTeamRepository)
+		loads := new(MockReviewLoadRepository)
+		service := impl.NewReviewerRuleService(nil, teams, nil, nil, nil, loads, fakeclock.New(fakeclock.Monday))
+		team := &models.Team{ID: 1, Name: "backend", Members: map[int]*models.TeamMember{
+			2: models.NewTeamMember(2, "Peer", true),
+			3: models.NewTeamMember(3, "Idle", true),
+		}}
+		teams.On("GetByID", 1).Return(team, nil)
+		loads.On("GetHalfLife", 1).Return(72*time.Hour, nil)
+		loads.On("GetByTeamName", "backend", fakeclock.Monday).Return(models.ReviewLoads{2: 40}, nil)
+
+		load, err := service.GetReviewLoad(1)
+		require.NoError(t, err)
+		assert.Equal(t, 72*time.Hour, load.HalfLife)
+		assert.Equal(t, models.ReviewLoads{2: 40, 3: 0}, load.Loads)
+	})
+
+	t.Run("half-life of unknown team", func(t *testing.T) {
+		loads := new(MockReviewLoadRepository)
+		service := impl.NewReviewerRuleService(nil, nil, nil, nil, nil, loads, fakeclock.New(fakeclock.Monday))
+		loads.On("SetHalfLife", 9, 24*time.Hour).Return(repositories.ErrTeamNotFoundInPersistence)
+
+		_, err := service.SetReviewHalfLife(9, 24*time.Hour)
+		assert.ErrorIs(t, err, repositories.ErrTeamNotFoundInPersistence)
+	})
+}
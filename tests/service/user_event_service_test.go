@@ -0,0 +1,57 @@
+package service
+
+import (
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserEventService_Subscribe(t *testing.T) {
+	t.Run("unknown user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		eventRepo := new(MockPullRequestEventRepository)
+		userRepo.On("GetByID", 9).Return(nil, repositories.ErrUserNotFoundInPersistence)
+
+		_, _, err := impl.NewUserEventService(userRepo, eventRepo, events.NewBroker()).Subscribe(9, 0)
+		assert.ErrorIs(t, err, repositories.ErrUserNotFoundInPersistence)
+	})
+
+	t.Run("without a last event id only new events are delivered", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		eventRepo := new(MockPullRequestEventRepository)
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2}, nil)
+		broker := events.NewBroker()
+
+		sub, missed, err := impl.NewUserEventService(userRepo, eventRepo, broker).Subscribe(2, 0)
+		require.NoError(t, err)
+		defer sub.Close()
+		assert.Empty(t, missed)
+		eventRepo.AssertNotCalled(t, "GetByUserID", 2, 0)
+
+		broker.Publish(&models.PullRequestEvent{ID: 5, PullRequestID: 1, Kind: models.EventReviewerAssigned, UserID: 2})
+		assert.Equal(t, 5, (<-sub.Events()).ID)
+	})
+
+	t.Run("resumes with the streamed events after the last id", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		eventRepo := new(MockPullRequestEventRepository)
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2}, nil)
+		eventRepo.On("GetByUserID", 2, 10).Return([]*models.PullRequestEvent{
+			{ID: 11, PullRequestID: 1, Kind: models.EventReviewerAssigned, UserID: 2},
+			{ID: 12, PullRequestID: 1, Kind: models.EventReviewApproved, UserID: 2},
+			{ID: 14, PullRequestID: 1, Kind: models.EventMerged, UserID: 2},
+		}, nil)
+
+		sub, missed, err := impl.NewUserEventService(userRepo, eventRepo, events.NewBroker()).Subscribe(2, 10)
+		require.NoError(t, err)
+		defer sub.Close()
+		require.Len(t, missed, 2)
+		assert.Equal(t, 11, missed[0].ID)
+		assert.Equal(t, 14, missed[1].ID)
+	})
+}
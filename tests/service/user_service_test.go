@@ -27,6 +27,14 @@ func (m *MockUserRepository) GetByID(id int) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByIDs(ids []int) ([]*models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
@@ -53,6 +61,11 @@ func (m *MockUserRepository) Deactivate(userID int) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	args := m.Called(userID, hours)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetWithFilters(teamName string, isActive bool) ([]*models.User, error) {
 	args := m.Called(teamName, isActive)
 	if args.Get(0) == nil {
@@ -69,10 +82,61 @@ func (m *MockUserRepository) GetActiveUsers() ([]*models.User, error) {
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserIdentityRepository) Add(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) GetByID(id int) (*models.UserIdentity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) GetByUserID(userID int) ([]*models.UserIdentity, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) GetByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) GetByLogin(provider models.VCSProvider, login string) (*models.UserIdentity, error) {
+	args := m.Called(provider, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) Update(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestUserService_Create(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		user := &models.User{
 			Name:     "John Doe",
@@ -90,7 +154,7 @@ func TestUserService_Create(t *testing.T) {
 
 	t.Run("creation with duplicate email", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		user := &models.User{
 			Name:     "John Doe",
@@ -110,7 +174,7 @@ func TestUserService_Create(t *testing.T) {
 func TestUserService_GetByID(t *testing.T) {
 	t.Run("successful get by id", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		expectedUser := &models.User{
 			ID:       1,
@@ -130,7 +194,7 @@ func TestUserService_GetByID(t *testing.T) {
 
 	t.Run("user not found", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		mockRepo.On("GetByID", 999).Return(nil, repositories.ErrUserNotFoundInPersistence)
 
@@ -144,7 +208,7 @@ func TestUserService_GetByID(t *testing.T) {
 func TestUserService_GetByEmail(t *testing.T) {
 	t.Run("successful get by email", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		expectedUser := &models.User{
 			ID:       1,
@@ -166,7 +230,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 func TestUserService_GetAll(t *testing.T) {
 	t.Run("successful get all users", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		expectedUsers := []*models.User{
 			{ID: 1, Name: "John Doe", Email: "john@example.com", TeamName: "backend", IsActive: true},
@@ -185,7 +249,7 @@ func TestUserService_GetAll(t *testing.T) {
 func TestUserService_Update(t *testing.T) {
 	t.Run("successful update", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		user := &models.User{
 			ID:       1,
@@ -206,7 +270,7 @@ func TestUserService_Update(t *testing.T) {
 func TestUserService_SetActive(t *testing.T) {
 	t.Run("successful activate user", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		user := &models.User{
 			ID:       1,
@@ -229,7 +293,7 @@ func TestUserService_SetActive(t *testing.T) {
 
 	t.Run("user not found for activation", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		mockRepo.On("GetByID", 999).Return(nil, repositories.ErrUserNotFoundInPersistence)
 
@@ -242,7 +306,7 @@ func TestUserService_SetActive(t *testing.T) {
 func TestUserService_Deactivate(t *testing.T) {
 	t.Run("successful deactivate", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
-		userService := impl.NewUserService(mockRepo)
+		userService := impl.NewUserService(mockRepo, new(MockUserIdentityRepository))
 
 		mockRepo.On("Deactivate", 1).Return(nil)
 
@@ -251,3 +315,59 @@ func TestUserService_Deactivate(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserService_AddIdentity(t *testing.T) {
+	t.Run("successful add", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockIdentities := new(MockUserIdentityRepository)
+		userService := impl.NewUserService(mockRepo, mockIdentities)
+
+		identity := models.NewUserIdentity(models.ProviderGitHub, "octocat", 1)
+		mockRepo.On("GetByID", 1).Return(&models.User{ID: 1, Name: "Octo"}, nil)
+		mockIdentities.On("Add", identity).Return(nil)
+
+		err := userService.AddIdentity(identity)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockIdentities.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockIdentities := new(MockUserIdentityRepository)
+		userService := impl.NewUserService(mockRepo, mockIdentities)
+
+		mockRepo.On("GetByID", 999).Return(nil, repositories.ErrUserNotFoundInPersistence)
+
+		err := userService.AddIdentity(models.NewUserIdentity(models.ProviderGitHub, "octocat", 999))
+		assert.ErrorIs(t, err, repositories.ErrUserNotFoundInPersistence)
+		mockIdentities.AssertNotCalled(t, "Add", mock.Anything)
+	})
+}
+
+func TestUserService_GetByExternalLogin(t *testing.T) {
+	t.Run("mapped login", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockIdentities := new(MockUserIdentityRepository)
+		userService := impl.NewUserService(mockRepo, mockIdentities)
+
+		user := &models.User{ID: 7, Name: "Octo"}
+		mockIdentities.On("GetByLogin", models.ProviderGitLab, "octo").
+			Return(&models.UserIdentity{ID: 1, Provider: models.ProviderGitLab, Login: "octo", UserID: 7}, nil)
+		mockRepo.On("GetByID", 7).Return(user, nil)
+
+		found, err := userService.GetByExternalLogin(models.ProviderGitLab, "octo")
+		assert.NoError(t, err)
+		assert.Equal(t, user, found)
+	})
+
+	t.Run("unmapped login", func(t *testing.T) {
+		mockIdentities := new(MockUserIdentityRepository)
+		userService := impl.NewUserService(new(MockUserRepository), mockIdentities)
+
+		mockIdentities.On("GetByLogin", models.ProviderGitHub, "ghost").Return(nil, repositories.ErrUserIdentityNotFound)
+
+		_, err := userService.GetByExternalLogin(models.ProviderGitHub, "ghost")
+		assert.ErrorIs(t, err, repositories.ErrUserIdentityNotFound)
+	})
+}
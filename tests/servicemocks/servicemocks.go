@@ -0,0 +1,243 @@
+// Package servicemocks holds testify mocks of the domain services for the tests of the APIs
+// that are thin layers over them.
+package servicemocks
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ services.UserService        = (*UserService)(nil)
+	_ services.TeamService        = (*TeamService)(nil)
+	_ services.PullRequestService = (*PullRequestService)(nil)
+)
+
+type UserService struct {
+	mock.Mock
+}
+
+func (m *UserService) Create(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *UserService) GetByID(id int) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *UserService) GetByIDs(ids []int) ([]*models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *UserService) GetByEmail(email string) (*models.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *UserService) GetAll() ([]*models.User, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *UserService) Update(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *UserService) SetActive(userID int, isActive bool) error {
+	args := m.Called(userID, isActive)
+	return args.Error(0)
+}
+
+func (m *UserService) Deactivate(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *UserService) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	args := m.Called(userID, hours)
+	return args.Error(0)
+}
+
+func (m *UserService) AddIdentity(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *UserService) GetIdentityByID(id int) (*models.UserIdentity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserIdentity), args.Error(1)
+}
+
+func (m *UserService) GetIdentities(userID int) ([]*models.UserIdentity, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *UserService) GetIdentitiesByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.UserIdentity), args.Error(1)
+}
+
+func (m *UserService) UpdateIdentity(identity *models.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *UserService) DeleteIdentity(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *UserService) GetByExternalLogin(provider models.VCSProvider, login string) (*models.User, error) {
+	args := m.Called(provider, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type TeamService struct {
+	mock.Mock
+}
+
+func (m *TeamService) Create(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *TeamService) GetByID(id int) (*models.Team, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Team), args.Error(1)
+}
+
+func (m *TeamService) GetByName(name string) (*models.Team, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Team), args.Error(1)
+}
+
+func (m *TeamService) GetAll() ([]*models.Team, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Team), args.Error(1)
+}
+
+func (m *TeamService) Update(team *models.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+type PullRequestService struct {
+	mock.Mock
+}
+
+func (m *PullRequestService) Create(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) GetByID(id int) (*models.PullRequest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PullRequest), args.Error(1)
+}
+
+func (m *PullRequestService) GetByAuthorID(authorID int) ([]*models.PullRequest, error) {
+	args := m.Called(authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequest), args.Error(1)
+}
+
+func (m *PullRequestService) GetByReviewerID(reviewerID int) ([]*models.PullRequest, error) {
+	args := m.Called(reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PullRequest), args.Error(1)
+}
+
+func (m *PullRequestService) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	args := m.Called(reviewerIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]*models.PullRequest), args.Error(1)
+}
+
+func (m *PullRequestService) Update(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) AddReviewers(pr *models.PullRequest, reviewers ...*models.User) error {
+	args := m.Called(pr, reviewers)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) RemoveReviewer(pr *models.PullRequest, reviewerID int) error {
+	args := m.Called(pr, reviewerID)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) Edit(pr *models.PullRequest, name string, status models.PRStatus, reviewers []*models.User) error {
+	args := m.Called(pr, name, status, reviewers)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) AssignReviewers(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) ReassignReviewers(pr *models.PullRequest, oldReviewer *models.User) error {
+	args := m.Called(pr, oldReviewer)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) MergeRequest(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
+
+func (m *PullRequestService) MarkMerged(pr *models.PullRequest) error {
+	args := m.Called(pr)
+	return args.Error(0)
+}
@@ -0,0 +1,50 @@
+package validators
+
+import (
+	"testing"
+
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/validators"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNotificationPreferencesRequest(t *testing.T) {
+	preference := func(channel, target string, kinds ...string) dtos.NotificationPreference {
+		return dtos.NotificationPreference{Channel: channel, Target: target, Kinds: kinds}
+	}
+	tests := []struct {
+		name        string
+		preferences []dtos.NotificationPreference
+		err         string
+	}{
+		{"nothing", nil, ""},
+		{"own email", []dtos.NotificationPreference{preference("email", "", "reviewer_assigned")}, ""},
+		{"channel turned off", []dtos.NotificationPreference{preference("slack", "https://hooks.slack.com/services/T/B/X")}, ""},
+		{"unknown channel", []dtos.NotificationPreference{preference("sms", "")}, "invalid channel. Must be 'email', 'slack' or 'webhook'"},
+		{"duplicate channel", []dtos.NotificationPreference{preference("email", ""), preference("email", "a@example.com")}, "channel email is listed more than once"},
+		{"kind nobody is told about", []dtos.NotificationPreference{preference("email", "", "review_approved")}, "invalid kind. Must be 'reviewer_assigned', 'reviewer_unassigned' or 'merged'"},
+		{"bad email", []dtos.NotificationPreference{preference("email", "not an address")}, "email target must be a valid email address"},
+		{"webhook without target", []dtos.NotificationPreference{preference("webhook", "", "merged")}, "webhook target must be an http or https URL"},
+		{"webhook to a file", []dtos.NotificationPreference{preference("webhook", "file:///etc/passwd")}, "webhook target must be an http or https URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.ValidateNotificationPreferencesRequest(&dtos.NotificationPreferencesRequest{Preferences: tt.preferences})
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.err, err.Error())
+			}
+		})
+	}
+
+	t.Run("email targets are reduced to the address", func(t *testing.T) {
+		req := &dtos.NotificationPreferencesRequest{Preferences: []dtos.NotificationPreference{preference("email", "Bob <bob@example.com>")}}
+		assert.NoError(t, validators.ValidateNotificationPreferencesRequest(req))
+		assert.Equal(t, "bob@example.com", req.Preferences[0].Target)
+	})
+}
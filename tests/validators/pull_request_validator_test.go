@@ -13,8 +13,8 @@ func TestValidateCreatePullRequestRequest_InvalidCases(t *testing.T) {
 	t.Run("empty name", func(t *testing.T) {
 		req := &dtos.CreatePullRequestRequest{
 			Name:      "",
-			AuthorID:  1,
-			Reviewers: []int{},
+			AuthorID:  dtos.NewID(1),
+			Reviewers: []dtos.ID{},
 		}
 
 		err := validators.ValidateCreatePullRequestRequest(req)
@@ -26,8 +26,8 @@ func TestValidateCreatePullRequestRequest_InvalidCases(t *testing.T) {
 	t.Run("name too short", func(t *testing.T) {
 		req := &dtos.CreatePullRequestRequest{
 			Name:      "a",
-			AuthorID:  1,
-			Reviewers: []int{},
+			AuthorID:  dtos.NewID(1),
+			Reviewers: []dtos.ID{},
 		}
 
 		err := validators.ValidateCreatePullRequestRequest(req)
@@ -39,8 +39,8 @@ func TestValidateCreatePullRequestRequest_InvalidCases(t *testing.T) {
 	t.Run("author id not positive", func(t *testing.T) {
 		req := &dtos.CreatePullRequestRequest{
 			Name:      "Valid Name",
-			AuthorID:  0,
-			Reviewers: []int{},
+			AuthorID:  dtos.NewID(0),
+			Reviewers: []dtos.ID{},
 		}
 
 		err := validators.ValidateCreatePullRequestRequest(req)
@@ -52,8 +52,8 @@ func TestValidateCreatePullRequestRequest_InvalidCases(t *testing.T) {
 	t.Run("too many reviewers", func(t *testing.T) {
 		req := &dtos.CreatePullRequestRequest{
 			Name:      "Valid Name",
-			AuthorID:  1,
-			Reviewers: []int{1, 2, 3},
+			AuthorID:  dtos.NewID(1),
+			Reviewers: dtos.NewIDs([]int{1, 2, 3}),
 		}
 
 		err := validators.ValidateCreatePullRequestRequest(req)
@@ -61,13 +61,27 @@ func TestValidateCreatePullRequestRequest_InvalidCases(t *testing.T) {
 			assert.Equal(t, "cannot assign more than 2 reviewers", err.Error())
 		}
 	})
+
+	t.Run("negative size", func(t *testing.T) {
+		size := -1
+		req := &dtos.CreatePullRequestRequest{
+			Name:     "Valid Name",
+			AuthorID: dtos.NewID(1),
+			Size:     &size,
+		}
+
+		err := validators.ValidateCreatePullRequestRequest(req)
+		if assert.Error(t, err) {
+			assert.Equal(t, "size must not be negative", err.Error())
+		}
+	})
 }
 
 func TestValidateCreatePullRequestRequest_ValidCase(t *testing.T) {
 	req := &dtos.CreatePullRequestRequest{
 		Name:      "Valid PR",
-		AuthorID:  1,
-		Reviewers: []int{2},
+		AuthorID:  dtos.NewID(1),
+		Reviewers: dtos.NewIDs([]int{2}),
 	}
 
 	err := validators.ValidateCreatePullRequestRequest(req)
@@ -0,0 +1,53 @@
+package validators
+
+import (
+	"testing"
+
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/app/validators"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWorkingHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours dtos.WorkingHours
+		err   string
+	}{
+		{"iana zone", dtos.WorkingHours{Timezone: "America/Sao_Paulo", StartHour: 8, EndHour: 17}, ""},
+		{"whole day", dtos.WorkingHours{Timezone: "UTC", StartHour: 0, EndHour: 24}, ""},
+		{"empty zone", dtos.WorkingHours{StartHour: 9, EndHour: 18}, "timezone must be an IANA time zone name, e.g. Europe/Moscow"},
+		{"local zone", dtos.WorkingHours{Timezone: "Local", StartHour: 9, EndHour: 18}, "timezone must be an IANA time zone name, e.g. Europe/Moscow"},
+		{"abbreviation", dtos.WorkingHours{Timezone: "MSK", StartHour: 9, EndHour: 18}, "timezone must be an IANA time zone name, e.g. Europe/Moscow"},
+		{"end before start", dtos.WorkingHours{Timezone: "UTC", StartHour: 18, EndHour: 9}, "working hours must satisfy 0 <= start_hour < end_hour <= 24"},
+		{"empty range", dtos.WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 9}, "working hours must satisfy 0 <= start_hour < end_hour <= 24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.ValidateWorkingHours(&tt.hours)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.err, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateCreateUserRequest_WorkingHours(t *testing.T) {
+	req := &dtos.CreateUserRequest{
+		Username:     "carol",
+		Email:        "carol@example.com",
+		TeamName:     "backend",
+		WorkingHours: &dtos.WorkingHours{Timezone: "Europe/Berlin", StartHour: 20, EndHour: 8},
+	}
+
+	assert.Error(t, validators.ValidateCreateUserRequest(req))
+
+	req.WorkingHours.StartHour = 7
+	assert.NoError(t, validators.ValidateCreateUserRequest(req))
+}
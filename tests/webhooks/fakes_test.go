@@ -0,0 +1,423 @@
+package webhooks
+
+import (
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/repositories"
+	"slices"
+	"strings"
+	"time"
+)
+
+type memoryUserRepository struct {
+	users []*models.User
+}
+
+func (r *memoryUserRepository) Add(user *models.User) error {
+	user.SetId(len(r.users) + 1)
+	r.users = append(r.users, user)
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(id int) (*models.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, repositories.ErrUserNotFoundInPersistence
+}
+
+func (r *memoryUserRepository) GetByIDs(ids []int) ([]*models.User, error) {
+	users := make([]*models.User, 0, len(ids))
+	for _, id := range ids {
+		if user, err := r.GetByID(id); err == nil {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, repositories.ErrUserWithThatEmailNotFound
+}
+
+func (r *memoryUserRepository) GetAll() ([]*models.User, error) {
+	return r.users, nil
+}
+
+func (r *memoryUserRepository) GetActiveUsers() ([]*models.User, error) {
+	return r.GetWithFilters("", true)
+}
+
+func (r *memoryUserRepository) GetWithFilters(teamName string, isActive bool) ([]*models.User, error) {
+	users := make([]*models.User, 0)
+	for _, user := range r.users {
+		if user.IsActive == isActive && (teamName == "" || user.TeamName == teamName) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepository) Update(user *models.User) error {
+	return nil
+}
+
+func (r *memoryUserRepository) Deactivate(userID int) error {
+	user, err := r.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	user.UpdateIsActive(false)
+	return nil
+}
+
+func (r *memoryUserRepository) SetWorkingHours(userID int, hours models.WorkingHours) error {
+	user, err := r.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	user.WorkingHours = hours
+	return nil
+}
+
+type memoryPullRequestRepository struct {
+	users *memoryUserRepository
+	prs   []*models.PullRequest
+}
+
+func (r *memoryPullRequestRepository) Add(pr *models.PullRequest) error {
+	pr.SetId(len(r.prs) + 1)
+	r.prs = append(r.prs, pr)
+	return nil
+}
+
+func (r *memoryPullRequestRepository) GetByID(id int) (*models.PullRequest, error) {
+	for _, pr := range r.prs {
+		if pr.ID == id {
+			return pr, nil
+		}
+	}
+	return nil, repositories.ErrPullRequestNotFoundInPersistence
+}
+
+func (r *memoryPullRequestRepository) GetAll() ([]*models.PullRequest, error) {
+	return r.prs, nil
+}
+
+func (r *memoryPullRequestRepository) GetByStatus(status models.PRStatus) ([]*models.PullRequest, error) {
+	return r.filter(func(pr *models.PullRequest) bool { return pr.Status == status }), nil
+}
+
+func (r *memoryPullRequestRepository) GetByAuthorID(authorID int) ([]*models.PullRequest, error) {
+	return r.filter(func(pr *models.PullRequest) bool { return pr.Author.ID == authorID }), nil
+}
+
+func (r *memoryPullRequestRepository) GetByReviewerID(reviewerID int) ([]*models.PullRequest, error) {
+	return r.filter(func(pr *models.PullRequest) bool {
+		for _, reviewer := range pr.Reviewers {
+			if reviewer.ID == reviewerID {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *memoryPullRequestRepository) GetByReviewerIDs(reviewerIDs []int) (map[int][]*models.PullRequest, error) {
+	prsByReviewer := make(map[int][]*models.PullRequest, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		prsByReviewer[reviewerID], _ = r.GetByReviewerID(reviewerID)
+	}
+	return prsByReviewer, nil
+}
+
+func (r *memoryPullRequestRepository) Update(pr *models.PullRequest) error {
+	_, err := r.GetByID(pr.ID)
+	return err
+}
+
+func (r *memoryPullRequestRepository) FindPossibleReviewers(author *models.User) ([]*models.User, error) {
+	candidates := make([]*models.User, 0)
+	for _, user := range r.users.users {
+		if user.IsActive && user.ID != author.ID && user.TeamName == author.TeamName {
+			candidates = append(candidates, user)
+		}
+	}
+	return candidates, nil
+}
+
+func (r *memoryPullRequestRepository) filter(keep func(pr *models.PullRequest) bool) []*models.PullRequest {
+	prs := make([]*models.PullRequest, 0)
+	for _, pr := range r.prs {
+		if keep(pr) {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+type memoryExternalPullRequestRepository struct {
+	links []*models.ExternalPullRequest
+}
+
+func (r *memoryExternalPullRequestRepository) Add(link *models.ExternalPullRequest) error {
+	if _, err := r.GetByExternalID(link.Provider, link.ExternalID); err == nil {
+		return repositories.ErrExternalPullRequestAlreadyExists
+	}
+	r.links = append(r.links, link)
+	return nil
+}
+
+func (r *memoryExternalPullRequestRepository) GetByExternalID(provider models.VCSProvider, externalID string) (*models.ExternalPullRequest, error) {
+	for _, link := range r.links {
+		if link.Provider == provider && link.ExternalID == externalID {
+			return link, nil
+		}
+	}
+	return nil, repositories.ErrExternalPullRequestNotFound
+}
+
+type memoryUserIdentityRepository struct {
+	identities []*models.UserIdentity
+}
+
+func (r *memoryUserIdentityRepository) Add(identity *models.UserIdentity) error {
+	if _, err := r.GetByLogin(identity.Provider, identity.Login); err == nil {
+		return repositories.ErrUserIdentityAlreadyExists
+	}
+	identity.SetId(len(r.identities) + 1)
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *memoryUserIdentityRepository) GetByID(id int) (*models.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.ID == id {
+			return identity, nil
+		}
+	}
+	return nil, repositories.ErrUserIdentityNotFound
+}
+
+func (r *memoryUserIdentityRepository) GetByUserID(userID int) ([]*models.UserIdentity, error) {
+	return r.GetByUserIDs([]int{userID})
+}
+
+func (r *memoryUserIdentityRepository) GetByUserIDs(userIDs []int) ([]*models.UserIdentity, error) {
+	identities := make([]*models.UserIdentity, 0)
+	for _, identity := range r.identities {
+		if slices.Contains(userIDs, identity.UserID) {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *memoryUserIdentityRepository) GetByLogin(provider models.VCSProvider, login string) (*models.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && strings.EqualFold(identity.Login, login) {
+			return identity, nil
+		}
+	}
+	return nil, repositories.ErrUserIdentityNotFound
+}
+
+func (r *memoryUserIdentityRepository) Update(identity *models.UserIdentity) error {
+	_, err := r.GetByID(identity.ID)
+	return err
+}
+
+func (r *memoryUserIdentityRepository) Delete(id int) error {
+	for i, identity := range r.identities {
+		if identity.ID == id {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return nil
+		}
+	}
+	return repositories.ErrUserIdentityNotFound
+}
+
+var _ repositories.UserIdentityRepository = (*memoryUserIdentityRepository)(nil)
+var _ repositories.UserRepository = (*memoryUserRepository)(nil)
+var _ repositories.PullRequestRepository = (*memoryPullRequestRepository)(nil)
+var _ repositories.ExternalPullRequestRepository = (*memoryExternalPullRequestRepository)(nil)
+
+type memoryReviewerRuleRepository struct {
+	rules models.ReviewerRules
+}
+
+func (r *memoryReviewerRuleRepository) Add(rule *models.ReviewerRule) error {
+	rule.SetId(len(r.rules) + 1)
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+func (r *memoryReviewerRuleRepository) GetByID(id int) (*models.ReviewerRule, error) {
+	for _, rule := range r.rules {
+		if rule.ID == id {
+			return rule, nil
+		}
+	}
+	return nil, repositories.ErrReviewerRuleNotFound
+}
+
+func (r *memoryReviewerRuleRepository) GetByTeamID(teamID int) (models.ReviewerRules, error) {
+	rules := make(models.ReviewerRules, 0)
+	for _, rule := range r.rules {
+		if rule.TeamID == teamID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (r *memoryReviewerRuleRepository) GetByTeamName(teamName string) (models.ReviewerRules, error) {
+	return models.ReviewerRules{}, nil
+}
+
+func (r *memoryReviewerRuleRepository) Delete(id int) error {
+	r.rules = slices.DeleteFunc(r.rules, func(rule *models.ReviewerRule) bool { return rule.ID == id })
+	return nil
+}
+
+type memoryUserTagRepository struct {
+	tags map[int][]string
+}
+
+func (r *memoryUserTagRepository) GetByUserID(userID int) ([]string, error) {
+	return r.tags[userID], nil
+}
+
+func (r *memoryUserTagRepository) GetByUserIDs(userIDs []int) (map[int][]string, error) {
+	tags := make(map[int][]string)
+	for _, id := range userIDs {
+		if r.tags[id] != nil {
+			tags[id] = r.tags[id]
+		}
+	}
+	return tags, nil
+}
+
+func (r *memoryUserTagRepository) Replace(userID int, tags []string) error {
+	if r.tags == nil {
+		r.tags = make(map[int][]string)
+	}
+	r.tags[userID] = tags
+	return nil
+}
+
+type memoryReviewPatternRepository struct {
+	patterns models.ReviewPatterns
+}
+
+func (r *memoryReviewPatternRepository) Add(pattern *models.ReviewPattern) error {
+	pattern.SetId(len(r.patterns) + 1)
+	r.patterns = append(r.patterns, pattern)
+	return nil
+}
+
+func (r *memoryReviewPatternRepository) GetByID(id int) (*models.ReviewPattern, error) {
+	for _, pattern := range r.patterns {
+		if pattern.ID == id {
+			return pattern, nil
+		}
+	}
+	return nil, repositories.ErrReviewPatternNotFound
+}
+
+func (r *memoryReviewPatternRepository) GetByTeamID(teamID int) (models.ReviewPatterns, error) {
+	patterns := make(models.ReviewPatterns, 0)
+	for _, pattern := range r.patterns {
+		if pattern.TeamID == teamID {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns, nil
+}
+
+func (r *memoryReviewPatternRepository) GetByTeamName(teamName string) (models.ReviewPatterns, error) {
+	return models.ReviewPatterns{}, nil
+}
+
+func (r *memoryReviewPatternRepository) Delete(id int) error {
+	r.patterns = slices.DeleteFunc(r.patterns, func(pattern *models.ReviewPattern) bool { return pattern.ID == id })
+	return nil
+}
+
+type memoryReviewLoadRepository struct {
+	prs *memoryPullRequestRepository
+}
+
+func (r *memoryReviewLoadRepository) GetByTeamName(teamName string, now time.Time) (models.ReviewLoads, error) {
+	loads := make(models.ReviewLoads)
+	for _, pr := range r.prs.prs {
+		for _, reviewer := range pr.Reviewers {
+			if reviewer.TeamName == teamName {
+				loads.Add(reviewer.ID, pr.Size, now.Sub(pr.CreatedAt), models.DefaultReviewHalfLife)
+			}
+		}
+	}
+	return loads, nil
+}
+
+func (r *memoryReviewLoadRepository) GetHalfLife(int) (time.Duration, error) {
+	return models.DefaultReviewHalfLife, nil
+}
+
+func (r *memoryReviewLoadRepository) SetHalfLife(int, time.Duration) error {
+	return nil
+}
+
+type memoryPullRequestEventRepository struct {
+	events []*models.PullRequestEvent
+}
+
+func (r *memoryPullRequestEventRepository) Add(event *models.PullRequestEvent) error {
+	event.SetId(len(r.events) + 1)
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memoryPullRequestEventRepository) GetByPullRequestID(prID int) ([]*models.PullRequestEvent, error) {
+	return r.filter(func(event *models.PullRequestEvent) bool { return event.PullRequestID == prID }), nil
+}
+
+func (r *memoryPullRequestEventRepository) GetByUserID(userID, afterID int) ([]*models.PullRequestEvent, error) {
+	return r.filter(func(event *models.PullRequestEvent) bool { return event.UserID == userID && event.ID > afterID }), nil
+}
+
+func (r *memoryPullRequestEventRepository) filter(keep func(*models.PullRequestEvent) bool) []*models.PullRequestEvent {
+	events := make([]*models.PullRequestEvent, 0)
+	for _, event := range r.events {
+		if keep(event) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// memoryTransactionManager hands out the memory repositories and drops what a failed transaction
+// added. begin, when set, runs before fn, as if another transaction had committed just before.
+type memoryTransactionManager struct {
+	prs      *memoryPullRequestRepository
+	external *memoryExternalPullRequestRepository
+	begin    func()
+}
+
+func (m *memoryTransactionManager) WithinTransaction(fn func(repos repositories.Repositories) error) error {
+	if m.begin != nil {
+		m.begin()
+	}
+	prs, links := len(m.prs.prs), len(m.external.links)
+	if err := fn(repositories.Repositories{PullRequests: m.prs, ExternalPullRequests: m.external}); err != nil {
+		m.prs.prs, m.external.links = m.prs.prs[:prs], m.external.links[:links]
+		return err
+	}
+	return nil
+}
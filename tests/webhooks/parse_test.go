@@ -0,0 +1,156 @@
+package webhooks
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reviewer-assignment-service/internal/app/webhooks"
+	"reviewer-assignment-service/internal/domain/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "s3cr3t"
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return body
+}
+
+func githubHeader(event string, body []byte, key string) http.Header {
+	header := http.Header{}
+	header.Set(webhooks.GitHubEventHeader, event)
+	header.Set(webhooks.GitHubSignatureHeader, webhooks.Sign(body, key))
+	return header
+}
+
+func gitlabHeader(token string) http.Header {
+	header := http.Header{}
+	header.Set(webhooks.GitLabEventHeader, "Merge Request Hook")
+	header.Set(webhooks.GitLabTokenHeader, token)
+	return header
+}
+
+func TestParseGitHub(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		action    models.VCSAction
+		reviewers []string
+		labels    []string
+	}{
+		{"github/pull_request_opened.json", models.VCSActionOpened, []string{"bob"}, []string{"security"}},
+		{"github/pull_request_review_requested.json", models.VCSActionReviewRequested, []string{"carol"}, nil},
+		{"github/pull_request_closed.json", models.VCSActionClosed, []string{}, nil},
+		{"github/pull_request_reopened.json", models.VCSActionReopened, []string{}, nil},
+		{"github/pull_request_merged.json", models.VCSActionMerged, []string{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			body := fixture(t, tt.fixture)
+
+			event, err := webhooks.ParseGitHub(githubHeader("pull_request", body, secret), body, secret)
+
+			require.NoError(t, err)
+			assert.Equal(t, models.ProviderGitHub, event.Provider)
+			assert.Equal(t, tt.action, event.Action)
+			assert.Equal(t, "acme/api#42", event.ExternalID)
+			assert.Equal(t, "alice", event.AuthorLogin)
+			assert.Equal(t, "Add rate limiting to the public API", event.Title)
+			assert.Equal(t, tt.reviewers, event.ReviewerLogins)
+			assert.Equal(t, tt.labels, event.Labels)
+		})
+	}
+
+	t.Run("opened carries size", func(t *testing.T) {
+		body := fixture(t, "github/pull_request_opened.json")
+
+		event, err := webhooks.ParseGitHub(githubHeader("pull_request", body, secret), body, secret)
+
+		require.NoError(t, err)
+		require.NotNil(t, event.Size)
+		assert.Equal(t, 150, *event.Size)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		body := fixture(t, "github/pull_request_opened.json")
+
+		_, err := webhooks.ParseGitHub(githubHeader("pull_request", body, "other"), body, secret)
+
+		assert.ErrorIs(t, err, webhooks.ErrInvalidSignature)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		body := fixture(t, "github/pull_request_opened.json")
+		header := githubHeader("pull_request", body, secret)
+		body = append(body, ' ')
+
+		_, err := webhooks.ParseGitHub(header, body, secret)
+
+		assert.ErrorIs(t, err, webhooks.ErrInvalidSignature)
+	})
+
+	t.Run("secret not configured", func(t *testing.T) {
+		body := fixture(t, "github/pull_request_opened.json")
+
+		_, err := webhooks.ParseGitHub(githubHeader("pull_request", body, ""), body, "")
+
+		assert.ErrorIs(t, err, webhooks.ErrInvalidSignature)
+	})
+
+	t.Run("ping is ignored", func(t *testing.T) {
+		body := fixture(t, "github/ping.json")
+
+		_, err := webhooks.ParseGitHub(githubHeader("ping", body, secret), body, secret)
+
+		assert.ErrorIs(t, err, webhooks.ErrIgnoredEvent)
+	})
+
+	t.Run("unhandled action is ignored", func(t *testing.T) {
+		body := fixture(t, "github/pull_request_labeled.json")
+
+		_, err := webhooks.ParseGitHub(githubHeader("pull_request", body, secret), body, secret)
+
+		assert.ErrorIs(t, err, webhooks.ErrIgnoredEvent)
+	})
+}
+
+func TestParseGitLab(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		action    models.VCSAction
+		reviewers []string
+		labels    []string
+	}{
+		{"gitlab/merge_request_open.json", models.VCSActionOpened, []string{}, []string{"billing"}},
+		{"gitlab/merge_request_reviewers_updated.json", models.VCSActionReviewRequested, []string{"dave"}, nil},
+		{"gitlab/merge_request_merge.json", models.VCSActionMerged, []string{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			body := fixture(t, tt.fixture)
+
+			event, err := webhooks.ParseGitLab(gitlabHeader(secret), body, secret)
+
+			require.NoError(t, err)
+			assert.Equal(t, models.ProviderGitLab, event.Provider)
+			assert.Equal(t, tt.action, event.Action)
+			assert.Equal(t, "acme/api!17", event.ExternalID)
+			assert.Equal(t, tt.reviewers, event.ReviewerLogins)
+			assert.Equal(t, tt.labels, event.Labels)
+		})
+	}
+
+	t.Run("wrong token", func(t *testing.T) {
+		body := fixture(t, "gitlab/merge_request_open.json")
+
+		_, err := webhooks.ParseGitLab(gitlabHeader("other"), body, secret)
+
+		assert.ErrorIs(t, err, webhooks.ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,300 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-assignment-service/internal/app/config"
+	"reviewer-assignment-service/internal/app/routes"
+	"reviewer-assignment-service/internal/app/transport/dtos"
+	"reviewer-assignment-service/internal/domain/events"
+	"reviewer-assignment-service/internal/domain/models"
+	"reviewer-assignment-service/internal/domain/services/impl"
+	"reviewer-assignment-service/internal/infrastructure/cache"
+	"reviewer-assignment-service/tests/fakeclock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type replayEnv struct {
+	router       http.Handler
+	prs          *memoryPullRequestRepository
+	identities   *memoryUserIdentityRepository
+	transactions *memoryTransactionManager
+	events       *memoryPullRequestEventRepository
+}
+
+func newReplayEnv() *replayEnv {
+	users := &memoryUserRepository{}
+	users.Add(models.NewUser("alice", "alice@example.com", true, "backend"))
+	users.Add(models.NewUser("bob", "bob@example.com", true, "backend"))
+	users.Add(models.NewUser("carol", "carol@example.com", false, "backend"))
+	users.Add(models.NewUser("David", "dave@example.com", true, "platform"))
+
+	identities := &memoryUserIdentityRepository{}
+	identities.Add(models.NewUserIdentity(models.ProviderGitLab, "dave", 4))
+	identities.Add(models.NewUserIdentity(models.ProviderGitHub, "dave-gh", 4))
+	identities.Add(models.NewUserIdentity(models.ProviderGitHub, "alice", 1))
+	identities.Add(models.NewUserIdentity(models.ProviderGitHub, "bob", 2))
+	identities.Add(models.NewUserIdentity(models.ProviderGitHub, "carol", 3))
+	identities.Add(models.NewUserIdentity(models.ProviderGitLab, "alice", 1))
+
+	prs := &memoryPullRequestRepository{users: users}
+	external := &memoryExternalPullRequestRepository{}
+	transactions := &memoryTransactionManager{prs: prs, external: external}
+
+	rules := &memoryReviewerRuleRepository{}
+	tags := &memoryUserTagRepository{}
+	patterns := &memoryReviewPatternRepository{}
+	loads := &memoryReviewLoadRepository{prs: prs}
+	prEvents := &memoryPullRequestEventRepository{}
+	broker := events.NewBroker()
+
+	clk := fakeclock.New(fakeclock.Monday)
+	userService := impl.NewUserService(users, identities)
+	prService := impl.NewPullRequestService(prs, rules, tags, patterns, loads, nil, prEvents, broker, nil, clk)
+	integrationService := impl.NewIntegrationService(prService, userService, external, transactions, clk)
+
+	return &replayEnv{
+		router: routes.SetupRouter(userService, prService, impl.NewTeamService(nil), integrationService, impl.NewImportService(nil), impl.NewOrgSyncService(nil, prService), impl.NewMembershipService(nil, prService), impl.NewReviewerRuleService(rules, nil, users, tags, patterns, loads, clk), impl.NewReviewSLAService(nil, nil, prService, clk), impl.NewReviewService(nil, nil, prService, clk), impl.NewIdempotencyService(nil, 0, clk), impl.NewUserEventService(users, prEvents, broker), impl.NewNotificationService(users, nil, nil, nil, clk), impl.NewDigestService(users, prs, nil, nil, clk), cache.NewInProcess(0, 0, clk), config.IntegrationsConfig{
+			GitHubWebhookSecret: secret,
+			GitLabWebhookToken:  secret,
+		}, config.RateLimitConfig{}, clk),
+		prs:          prs,
+		identities:   identities,
+		transactions: transactions,
+		events:       prEvents,
+	}
+}
+
+func (e *replayEnv) deliver(t *testing.T, path string, header http.Header, body []byte) (int, *dtos.WebhookResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header = header.Clone()
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+
+	var response dtos.WebhookResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	}
+	return rec.Code, &response
+}
+
+// recorded lists the kind and user of every recorded event, in the order they were recorded.
+func (e *replayEnv) recorded() []string {
+	recorded := make([]string, 0, len(e.events.events))
+	for _, event := range e.events.events {
+		recorded = append(recorded, fmt.Sprintf("%s %d", event.Kind, event.UserID))
+	}
+	return recorded
+}
+
+func reviewerNames(response *dtos.WebhookResponse) []string {
+	names := make([]string, 0)
+	for _, reviewer := range response.PullRequest.Reviewers {
+		names = append(names, reviewer.Username)
+	}
+	return names
+}
+
+func TestReplay_GitHubPullRequestLifecycle(t *testing.T) {
+	env := newReplayEnv()
+
+	steps := []struct {
+		fixture   string
+		status    string
+		reviewers []string
+	}{
+		{"github/pull_request_opened.json", "OPEN", []string{"bob"}},
+		{"github/pull_request_review_requested.json", "OPEN", []string{"bob", "carol"}},
+		{"github/pull_request_closed.json", "CLOSED", []string{"bob", "carol"}},
+		{"github/pull_request_reopened.json", "OPEN", []string{"bob", "carol"}},
+		{"github/pull_request_merged.json", "MERGED", []string{"bob", "carol"}},
+	}
+
+	for _, step := range steps {
+		body := fixture(t, step.fixture)
+		header := githubHeader("pull_request", body, secret)
+
+		code, response := env.deliver(t, "/integrations/github", header, body)
+		require.Equal(t, http.StatusOK, code, step.fixture)
+		assert.Equal(t, string(models.VCSEventApplied), response.Outcome, step.fixture)
+		assert.Equal(t, step.status, response.PullRequest.Status, step.fixture)
+		assert.Equal(t, step.reviewers, reviewerNames(response), step.fixture)
+
+		code, redelivered := env.deliver(t, "/integrations/github", header, body)
+		require.Equal(t, http.StatusOK, code, step.fixture)
+		assert.Equal(t, string(models.VCSEventDuplicate), redelivered.Outcome, step.fixture)
+		assert.Equal(t, response.PullRequest.ID, redelivered.PullRequest.ID, step.fixture)
+	}
+
+	assert.Len(t, env.prs.prs, 1)
+}
+
+func TestReplay_GitLabMergeRequestLifecycle(t *testing.T) {
+	env := newReplayEnv()
+
+	steps := []struct {
+		fixture   string
+		status    string
+		reviewers []string
+	}{
+		{"gitlab/merge_request_open.json", "OPEN", []string{"bob"}},
+		{"gitlab/merge_request_reviewers_updated.json", "OPEN", []string{"bob", "David"}},
+		{"gitlab/merge_request_merge.json", "MERGED", []string{"bob", "David"}},
+	}
+
+	for _, step := range steps {
+		body := fixture(t, step.fixture)
+
+		code, response := env.deliver(t, "/integrations/gitlab", gitlabHeader(secret), body)
+		require.Equal(t, http.StatusOK, code, step.fixture)
+		assert.Equal(t, string(models.VCSEventApplied), response.Outcome, step.fixture)
+		assert.Equal(t, step.status, response.PullRequest.Status, step.fixture)
+		assert.Equal(t, step.reviewers, reviewerNames(response), step.fixture)
+
+		code, redelivered := env.deliver(t, "/integrations/gitlab", gitlabHeader(secret), body)
+		require.Equal(t, http.StatusOK, code, step.fixture)
+		assert.Equal(t, string(models.VCSEventDuplicate), redelivered.Outcome, step.fixture)
+	}
+
+	assert.Len(t, env.prs.prs, 1)
+}
+
+func TestReplay_RequestedReviewersAreRecorded(t *testing.T) {
+	env := newReplayEnv()
+
+	for _, name := range []string{"github/pull_request_opened.json", "github/pull_request_review_requested.json"} {
+		body := fixture(t, name)
+		code, _ := env.deliver(t, "/integrations/github", githubHeader("pull_request", body, secret), body)
+		require.Equal(t, http.StatusOK, code, name)
+	}
+
+	assert.Equal(t, []string{"reviewer_assigned 2", "reviewer_assigned 3"}, env.recorded())
+}
+
+func TestReplay_MergeIsRecorded(t *testing.T) {
+	env := newReplayEnv()
+
+	for _, name := range []string{"github/pull_request_opened.json", "github/pull_request_merged.json"} {
+		body := fixture(t, name)
+		code, _ := env.deliver(t, "/integrations/github", githubHeader("pull_request", body, secret), body)
+		require.Equal(t, http.StatusOK, code, name)
+	}
+
+	assert.Equal(t, []string{"reviewer_assigned 2", "merged 2"}, env.recorded())
+}
+
+func TestReplay_OpenedWithNobodyToAssignIsAcknowledged(t *testing.T) {
+	env := newReplayEnv()
+	bob, err := env.prs.users.GetByID(2)
+	require.NoError(t, err)
+	bob.IsActive = false
+
+	body := fixture(t, "gitlab/merge_request_open.json")
+
+	code, response := env.deliver(t, "/integrations/gitlab", gitlabHeader(secret), body)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, string(models.VCSEventApplied), response.Outcome)
+	assert.Empty(t, reviewerNames(response))
+	require.NotNil(t, response.PullRequest.Warning)
+	assert.Equal(t, "REVIEWER_NOT_FOUND", response.PullRequest.Warning.Code)
+
+	code, redelivered := env.deliver(t, "/integrations/gitlab", gitlabHeader(secret), body)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, string(models.VCSEventDuplicate), redelivered.Outcome)
+	assert.Nil(t, redelivered.PullRequest.Warning)
+	assert.Len(t, env.prs.prs, 1)
+}
+
+func TestReplay_ConcurrentOpenReportsThePullRequestCreatedFirst(t *testing.T) {
+	env := newReplayEnv()
+	body := fixture(t, "github/pull_request_opened.json")
+	header := githubHeader("pull_request", body, secret)
+
+	var first *dtos.WebhookResponse
+	env.transactions.begin = func() {
+		// The same delivery arrives again and commits while this one is past its lookup.
+		env.transactions.begin = nil
+		var code int
+		code, first = env.deliver(t, "/integrations/github", header, body)
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	code, second := env.deliver(t, "/integrations/github", header, body)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, string(models.VCSEventApplied), first.Outcome)
+	assert.Equal(t, string(models.VCSEventDuplicate), second.Outcome)
+	assert.Equal(t, first.PullRequest.ID, second.PullRequest.ID)
+	assert.Len(t, env.prs.prs, 1)
+}
+
+func TestReplay_LoginsAreNotMatchedAgainstUserNames(t *testing.T) {
+	env := newReplayEnv()
+	identity, err := env.identities.GetByLogin(models.ProviderGitHub, "alice")
+	require.NoError(t, err)
+	require.NoError(t, env.identities.Delete(identity.ID))
+
+	body := fixture(t, "github/pull_request_opened.json")
+	code, _ := env.deliver(t, "/integrations/github", githubHeader("pull_request", body, secret), body)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code, "alice is a user name, not a mapped login")
+	assert.Empty(t, env.prs.prs)
+}
+
+func TestReplay_ReviewRequestSkipsUnknownLogins(t *testing.T) {
+	env := newReplayEnv()
+	identity, err := env.identities.GetByLogin(models.ProviderGitHub, "carol")
+	require.NoError(t, err)
+	require.NoError(t, env.identities.Delete(identity.ID))
+
+	for _, name := range []string{"github/pull_request_opened.json", "github/pull_request_review_requested.json"} {
+		body := fixture(t, name)
+		code, response := env.deliver(t, "/integrations/github", githubHeader("pull_request", body, secret), body)
+		require.Equal(t, http.StatusOK, code, name)
+		assert.Equal(t, []string{"bob"}, reviewerNames(response), name)
+	}
+}
+
+func TestReplay_ResponseCarriesReviewerLogins(t *testing.T) {
+	env := newReplayEnv()
+
+	for _, name := range []string{"gitlab/merge_request_open.json", "gitlab/merge_request_reviewers_updated.json"} {
+		body := fixture(t, name)
+		code, response := env.deliver(t, "/integrations/gitlab", gitlabHeader(secret), body)
+		require.Equal(t, http.StatusOK, code, name)
+		if name == "gitlab/merge_request_reviewers_updated.json" {
+			require.Len(t, response.PullRequest.Reviewers, 2)
+			assert.Equal(t, map[string]string{"github": "bob"}, response.PullRequest.Reviewers[0].ExternalLogins)
+			assert.Equal(t, map[string]string{"gitlab": "dave", "github": "dave-gh"}, response.PullRequest.Reviewers[1].ExternalLogins)
+		}
+	}
+}
+
+func TestReplay_EventsForUntrackedPullRequestsAreIgnored(t *testing.T) {
+	env := newReplayEnv()
+	body := fixture(t, "github/pull_request_closed.json")
+
+	code, response := env.deliver(t, "/integrations/github", githubHeader("pull_request", body, secret), body)
+
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, string(models.VCSEventIgnored), response.Outcome)
+	assert.Nil(t, response.PullRequest)
+}
+
+func TestReplay_InvalidSignatureIsRejected(t *testing.T) {
+	env := newReplayEnv()
+	body := fixture(t, "github/pull_request_opened.json")
+
+	code, _ := env.deliver(t, "/integrations/github", githubHeader("pull_request", body, "forged"), body)
+
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Empty(t, env.prs.prs)
+}